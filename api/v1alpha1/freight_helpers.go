@@ -0,0 +1,32 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetFreight returns a pointer to the Freight resource specified by the
+// namespacedName argument. If no such resource is found, nil is returned
+// instead.
+func GetFreight(
+	ctx context.Context,
+	c client.Client,
+	namespacedName types.NamespacedName,
+) (*Freight, error) {
+	freight := Freight{}
+	if err := c.Get(ctx, namespacedName, &freight); err != nil {
+		if err = client.IgnoreNotFound(err); err == nil {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(
+			err,
+			"error getting Freight %q in namespace %q",
+			namespacedName.Name,
+			namespacedName.Namespace,
+		)
+	}
+	return &freight, nil
+}
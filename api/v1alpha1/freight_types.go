@@ -0,0 +1,96 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name=Age,type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Freight represents a collection of versioned artifacts. The name of a Freight
+// resource is a system-calculated value derived from those artifacts, and is
+// therefore equal to the ID of any SimpleFreight that references it from a
+// Stage's status.
+type Freight struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Commits describes specific Git repository commits.
+	Commits []GitCommit `json:"commits,omitempty"`
+	// Images describes specific versions of specific container images.
+	Images []Image `json:"images,omitempty"`
+	// Charts describes specific versions of specific Helm charts.
+	Charts []Chart `json:"charts,omitempty"`
+	// Status describes the current status of this Freight.
+	Status FreightStatus `json:"status,omitempty"`
+}
+
+func (f *Freight) GetStatus() *FreightStatus {
+	return &f.Status
+}
+
+// ToSimpleFreight returns a SimpleFreight containing a copy of this Freight's
+// materials. The SimpleFreight's ID is the name of this Freight and its
+// FirstSeen time is the time at which this Freight was created.
+func (f *Freight) ToSimpleFreight() SimpleFreight {
+	firstSeen := f.CreationTimestamp
+	simpleFreight := SimpleFreight{
+		ID:        f.Name,
+		FirstSeen: &firstSeen,
+	}
+	c := f.DeepCopy()
+	simpleFreight.Commits = c.Commits
+	simpleFreight.Images = c.Images
+	simpleFreight.Charts = c.Charts
+	return simpleFreight
+}
+
+// IsVerifiedIn returns true if this Freight has been verified in the Stage with
+// the specified name.
+func (f *Freight) IsVerifiedIn(stageName string) bool {
+	_, ok := f.Status.VerifiedIn[stageName]
+	return ok
+}
+
+// IsApprovedFor returns true if this Freight has been manually approved for
+// promotion to the Stage with the specified name.
+func (f *Freight) IsApprovedFor(stageName string) bool {
+	_, ok := f.Status.ApprovedFor[stageName]
+	return ok
+}
+
+// FreightStatus describes a piece of Freight's most recently observed state.
+type FreightStatus struct {
+	// VerifiedIn describes the Stages in which this Freight has been verified
+	// through promotion and subsequent health checks. It is keyed by Stage name.
+	VerifiedIn map[string]VerifiedStage `json:"verifiedIn,omitempty"`
+	// ApprovedFor describes the Stages for which this Freight has been manually
+	// approved, making it available for promotion to those Stages regardless of
+	// whether it has been verified upstream. It is keyed by Stage name.
+	ApprovedFor map[string]ApprovedStage `json:"approvedFor,omitempty"`
+}
+
+// VerifiedStage describes a Stage in which Freight has been verified.
+type VerifiedStage struct {
+	// VerifiedAt is the time at which the Freight was first observed to be
+	// verified in the Stage.
+	VerifiedAt *metav1.Time `json:"verifiedAt,omitempty"`
+}
+
+// ApprovedStage describes a Stage for which Freight has been manually
+// approved.
+type ApprovedStage struct {
+	// ApprovedAt is the time at which the Freight was approved for the Stage.
+	ApprovedAt *metav1.Time `json:"approvedAt,omitempty"`
+	// ApprovedBy identifies the subject that approved the Freight for the Stage.
+	ApprovedBy string `json:"approvedBy,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FreightList is a list of Freight resources.
+type FreightList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Freight `json:"items"`
+}
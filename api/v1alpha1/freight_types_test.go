@@ -0,0 +1,74 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFreightToSimpleFreight(t *testing.T) {
+	creationTime := metav1.NewTime(time.Now().Truncate(time.Second))
+	freight := &Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "fake-freight",
+			CreationTimestamp: creationTime,
+		},
+		Commits: []GitCommit{
+			{
+				RepoURL: "fake-git-url",
+				ID:      "fake-commit-id",
+			},
+		},
+		Images: []Image{
+			{
+				RepoURL: "fake-image-url",
+				Tag:     "fake-tag",
+			},
+		},
+		Charts: []Chart{
+			{
+				RegistryURL: "fake-registry-url",
+				Name:        "fake-chart",
+				Version:     "fake-version",
+			},
+		},
+	}
+	simpleFreight := freight.ToSimpleFreight()
+	require.Equal(t, "fake-freight", simpleFreight.ID)
+	require.Equal(t, &creationTime, simpleFreight.FirstSeen)
+	require.Equal(t, freight.Commits, simpleFreight.Commits)
+	require.Equal(t, freight.Images, simpleFreight.Images)
+	require.Equal(t, freight.Charts, simpleFreight.Charts)
+	require.False(t, simpleFreight.Qualified)
+	// Modifying the copy should not affect the original
+	simpleFreight.Images[0].Tag = "another-fake-tag"
+	require.Equal(t, "fake-tag", freight.Images[0].Tag)
+}
+
+func TestFreightIsVerifiedIn(t *testing.T) {
+	freight := &Freight{
+		Status: FreightStatus{
+			VerifiedIn: map[string]VerifiedStage{
+				"fake-stage": {},
+			},
+		},
+	}
+	require.True(t, freight.IsVerifiedIn("fake-stage"))
+	require.False(t, freight.IsVerifiedIn("another-fake-stage"))
+	require.False(t, (&Freight{}).IsVerifiedIn("fake-stage"))
+}
+
+func TestFreightIsApprovedFor(t *testing.T) {
+	freight := &Freight{
+		Status: FreightStatus{
+			ApprovedFor: map[string]ApprovedStage{
+				"fake-stage": {},
+			},
+		},
+	}
+	require.True(t, freight.IsApprovedFor("fake-stage"))
+	require.False(t, freight.IsApprovedFor("another-fake-stage"))
+	require.False(t, (&Freight{}).IsApprovedFor("fake-stage"))
+}
@@ -27,6 +27,8 @@ var (
 // addKnownTypes adds the set of types defined in this package to the supplied scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(GroupVersion,
+		&Freight{},
+		&FreightList{},
 		&Stage{},
 		&StageList{},
 		&Promotion{},
@@ -2,8 +2,14 @@ package v1alpha1
 
 const (
 	LabelProjectKey = "kargo.akuity.io/project"
+	LabelFreightKey = "kargo.akuity.io/freight"
 
 	LabelTrueValue = "true"
 
 	AnnotationKeyRefresh = "kargo.akuity.io/refresh"
+	AnnotationKeyAbort   = "kargo.akuity.io/abort"
+
+	// AnnotationKeyCreateActor is the key of an annotation that identifies the
+	// user who created a resource, when known.
+	AnnotationKeyCreateActor = "kargo.akuity.io/create-actor"
 )
@@ -0,0 +1,59 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetPromotion returns a pointer to the Promotion resource specified by the
+// namespacedName argument. If no such resource is found, nil is returned
+// instead.
+func GetPromotion(
+	ctx context.Context,
+	c client.Client,
+	namespacedName types.NamespacedName,
+) (*Promotion, error) {
+	promo := Promotion{}
+	if err := c.Get(ctx, namespacedName, &promo); err != nil {
+		if err = client.IgnoreNotFound(err); err == nil {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(
+			err,
+			"error getting Promotion %q in namespace %q",
+			namespacedName.Name,
+			namespacedName.Namespace,
+		)
+	}
+	return &promo, nil
+}
+
+// AbortPromotion requests that a Promotion be aborted by setting an annotation
+// on the Promotion, causing the controller to remove it from its queue or, if
+// it is already being executed, to interrupt it. Currently, the annotation
+// value is the timestamp of the request.
+func AbortPromotion(
+	ctx context.Context,
+	c client.Client,
+	namespacedName types.NamespacedName,
+) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	promo := Promotion{}
+	promo.Name = namespacedName.Name
+	promo.Namespace = namespacedName.Namespace
+	patchBytes := []byte(fmt.Sprintf(`{"metadata":{"annotations":{"%s":"%s"}}}`, AnnotationKeyAbort, now))
+	patch := client.RawPatch(types.MergePatchType, patchBytes)
+	return c.Patch(ctx, &promo, patch)
+}
+
+// IsAbortRequested returns true if a user has requested that the Promotion be
+// aborted.
+func (p *Promotion) IsAbortRequested() bool {
+	_, ok := p.Annotations[AnnotationKeyAbort]
+	return ok
+}
@@ -9,14 +9,17 @@ type PromotionPhase string
 const (
 	// PromotionPhasePending denotes a Promotion that has not been executed yet.
 	// i.e. It is currently waiting in a queue. Queues are stage-specific and
-	// prioritized by Promotion creation time.
+	// prioritized by Promotion priority, then by Promotion creation time.
 	PromotionPhasePending PromotionPhase = "Pending"
 	// PromotionPhaseRunning denotes a Promotion that is actively being executed.
-	//
-	// TODO: "Active" is the operative word here. We are leaving room for the
-	// possibility in the near future that an in-progress Promotion might be
-	// paused/suspended pending some user action.
+	// While a Promotion is Running, the controller executing it periodically
+	// renews a lease recorded in the Promotion's status.
 	PromotionPhaseRunning PromotionPhase = "Running"
+	// PromotionPhaseWaiting denotes a Promotion that has been executed as far as
+	// it can be without outside intervention. e.g. It has proposed changes via a
+	// pull request that has not been merged yet. A Waiting Promotion is checked
+	// periodically and proceeds once the intervention has occurred.
+	PromotionPhaseWaiting PromotionPhase = "Waiting"
 	// PromotionPhaseSucceeded denotes a Promotion that has been successfully
 	// executed.
 	PromotionPhaseSucceeded PromotionPhase = "Succeeded"
@@ -28,11 +31,20 @@ const (
 	// room for the possibility in the near future that a Promotion might fail
 	// as a result of some user action.
 	PromotionPhaseErrored PromotionPhase = "Errored"
+	// PromotionPhaseAborted denotes a Promotion that was aborted at a user's
+	// request before it could complete. An aborted Promotion that was already
+	// being executed may have been partially applied.
+	PromotionPhaseAborted PromotionPhase = "Aborted"
+	// PromotionPhaseSuperseded denotes a Promotion that was never executed
+	// because a newer Promotion for the same Stage was created while it was
+	// still Pending and the Stage's PromotionPolicy enables superseding.
+	PromotionPhaseSuperseded PromotionPhase = "Superseded"
 )
 
 // IsTerminal returns true if the PromotionPhase is a terminal one.
 func (p *PromotionPhase) IsTerminal() bool {
-	return *p == PromotionPhaseSucceeded || *p == PromotionPhaseErrored
+	return *p == PromotionPhaseSucceeded || *p == PromotionPhaseErrored ||
+		*p == PromotionPhaseAborted || *p == PromotionPhaseSuperseded
 }
 
 //+kubebuilder:resource:shortName={promo,promos}
@@ -40,6 +52,8 @@ func (p *PromotionPhase) IsTerminal() bool {
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name=Stage,type=string,JSONPath=`.spec.stage`
 //+kubebuilder:printcolumn:name=Freight,type=string,JSONPath=`.spec.freight`
+//+kubebuilder:printcolumn:name=Rollback,type=boolean,JSONPath=`.spec.rollback`
+//+kubebuilder:printcolumn:name=Priority,type=integer,JSONPath=`.spec.priority`,priority=1
 //+kubebuilder:printcolumn:name=Phase,type=string,JSONPath=`.status.phase`
 //+kubebuilder:printcolumn:name=Age,type=date,JSONPath=`.metadata.creationTimestamp`
 
@@ -73,12 +87,27 @@ type PromotionSpec struct {
 	//+kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$
 	Stage string `json:"stage"`
 	// Freight specifies the specific Freight into which the Stage referenced by
-	// the Stage field should be transitioned. The Freight MUST be among the Stage's
-	// Status.AvailableFreight or the Promotion will ultimately fail.
-	//
+	// the Stage field should be transitioned. The Freight MUST be among the
+	// Stage's Status.AvailableFreight or otherwise be a Freight resource in the
+	// Stage's namespace that is eligible for promotion to the Stage.
 	//
 	//+kubebuilder:validation:MinLength=1
 	Freight string `json:"freight"`
+	// FreightSnapshot is a complete copy of the Freight referenced by the
+	// Freight field, as resolved at the time this Promotion was created. It is
+	// populated automatically and, like the rest of the spec, is immutable.
+	// Promotions are executed using this snapshot, which makes every Promotion
+	// a self-contained record of exactly what was promoted.
+	FreightSnapshot *SimpleFreight `json:"freightSnapshot,omitempty"`
+	// Rollback denotes whether this Promotion returns the Stage to Freight that
+	// was previously deployed to it. The Freight referenced by the Freight field
+	// of a rollback Promotion is resolved from the Stage's Status.History.
+	Rollback bool `json:"rollback,omitempty"`
+	// Priority influences the order in which Pending Promotions for the same
+	// Stage are executed. Promotions with a higher priority are executed before
+	// those with a lower priority. Promotions of equal priority are executed in
+	// the order they were created. This field defaults to 0.
+	Priority int32 `json:"priority,omitempty"`
 }
 
 // PromotionStatus describes the current state of the transition represented by
@@ -90,6 +119,85 @@ type PromotionStatus struct {
 	// from executing this Promotion. i.e. If the Phase field has a value of
 	// Failed, this field can be expected to explain why.
 	Error string `json:"error,omitempty"`
+	// Message is a human-readable explanation of the Promotion's current state.
+	// i.e. If the Phase field has a value of Pending, this field may explain why
+	// the Promotion is not proceeding.
+	Message string `json:"message,omitempty"`
+	// Attempts records each attempt that has been made to execute this
+	// Promotion, in order.
+	Attempts []PromotionAttempt `json:"attempts,omitempty"`
+	// NextAttemptAt is the earliest time at which a failed Promotion that is
+	// awaiting a retry will be attempted again.
+	NextAttemptAt *metav1.Time `json:"nextAttemptAt,omitempty"`
+	// Lease records which controller is executing a Running Promotion and when
+	// it last confirmed that it is still doing so. A Running Promotion whose
+	// lease has expired was left behind by a controller that stopped while
+	// executing it.
+	Lease *PromotionLease `json:"lease,omitempty"`
+	// Steps describes each step taken by the promotion mechanisms during the
+	// most recent attempt to execute this Promotion, in order.
+	Steps []PromotionStep `json:"steps,omitempty"`
+}
+
+// PromotionAttempt describes a single attempt to execute a Promotion.
+type PromotionAttempt struct {
+	// StartedAt is the time at which the attempt began.
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+	// Error describes why the attempt failed. It is empty if the attempt
+	// succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// PromotionLease records which controller is executing a Running Promotion.
+type PromotionLease struct {
+	// HolderIdentity identifies the controller executing the Promotion.
+	HolderIdentity string `json:"holderIdentity,omitempty"`
+	// RenewTime is the last time the controller executing the Promotion
+	// confirmed that it is still doing so.
+	RenewTime *metav1.Time `json:"renewTime,omitempty"`
+}
+
+type PromotionStepPhase string
+
+const (
+	// PromotionStepPhaseSucceeded denotes a step that completed successfully.
+	PromotionStepPhaseSucceeded PromotionStepPhase = "Succeeded"
+	// PromotionStepPhaseWaiting denotes a step that proposed changes via a pull
+	// request that has not been merged yet.
+	PromotionStepPhaseWaiting PromotionStepPhase = "Waiting"
+	// PromotionStepPhaseErrored denotes a step that failed.
+	PromotionStepPhaseErrored PromotionStepPhase = "Errored"
+)
+
+// PromotionStep describes a single step taken by a promotion mechanism while
+// executing a Promotion. e.g. A commit to a Git repository or an update to an
+// Argo CD Application.
+type PromotionStep struct {
+	// Mechanism is the name of the promotion mechanism that took the step.
+	Mechanism string `json:"mechanism"`
+	// StartedAt is the time at which the step began.
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+	// FinishedAt is the time at which the step completed.
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty"`
+	// RepoURL is the URL of the Git repository that was updated, if any.
+	RepoURL string `json:"repoURL,omitempty"`
+	// Branch is the branch of the Git repository that was written to, if any.
+	Branch string `json:"branch,omitempty"`
+	// CommitID is the ID of the commit that resulted from the step, if any.
+	CommitID string `json:"commitID,omitempty"`
+	// PullRequestURL is the URL of the pull request via which the step proposed
+	// changes, if any.
+	PullRequestURL string `json:"pullRequestURL,omitempty"`
+	// SigningKeyFingerprint is the fingerprint of the key with which the
+	// step's commits were signed, if any.
+	SigningKeyFingerprint string `json:"signingKeyFingerprint,omitempty"`
+	// Application is the namespace and name, in the form <namespace>/<name>, of
+	// the Argo CD Application that was updated, if any.
+	Application string `json:"application,omitempty"`
+	// Phase describes the outcome of the step.
+	Phase PromotionStepPhase `json:"phase,omitempty"`
+	// Message explains why the step failed. It is empty if the step succeeded.
+	Message string `json:"message,omitempty"`
 }
 
 //+kubebuilder:object:root=true
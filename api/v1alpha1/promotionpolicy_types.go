@@ -23,11 +23,69 @@ type PromotionPolicy struct {
 	// other conditions also required for an auto-promotion to occur.
 	// Specifically, there must be a single source of new Freight, so regardless
 	// of the value of this field, an auto-promotion could never occur for a Stage
-	// subscribed to MULTIPLE upstream Stages. This field defaults to false, but
-	// is commonly set to true for Stages that subscribe to repositories instead
-	// of other, upstream Stages. This allows users to define Stages that are
-	// automatically updated as soon as new materials are detected.
+	// subscribed to MULTIPLE upstream Stages unless that Stage also specifies an
+	// upstream join strategy. This field defaults to false, but is commonly set
+	// to true for Stages that subscribe to repositories instead of other,
+	// upstream Stages. This allows users to define Stages that are automatically
+	// updated as soon as new materials are detected.
 	EnableAutoPromotion bool `json:"enableAutoPromotion,omitempty"`
+	// Windows describes recurring periods of time during which Promotions to
+	// the Stage referenced by the Stage field are permitted to proceed. When
+	// this field is non-empty, Promotions, whether created automatically or
+	// manually, will only proceed during one of these windows. Outside of them,
+	// Promotions remain Pending.
+	Windows []PromotionWindow `json:"windows,omitempty"`
+	// Blackouts describes recurring periods of time during which Promotions to
+	// the Stage referenced by the Stage field are NOT permitted to proceed, such
+	// as weekends or declared change freezes. Blackouts take precedence over
+	// Windows. During a blackout, Promotions remain Pending.
+	Blackouts []PromotionWindow `json:"blackouts,omitempty"`
+	// Retry describes how Promotions to the Stage referenced by the Stage field
+	// are retried after failing. When left unspecified, a Promotion is attempted
+	// only once.
+	Retry *PromotionRetry `json:"retry,omitempty"`
+	// Timeout limits how long a Promotion to the Stage referenced by the Stage
+	// field may take, measured from the start of its first attempt and
+	// including any retries. A Promotion that exceeds this limit fails and is
+	// not retried. When left unspecified, Promotions are not time-limited.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Supersede indicates whether the creation of a new Promotion for the Stage
+	// referenced by the Stage field supersedes any older Promotions for the
+	// same Stage that are still Pending. Superseded Promotions are never
+	// executed. This is useful when only the newest of several queued
+	// Promotions matters. This field defaults to false.
+	Supersede bool `json:"supersede,omitempty"`
+}
+
+// PromotionRetry describes how failed Promotions are retried.
+type PromotionRetry struct {
+	// MaxAttempts is the maximum number of times a Promotion will be attempted,
+	// including the first attempt.
+	//
+	//+kubebuilder:validation:Minimum=1
+	MaxAttempts int32 `json:"maxAttempts"`
+	// Backoff is how long to wait after the first failed attempt before trying
+	// again. The wait doubles after each subsequent failed attempt, up to
+	// MaxBackoff. When left unspecified, this defaults to 10s.
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+	// MaxBackoff is the longest to wait between attempts. When left
+	// unspecified, this defaults to 5m.
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
+// PromotionWindow describes a recurring period of time.
+type PromotionWindow struct {
+	// Schedule is a standard, five field cron expression (e.g. "0 22 * * 5")
+	// describing when the period of time begins.
+	//
+	//+kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+	// Duration describes how long the period of time lasts after each time it
+	// begins (e.g. "2h" or "60h30m").
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone is the name of the IANA time zone (e.g. "America/New_York") in
+	// which the Schedule is interpreted. When left unspecified, UTC is assumed.
+	TimeZone string `json:"timeZone,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 
+	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -20,12 +21,47 @@ const (
 	ImageUpdateStrategyDigest ImageUpdateStrategy = "Digest"
 )
 
-// +kubebuilder:validation:Enum={Image,Tag}
+// +kubebuilder:validation:Enum={NewestFromBranch,SemVer,Lexical,NewestTag}
+type CommitSelectionStrategy string
+
+const (
+	// CommitSelectionStrategyNewestFromBranch selects the commit at the head of
+	// a branch.
+	CommitSelectionStrategyNewestFromBranch CommitSelectionStrategy = "NewestFromBranch"
+	// CommitSelectionStrategySemVer selects the commit referenced by the tag
+	// representing the greatest semantic version.
+	CommitSelectionStrategySemVer CommitSelectionStrategy = "SemVer"
+	// CommitSelectionStrategyLexical selects the commit referenced by the tag
+	// whose name is lexically greatest.
+	CommitSelectionStrategyLexical CommitSelectionStrategy = "Lexical"
+	// CommitSelectionStrategyNewestTag selects the commit referenced by the
+	// most recently created tag.
+	CommitSelectionStrategyNewestTag CommitSelectionStrategy = "NewestTag"
+)
+
+// +kubebuilder:validation:Enum={Image,Tag,Digest,Tag@Digest}
 type ImageUpdateValueType string
 
 const (
 	ImageUpdateValueTypeImage ImageUpdateValueType = "Image"
 	ImageUpdateValueTypeTag   ImageUpdateValueType = "Tag"
+	// ImageUpdateValueTypeDigest references an image by its digest alone.
+	ImageUpdateValueTypeDigest ImageUpdateValueType = "Digest"
+	// ImageUpdateValueTypeTagAtDigest references an image by its tag and its
+	// digest, i.e. <tag>@<digest>.
+	ImageUpdateValueTypeTagAtDigest ImageUpdateValueType = "Tag@Digest"
+)
+
+// +kubebuilder:validation:Enum={All,Any}
+type JoinStrategy string
+
+const (
+	// JoinStrategyAll makes Freight available to a Stage only once it has been
+	// qualified in all of the Stage's upstream Stages.
+	JoinStrategyAll JoinStrategy = "All"
+	// JoinStrategyAny makes Freight available to a Stage as soon as it has been
+	// qualified in any one of the Stage's upstream Stages.
+	JoinStrategyAny JoinStrategy = "Any"
 )
 
 type HealthState string
@@ -110,6 +146,13 @@ type StageSpec struct {
 	// Stages to be able to subscribe to a single upstream Stage where they may
 	// otherwise have subscribed to multiple upstream Stages.
 	PromotionMechanisms *PromotionMechanisms `json:"promotionMechanisms,omitempty"`
+	// Verification describes how to verify that a Promotion to this Stage was
+	// successful before the Stage's current Freight is qualified for promotion
+	// to downstream Stages. This is an optional field. When left unspecified,
+	// Freight is qualified as soon as the Stage is observed to be synced and
+	// healthy. Verification has no effect on Stages without promotion
+	// mechanisms.
+	Verification *Verification `json:"verification,omitempty"`
 }
 
 // Subscriptions describes a Stage's sources of material.
@@ -120,6 +163,15 @@ type Subscriptions struct {
 	// UpstreamStages identifies other Stages as potential sources of material
 	// for this Stage. This field is mutually exclusive with the Repos field.
 	UpstreamStages []StageSubscription `json:"upstreamStages,omitempty"`
+	// UpstreamJoinStrategy specifies how Freight from multiple upstream Stages
+	// is joined to determine what Freight is available to this Stage. "All"
+	// makes Freight available only once it has been qualified in every upstream
+	// Stage. "Any" makes Freight available once it has been qualified in any
+	// upstream Stage. When left unspecified, Freight qualified in any upstream
+	// Stage is available for manual promotion, but auto-promotion will not
+	// proceed for a Stage with multiple upstream Stages. This field has no
+	// effect when the UpstreamStages field is empty.
+	UpstreamJoinStrategy JoinStrategy `json:"upstreamJoinStrategy,omitempty"`
 }
 
 // RepoSubscriptions describes various sorts of repositories a Stage uses
@@ -147,6 +199,68 @@ type GitSubscription struct {
 	//+kubebuilder:validation:MinLength=1
 	//+kubebuilder:validation:Pattern=`^\w+([-/]\w+)*$`
 	Branch string `json:"branch,omitempty"`
+	// CommitSelectionStrategy specifies the rules for how to identify the newest
+	// commit of interest in the repository. When this is NewestFromBranch, the
+	// commit at the head of the branch specified by the Branch field is
+	// selected. All other strategies select the commit referenced by one of the
+	// repository's tags and cannot be used in conjunction with the Branch field.
+	// This field is optional. When left unspecified, the field is implicitly
+	// treated as if its value were "NewestFromBranch".
+	//
+	// +kubebuilder:default=NewestFromBranch
+	CommitSelectionStrategy CommitSelectionStrategy `json:"commitSelectionStrategy,omitempty"`
+	// SemverConstraint specifies constraints on what tags are permissible. The
+	// value in this field only has any effect when the CommitSelectionStrategy
+	// is SemVer. This field is also optional. When left unspecified (and the
+	// CommitSelectionStrategy is SemVer), there will be no constraints, which
+	// means the commit referenced by the latest semantically versioned tag will
+	// always be used.
+	//
+	//+kubebuilder:validation:Optional
+	SemverConstraint string `json:"semverConstraint,omitempty"`
+	// AllowTags is a regular expression that can optionally be used to limit the
+	// tags that are considered in determining the newest commit of interest.
+	// This field is optional and has no effect when the CommitSelectionStrategy
+	// is NewestFromBranch.
+	//
+	//+kubebuilder:validation:Optional
+	AllowTags string `json:"allowTags,omitempty"`
+	// IgnoreTags is a list of regular expressions. Tags matching any of them in
+	// their entirety are ignored when determining the newest commit of interest.
+	// This field is optional and has no effect when the CommitSelectionStrategy
+	// is NewestFromBranch.
+	//
+	//+kubebuilder:validation:Optional
+	IgnoreTags []string `json:"ignoreTags,omitempty"`
+	// IncludePaths is a list of git glob pathspecs, e.g. "apps/**". When
+	// specified, commits that do not change any file matching at least one of
+	// them are disregarded, so the newest commit of interest is the newest commit
+	// to have changed a relevant file. This field is optional and may only be
+	// used when the CommitSelectionStrategy is NewestFromBranch.
+	//
+	//+kubebuilder:validation:Optional
+	IncludePaths []string `json:"includePaths,omitempty"`
+	// ExcludePaths is a list of git glob pathspecs. Changes to files matching
+	// any of them are disregarded, so commits that change only such files are
+	// not of interest. This field is optional and may only be used when the
+	// CommitSelectionStrategy is NewestFromBranch.
+	//
+	//+kubebuilder:validation:Optional
+	ExcludePaths []string `json:"excludePaths,omitempty"`
+	// IgnoreMessages is a list of regular expressions. Commits whose message
+	// matches any of them, e.g. \[skip kargo\], are disregarded. This field is
+	// optional and may only be used when the CommitSelectionStrategy is
+	// NewestFromBranch.
+	//
+	//+kubebuilder:validation:Optional
+	IgnoreMessages []string `json:"ignoreMessages,omitempty"`
+	// IgnoreAuthors is a list of regular expressions. Commits whose author, in
+	// the form "Name <email>", matches any of them are disregarded. This field is
+	// optional and may only be used when the CommitSelectionStrategy is
+	// NewestFromBranch.
+	//
+	//+kubebuilder:validation:Optional
+	IgnoreAuthors []string `json:"ignoreAuthors,omitempty"`
 }
 
 // ImageSubscription defines a subscription to an image repository.
@@ -160,7 +274,9 @@ type ImageSubscription struct {
 	// GitRepoURL optionally specifies the URL of a Git repository that contains
 	// the source code for the image repository referenced by the RepoURL field.
 	// When this is specified, Kargo MAY be able to infer and link to the exact
-	// revision of that source code that was used to build the image.
+	// revision of that source code that was used to build the image. Images
+	// that record their source using the standard
+	// org.opencontainers.image.source label do not require this.
 	//
 	//+kubebuilder:validation:Optional
 	//+kubebuilder:validation:Pattern=`^(((https?://)|([\w-]+@))([\w\d\.]+)(:[\d]+)?/(.*))?$`
@@ -207,6 +323,90 @@ type ImageSubscription struct {
 	//
 	//+kubebuilder:validation:Optional
 	Platform string `json:"platform,omitempty"`
+	// Verification optionally specifies a policy that cosign signatures of an
+	// image must satisfy for the image to be eligible for use in Freight. Tags
+	// whose signatures (or attestations) do not satisfy the policy are passed
+	// over in favor of the next newest version of the image. This field is
+	// optional.
+	//
+	//+kubebuilder:validation:Optional
+	Verification *ImageVerification `json:"verification,omitempty"`
+}
+
+// ImageVerification describes a policy that cosign signatures of an image must
+// satisfy. An image satisfies the policy if it has at least one signature made
+// using any of the specified public keys or by any of the specified keyless
+// identities. At least one of PublicKeys or Keyless must be specified.
+type ImageVerification struct {
+	// PublicKeys is a list of PEM-encoded public keys. Signatures made using any
+	// of these keys are trusted.
+	//
+	//+kubebuilder:validation:Optional
+	PublicKeys []string `json:"publicKeys,omitempty"`
+	// Keyless describes identities whose keyless signatures, i.e. signatures
+	// made using short-lived certificates, such as those issued by Sigstore's
+	// Fulcio, are trusted.
+	//
+	//+kubebuilder:validation:Optional
+	Keyless *KeylessVerification `json:"keyless,omitempty"`
+	// Attestations is a list of in-toto predicate types, e.g.
+	// https://slsa.dev/provenance/v0.2, for each of which an image must ALSO
+	// have an attestation that is signed by a trusted key or identity.
+	//
+	//+kubebuilder:validation:Optional
+	Attestations []string `json:"attestations,omitempty"`
+}
+
+// KeylessVerification describes identities whose keyless signatures are
+// trusted.
+type KeylessVerification struct {
+	// Identities is a list of identities. A keyless signature is trusted if the
+	// certificate it was made with was issued to any of these identities.
+	//
+	//+kubebuilder:validation:MinItems=1
+	Identities []KeylessIdentity `json:"identities"`
+	// RootCertificates is a PEM-encoded bundle of the certificates, e.g. those
+	// of Sigstore's Fulcio, that keyless signing certificates must chain to.
+	// Intermediate certificates may also be included.
+	//
+	//+kubebuilder:validation:MinLength=1
+	RootCertificates string `json:"rootCertificates"`
+	// TransparencyLogPublicKey is the PEM-encoded public key of the
+	// transparency log, e.g. Sigstore's Rekor, that keyless signatures must have
+	// been recorded in. Keyless signing certificates are only briefly valid, so a
+	// signature is only trusted if the transparency log attests to it having
+	// been recorded while its certificate was valid.
+	//
+	//+kubebuilder:validation:MinLength=1
+	TransparencyLogPublicKey string `json:"transparencyLogPublicKey"`
+}
+
+// KeylessIdentity describes an identity that keyless signing certificates may
+// be issued to. A certificate matches the identity if it matches every field
+// that is specified. At least one of Issuer or IssuerRegexp and at least one
+// of Subject or SubjectRegexp must be specified.
+type KeylessIdentity struct {
+	// Issuer is the exact OIDC issuer, e.g.
+	// https://token.actions.githubusercontent.com, that authenticated the
+	// signer.
+	//
+	//+kubebuilder:validation:Optional
+	Issuer string `json:"issuer,omitempty"`
+	// IssuerRegexp is a regular expression that the OIDC issuer that
+	// authenticated the signer must match.
+	//
+	//+kubebuilder:validation:Optional
+	IssuerRegexp string `json:"issuerRegexp,omitempty"`
+	// Subject is the exact subject, i.e. an email address or URI, e.g. that of
+	// a GitHub Actions workflow, that the certificate was issued to.
+	//
+	//+kubebuilder:validation:Optional
+	Subject string `json:"subject,omitempty"`
+	// SubjectRegexp is a regular expression that the subject that the
+	// certificate was issued to must match.
+	//
+	//+kubebuilder:validation:Optional
+	SubjectRegexp string `json:"subjectRegexp,omitempty"`
 }
 
 // ChartSubscription defines a subscription to a Helm chart repository.
@@ -295,6 +495,53 @@ type GitRepoUpdate struct {
 	// the Stage. This is mutually exclusive with the Bookkeeper and Kustomize
 	// fields.
 	Helm *HelmPromotionMechanism `json:"helm,omitempty"`
+	// PullRequest, when specified, indicates that updates should be proposed to
+	// the branch specified by the WriteBranch field via a pull request instead
+	// of being committed to it directly. A Promotion that opens a pull request
+	// waits for it to be merged before it proceeds. This is not supported in
+	// conjunction with the Bookkeeper field.
+	PullRequest *PullRequestPromotionMechanism `json:"pullRequest,omitempty"`
+	// CommitMessageTemplate is a Go template from which the message of any
+	// commit made to the repository is rendered. The template has access to the
+	// Stage (.Stage), the Promotion (.Promotion), the Freight being promoted
+	// (.Freight) and a summary of the changes being made (.Changes). This field
+	// is optional. When not specified, a message summarizing the changes is
+	// used. When used in conjunction with the Bookkeeper field, only the first
+	// line of the message Bookkeeper generates is replaced.
+	CommitMessageTemplate string `json:"commitMessageTemplate,omitempty"`
+	// Author describes the identity under which any commit made to the
+	// repository is authored. This field is optional. When not specified, a
+	// default identity is used. This is not supported in conjunction with the
+	// Bookkeeper field.
+	Author *GitAuthor `json:"author,omitempty"`
+}
+
+// GitAuthor describes the identity under which commits are authored.
+type GitAuthor struct {
+	// Name is the name of the author.
+	Name string `json:"name,omitempty"`
+	// Email is the email address of the author.
+	Email string `json:"email,omitempty"`
+	// UsePromotionCreator indicates that commits should be authored by the user
+	// who created the Promotion, when known. That user's name is used in place
+	// of the Name field and, if it is an email address, in place of the Email
+	// field as well. When the Promotion's creator is not known, as is the case
+	// for automatic Promotions, the Name and Email fields are used as-is.
+	UsePromotionCreator bool `json:"usePromotionCreator,omitempty"`
+}
+
+// PullRequestPromotionMechanism describes how to propose updates to a Git
+// repository via a pull request.
+type PullRequestPromotionMechanism struct {
+	// Provider identifies the Git hosting provider to open the pull request
+	// with. This field is optional. When not specified, the provider is inferred
+	// from the repository URL, which works for repositories hosted on github.com
+	// or gitlab.com. The repository's credentials must include a token, in their
+	// password field, that is permitted to open pull requests.
+	//
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Enum=github;gitlab;gitea
+	Provider string `json:"provider,omitempty"`
 }
 
 // BookkeeperPromotionMechanism describes how to use Bookkeeper to incorporate
@@ -324,6 +571,13 @@ type KustomizeImageUpdate struct {
 	//+kubebuilder:validation:MinLength=1
 	//+kubebuilder:validation:Pattern=^[\w-\.]+(/[\w-\.]+)*$
 	Path string `json:"path"`
+	// Value specifies how the image is referenced. Valid values are "Digest",
+	// which references the image by <image name>@<digest>, and "Tag@Digest",
+	// which references the image by <image name>:<tag>@<digest>. Otherwise, the
+	// image is referenced by <image name>:<tag>.
+	//
+	//+kubebuilder:validation:Optional
+	Value ImageUpdateValueType `json:"value,omitempty"`
 }
 
 // HelmPromotionMechanism describes how to use Helm to incorporate newly
@@ -358,8 +612,11 @@ type HelmImageUpdate struct {
 	Key string `json:"key"`
 	// Value specifies the new value for the specified key in the specified Helm
 	// values file. Valid values are "Image", which replaces the value of the
-	// specified key with the entire <image name>:<tag>, or "Tag" which replaces
-	// the value of the specified with just the new tag. This is a required field.
+	// specified key with the entire <image name>:<tag>, "Tag" which replaces
+	// the value of the specified with just the new tag, "Digest" which replaces
+	// the value of the specified key with just the new digest, or "Tag@Digest"
+	// which replaces the value of the specified key with <tag>@<digest>. This is
+	// a required field.
 	Value ImageUpdateValueType `json:"value"`
 }
 
@@ -454,6 +711,13 @@ type ArgoCDKustomize struct {
 	//
 	//+kubebuilder:validation:MinItems=1
 	Images []string `json:"images"`
+	// Value specifies how the images are referenced. Valid values are "Digest",
+	// which references each image by <image name>@<digest>, and "Tag@Digest",
+	// which references each image by <image name>:<tag>@<digest>. Otherwise,
+	// images are referenced by <image name>:<tag>.
+	//
+	//+kubebuilder:validation:Optional
+	Value ImageUpdateValueType `json:"value,omitempty"`
 }
 
 // ArgoCDHelm describes updates to an Argo CD Application source's Helm-specific
@@ -480,25 +744,66 @@ type ArgoCDHelmImageUpdate struct {
 	Key string `json:"key"`
 	// Value specifies the new value for the specified key in the Argo CD
 	// Application's Helm parameters. Valid values are "Image", which replaces the
-	// value of the specified key with the entire <image name>:<tag>, or "Tag"
-	// which replaces the value of the specified with just the new tag. This is a
-	// required field.
+	// value of the specified key with the entire <image name>:<tag>, "Tag" which
+	// replaces the value of the specified with just the new tag, "Digest" which
+	// replaces the value of the specified key with just the new digest, or
+	// "Tag@Digest" which replaces the value of the specified key with
+	// <tag>@<digest>. This is a required field.
 	Value ImageUpdateValueType `json:"value"`
 }
 
+// Verification describes how to verify that a Promotion to a Stage was
+// successful.
+type Verification struct {
+	// Jobs describes Kubernetes Jobs that are run in parallel once a Stage has
+	// been promoted to new Freight and is observed to be synced and healthy. The
+	// Freight is only qualified for promotion to downstream Stages once every
+	// one of these Jobs has completed successfully.
+	//
+	//+kubebuilder:validation:MinItems=1
+	Jobs []VerificationJob `json:"jobs"`
+}
+
+// VerificationJob describes a Kubernetes Job that is used to verify a
+// Promotion to a Stage.
+type VerificationJob struct {
+	// Name is a short name for the Job that is unique within the Stage. It is
+	// used as part of the name of every Job that is created from the Template.
+	// This is a required field.
+	//
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:MaxLength=32
+	//+kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	Name string `json:"name"`
+	// Template is the specification of the Job to be run. It is a
+	// batch/v1 JobSpec. This is a required field.
+	//
+	//+kubebuilder:validation:Type=object
+	//+kubebuilder:validation:Schemaless
+	//+kubebuilder:pruning:PreserveUnknownFields
+	Template batchv1.JobSpec `json:"template"`
+}
+
 // StageStatus describes a Stages's most recently observed Freight as well
 // current and recent Freight.
 type StageStatus struct {
-	// AvailableFreight is a stack of available Freight, where each Freight is
-	// essentially a "bill of materials" describing what can be automatically or
-	// manually deployed to the Stage.
+	// AvailableFreight is a stack of references to Freight that can be
+	// automatically or manually deployed to the Stage. Each reference names a
+	// Freight resource in the Stage's namespace, which describes the Freight's
+	// materials. Only the ten most recently discovered Freight are referenced,
+	// but older Freight remains available for promotion for as long as its
+	// Freight resource exists.
 	AvailableFreight FreightStack `json:"availableFreight,omitempty"`
 	// CurrentFreight is the Stage's current Freight -- a "bill of materials"
-	// describing what is currently deployed to the Stage.
-	CurrentFreight *Freight `json:"currentFreight,omitempty"`
-	// History is a stack of recent Freight, where each Freight is essentially a
-	// "bill of materials" describing what was deployed to the Stage. By default,
-	// the last ten Freight are stored.
+	// describing what is currently deployed to the Stage. Unlike the Freight
+	// referenced by AvailableFreight and History, this is a complete copy of the
+	// Freight's materials, because it also records details that pertain only to
+	// this Stage, such as the commits its health is checked against.
+	CurrentFreight *SimpleFreight `json:"currentFreight,omitempty"`
+	// History is a stack of references to Freight that was recently deployed to
+	// the Stage. Each reference names a Freight resource in the Stage's
+	// namespace, which describes the Freight's materials. The last ten Freight
+	// are referenced.
 	History FreightStack `json:"history,omitempty"`
 	// Health is the Stage's last observed health.
 	Health *Health `json:"health,omitempty"`
@@ -511,12 +816,84 @@ type StageStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// CurrentPromotion is a reference to the currently Running promotion.
 	CurrentPromotion *PromotionInfo `json:"currentPromotion,omitempty"`
+	// Verification describes the progress and outcome of the verification of
+	// the Stage's current Freight. This is only populated for Stages that
+	// specify verification.
+	Verification *VerificationInfo `json:"verification,omitempty"`
+	// RejectedImageTags describes tags of subscribed images that were passed
+	// over the last time the Stage's image subscriptions were synced because
+	// their signatures did not satisfy the subscription's verification policy.
+	RejectedImageTags []RejectedImageTag `json:"rejectedImageTags,omitempty"`
+}
+
+// RejectedImageTag describes a tag of an image that was passed over because
+// its signatures did not satisfy a verification policy.
+type RejectedImageTag struct {
+	// RepoURL describes the repository in which the image can be found.
+	RepoURL string `json:"repoURL"`
+	// Tag identifies the tag that was passed over.
+	Tag string `json:"tag"`
+	// Digest identifies the manifest the tag referenced when it was passed
+	// over.
+	Digest string `json:"digest,omitempty"`
+	// Reason describes why the tag was passed over.
+	Reason string `json:"reason"`
 }
 
-// Freight is a "bill of materials" describing what is, was, or can be deployed
-// to a Stage.
-type Freight struct {
-	// ID is a unique, system-assigned identifier for this Freight.
+type VerificationPhase string
+
+const (
+	VerificationPhaseRunning   VerificationPhase = "Running"
+	VerificationPhaseSucceeded VerificationPhase = "Succeeded"
+	VerificationPhaseFailed    VerificationPhase = "Failed"
+)
+
+// IsTerminal returns true if the VerificationPhase is a terminal one.
+func (v VerificationPhase) IsTerminal() bool {
+	switch v {
+	case VerificationPhaseSucceeded, VerificationPhaseFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// VerificationInfo describes the verification of a Stage's current Freight.
+type VerificationInfo struct {
+	// FreightID is the ID of the Freight that is being verified.
+	FreightID string `json:"freightID"`
+	// Phase describes the overall progress of the verification. It is Succeeded
+	// only when all Jobs have succeeded and Failed as soon as any Job has
+	// failed.
+	Phase VerificationPhase `json:"phase,omitempty"`
+	// Jobs describes the progress of the individual Jobs that were created to
+	// verify the Freight.
+	Jobs []VerificationJobStatus `json:"jobs,omitempty"`
+}
+
+// VerificationJobStatus describes the progress of a single Kubernetes Job that
+// was created to verify a Stage's current Freight.
+type VerificationJobStatus struct {
+	// Name is the name of the VerificationJob from which the Job was created.
+	Name string `json:"name"`
+	// JobName is the name of the Kubernetes Job in the Stage's namespace.
+	JobName string `json:"jobName"`
+	// Phase describes the progress of the Job.
+	Phase VerificationPhase `json:"phase,omitempty"`
+	// Message is a human-readable explanation of the Job's outcome, if any.
+	Message string `json:"message,omitempty"`
+	// LogsRef is a reference to the Job's logs in a form that is accepted by
+	// `kubectl logs`. e.g. job/<job name>
+	LogsRef string `json:"logsRef,omitempty"`
+}
+
+// SimpleFreight is a "bill of materials" describing what is, was, or can be
+// deployed to a Stage. In a Stage's status, it is usually a mere reference to a
+// Freight resource -- i.e. its materials are omitted and must be resolved from
+// the Freight resource it names.
+type SimpleFreight struct {
+	// ID is a unique, system-assigned identifier for this Freight. It is also
+	// the name of the corresponding Freight resource in the Stage's namespace.
 	ID string `json:"id,omitempty"`
 	// FirstSeen represents the date/time when this Freight first entered the
 	// system. This is useful and important information because it enables the
@@ -542,22 +919,46 @@ type Freight struct {
 	// failures in Stage health evaluation do not disqualify a Freight that is
 	// already qualified.
 	Qualified bool `json:"qualified,omitempty"`
+	// Rollback denotes whether this Freight was deployed to a Stage by a
+	// rollback Promotion. This field is only meaningful for Freight in a Stage's
+	// Status.History.
+	Rollback bool `json:"rollback,omitempty"`
 }
 
-func (f *Freight) UpdateFreightID() {
+// Reference returns a copy of this SimpleFreight that omits its materials and
+// therefore merely references the Freight resource named by its ID.
+func (f *SimpleFreight) Reference() SimpleFreight {
+	ref := SimpleFreight{
+		ID:         f.ID,
+		Provenance: f.Provenance,
+		Qualified:  f.Qualified,
+		Rollback:   f.Rollback,
+	}
+	if f.FirstSeen != nil {
+		ref.FirstSeen = f.FirstSeen.DeepCopy()
+	}
+	return ref
+}
+
+func (f *SimpleFreight) UpdateFreightID() {
 	size := len(f.Commits) + len(f.Images) + len(f.Charts)
 	materials := make([]string, 0, size)
 	for _, commit := range f.Commits {
-		materials = append(
-			materials,
-			fmt.Sprintf("%s:%s", commit.RepoURL, commit.ID),
-		)
+		material := fmt.Sprintf("%s:%s", commit.RepoURL, commit.ID)
+		if commit.Tag != "" {
+			// The same commit selected by way of a different tag is different
+			// Freight
+			material = fmt.Sprintf("%s:%s@%s", commit.RepoURL, commit.Tag, commit.ID)
+		}
+		materials = append(materials, material)
 	}
 	for _, image := range f.Images {
-		materials = append(
-			materials,
-			fmt.Sprintf("%s:%s", image.RepoURL, image.Tag),
-		)
+		material := fmt.Sprintf("%s:%s", image.RepoURL, image.Tag)
+		if image.Digest != "" {
+			// The same tag re-pushed with different content is different Freight
+			material = fmt.Sprintf("%s@%s", material, image.Digest)
+		}
+		materials = append(materials, material)
 	}
 	for _, chart := range f.Charts {
 		materials = append(
@@ -572,7 +973,7 @@ func (f *Freight) UpdateFreightID() {
 	)
 }
 
-type FreightStack []Freight
+type FreightStack []SimpleFreight
 
 // Empty returns a bool indicating whether or not the FreightStack is empty.
 // nil counts as empty.
@@ -585,7 +986,7 @@ func (f FreightStack) Empty() bool {
 // is returned instead. A boolean is also returned indicating whether the
 // returned Freight came from the top of the stack (true) or is a zero value for
 // that type (false).
-func (f *FreightStack) Pop() (Freight, bool) {
+func (f *FreightStack) Pop() (SimpleFreight, bool) {
 	item, ok := f.Top()
 	if ok {
 		*f = (*f)[1:]
@@ -598,22 +999,29 @@ func (f *FreightStack) Pop() (Freight, bool) {
 // instead. A boolean is also returned indicating whether the returned Freight
 // came from the top of the stack (true) or is a zero value for that type
 // (false).
-func (f FreightStack) Top() (Freight, bool) {
+func (f FreightStack) Top() (SimpleFreight, bool) {
 	if f.Empty() {
-		return Freight{}, false
+		return SimpleFreight{}, false
 	}
 	item := *f[0].DeepCopy()
 	return item, true
 }
 
-// Push pushes one or more Freight onto the FreightStack. The order of
-// the new elements at the top of the stack will be equal to the order in which
-// they were passed to this function. i.e. The first new element passed will be
-// the element at the top of the stack. If resulting modification grow the depth
-// of the stack beyond 10 elements, the stack is truncated at the bottom. i.e.
-// Modified to contain only the top 10 elements.
-func (f *FreightStack) Push(freight ...Freight) {
-	*f = append(freight, *f...)
+// Push pushes references to one or more Freight onto the FreightStack. The
+// order of the new elements at the top of the stack will be equal to the order
+// in which they were passed to this function. i.e. The first new element passed
+// will be the element at the top of the stack. Only references are pushed, so
+// the materials of the Freight passed to this function are omitted from the
+// stack. If resulting modification grow the depth of the stack beyond 10
+// elements, the stack is truncated at the bottom. i.e. Modified to contain only
+// the top 10 elements. Freight whose references are truncated this way is not
+// lost, as its Freight resource persists.
+func (f *FreightStack) Push(freight ...SimpleFreight) {
+	refs := make([]SimpleFreight, len(freight))
+	for i := range freight {
+		refs[i] = freight[i].Reference()
+	}
+	*f = append(refs, *f...)
 	const max = 10
 	if len(*f) > max {
 		*f = (*f)[:max]
@@ -631,6 +1039,22 @@ type Image struct {
 	// Tag identifies a specific version of the image in the repository specified
 	// by RepoURL.
 	Tag string `json:"tag,omitempty"`
+	// Digest identifies the specific content the tag referenced when it was
+	// discovered. Unlike the tag, it cannot be changed to reference different
+	// content.
+	Digest string `json:"digest,omitempty"`
+	// SourceRepoURL specifies the URL of the repository containing the source
+	// code the image was built from, as recorded by the image's
+	// org.opencontainers.image.source label or, failing that, as specified by
+	// the subscription's GitRepoURL field.
+	SourceRepoURL string `json:"sourceRepoURL,omitempty"`
+	// SourceCommitID identifies the commit in the repository specified by
+	// SourceRepoURL that the image was built from, as recorded by the image's
+	// org.opencontainers.image.revision label.
+	SourceCommitID string `json:"sourceCommitID,omitempty"`
+	// BuildTime is when the image was built, as recorded by the image's
+	// org.opencontainers.image.created label.
+	BuildTime *metav1.Time `json:"buildTime,omitempty"`
 }
 
 // Chart describes a specific version of a Helm chart.
@@ -652,6 +1076,8 @@ type GitCommit struct {
 	ID string `json:"id,omitempty"`
 	// Branch denotes the branch of the repository where this commit was found.
 	Branch string `json:"branch,omitempty"`
+	// Tag denotes the tag through which this commit was found.
+	Tag string `json:"tag,omitempty"`
 	// HealthCheckCommit is the ID of a specific commit. When specified,
 	// assessments of Stage health will used this value (instead of ID) when
 	// determining if applicable sources of Argo CD Application resources
@@ -727,5 +1153,5 @@ type PromotionInfo struct {
 	// Name is the name of the Promotion
 	Name string `json:"name"`
 	// Freight is the freight being promoted
-	Freight Freight `json:"freight"`
+	Freight SimpleFreight `json:"freight"`
 }
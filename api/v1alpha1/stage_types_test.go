@@ -76,7 +76,7 @@ func TestGitCommitEquals(t *testing.T) {
 }
 
 func TestStageFreightUpdateID(t *testing.T) {
-	freight := Freight{
+	freight := SimpleFreight{
 		Commits: []GitCommit{
 			{
 				RepoURL: "fake-git-repo",
@@ -108,6 +108,20 @@ func TestStageFreightUpdateID(t *testing.T) {
 	freight.Commits[0].ID = "a-different-fake-commit"
 	freight.UpdateFreightID()
 	require.NotEqual(t, result, freight.ID)
+	// Including an image's digest
+	result = freight.ID
+	freight.Images[0].Digest = "fake-image-digest"
+	freight.UpdateFreightID()
+	require.NotEqual(t, result, freight.ID)
+	// And the tag by which a commit was selected
+	result = freight.ID
+	freight.Commits[0].Tag = "fake-tag"
+	freight.UpdateFreightID()
+	require.NotEqual(t, result, freight.ID)
+	tagged := freight.ID
+	freight.Commits[0].Tag = "another-fake-tag"
+	freight.UpdateFreightID()
+	require.NotEqual(t, tagged, freight.ID)
 }
 
 func TestStageFreightStackEmpty(t *testing.T) {
@@ -144,28 +158,28 @@ func TestStageFreightStackPop(t *testing.T) {
 		name            string
 		stack           FreightStack
 		expectedStack   FreightStack
-		expectedFreight Freight
+		expectedFreight SimpleFreight
 		expectedOK      bool
 	}{
 		{
 			name:            "stack is nil",
 			stack:           nil,
 			expectedStack:   nil,
-			expectedFreight: Freight{},
+			expectedFreight: SimpleFreight{},
 			expectedOK:      false,
 		},
 		{
 			name:            "stack is empty",
 			stack:           FreightStack{},
 			expectedStack:   FreightStack{},
-			expectedFreight: Freight{},
+			expectedFreight: SimpleFreight{},
 			expectedOK:      false,
 		},
 		{
 			name:            "stack has items",
 			stack:           FreightStack{{ID: "foo"}, {ID: "bar"}},
 			expectedStack:   FreightStack{{ID: "bar"}},
-			expectedFreight: Freight{ID: "foo"},
+			expectedFreight: SimpleFreight{ID: "foo"},
 			expectedOK:      true,
 		},
 	}
@@ -183,25 +197,25 @@ func TestStageFreightStackTop(t *testing.T) {
 	testCases := []struct {
 		name            string
 		stack           FreightStack
-		expectedFreight Freight
+		expectedFreight SimpleFreight
 		expectedOK      bool
 	}{
 		{
 			name:            "stack is nil",
 			stack:           nil,
-			expectedFreight: Freight{},
+			expectedFreight: SimpleFreight{},
 			expectedOK:      false,
 		},
 		{
 			name:            "stack is empty",
 			stack:           FreightStack{},
-			expectedFreight: Freight{},
+			expectedFreight: SimpleFreight{},
 			expectedOK:      false,
 		},
 		{
 			name:            "stack has items",
 			stack:           FreightStack{{ID: "foo"}, {ID: "bar"}},
-			expectedFreight: Freight{ID: "foo"},
+			expectedFreight: SimpleFreight{ID: "foo"},
 			expectedOK:      true,
 		},
 	}
@@ -220,27 +234,56 @@ func TestStageFreightStackPush(t *testing.T) {
 	testCases := []struct {
 		name          string
 		stack         FreightStack
-		newFreight    []Freight
+		newFreight    []SimpleFreight
 		expectedStack FreightStack
 	}{
 		{
 			name:          "initial stack is nil",
 			stack:         nil,
-			newFreight:    []Freight{{ID: "foo"}, {ID: "bar"}},
+			newFreight:    []SimpleFreight{{ID: "foo"}, {ID: "bar"}},
 			expectedStack: FreightStack{{ID: "foo"}, {ID: "bar"}},
 		},
 		{
 			name:          "initial stack is not nil",
 			stack:         FreightStack{{ID: "foo"}},
-			newFreight:    []Freight{{ID: "bar"}},
+			newFreight:    []SimpleFreight{{ID: "bar"}},
 			expectedStack: FreightStack{{ID: "bar"}, {ID: "foo"}},
 		},
+		{
+			name:  "materials are omitted",
+			stack: FreightStack{{ID: "foo"}},
+			newFreight: []SimpleFreight{
+				{
+					ID:        "bar",
+					Qualified: true,
+					Commits: []GitCommit{
+						{
+							RepoURL: "fake-git-repo",
+							ID:      "fake-commit-id",
+						},
+					},
+					Images: []Image{
+						{
+							RepoURL: "fake-image-repo",
+							Tag:     "fake-image-tag",
+						},
+					},
+				},
+			},
+			expectedStack: FreightStack{
+				{
+					ID:        "bar",
+					Qualified: true,
+				},
+				{ID: "foo"},
+			},
+		},
 		{
 			name: "initial stack is full",
 			stack: FreightStack{
 				{}, {}, {}, {}, {}, {}, {}, {}, {}, {},
 			},
-			newFreight: []Freight{{ID: "foo"}},
+			newFreight: []SimpleFreight{{ID: "foo"}},
 			expectedStack: FreightStack{
 				{ID: "foo"}, {}, {}, {}, {}, {}, {}, {}, {}, {},
 			},
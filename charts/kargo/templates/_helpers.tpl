@@ -62,6 +62,10 @@ app.kubernetes.io/component: garbage-collector
 app.kubernetes.io/component: webhooks-server
 {{- end -}}
 
+{{- define "kargo.externalWebhooksServer.labels" -}}
+app.kubernetes.io/component: external-webhooks-server
+{{- end -}}
+
 {{- define "call-nested" }}
 {{- $dot := index . 0 }}
 {{- $subchart := index . 1 }}
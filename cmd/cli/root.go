@@ -15,7 +15,9 @@ import (
 	"github.com/akuity/kargo/internal/api"
 	apiconfig "github.com/akuity/kargo/internal/api/config"
 	"github.com/akuity/kargo/internal/api/kubernetes"
+	"github.com/akuity/kargo/internal/cli/abort"
 	"github.com/akuity/kargo/internal/cli/apply"
+	"github.com/akuity/kargo/internal/cli/approve"
 	"github.com/akuity/kargo/internal/cli/create"
 	"github.com/akuity/kargo/internal/cli/delete"
 	"github.com/akuity/kargo/internal/cli/get"
@@ -90,7 +92,9 @@ func NewRootCommand(opt *option.Option, rs *rootState) (*cobra.Command, error) {
 	option.InsecureTLS(&opt.InsecureTLS)(cmd.PersistentFlags())
 	option.LocalServer(&opt.UseLocalServer)(cmd.PersistentFlags())
 
+	cmd.AddCommand(abort.NewCommand(opt))
 	cmd.AddCommand(apply.NewCommand(opt))
+	cmd.AddCommand(approve.NewCommand(opt))
 	cmd.AddCommand(create.NewCommand(opt))
 	cmd.AddCommand(delete.NewCommand(opt))
 	cmd.AddCommand(get.NewCommand(opt))
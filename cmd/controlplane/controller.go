@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -20,6 +21,8 @@ import (
 	"github.com/akuity/kargo/internal/controller/promotions"
 	"github.com/akuity/kargo/internal/controller/stages"
 	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/discovery"
+	libGit "github.com/akuity/kargo/internal/git"
 	"github.com/akuity/kargo/internal/logging"
 	"github.com/akuity/kargo/internal/os"
 	"github.com/akuity/kargo/internal/types"
@@ -67,6 +70,13 @@ func newControllerCommand() *cobra.Command {
 							"scheme",
 					)
 				}
+				if err = batchv1.AddToScheme(scheme); err != nil {
+					return errors.Wrap(
+						err,
+						"error adding Kubernetes batch API to Kargo controller manager "+
+							"scheme",
+					)
+				}
 				if err = kargoapi.AddToScheme(scheme); err != nil {
 					return errors.Wrap(
 						err,
@@ -146,11 +156,23 @@ func newControllerCommand() *cobra.Command {
 				argoClientForCreds,
 			)
 
+			// Discovery and promotion share a cache of git repositories so that
+			// neither has to clone a repository anew each time it is needed.
+			gitCache, err := libGit.NewCache(
+				os.GetEnv("GIT_CACHE_DIR", "/tmp/kargo-git-cache"),
+				int64(types.MustParseInt(os.GetEnv("GIT_CACHE_MAX_SIZE_MIB", "2048")))<<20,
+			)
+			if err != nil {
+				return errors.Wrap(err, "error initializing git repository cache")
+			}
+
 			if err := stages.SetupReconcilerWithManager(
 				ctx,
 				kargoMgr,
 				appMgr,
 				credentialsDB,
+				gitCache,
+				discovery.NewService(discovery.ConfigFromEnv()),
 				shardName,
 			); err != nil {
 				return errors.Wrap(err, "error setting up Stages reconciler")
@@ -165,7 +187,9 @@ func newControllerCommand() *cobra.Command {
 						LogLevel: bookkeeper.LogLevel(logging.LoggerFromContext(ctx).Level),
 					},
 				),
+				gitCache,
 				shardName,
+				types.MustParseInt(os.GetEnv("MAX_CONCURRENT_PROMOTIONS", "4")),
 			); err != nil {
 				return errors.Wrap(err, "error setting up Promotions reconciler")
 			}
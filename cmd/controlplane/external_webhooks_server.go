@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/api/kubernetes"
+	"github.com/akuity/kargo/internal/os"
+	versionpkg "github.com/akuity/kargo/internal/version"
+	"github.com/akuity/kargo/internal/webhook/external"
+)
+
+func newExternalWebhooksServerCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "external-webhooks-server",
+		DisableAutoGenTag: true,
+		SilenceErrors:     true,
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			version := versionpkg.GetVersion()
+			log.WithFields(log.Fields{
+				"version": version.Version,
+				"commit":  version.GitCommit,
+			}).Info("Starting Kargo External Webhooks Server")
+
+			cfg := external.ServerConfigFromEnv()
+
+			var kubeClient client.Client
+			{
+				restCfg, err :=
+					kubernetes.GetRestConfig(ctx, os.GetEnv("KUBECONFIG", ""))
+				if err != nil {
+					return errors.Wrap(err, "error loading REST config")
+				}
+				scheme := runtime.NewScheme()
+				if err = corev1.AddToScheme(scheme); err != nil {
+					return errors.Wrap(err, "error adding Kubernetes core API to scheme")
+				}
+				if err = kargoapi.AddToScheme(scheme); err != nil {
+					return errors.Wrap(err, "error adding Kargo API to scheme")
+				}
+				if kubeClient, err = client.New(
+					restCfg,
+					client.Options{
+						Scheme: scheme,
+					},
+				); err != nil {
+					return errors.Wrap(err, "error initializing Kubernetes client")
+				}
+			}
+
+			l, err := net.Listen(
+				"tcp",
+				fmt.Sprintf(
+					"%s:%s",
+					os.GetEnv("HOST", "0.0.0.0"),
+					os.GetEnv("PORT", "8080"),
+				),
+			)
+			if err != nil {
+				return errors.Wrap(err, "error creating listener")
+			}
+			defer l.Close()
+
+			return errors.Wrap(
+				external.NewServer(cfg, kubeClient).Serve(ctx, l),
+				"serve",
+			)
+		},
+	}
+}
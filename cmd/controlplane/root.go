@@ -21,6 +21,7 @@ var (
 func Execute(ctx context.Context) error {
 	rootCmd.AddCommand(newAPICommand())
 	rootCmd.AddCommand(newControllerCommand())
+	rootCmd.AddCommand(newExternalWebhooksServerCommand())
 	rootCmd.AddCommand(newGarbageCollectorCommand())
 	rootCmd.AddCommand(newVersionCommand())
 	rootCmd.AddCommand(newWebhooksServerCommand())
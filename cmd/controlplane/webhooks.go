@@ -14,6 +14,7 @@ import (
 	"github.com/akuity/kargo/internal/kubeclient"
 	"github.com/akuity/kargo/internal/os"
 	versionpkg "github.com/akuity/kargo/internal/version"
+	"github.com/akuity/kargo/internal/webhook/freight"
 	"github.com/akuity/kargo/internal/webhook/promotion"
 	"github.com/akuity/kargo/internal/webhook/promotionpolicy"
 	"github.com/akuity/kargo/internal/webhook/stage"
@@ -67,10 +68,19 @@ func newWebhooksServerCommand() *cobra.Command {
 				return errors.Wrap(err, "index PromotionPolicies by Stage")
 			}
 
+			if err = freight.SetupWebhookWithManager(
+				freight.WebhookConfigFromEnv(),
+				mgr,
+			); err != nil {
+				return errors.Wrap(err, "setup Freight webhook")
+			}
 			if err = stage.SetupWebhookWithManager(mgr); err != nil {
 				return errors.Wrap(err, "setup Stage webhook")
 			}
-			if err = promotion.SetupWebhookWithManager(mgr); err != nil {
+			if err = promotion.SetupWebhookWithManager(
+				promotion.WebhookConfigFromEnv(),
+				mgr,
+			); err != nil {
 				return errors.Wrap(err, "setup Promotion webhook")
 			}
 			if err = promotionpolicy.SetupWebhookWithManager(mgr); err != nil {
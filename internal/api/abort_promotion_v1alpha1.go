@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	typesv1alpha1 "github.com/akuity/kargo/internal/api/types/v1alpha1"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+// AbortPromotion requests that the specified Promotion be aborted. A Pending
+// Promotion is removed from its Stage's queue by the controller, while one that
+// is already being executed is interrupted.
+func (s *server) AbortPromotion(
+	ctx context.Context,
+	req *connect.Request[svcv1alpha1.AbortPromotionRequest],
+) (*connect.Response[svcv1alpha1.AbortPromotionResponse], error) {
+	if req.Msg.GetProject() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("project should not be empty"))
+	}
+	if req.Msg.GetName() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("name should not be empty"))
+	}
+	if err := s.validateProject(ctx, req.Msg.GetProject()); err != nil {
+		return nil, err
+	}
+
+	key := types.NamespacedName{
+		Namespace: req.Msg.GetProject(),
+		Name:      req.Msg.GetName(),
+	}
+	promo, err := kargoapi.GetPromotion(ctx, s.client, key)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if promo == nil {
+		return nil, connect.NewError(
+			connect.CodeNotFound,
+			errors.Errorf("promotion %q not found", req.Msg.GetName()),
+		)
+	}
+	if promo.Status.Phase.IsTerminal() {
+		return nil, connect.NewError(
+			connect.CodeFailedPrecondition,
+			errors.Errorf(
+				"promotion %q cannot be aborted because it is already %s",
+				req.Msg.GetName(),
+				promo.Status.Phase,
+			),
+		)
+	}
+
+	if err = kargoapi.AbortPromotion(ctx, s.client, key); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if promo, err = kargoapi.GetPromotion(ctx, s.client, key); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if promo == nil {
+		return nil, connect.NewError(
+			connect.CodeNotFound,
+			errors.Errorf("promotion %q not found", req.Msg.GetName()),
+		)
+	}
+	return connect.NewResponse(&svcv1alpha1.AbortPromotionResponse{
+		Promotion: typesv1alpha1.ToPromotionProto(*promo),
+	}), nil
+}
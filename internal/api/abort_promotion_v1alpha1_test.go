@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	libClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/api/kubernetes"
+	"github.com/akuity/kargo/internal/api/user"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+func TestAbortPromotion(t *testing.T) {
+	testSets := map[string]struct {
+		req          *svcv1alpha1.AbortPromotionRequest
+		errExpected  bool
+		expectedCode connect.Code
+	}{
+		"empty name": {
+			req: &svcv1alpha1.AbortPromotionRequest{
+				Project: "kargo-demo",
+			},
+			errExpected:  true,
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		"non-existing Promotion": {
+			req: &svcv1alpha1.AbortPromotionRequest{
+				Project: "kargo-demo",
+				Name:    "non-existing-promotion",
+			},
+			errExpected:  true,
+			expectedCode: connect.CodeNotFound,
+		},
+		"terminal Promotion": {
+			req: &svcv1alpha1.AbortPromotionRequest{
+				Project: "kargo-demo",
+				Name:    "succeeded-promotion",
+			},
+			errExpected:  true,
+			expectedCode: connect.CodeFailedPrecondition,
+		},
+		"pending Promotion": {
+			req: &svcv1alpha1.AbortPromotionRequest{
+				Project: "kargo-demo",
+				Name:    "pending-promotion",
+			},
+		},
+	}
+	for name, ts := range testSets {
+		ts := ts
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// Simulate an admin user to prevent any authz issues with the authorizing
+			// client.
+			ctx := user.ContextWithInfo(
+				context.Background(),
+				user.Info{
+					IsAdmin: true,
+				},
+			)
+
+			client, err := kubernetes.NewClient(
+				ctx,
+				&rest.Config{},
+				kubernetes.ClientOptions{
+					NewInternalClient: func(
+						context.Context,
+						*rest.Config,
+						*runtime.Scheme,
+					) (libClient.Client, error) {
+						return fake.NewClientBuilder().
+							WithScheme(mustNewScheme()).
+							WithObjects(
+								mustNewObject[corev1.Namespace]("testdata/namespace.yaml"),
+								&kargoapi.Promotion{
+									ObjectMeta: metav1.ObjectMeta{
+										Name:      "pending-promotion",
+										Namespace: "kargo-demo",
+									},
+									Spec: &kargoapi.PromotionSpec{
+										Stage:   "test",
+										Freight: "fake-freight",
+									},
+									Status: kargoapi.PromotionStatus{
+										Phase: kargoapi.PromotionPhasePending,
+									},
+								},
+								&kargoapi.Promotion{
+									ObjectMeta: metav1.ObjectMeta{
+										Name:      "succeeded-promotion",
+										Namespace: "kargo-demo",
+									},
+									Spec: &kargoapi.PromotionSpec{
+										Stage:   "test",
+										Freight: "fake-freight",
+									},
+									Status: kargoapi.PromotionStatus{
+										Phase: kargoapi.PromotionPhaseSucceeded,
+									},
+								},
+							).
+							Build(), nil
+					},
+				},
+			)
+			require.NoError(t, err)
+
+			res, err := (&server{
+				client: client,
+			}).AbortPromotion(ctx, connect.NewRequest(ts.req))
+			if ts.errExpected {
+				require.Error(t, err)
+				require.Equal(t, ts.expectedCode, connect.CodeOf(err))
+				return
+			}
+
+			require.Equal(t, ts.req.GetName(), res.Msg.GetPromotion().GetMetadata().GetName())
+
+			var actual kargoapi.Promotion
+			require.NoError(t, client.Get(ctx, libClient.ObjectKey{
+				Namespace: ts.req.GetProject(),
+				Name:      ts.req.GetName(),
+			}, &actual))
+			require.True(t, actual.IsAbortRequested())
+		})
+	}
+}
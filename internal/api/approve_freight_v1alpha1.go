@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	typesv1alpha1 "github.com/akuity/kargo/internal/api/types/v1alpha1"
+	"github.com/akuity/kargo/internal/api/user"
+	"github.com/akuity/kargo/internal/kubeclient"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+// ApproveFreight records, in the status of the specified Freight, that it has
+// been manually approved for promotion to the specified Stage. The caller must
+// be permitted the virtual "approve" verb on that Stage. This is checked here,
+// rather than by the Freight webhook, because the webhook only ever sees this
+// server's own identity and not the caller's.
+func (s *server) ApproveFreight(
+	ctx context.Context,
+	req *connect.Request[svcv1alpha1.ApproveFreightRequest],
+) (*connect.Response[svcv1alpha1.ApproveFreightResponse], error) {
+	if err := validateProjectAndStageNonEmpty(req.Msg.GetProject(), req.Msg.GetStage()); err != nil {
+		return nil, err
+	}
+	if req.Msg.GetId() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id should not be empty"))
+	}
+	if err := s.validateProject(ctx, req.Msg.GetProject()); err != nil {
+		return nil, err
+	}
+	if _, err := getStage(ctx, s.client, req.Msg.GetProject(), req.Msg.GetStage()); err != nil {
+		return nil, err
+	}
+	if err := s.client.Authorize(
+		ctx,
+		"approve",
+		schema.GroupVersionResource{
+			Group:    kargoapi.GroupVersion.Group,
+			Version:  kargoapi.GroupVersion.Version,
+			Resource: "stages",
+		},
+		"",
+		types.NamespacedName{
+			Namespace: req.Msg.GetProject(),
+			Name:      req.Msg.GetStage(),
+		},
+	); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	freight, err := kargoapi.GetFreight(
+		ctx,
+		s.client,
+		types.NamespacedName{
+			Namespace: req.Msg.GetProject(),
+			Name:      req.Msg.GetId(),
+		},
+	)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if freight == nil {
+		return nil, connect.NewError(
+			connect.CodeNotFound,
+			fmt.Errorf("freight %q not found in namespace %q", req.Msg.GetId(), req.Msg.GetProject()),
+		)
+	}
+
+	if !freight.IsApprovedFor(req.Msg.GetStage()) {
+		if err = kubeclient.PatchStatus(
+			ctx,
+			s.client,
+			freight,
+			func(status *kargoapi.FreightStatus) {
+				if status.ApprovedFor == nil {
+					status.ApprovedFor = map[string]kargoapi.ApprovedStage{}
+				}
+				now := metav1.Now()
+				status.ApprovedFor[req.Msg.GetStage()] = kargoapi.ApprovedStage{
+					ApprovedAt: &now,
+					ApprovedBy: getApprover(ctx),
+				}
+			},
+		); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	return connect.NewResponse(&svcv1alpha1.ApproveFreightResponse{
+		Freight: typesv1alpha1.ToFreightProto(freight.ToSimpleFreight()),
+	}), nil
+}
+
+// getApprover returns a description of the user bound to the provided context
+// that is suitable for recording as the approver of Freight.
+func getApprover(ctx context.Context) string {
+	userInfo, ok := user.InfoFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if userInfo.IsAdmin {
+		return "admin"
+	}
+	return userInfo.Username
+}
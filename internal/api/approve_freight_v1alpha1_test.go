@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	libClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/api/kubernetes"
+	"github.com/akuity/kargo/internal/api/user"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+func TestApproveFreight(t *testing.T) {
+	testSets := map[string]struct {
+		req          *svcv1alpha1.ApproveFreightRequest
+		errExpected  bool
+		expectedCode connect.Code
+	}{
+		"empty Stage": {
+			req: &svcv1alpha1.ApproveFreightRequest{
+				Project: "kargo-demo",
+				Id:      "fake-freight",
+			},
+			errExpected:  true,
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		"empty Freight": {
+			req: &svcv1alpha1.ApproveFreightRequest{
+				Project: "kargo-demo",
+				Stage:   "test",
+			},
+			errExpected:  true,
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		"non-existing Stage": {
+			req: &svcv1alpha1.ApproveFreightRequest{
+				Project: "kargo-demo",
+				Id:      "fake-freight",
+				Stage:   "testx",
+			},
+			errExpected:  true,
+			expectedCode: connect.CodeNotFound,
+		},
+		"non-existing Freight": {
+			req: &svcv1alpha1.ApproveFreightRequest{
+				Project: "kargo-demo",
+				Id:      "another-fake-freight",
+				Stage:   "test",
+			},
+			errExpected:  true,
+			expectedCode: connect.CodeNotFound,
+		},
+		"existing Freight": {
+			req: &svcv1alpha1.ApproveFreightRequest{
+				Project: "kargo-demo",
+				Id:      "fake-freight",
+				Stage:   "test",
+			},
+		},
+	}
+	for name, ts := range testSets {
+		ts := ts
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// Simulate an admin user to prevent any authz issues with the authorizing
+			// client.
+			ctx := user.ContextWithInfo(
+				context.Background(),
+				user.Info{
+					IsAdmin: true,
+				},
+			)
+
+			client, err := kubernetes.NewClient(
+				ctx,
+				&rest.Config{},
+				kubernetes.ClientOptions{
+					NewInternalClient: func(
+						context.Context,
+						*rest.Config,
+						*runtime.Scheme,
+					) (libClient.Client, error) {
+						return fake.NewClientBuilder().
+							WithScheme(mustNewScheme()).
+							WithObjects(
+								mustNewObject[corev1.Namespace]("testdata/namespace.yaml"),
+								mustNewObject[kargoapi.Stage]("testdata/stage.yaml"),
+								&kargoapi.Freight{
+									ObjectMeta: metav1.ObjectMeta{
+										Name:      "fake-freight",
+										Namespace: "kargo-demo",
+									},
+								},
+							).
+							Build(), nil
+					},
+				},
+			)
+			require.NoError(t, err)
+
+			res, err := (&server{
+				client: client,
+			}).ApproveFreight(ctx, connect.NewRequest(ts.req))
+			if ts.errExpected {
+				require.Error(t, err)
+				require.Equal(t, ts.expectedCode, connect.CodeOf(err))
+				return
+			}
+
+			require.Equal(t, ts.req.GetId(), res.Msg.GetFreight().GetId())
+
+			var actual kargoapi.Freight
+			require.NoError(t, client.Get(ctx, libClient.ObjectKey{
+				Namespace: ts.req.GetProject(),
+				Name:      ts.req.GetId(),
+			}, &actual))
+			require.True(t, actual.IsApprovedFor(ts.req.GetStage()))
+			require.Equal(t, "admin", actual.Status.ApprovedFor[ts.req.GetStage()].ApprovedBy)
+		})
+	}
+}
@@ -89,6 +89,17 @@ type Client interface {
 		namespace string,
 		opts metav1.ListOptions,
 	) (watch.Interface, error)
+	// Authorize returns an error if the context-bound user is not permitted to
+	// perform the specified verb on the resource described by the provided
+	// arguments. This is useful for enforcing RBAC for virtual verbs that do
+	// not correspond to any operation the client itself performs.
+	Authorize(
+		ctx context.Context,
+		verb string,
+		gvr schema.GroupVersionResource,
+		subresource string,
+		key libClient.ObjectKey,
+	) error
 }
 
 // client implements Client.
@@ -462,6 +473,24 @@ func (c *client) Watch(
 	return ri.Watch(ctx, opts)
 }
 
+func (c *client) Authorize(
+	ctx context.Context,
+	verb string,
+	gvr schema.GroupVersionResource,
+	subresource string,
+	key libClient.ObjectKey,
+) error {
+	_, err := c.getAuthorizedClientFn(
+		ctx,
+		c.internalClient,
+		verb,
+		gvr,
+		subresource,
+		key,
+	)
+	return err
+}
+
 func GetRestConfig(ctx context.Context, path string) (*rest.Config, error) {
 	logger := logging.LoggerFromContext(ctx)
 
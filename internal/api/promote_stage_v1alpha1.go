@@ -5,6 +5,7 @@ import (
 
 	"connectrpc.com/connect"
 
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	typesv1alpha1 "github.com/akuity/kargo/internal/api/types/v1alpha1"
 	"github.com/akuity/kargo/internal/kargo"
 	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
@@ -25,10 +26,19 @@ func (s *server) PromoteStage(
 		return nil, err
 	}
 	if _, err = validateFreightExists(req.Msg.GetFreight(), stage.Status.AvailableFreight); err != nil {
-		return nil, err
+		// Freight that isn't available to the Stage may still have been manually
+		// approved for it.
+		if connect.CodeOf(err) != connect.CodeNotFound {
+			return nil, err
+		}
+		if approvedErr := s.validateFreightApproved(ctx, *stage, req.Msg.GetFreight()); approvedErr != nil {
+			return nil, err
+		}
 	}
 
 	promotion := kargo.NewPromotion(*stage, req.Msg.GetFreight())
+	promotion.Spec.Priority = req.Msg.GetPriority()
+	recordPromotionCreator(ctx, &promotion)
 	if err := s.client.Create(ctx, &promotion); err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
@@ -36,3 +46,17 @@ func (s *server) PromoteStage(
 		Promotion: typesv1alpha1.ToPromotionProto(promotion),
 	}), nil
 }
+
+// recordPromotionCreator annotates the provided Promotion with a description of
+// the user bound to the provided context, if any, so that promotion mechanisms
+// can attribute the changes they make to that user.
+func recordPromotionCreator(ctx context.Context, promo *kargoapi.Promotion) {
+	creator := getApprover(ctx)
+	if creator == "" {
+		return
+	}
+	if promo.Annotations == nil {
+		promo.Annotations = map[string]string{}
+	}
+	promo.Annotations[kargoapi.AnnotationKeyCreateActor] = creator
+}
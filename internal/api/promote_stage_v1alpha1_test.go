@@ -7,7 +7,9 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	libClient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -51,6 +53,22 @@ func TestPromoteStage(t *testing.T) {
 			errExpected:  true,
 			expectedCode: connect.CodeNotFound,
 		},
+		"existing Stage with Freight approved for another Stage": {
+			req: &svcv1alpha1.PromoteStageRequest{
+				Project: "kargo-demo",
+				Name:    "test",
+				Freight: "unapproved-freight",
+			},
+			errExpected:  true,
+			expectedCode: connect.CodeNotFound,
+		},
+		"existing Stage with approved Freight": {
+			req: &svcv1alpha1.PromoteStageRequest{
+				Project: "kargo-demo",
+				Name:    "test",
+				Freight: "approved-freight",
+			},
+		},
 		"existing Freight": {
 			req: &svcv1alpha1.PromoteStageRequest{
 				Project: "kargo-demo",
@@ -58,6 +76,14 @@ func TestPromoteStage(t *testing.T) {
 				Freight: "73024971ee9c6daac0ad78aea87803bf332cfdb7",
 			},
 		},
+		"existing Freight with priority": {
+			req: &svcv1alpha1.PromoteStageRequest{
+				Project:  "kargo-demo",
+				Name:     "test",
+				Freight:  "73024971ee9c6daac0ad78aea87803bf332cfdb7",
+				Priority: proto.Int32(10),
+			},
+		},
 	}
 	for name, ts := range testSets {
 		ts := ts
@@ -86,6 +112,28 @@ func TestPromoteStage(t *testing.T) {
 							WithScheme(mustNewScheme()).
 							WithObjects(
 								mustNewObject[corev1.Namespace]("testdata/namespace.yaml"),
+								&kargoapi.Freight{
+									ObjectMeta: metav1.ObjectMeta{
+										Name:      "approved-freight",
+										Namespace: "kargo-demo",
+									},
+									Status: kargoapi.FreightStatus{
+										ApprovedFor: map[string]kargoapi.ApprovedStage{
+											"test": {},
+										},
+									},
+								},
+								&kargoapi.Freight{
+									ObjectMeta: metav1.ObjectMeta{
+										Name:      "unapproved-freight",
+										Namespace: "kargo-demo",
+									},
+									Status: kargoapi.FreightStatus{
+										ApprovedFor: map[string]kargoapi.ApprovedStage{
+											"another-stage": {},
+										},
+									},
+								},
 							).
 							WithLists(&kargoapi.StageList{
 								Items: []kargoapi.Stage{
@@ -116,6 +164,12 @@ func TestPromoteStage(t *testing.T) {
 				Namespace: ts.req.GetProject(),
 				Name:      res.Msg.GetPromotion().GetMetadata().GetName(),
 			}, &actual))
+			require.Equal(t, ts.req.GetPriority(), actual.Spec.Priority)
+			require.Equal(
+				t,
+				"admin",
+				actual.Annotations[kargoapi.AnnotationKeyCreateActor],
+			)
 		})
 	}
 }
@@ -65,6 +65,7 @@ func (s *server) PromoteSubscribers(
 			logger.Warnf("Freight '%s' does not appear in available Freight of '%s'", req.Msg.GetFreight(), subscriber.Name)
 		}
 		newPromo := kargo.NewPromotion(subscriber, req.Msg.GetFreight())
+		recordPromotionCreator(ctx, &newPromo)
 		if err := s.client.Create(ctx, &newPromo); err != nil {
 			promoteErrs = append(promoteErrs, err)
 			continue
@@ -53,10 +53,21 @@ func (s *server) QueryFreight(
 		stages = list.Items
 	}
 
+	// Stages' statuses only reference Freight, so its details have to be looked
+	// up from the Freight resources themselves.
+	var freightList kargoapi.FreightList
+	if err := s.client.List(ctx, &freightList, client.InNamespace(req.Msg.GetProject())); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	freightByID := make(map[string]kargoapi.Freight, len(freightList.Items))
+	for _, f := range freightList.Items {
+		freightByID[f.Name] = f
+	}
+
 	seen := make(map[string]bool)
 	freightGroups := make(map[string]*svcv1alpha1.FreightList)
 	for _, s := range stages {
-		addToGroups(req.Msg, freightGroups, s, seen)
+		addToGroups(req.Msg, freightGroups, s, freightByID, seen)
 	}
 	sortFreightGroups(req.Msg.GetOrderBy(), req.Msg.GetReverse(), freightGroups)
 
@@ -69,6 +80,7 @@ func addToGroups(
 	req *svcv1alpha1.QueryFreightRequest,
 	groups map[string]*svcv1alpha1.FreightList,
 	stage kargoapi.Stage,
+	freightByID map[string]kargoapi.Freight,
 	seen map[string]bool,
 ) {
 
@@ -77,6 +89,13 @@ func addToGroups(
 			if seen[f.ID] {
 				continue
 			}
+			// Older Stages may still hold complete copies of Freight that no
+			// longer exists as a resource, in which case those copies are used
+			if freight, ok := freightByID[f.ID]; ok {
+				f.Commits = freight.Commits
+				f.Images = freight.Images
+				f.Charts = freight.Charts
+			}
 			// clear out stage-specific information
 			f.Qualified = false // Qualification is WRT a Stage
 			f.Provenance = ""
@@ -111,7 +130,7 @@ func addToGroups(
 	appendToStageGroups(stage.Status.History)
 }
 
-func appendToFreightList(list *svcv1alpha1.FreightList, f kargoapi.Freight) *svcv1alpha1.FreightList {
+func appendToFreightList(list *svcv1alpha1.FreightList, f kargoapi.SimpleFreight) *svcv1alpha1.FreightList {
 	if list == nil {
 		list = &svcv1alpha1.FreightList{}
 	}
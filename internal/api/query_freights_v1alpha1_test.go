@@ -109,6 +109,9 @@ func TestQueryFreight(t *testing.T) {
 				require.Len(t, res.GetGroups(), 1)
 				require.Len(t, res.GetGroups()[""].Freight, 1)
 				require.Equal(t, res.GetGroups()[""].Freight[0].Id, "dddddddddddddddddddddddddddddddddddddddd")
+				// Details of referenced Freight come from the Freight resource
+				require.Len(t, res.GetGroups()[""].Freight[0].Images, 1)
+				require.Equal(t, res.GetGroups()[""].Freight[0].Images[0].Tag, "v0.0.0")
 			},
 		},
 		"query group by container_repo": {
@@ -191,6 +194,7 @@ func TestQueryFreight(t *testing.T) {
 							WithScheme(mustNewScheme()).
 							WithObjects(
 								mustNewObject[corev1.Namespace]("testdata/namespace.yaml"),
+								mustNewObject[kargoapi.Freight]("testdata/query-freight-freight.yaml"),
 							).
 							WithLists(&kargoapi.StageList{
 								Items: []kargoapi.Stage{
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	typesv1alpha1 "github.com/akuity/kargo/internal/api/types/v1alpha1"
+	"github.com/akuity/kargo/internal/kargo"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+// RollbackStage creates a rollback Promotion that returns the specified Stage
+// to Freight that was previously deployed to it. The target Freight is either
+// specified explicitly or selected by stepping back through the Stage's
+// history.
+func (s *server) RollbackStage(
+	ctx context.Context,
+	req *connect.Request[svcv1alpha1.RollbackStageRequest],
+) (*connect.Response[svcv1alpha1.RollbackStageResponse], error) {
+	if err := validateProjectAndStageNonEmpty(req.Msg.GetProject(), req.Msg.GetName()); err != nil {
+		return nil, err
+	}
+	if req.Msg.Steps != nil && req.Msg.Freight != nil {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("steps and freight are mutually exclusive"),
+		)
+	}
+	if req.Msg.Steps != nil && req.Msg.GetSteps() == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("steps should be greater than 0"))
+	}
+	if err := s.validateProject(ctx, req.Msg.GetProject()); err != nil {
+		return nil, err
+	}
+	stage, err := getStage(ctx, s.client, req.Msg.GetProject(), req.Msg.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	freightID, err := getRollbackFreightID(
+		stage.Status.History,
+		req.Msg.GetSteps(),
+		req.Msg.GetFreight(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	promotion := kargo.NewPromotion(*stage, freightID)
+	promotion.Spec.Rollback = true
+	recordPromotionCreator(ctx, &promotion)
+	if err := s.client.Create(ctx, &promotion); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&svcv1alpha1.RollbackStageResponse{
+		Promotion: typesv1alpha1.ToPromotionProto(promotion),
+	}), nil
+}
+
+// getRollbackFreightID returns the ID of the Freight in the provided history
+// that a rollback should target. If freightID is non-empty, it must identify
+// Freight in the history other than the current Freight. Otherwise, the Freight
+// the specified number of steps (defaulting to 1) behind the current Freight is
+// selected. Only distinct Freight count as steps: entries for the current
+// Freight and entries repeating the Freight of the previous step are skipped
+// over, since stepping to either would not change where the Stage ends up.
+func getRollbackFreightID(
+	history kargoapi.FreightStack,
+	steps uint32,
+	freightID string,
+) (string, error) {
+	if len(history) == 0 {
+		return "", connect.NewError(
+			connect.CodeFailedPrecondition,
+			errors.New("Stage has no history to roll back through"),
+		)
+	}
+	current := history[0]
+	if freightID != "" {
+		if freightID == current.ID {
+			return "", connect.NewError(
+				connect.CodeFailedPrecondition,
+				fmt.Errorf("freight %q is already the Stage's current Freight", freightID),
+			)
+		}
+		for _, f := range history[1:] {
+			if f.ID == freightID {
+				return freightID, nil
+			}
+		}
+		return "", connect.NewError(
+			connect.CodeNotFound,
+			fmt.Errorf("freight %q not found in Stage history", freightID),
+		)
+	}
+	if steps == 0 {
+		steps = 1
+	}
+	var stepped uint32
+	lastID := current.ID
+	for _, f := range history[1:] {
+		if f.ID == current.ID || f.ID == lastID {
+			continue
+		}
+		lastID = f.ID
+		if stepped++; stepped == steps {
+			return f.ID, nil
+		}
+	}
+	return "", connect.NewError(
+		connect.CodeFailedPrecondition,
+		fmt.Errorf(
+			"cannot roll back %d step(s); Stage history only contains %d "+
+				"previous Freight",
+			steps,
+			stepped,
+		),
+	)
+}
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	libClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/api/kubernetes"
+	"github.com/akuity/kargo/internal/api/user"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+func TestRollbackStage(t *testing.T) {
+	testSets := map[string]struct {
+		req             *svcv1alpha1.RollbackStageRequest
+		history         kargoapi.FreightStack
+		errExpected     bool
+		expectedCode    connect.Code
+		expectedFreight string
+	}{
+		"empty Stage": {
+			req: &svcv1alpha1.RollbackStageRequest{
+				Project: "kargo-demo",
+			},
+			errExpected:  true,
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		"both steps and Freight": {
+			req: &svcv1alpha1.RollbackStageRequest{
+				Project: "kargo-demo",
+				Name:    "test",
+				Steps:   proto.Uint32(1),
+				Freight: proto.String("freight-2"),
+			},
+			errExpected:  true,
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		"zero steps": {
+			req: &svcv1alpha1.RollbackStageRequest{
+				Project: "kargo-demo",
+				Name:    "test",
+				Steps:   proto.Uint32(0),
+			},
+			errExpected:  true,
+			expectedCode: connect.CodeInvalidArgument,
+		},
+		"non-existing Stage": {
+			req: &svcv1alpha1.RollbackStageRequest{
+				Project: "kargo-demo",
+				Name:    "testx",
+			},
+			errExpected:  true,
+			expectedCode: connect.CodeNotFound,
+		},
+		"too many steps": {
+			req: &svcv1alpha1.RollbackStageRequest{
+				Project: "kargo-demo",
+				Name:    "test",
+				Steps:   proto.Uint32(3),
+			},
+			errExpected:  true,
+			expectedCode: connect.CodeFailedPrecondition,
+		},
+		"current Freight": {
+			req: &svcv1alpha1.RollbackStageRequest{
+				Project: "kargo-demo",
+				Name:    "test",
+				Freight: proto.String("freight-3"),
+			},
+			errExpected:  true,
+			expectedCode: connect.CodeFailedPrecondition,
+		},
+		"Freight not in history": {
+			req: &svcv1alpha1.RollbackStageRequest{
+				Project: "kargo-demo",
+				Name:    "test",
+				Freight: proto.String("freight-x"),
+			},
+			errExpected:  true,
+			expectedCode: connect.CodeNotFound,
+		},
+		"default steps": {
+			req: &svcv1alpha1.RollbackStageRequest{
+				Project: "kargo-demo",
+				Name:    "test",
+			},
+			expectedFreight: "freight-2",
+		},
+		"explicit steps": {
+			req: &svcv1alpha1.RollbackStageRequest{
+				Project: "kargo-demo",
+				Name:    "test",
+				Steps:   proto.Uint32(2),
+			},
+			expectedFreight: "freight-1",
+		},
+		"duplicated history entries": {
+			req: &svcv1alpha1.RollbackStageRequest{
+				Project: "kargo-demo",
+				Name:    "test",
+				Steps:   proto.Uint32(2),
+			},
+			history: kargoapi.FreightStack{
+				{ID: "freight-3"},
+				{ID: "freight-3"},
+				{ID: "freight-2"},
+				{ID: "freight-2"},
+				{ID: "freight-3"},
+				{ID: "freight-2"},
+				{ID: "freight-1"},
+			},
+			expectedFreight: "freight-1",
+		},
+		"too many steps through duplicated history entries": {
+			req: &svcv1alpha1.RollbackStageRequest{
+				Project: "kargo-demo",
+				Name:    "test",
+				Steps:   proto.Uint32(2),
+			},
+			history: kargoapi.FreightStack{
+				{ID: "freight-3"},
+				{ID: "freight-2"},
+				{ID: "freight-2"},
+				{ID: "freight-3"},
+			},
+			errExpected:  true,
+			expectedCode: connect.CodeFailedPrecondition,
+		},
+		"explicit Freight": {
+			req: &svcv1alpha1.RollbackStageRequest{
+				Project: "kargo-demo",
+				Name:    "test",
+				Freight: proto.String("freight-1"),
+			},
+			expectedFreight: "freight-1",
+		},
+	}
+	for name, ts := range testSets {
+		ts := ts
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// Simulate an admin user to prevent any authz issues with the authorizing
+			// client.
+			ctx := user.ContextWithInfo(
+				context.Background(),
+				user.Info{
+					IsAdmin: true,
+				},
+			)
+
+			stage := mustNewObject[kargoapi.Stage]("testdata/stage.yaml")
+			stage.Status.History = ts.history
+			if stage.Status.History == nil {
+				stage.Status.History = kargoapi.FreightStack{
+					{ID: "freight-3"},
+					{ID: "freight-2"},
+					{ID: "freight-1"},
+				}
+			}
+
+			client, err := kubernetes.NewClient(
+				ctx,
+				&rest.Config{},
+				kubernetes.ClientOptions{
+					NewInternalClient: func(
+						context.Context,
+						*rest.Config,
+						*runtime.Scheme,
+					) (libClient.Client, error) {
+						return fake.NewClientBuilder().
+							WithScheme(mustNewScheme()).
+							WithObjects(
+								mustNewObject[corev1.Namespace]("testdata/namespace.yaml"),
+							).
+							WithLists(&kargoapi.StageList{
+								Items: []kargoapi.Stage{*stage},
+							}).
+							Build(), nil
+					},
+				},
+			)
+			require.NoError(t, err)
+
+			res, err := (&server{
+				client: client,
+			}).RollbackStage(ctx, connect.NewRequest(ts.req))
+			if ts.errExpected {
+				require.Error(t, err)
+				require.Equal(t, ts.expectedCode, connect.CodeOf(err))
+				return
+			}
+			require.NoError(t, err)
+
+			var actual kargoapi.Promotion
+			require.NoError(t, client.Get(ctx, libClient.ObjectKey{
+				Namespace: ts.req.GetProject(),
+				Name:      res.Msg.GetPromotion().GetMetadata().GetName(),
+			}, &actual))
+			require.Equal(t, ts.req.GetName(), actual.Spec.Stage)
+			require.Equal(t, ts.expectedFreight, actual.Spec.Freight)
+			require.True(t, actual.Spec.Rollback)
+		})
+	}
+}
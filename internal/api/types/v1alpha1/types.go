@@ -1,8 +1,13 @@
 package v1alpha1
 
 import (
+	"encoding/json"
+	"time"
+
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	batchv1 "k8s.io/api/batch/v1"
 	kubemetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
@@ -52,6 +57,7 @@ func FromStageSpecProto(s *v1alpha1.StageSpec) *kargoapi.StageSpec {
 	return &kargoapi.StageSpec{
 		Subscriptions:       FromSubscriptionsProto(s.GetSubscriptions()),
 		PromotionMechanisms: FromPromotionMechanismsProto(s.GetPromotionMechanisms()),
+		Verification:        FromVerificationProto(s.GetVerification()),
 	}
 }
 
@@ -67,16 +73,38 @@ func FromStageStatusProto(s *v1alpha1.StageStatus) *kargoapi.StageStatus {
 	for idx, freight := range s.GetHistory() {
 		history[idx] = *FromFreightProto(freight)
 	}
+	var rejectedImageTags []kargoapi.RejectedImageTag
+	if len(s.GetRejectedImageTags()) > 0 {
+		rejectedImageTags =
+			make([]kargoapi.RejectedImageTag, len(s.GetRejectedImageTags()))
+		for idx, tag := range s.GetRejectedImageTags() {
+			rejectedImageTags[idx] = *FromRejectedImageTagProto(tag)
+		}
+	}
 	return &kargoapi.StageStatus{
-		AvailableFreight: availableFreight,
-		CurrentFreight:   FromFreightProto(s.GetCurrentFreight()),
-		History:          history,
-		Health:           FromHealthProto(s.GetHealth()),
-		Error:            s.GetError(),
+		AvailableFreight:  availableFreight,
+		CurrentFreight:    FromFreightProto(s.GetCurrentFreight()),
+		History:           history,
+		Health:            FromHealthProto(s.GetHealth()),
+		Error:             s.GetError(),
+		Verification:      FromVerificationInfoProto(s.GetVerification()),
+		RejectedImageTags: rejectedImageTags,
+	}
+}
+
+func FromRejectedImageTagProto(t *v1alpha1.RejectedImageTag) *kargoapi.RejectedImageTag {
+	if t == nil {
+		return nil
+	}
+	return &kargoapi.RejectedImageTag{
+		RepoURL: t.GetRepoUrl(),
+		Tag:     t.GetTag(),
+		Digest:  t.GetDigest(),
+		Reason:  t.GetReason(),
 	}
 }
 
-func FromFreightProto(s *v1alpha1.Freight) *kargoapi.Freight {
+func FromFreightProto(s *v1alpha1.Freight) *kargoapi.SimpleFreight {
 	if s == nil {
 		return nil
 	}
@@ -97,7 +125,7 @@ func FromFreightProto(s *v1alpha1.Freight) *kargoapi.Freight {
 	for idx, chart := range s.GetCharts() {
 		charts[idx] = *FromChartProto(chart)
 	}
-	return &kargoapi.Freight{
+	return &kargoapi.SimpleFreight{
 		ID:         s.GetId(),
 		FirstSeen:  firstSeen,
 		Provenance: s.GetProvenance(),
@@ -105,6 +133,7 @@ func FromFreightProto(s *v1alpha1.Freight) *kargoapi.Freight {
 		Images:     images,
 		Charts:     charts,
 		Qualified:  s.GetQualified(),
+		Rollback:   s.GetRollback(),
 	}
 }
 
@@ -116,6 +145,7 @@ func FromGitCommitProto(g *v1alpha1.GitCommit) *kargoapi.GitCommit {
 		RepoURL:           g.GetRepoUrl(),
 		ID:                g.GetId(),
 		Branch:            g.GetBranch(),
+		Tag:               g.GetTag(),
 		HealthCheckCommit: g.GetHealthCheckCommit(),
 	}
 }
@@ -124,9 +154,19 @@ func FromImageProto(i *v1alpha1.Image) *kargoapi.Image {
 	if i == nil {
 		return nil
 	}
+	var buildTime *kubemetav1.Time
+	if i.GetBuildTime() != nil {
+		bt := kubemetav1.NewTime(i.GetBuildTime().AsTime())
+		buildTime = &bt
+	}
 	return &kargoapi.Image{
-		RepoURL: i.GetRepoUrl(),
-		Tag:     i.GetTag(),
+		RepoURL:        i.GetRepoUrl(),
+		GitRepoURL:     i.GetGitRepoUrl(),
+		Tag:            i.GetTag(),
+		Digest:         i.GetDigest(),
+		SourceRepoURL:  i.GetSourceRepoUrl(),
+		SourceCommitID: i.GetSourceCommitId(),
+		BuildTime:      buildTime,
 	}
 }
 
@@ -195,8 +235,9 @@ func FromSubscriptionsProto(s *v1alpha1.Subscriptions) *kargoapi.Subscriptions {
 		upstreamStages[idx] = *FromStageSubscriptionProto(stage)
 	}
 	return &kargoapi.Subscriptions{
-		Repos:          FromRepoSubscriptionsProto(s.GetRepos()),
-		UpstreamStages: upstreamStages,
+		Repos:                FromRepoSubscriptionsProto(s.GetRepos()),
+		UpstreamStages:       upstreamStages,
+		UpstreamJoinStrategy: kargoapi.JoinStrategy(s.GetUpstreamJoinStrategy()),
 	}
 }
 
@@ -230,6 +271,16 @@ func FromGitSubscriptionProto(s *v1alpha1.GitSubscription) *kargoapi.GitSubscrip
 	return &kargoapi.GitSubscription{
 		RepoURL: s.GetRepoUrl(),
 		Branch:  s.GetBranch(),
+		CommitSelectionStrategy: kargoapi.CommitSelectionStrategy(
+			s.GetCommitSelectionStrategy(),
+		),
+		SemverConstraint: s.GetSemverConstraint(),
+		AllowTags:        s.GetAllowTags(),
+		IgnoreTags:       s.GetIgnoreTags(),
+		IncludePaths:     s.GetIncludePaths(),
+		ExcludePaths:     s.GetExcludePaths(),
+		IgnoreMessages:   s.GetIgnoreMessages(),
+		IgnoreAuthors:    s.GetIgnoreAuthors(),
 	}
 }
 
@@ -244,6 +295,38 @@ func FromImageSubscriptionProto(s *v1alpha1.ImageSubscription) *kargoapi.ImageSu
 		AllowTags:        s.GetAllowTags(),
 		IgnoreTags:       s.GetIgnoreTags(),
 		Platform:         s.GetPlatform(),
+		Verification:     FromImageVerificationProto(s.GetVerification()),
+	}
+}
+
+func FromImageVerificationProto(v *v1alpha1.ImageVerification) *kargoapi.ImageVerification {
+	if v == nil {
+		return nil
+	}
+	return &kargoapi.ImageVerification{
+		PublicKeys:   v.GetPublicKeys(),
+		Keyless:      FromKeylessVerificationProto(v.GetKeyless()),
+		Attestations: v.GetAttestations(),
+	}
+}
+
+func FromKeylessVerificationProto(v *v1alpha1.KeylessVerification) *kargoapi.KeylessVerification {
+	if v == nil {
+		return nil
+	}
+	identities := make([]kargoapi.KeylessIdentity, len(v.GetIdentities()))
+	for idx, identity := range v.GetIdentities() {
+		identities[idx] = kargoapi.KeylessIdentity{
+			Issuer:        identity.GetIssuer(),
+			IssuerRegexp:  identity.GetIssuerRegexp(),
+			Subject:       identity.GetSubject(),
+			SubjectRegexp: identity.GetSubjectRegexp(),
+		}
+	}
+	return &kargoapi.KeylessVerification{
+		Identities:               identities,
+		RootCertificates:         v.GetRootCertificates(),
+		TransparencyLogPublicKey: v.GetTransparencyLogPublicKey(),
 	}
 }
 
@@ -281,12 +364,37 @@ func FromGitRepoUpdateProto(u *v1alpha1.GitRepoUpdate) *kargoapi.GitRepoUpdate {
 		return nil
 	}
 	return &kargoapi.GitRepoUpdate{
-		RepoURL:     u.GetRepoUrl(),
-		ReadBranch:  u.GetReadBranch(),
-		WriteBranch: u.GetWriteBranch(),
-		Bookkeeper:  FromBookkeeperPromotionMechanismProto(u.GetBookkeeper()),
-		Kustomize:   FromKustomizePromotionMechanismProto(u.GetKustomize()),
-		Helm:        FromHelmPromotionMechanismProto(u.GetHelm()),
+		RepoURL:               u.GetRepoUrl(),
+		ReadBranch:            u.GetReadBranch(),
+		WriteBranch:           u.GetWriteBranch(),
+		Bookkeeper:            FromBookkeeperPromotionMechanismProto(u.GetBookkeeper()),
+		Kustomize:             FromKustomizePromotionMechanismProto(u.GetKustomize()),
+		Helm:                  FromHelmPromotionMechanismProto(u.GetHelm()),
+		PullRequest:           FromPullRequestPromotionMechanismProto(u.GetPullRequest()),
+		CommitMessageTemplate: u.GetCommitMessageTemplate(),
+		Author:                FromGitAuthorProto(u.GetAuthor()),
+	}
+}
+
+func FromGitAuthorProto(a *v1alpha1.GitAuthor) *kargoapi.GitAuthor {
+	if a == nil {
+		return nil
+	}
+	return &kargoapi.GitAuthor{
+		Name:                a.GetName(),
+		Email:               a.GetEmail(),
+		UsePromotionCreator: a.GetUsePromotionCreator(),
+	}
+}
+
+func FromPullRequestPromotionMechanismProto(
+	m *v1alpha1.PullRequestPromotionMechanism,
+) *kargoapi.PullRequestPromotionMechanism {
+	if m == nil {
+		return nil
+	}
+	return &kargoapi.PullRequestPromotionMechanism{
+		Provider: m.GetProvider(),
 	}
 }
 
@@ -321,6 +429,7 @@ func FromKustomizeImageUpdateProto(u *v1alpha1.KustomizeImageUpdate) *kargoapi.K
 	return &kargoapi.KustomizeImageUpdate{
 		Image: u.GetImage(),
 		Path:  u.GetPath(),
+		Value: kargoapi.ImageUpdateValueType(u.GetValue()),
 	}
 }
 
@@ -403,6 +512,7 @@ func FromArgoCDKustomizeProto(k *v1alpha1.ArgoCDKustomize) *kargoapi.ArgoCDKusto
 	}
 	return &kargoapi.ArgoCDKustomize{
 		Images: k.GetImages(),
+		Value:  kargoapi.ImageUpdateValueType(k.GetValue()),
 	}
 }
 
@@ -439,6 +549,58 @@ func FromStageSubscriptionProto(s *v1alpha1.StageSubscription) *kargoapi.StageSu
 	}
 }
 
+func FromVerificationProto(v *v1alpha1.Verification) *kargoapi.Verification {
+	if v == nil {
+		return nil
+	}
+	jobs := make([]kargoapi.VerificationJob, len(v.GetJobs()))
+	for idx, job := range v.GetJobs() {
+		jobs[idx] = *FromVerificationJobProto(job)
+	}
+	return &kargoapi.Verification{
+		Jobs: jobs,
+	}
+}
+
+func FromVerificationJobProto(j *v1alpha1.VerificationJob) *kargoapi.VerificationJob {
+	if j == nil {
+		return nil
+	}
+	var template batchv1.JobSpec
+	if j.GetTemplate() != nil {
+		// The template is an arbitrary JSON object. If it cannot be mapped onto a
+		// JobSpec, it is left empty and the Job will fail to be created.
+		if templateJSON, err := j.GetTemplate().MarshalJSON(); err == nil {
+			_ = json.Unmarshal(templateJSON, &template)
+		}
+	}
+	return &kargoapi.VerificationJob{
+		Name:     j.GetName(),
+		Template: template,
+	}
+}
+
+func FromVerificationInfoProto(v *v1alpha1.VerificationInfo) *kargoapi.VerificationInfo {
+	if v == nil {
+		return nil
+	}
+	jobs := make([]kargoapi.VerificationJobStatus, len(v.GetJobs()))
+	for idx, job := range v.GetJobs() {
+		jobs[idx] = kargoapi.VerificationJobStatus{
+			Name:    job.GetName(),
+			JobName: job.GetJobName(),
+			Phase:   kargoapi.VerificationPhase(job.GetPhase()),
+			Message: job.GetMessage(),
+			LogsRef: job.GetLogsRef(),
+		}
+	}
+	return &kargoapi.VerificationInfo{
+		FreightID: v.GetFreightId(),
+		Phase:     kargoapi.VerificationPhase(v.GetPhase()),
+		Jobs:      jobs,
+	}
+}
+
 func FromPromotionProto(p *v1alpha1.Promotion) *kargoapi.Promotion {
 	if p == nil {
 		return nil
@@ -467,8 +629,11 @@ func FromPromotionSpecProto(s *v1alpha1.PromotionSpec) *kargoapi.PromotionSpec {
 		return nil
 	}
 	return &kargoapi.PromotionSpec{
-		Stage:   s.GetStage(),
-		Freight: s.GetFreight(),
+		Stage:           s.GetStage(),
+		Freight:         s.GetFreight(),
+		FreightSnapshot: FromFreightProto(s.GetFreightSnapshot()),
+		Rollback:        s.GetRollback(),
+		Priority:        s.GetPriority(),
 	}
 }
 
@@ -476,9 +641,80 @@ func FromPromotionStatusProto(s *v1alpha1.PromotionStatus) *kargoapi.PromotionSt
 	if s == nil {
 		return nil
 	}
+	var attempts []kargoapi.PromotionAttempt
+	if len(s.GetAttempts()) > 0 {
+		attempts = make([]kargoapi.PromotionAttempt, len(s.GetAttempts()))
+		for idx, a := range s.GetAttempts() {
+			var startedAt *kubemetav1.Time
+			if a.GetStartedAt() != nil {
+				t := kubemetav1.NewTime(a.GetStartedAt().AsTime())
+				startedAt = &t
+			}
+			attempts[idx] = kargoapi.PromotionAttempt{
+				StartedAt: startedAt,
+				Error:     a.GetError(),
+			}
+		}
+	}
+	var nextAttemptAt *kubemetav1.Time
+	if s.GetNextAttemptAt() != nil {
+		t := kubemetav1.NewTime(s.GetNextAttemptAt().AsTime())
+		nextAttemptAt = &t
+	}
+	var lease *kargoapi.PromotionLease
+	if s.GetLease() != nil {
+		lease = &kargoapi.PromotionLease{
+			HolderIdentity: s.GetLease().GetHolderIdentity(),
+		}
+		if s.GetLease().GetRenewTime() != nil {
+			t := kubemetav1.NewTime(s.GetLease().GetRenewTime().AsTime())
+			lease.RenewTime = &t
+		}
+	}
+	var steps []kargoapi.PromotionStep
+	if len(s.GetSteps()) > 0 {
+		steps = make([]kargoapi.PromotionStep, len(s.GetSteps()))
+		for idx, step := range s.GetSteps() {
+			steps[idx] = *FromPromotionStepProto(step)
+		}
+	}
 	return &kargoapi.PromotionStatus{
-		Phase: kargoapi.PromotionPhase(s.GetPhase()),
-		Error: s.GetError(),
+		Phase:         kargoapi.PromotionPhase(s.GetPhase()),
+		Error:         s.GetError(),
+		Message:       s.GetMessage(),
+		Attempts:      attempts,
+		NextAttemptAt: nextAttemptAt,
+		Lease:         lease,
+		Steps:         steps,
+	}
+}
+
+func FromPromotionStepProto(s *v1alpha1.PromotionStep) *kargoapi.PromotionStep {
+	if s == nil {
+		return nil
+	}
+	var startedAt *kubemetav1.Time
+	if s.GetStartedAt() != nil {
+		t := kubemetav1.NewTime(s.GetStartedAt().AsTime())
+		startedAt = &t
+	}
+	var finishedAt *kubemetav1.Time
+	if s.GetFinishedAt() != nil {
+		t := kubemetav1.NewTime(s.GetFinishedAt().AsTime())
+		finishedAt = &t
+	}
+	return &kargoapi.PromotionStep{
+		Mechanism:             s.GetMechanism(),
+		StartedAt:             startedAt,
+		FinishedAt:            finishedAt,
+		RepoURL:               s.GetRepoUrl(),
+		Branch:                s.GetBranch(),
+		CommitID:              s.GetCommitId(),
+		Application:           s.GetApplication(),
+		PullRequestURL:        s.GetPullRequestUrl(),
+		SigningKeyFingerprint: s.GetSigningKeyFingerprint(),
+		Phase:                 kargoapi.PromotionStepPhase(s.GetPhase()),
+		Message:               s.GetMessage(),
 	}
 }
 
@@ -498,9 +734,54 @@ func FromPromotionPolicyProto(p *v1alpha1.PromotionPolicy) *kargoapi.PromotionPo
 		ObjectMeta:          objectMeta,
 		Stage:               p.GetStage(),
 		EnableAutoPromotion: p.GetEnableAutoPromotion(),
+		Windows:             FromPromotionWindowsProto(p.GetWindows()),
+		Blackouts:           FromPromotionWindowsProto(p.GetBlackouts()),
+		Retry:               FromPromotionRetryProto(p.GetRetry()),
+		Timeout:             fromOptionalDurationProto(p.Timeout),
+		Supersede:           p.GetSupersede(),
+	}
+}
+
+func FromPromotionRetryProto(r *v1alpha1.PromotionRetry) *kargoapi.PromotionRetry {
+	if r == nil {
+		return nil
+	}
+	return &kargoapi.PromotionRetry{
+		MaxAttempts: r.GetMaxAttempts(),
+		Backoff:     fromOptionalDurationProto(r.Backoff),
+		MaxBackoff:  fromOptionalDurationProto(r.MaxBackoff),
 	}
 }
 
+// fromOptionalDurationProto converts an optional duration string to a
+// *kubemetav1.Duration. Durations are validated upon admission, so there's no
+// need to handle parsing errors here.
+func fromOptionalDurationProto(s *string) *kubemetav1.Duration {
+	if s == nil {
+		return nil
+	}
+	duration, _ := time.ParseDuration(*s)
+	return &kubemetav1.Duration{Duration: duration}
+}
+
+func FromPromotionWindowsProto(windows []*v1alpha1.PromotionWindow) []kargoapi.PromotionWindow {
+	if len(windows) == 0 {
+		return nil
+	}
+	res := make([]kargoapi.PromotionWindow, len(windows))
+	for idx, w := range windows {
+		// The duration is validated upon admission, so there's no need to
+		// handle the error here.
+		duration, _ := time.ParseDuration(w.GetDuration())
+		res[idx] = kargoapi.PromotionWindow{
+			Schedule: w.GetSchedule(),
+			Duration: kubemetav1.Duration{Duration: duration},
+			TimeZone: w.GetTimeZone(),
+		}
+	}
+	return res
+}
+
 func ToStageProto(e kargoapi.Stage) *v1alpha1.Stage {
 	// Status
 	availableFreight := make([]*v1alpha1.Freight, len(e.Status.AvailableFreight))
@@ -527,6 +808,20 @@ func ToStageProto(e kargoapi.Stage) *v1alpha1.Stage {
 	if e.Spec.PromotionMechanisms != nil {
 		promotionMechanisms = ToPromotionMechanismsProto(*e.Spec.PromotionMechanisms)
 	}
+	var verification *v1alpha1.Verification
+	if e.Spec.Verification != nil {
+		verification = ToVerificationProto(*e.Spec.Verification)
+	}
+	var verificationInfo *v1alpha1.VerificationInfo
+	if e.Status.Verification != nil {
+		verificationInfo = ToVerificationInfoProto(*e.Status.Verification)
+	}
+	rejectedImageTags :=
+		make([]*v1alpha1.RejectedImageTag, len(e.Status.RejectedImageTags))
+	for idx := range e.Status.RejectedImageTags {
+		rejectedImageTags[idx] =
+			ToRejectedImageTagProto(e.Status.RejectedImageTags[idx])
+	}
 	var currentPromotion *v1alpha1.PromotionInfo
 	if e.Status.CurrentPromotion != nil {
 		currentPromotion = &v1alpha1.PromotionInfo{
@@ -541,18 +836,30 @@ func ToStageProto(e kargoapi.Stage) *v1alpha1.Stage {
 		Spec: &v1alpha1.StageSpec{
 			Subscriptions:       ToSubscriptionsProto(*e.Spec.Subscriptions),
 			PromotionMechanisms: promotionMechanisms,
+			Verification:        verification,
 		},
 		Status: &v1alpha1.StageStatus{
-			AvailableFreight: availableFreight,
-			CurrentFreight:   currentFreight,
-			CurrentPromotion: currentPromotion,
-			History:          history,
-			Health:           health,
-			Error:            e.Status.Error,
+			AvailableFreight:  availableFreight,
+			CurrentFreight:    currentFreight,
+			CurrentPromotion:  currentPromotion,
+			History:           history,
+			Health:            health,
+			Error:             e.Status.Error,
+			Verification:      verificationInfo,
+			RejectedImageTags: rejectedImageTags,
 		},
 	}
 }
 
+func ToRejectedImageTagProto(t kargoapi.RejectedImageTag) *v1alpha1.RejectedImageTag {
+	return &v1alpha1.RejectedImageTag{
+		RepoUrl: t.RepoURL,
+		Tag:     t.Tag,
+		Digest:  proto.String(t.Digest),
+		Reason:  t.Reason,
+	}
+}
+
 func ToSubscriptionsProto(s kargoapi.Subscriptions) *v1alpha1.Subscriptions {
 	var repos *v1alpha1.RepoSubscriptions
 	if s.Repos != nil {
@@ -577,19 +884,32 @@ func ToSubscriptionsProto(s kargoapi.Subscriptions) *v1alpha1.Subscriptions {
 		upstreamStages[idx] = ToStageSubscriptionProto(s.UpstreamStages[idx])
 	}
 	return &v1alpha1.Subscriptions{
-		Repos:          repos,
-		UpstreamStages: upstreamStages,
+		Repos:                repos,
+		UpstreamStages:       upstreamStages,
+		UpstreamJoinStrategy: proto.String(string(s.UpstreamJoinStrategy)),
 	}
 }
 
 func ToGitSubscriptionProto(g kargoapi.GitSubscription) *v1alpha1.GitSubscription {
 	return &v1alpha1.GitSubscription{
-		RepoUrl: g.RepoURL,
-		Branch:  g.Branch,
+		RepoUrl:                 g.RepoURL,
+		Branch:                  g.Branch,
+		CommitSelectionStrategy: string(g.CommitSelectionStrategy),
+		SemverConstraint:        proto.String(g.SemverConstraint),
+		AllowTags:               proto.String(g.AllowTags),
+		IgnoreTags:              g.IgnoreTags,
+		IncludePaths:            g.IncludePaths,
+		ExcludePaths:            g.ExcludePaths,
+		IgnoreMessages:          g.IgnoreMessages,
+		IgnoreAuthors:           g.IgnoreAuthors,
 	}
 }
 
 func ToImageSubscriptionProto(i kargoapi.ImageSubscription) *v1alpha1.ImageSubscription {
+	var verification *v1alpha1.ImageVerification
+	if i.Verification != nil {
+		verification = ToImageVerificationProto(*i.Verification)
+	}
 	return &v1alpha1.ImageSubscription{
 		RepoUrl:          i.RepoURL,
 		UpdateStrategy:   string(i.UpdateStrategy),
@@ -597,6 +917,36 @@ func ToImageSubscriptionProto(i kargoapi.ImageSubscription) *v1alpha1.ImageSubsc
 		AllowTags:        proto.String(i.AllowTags),
 		IgnoreTags:       i.IgnoreTags,
 		Platform:         proto.String(i.Platform),
+		Verification:     verification,
+	}
+}
+
+func ToImageVerificationProto(v kargoapi.ImageVerification) *v1alpha1.ImageVerification {
+	var keyless *v1alpha1.KeylessVerification
+	if v.Keyless != nil {
+		keyless = ToKeylessVerificationProto(*v.Keyless)
+	}
+	return &v1alpha1.ImageVerification{
+		PublicKeys:   v.PublicKeys,
+		Keyless:      keyless,
+		Attestations: v.Attestations,
+	}
+}
+
+func ToKeylessVerificationProto(v kargoapi.KeylessVerification) *v1alpha1.KeylessVerification {
+	identities := make([]*v1alpha1.KeylessIdentity, len(v.Identities))
+	for idx, identity := range v.Identities {
+		identities[idx] = &v1alpha1.KeylessIdentity{
+			Issuer:        proto.String(identity.Issuer),
+			IssuerRegexp:  proto.String(identity.IssuerRegexp),
+			Subject:       proto.String(identity.Subject),
+			SubjectRegexp: proto.String(identity.SubjectRegexp),
+		}
+	}
+	return &v1alpha1.KeylessVerification{
+		Identities:               identities,
+		RootCertificates:         v.RootCertificates,
+		TransparencyLogPublicKey: v.TransparencyLogPublicKey,
 	}
 }
 
@@ -614,6 +964,48 @@ func ToStageSubscriptionProto(e kargoapi.StageSubscription) *v1alpha1.StageSubsc
 	}
 }
 
+func ToVerificationProto(v kargoapi.Verification) *v1alpha1.Verification {
+	jobs := make([]*v1alpha1.VerificationJob, len(v.Jobs))
+	for idx := range v.Jobs {
+		jobs[idx] = ToVerificationJobProto(v.Jobs[idx])
+	}
+	return &v1alpha1.Verification{
+		Jobs: jobs,
+	}
+}
+
+func ToVerificationJobProto(j kargoapi.VerificationJob) *v1alpha1.VerificationJob {
+	var template *structpb.Struct
+	if templateJSON, err := json.Marshal(j.Template); err == nil {
+		template = &structpb.Struct{}
+		if err = template.UnmarshalJSON(templateJSON); err != nil {
+			template = nil
+		}
+	}
+	return &v1alpha1.VerificationJob{
+		Name:     j.Name,
+		Template: template,
+	}
+}
+
+func ToVerificationInfoProto(v kargoapi.VerificationInfo) *v1alpha1.VerificationInfo {
+	jobs := make([]*v1alpha1.VerificationJobStatus, len(v.Jobs))
+	for idx, job := range v.Jobs {
+		jobs[idx] = &v1alpha1.VerificationJobStatus{
+			Name:    job.Name,
+			JobName: job.JobName,
+			Phase:   string(job.Phase),
+			Message: proto.String(job.Message),
+			LogsRef: proto.String(job.LogsRef),
+		}
+	}
+	return &v1alpha1.VerificationInfo{
+		FreightId: v.FreightID,
+		Phase:     string(v.Phase),
+		Jobs:      jobs,
+	}
+}
+
 func ToPromotionMechanismsProto(p kargoapi.PromotionMechanisms) *v1alpha1.PromotionMechanisms {
 	gitRepoUpdates := make([]*v1alpha1.GitRepoUpdate, len(p.GitRepoUpdates))
 	for idx := range p.GitRepoUpdates {
@@ -642,13 +1034,40 @@ func ToGitRepoUpdateProto(g kargoapi.GitRepoUpdate) *v1alpha1.GitRepoUpdate {
 	if g.Helm != nil {
 		helm = ToHelmPromotionMechanismProto(*g.Helm)
 	}
+	var pullRequest *v1alpha1.PullRequestPromotionMechanism
+	if g.PullRequest != nil {
+		pullRequest = ToPullRequestPromotionMechanismProto(*g.PullRequest)
+	}
+	var author *v1alpha1.GitAuthor
+	if g.Author != nil {
+		author = ToGitAuthorProto(*g.Author)
+	}
 	return &v1alpha1.GitRepoUpdate{
-		RepoUrl:     g.RepoURL,
-		ReadBranch:  proto.String(g.ReadBranch),
-		WriteBranch: g.WriteBranch,
-		Bookkeeper:  bookkeeper,
-		Kustomize:   kustomize,
-		Helm:        helm,
+		RepoUrl:               g.RepoURL,
+		ReadBranch:            proto.String(g.ReadBranch),
+		WriteBranch:           g.WriteBranch,
+		Bookkeeper:            bookkeeper,
+		Kustomize:             kustomize,
+		Helm:                  helm,
+		PullRequest:           pullRequest,
+		CommitMessageTemplate: proto.String(g.CommitMessageTemplate),
+		Author:                author,
+	}
+}
+
+func ToGitAuthorProto(a kargoapi.GitAuthor) *v1alpha1.GitAuthor {
+	return &v1alpha1.GitAuthor{
+		Name:                proto.String(a.Name),
+		Email:               proto.String(a.Email),
+		UsePromotionCreator: proto.Bool(a.UsePromotionCreator),
+	}
+}
+
+func ToPullRequestPromotionMechanismProto(
+	p kargoapi.PullRequestPromotionMechanism,
+) *v1alpha1.PullRequestPromotionMechanism {
+	return &v1alpha1.PullRequestPromotionMechanism{
+		Provider: proto.String(p.Provider),
 	}
 }
 
@@ -674,6 +1093,7 @@ func ToKustomizeImageUpdateProto(k kargoapi.KustomizeImageUpdate) *v1alpha1.Kust
 	return &v1alpha1.KustomizeImageUpdate{
 		Image: k.Image,
 		Path:  k.Path,
+		Value: proto.String(string(k.Value)),
 	}
 }
 
@@ -742,6 +1162,7 @@ func ToArgoCDSourceUpdateProto(a kargoapi.ArgoCDSourceUpdate) *v1alpha1.ArgoCDSo
 func ToArgoCDKustomizeProto(a kargoapi.ArgoCDKustomize) *v1alpha1.ArgoCDKustomize {
 	return &v1alpha1.ArgoCDKustomize{
 		Images: a.Images,
+		Value:  proto.String(string(a.Value)),
 	}
 }
 
@@ -763,7 +1184,7 @@ func ToArgoCDHelmImageUpdateProto(a kargoapi.ArgoCDHelmImageUpdate) *v1alpha1.Ar
 	}
 }
 
-func ToFreightProto(e kargoapi.Freight) *v1alpha1.Freight {
+func ToFreightProto(e kargoapi.SimpleFreight) *v1alpha1.Freight {
 	var firstSeen *timestamppb.Timestamp
 	if e.FirstSeen != nil {
 		firstSeen = timestamppb.New(e.FirstSeen.Time)
@@ -788,6 +1209,7 @@ func ToFreightProto(e kargoapi.Freight) *v1alpha1.Freight {
 		Images:     images,
 		Charts:     charts,
 		Qualified:  &e.Qualified,
+		Rollback:   proto.Bool(e.Rollback),
 	}
 }
 
@@ -796,6 +1218,7 @@ func ToGitCommitProto(g kargoapi.GitCommit) *v1alpha1.GitCommit {
 		RepoUrl:           g.RepoURL,
 		Id:                g.ID,
 		Branch:            g.Branch,
+		Tag:               g.Tag,
 		HealthCheckCommit: proto.String(g.HealthCheckCommit),
 		Message:           g.Message,
 		Author:            g.Author,
@@ -803,9 +1226,18 @@ func ToGitCommitProto(g kargoapi.GitCommit) *v1alpha1.GitCommit {
 }
 
 func ToImageProto(i kargoapi.Image) *v1alpha1.Image {
+	var buildTime *timestamppb.Timestamp
+	if i.BuildTime != nil {
+		buildTime = timestamppb.New(i.BuildTime.Time)
+	}
 	return &v1alpha1.Image{
-		RepoUrl: i.RepoURL,
-		Tag:     i.Tag,
+		RepoUrl:        i.RepoURL,
+		GitRepoUrl:     proto.String(i.GitRepoURL),
+		Tag:            i.Tag,
+		Digest:         proto.String(i.Digest),
+		SourceRepoUrl:  proto.String(i.SourceRepoURL),
+		SourceCommitId: proto.String(i.SourceCommitID),
+		BuildTime:      buildTime,
 	}
 }
 
@@ -863,21 +1295,85 @@ func ToPromotionProto(p kargoapi.Promotion) *v1alpha1.Promotion {
 	metadata := p.ObjectMeta.DeepCopy()
 	metadata.SetManagedFields(nil)
 
+	var freightSnapshot *v1alpha1.Freight
+	if p.Spec.FreightSnapshot != nil {
+		freightSnapshot = ToFreightProto(*p.Spec.FreightSnapshot)
+	}
+	attempts := make([]*v1alpha1.PromotionAttempt, len(p.Status.Attempts))
+	for idx, a := range p.Status.Attempts {
+		var startedAt *timestamppb.Timestamp
+		if a.StartedAt != nil {
+			startedAt = timestamppb.New(a.StartedAt.Time)
+		}
+		attempts[idx] = &v1alpha1.PromotionAttempt{
+			StartedAt: startedAt,
+			Error:     proto.String(a.Error),
+		}
+	}
+	var nextAttemptAt *timestamppb.Timestamp
+	if p.Status.NextAttemptAt != nil {
+		nextAttemptAt = timestamppb.New(p.Status.NextAttemptAt.Time)
+	}
+	var lease *v1alpha1.PromotionLease
+	if p.Status.Lease != nil {
+		lease = &v1alpha1.PromotionLease{
+			HolderIdentity: proto.String(p.Status.Lease.HolderIdentity),
+		}
+		if p.Status.Lease.RenewTime != nil {
+			lease.RenewTime = timestamppb.New(p.Status.Lease.RenewTime.Time)
+		}
+	}
+	steps := make([]*v1alpha1.PromotionStep, len(p.Status.Steps))
+	for idx, step := range p.Status.Steps {
+		steps[idx] = ToPromotionStepProto(step)
+	}
 	return &v1alpha1.Promotion{
 		ApiVersion: p.APIVersion,
 		Kind:       p.Kind,
 		Metadata:   typesmetav1.ToObjectMetaProto(*metadata),
 		Spec: &v1alpha1.PromotionSpec{
-			Stage:   p.Spec.Stage,
-			Freight: p.Spec.Freight,
+			Stage:           p.Spec.Stage,
+			Freight:         p.Spec.Freight,
+			FreightSnapshot: freightSnapshot,
+			Rollback:        proto.Bool(p.Spec.Rollback),
+			Priority:        proto.Int32(p.Spec.Priority),
 		},
 		Status: &v1alpha1.PromotionStatus{
-			Phase: string(p.Status.Phase),
-			Error: p.Status.Error,
+			Phase:         string(p.Status.Phase),
+			Error:         p.Status.Error,
+			Message:       proto.String(p.Status.Message),
+			Attempts:      attempts,
+			NextAttemptAt: nextAttemptAt,
+			Lease:         lease,
+			Steps:         steps,
 		},
 	}
 }
 
+func ToPromotionStepProto(s kargoapi.PromotionStep) *v1alpha1.PromotionStep {
+	var startedAt *timestamppb.Timestamp
+	if s.StartedAt != nil {
+		startedAt = timestamppb.New(s.StartedAt.Time)
+	}
+	var finishedAt *timestamppb.Timestamp
+	if s.FinishedAt != nil {
+		finishedAt = timestamppb.New(s.FinishedAt.Time)
+	}
+	return &v1alpha1.PromotionStep{
+		Mechanism:             s.Mechanism,
+		StartedAt:             startedAt,
+		FinishedAt:            finishedAt,
+		RepoUrl:               proto.String(s.RepoURL),
+		Branch:                proto.String(s.Branch),
+		CommitId:              proto.String(s.CommitID),
+		Application:           proto.String(s.Application),
+		PullRequestUrl:        proto.String(s.PullRequestURL),
+		SigningKeyFingerprint: proto.String(s.SigningKeyFingerprint),
+		Phase:                 string(s.Phase),
+		Message:               proto.String(s.Message),
+	}
+}
+
 func ToPromotionPolicyProto(p kargoapi.PromotionPolicy) *v1alpha1.PromotionPolicy {
 	metadata := p.ObjectMeta.DeepCopy()
 	metadata.SetManagedFields(nil)
@@ -888,7 +1384,42 @@ func ToPromotionPolicyProto(p kargoapi.PromotionPolicy) *v1alpha1.PromotionPolic
 		Metadata:            typesmetav1.ToObjectMetaProto(*metadata),
 		Stage:               p.Stage,
 		EnableAutoPromotion: p.EnableAutoPromotion,
+		Windows:             ToPromotionWindowsProto(p.Windows),
+		Blackouts:           ToPromotionWindowsProto(p.Blackouts),
+		Retry:               ToPromotionRetryProto(p.Retry),
+		Timeout:             toOptionalDurationProto(p.Timeout),
+		Supersede:           proto.Bool(p.Supersede),
+	}
+}
+
+func ToPromotionRetryProto(r *kargoapi.PromotionRetry) *v1alpha1.PromotionRetry {
+	if r == nil {
+		return nil
+	}
+	return &v1alpha1.PromotionRetry{
+		MaxAttempts: r.MaxAttempts,
+		Backoff:     toOptionalDurationProto(r.Backoff),
+		MaxBackoff:  toOptionalDurationProto(r.MaxBackoff),
+	}
+}
+
+func toOptionalDurationProto(d *kubemetav1.Duration) *string {
+	if d == nil {
+		return nil
+	}
+	return proto.String(d.Duration.String())
+}
+
+func ToPromotionWindowsProto(windows []kargoapi.PromotionWindow) []*v1alpha1.PromotionWindow {
+	res := make([]*v1alpha1.PromotionWindow, len(windows))
+	for idx, w := range windows {
+		res[idx] = &v1alpha1.PromotionWindow{
+			Schedule: w.Schedule,
+			Duration: w.Duration.Duration.String(),
+			TimeZone: proto.String(w.TimeZone),
+		}
 	}
+	return res
 }
 
 func ToVersionProto(v version.Version) *svcv1alpha1.VersionInfo {
@@ -6,6 +6,7 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
@@ -38,7 +39,7 @@ func validateProjectAndStageNonEmpty(project string, stage string) error {
 
 // validateFreightExists returns the Freight with the given ID in the list of Freight, otherwise
 // return an error if it doesn't exist
-func validateFreightExists(freight string, freightStack kargoapi.FreightStack) (*kargoapi.Freight, error) {
+func validateFreightExists(freight string, freightStack kargoapi.FreightStack) (*kargoapi.SimpleFreight, error) {
 	if freight == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("freight should not be empty"))
 	}
@@ -50,6 +51,23 @@ func validateFreightExists(freight string, freightStack kargoapi.FreightStack) (
 	return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("freight %q not found in Stage", freight))
 }
 
+// validateFreightApproved returns an error if the Freight with the given ID
+// does not exist in the Stage's namespace or has not been manually approved for
+// the Stage.
+func (s *server) validateFreightApproved(ctx context.Context, stage kargoapi.Stage, freightID string) error {
+	freight, err := kargoapi.GetFreight(ctx, s.client, types.NamespacedName{
+		Namespace: stage.Namespace,
+		Name:      freightID,
+	})
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if freight == nil || !freight.IsApprovedFor(stage.Name) {
+		return connect.NewError(connect.CodeNotFound, fmt.Errorf("freight %q not approved for Stage", freightID))
+	}
+	return nil
+}
+
 func validateGroupByOrderBy(group string, groupBy string, orderBy string) error {
 	if group != "" && groupBy == "" {
 		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("Cannot filter by group without group by"))
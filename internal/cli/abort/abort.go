@@ -0,0 +1,16 @@
+package abort
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/akuity/kargo/internal/cli/option"
+)
+
+func NewCommand(opt *option.Option) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "abort",
+		Short: "Abort in-flight operations",
+	}
+	cmd.AddCommand(newPromotionCommand(opt))
+	return cmd
+}
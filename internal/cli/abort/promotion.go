@@ -0,0 +1,55 @@
+package abort
+
+import (
+	goerrors "errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+
+	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/option"
+	v1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+func newPromotionCommand(opt *option.Option) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "promotion --project=project [NAME]...",
+		Aliases: []string{"promotions", "promo", "promos"},
+		Short:   "Abort pending or running promotions by name",
+		Args:    cobra.MinimumNArgs(1),
+		Example: `
+# Abort a promotion
+kargo abort promotion --project=my-project some-promotion
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			kargoSvcCli, err := client.GetClientFromConfig(ctx, opt)
+			if err != nil {
+				return errors.New("get client from config")
+			}
+
+			project := opt.Project.OrElse("")
+			if project == "" {
+				return errors.New("project is required")
+			}
+
+			var resErr error
+			for _, name := range slices.Compact(args) {
+				if _, err := kargoSvcCli.AbortPromotion(ctx, connect.NewRequest(&v1alpha1.AbortPromotionRequest{
+					Project: project,
+					Name:    name,
+				})); err != nil {
+					resErr = goerrors.Join(resErr, errors.Wrap(err, "Error"))
+					continue
+				}
+				_, _ = fmt.Fprintf(opt.IOStreams.Out, "Promotion Abort Requested: %q\n", name)
+			}
+			return resErr
+		},
+	}
+	option.OptionalProject(opt.Project)(cmd.Flags())
+	return cmd
+}
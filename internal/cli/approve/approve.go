@@ -0,0 +1,65 @@
+package approve
+
+import (
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/option"
+	v1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+type Flags struct {
+	Freight string
+	Stage   string
+}
+
+func NewCommand(opt *option.Option) *cobra.Command {
+	var flag Flags
+	cmd := &cobra.Command{
+		Use:   "approve (PROJECT) --freight=freight-id --stage=stage",
+		Short: "Manually approve Freight for promotion to a Stage",
+		Args:  cobra.ExactArgs(1),
+		Example: `
+# Approve Freight for promotion to the prod Stage, bypassing any upstream Stages
+kargo approve my-project --freight=abc123 --stage=prod
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			kargoSvcCli, err := client.GetClientFromConfig(ctx, opt)
+			if err != nil {
+				return err
+			}
+
+			project := strings.TrimSpace(args[0])
+			if project == "" {
+				return errors.New("project is required")
+			}
+			freight := strings.TrimSpace(flag.Freight)
+			if freight == "" {
+				return errors.New("freight is required")
+			}
+			stage := strings.TrimSpace(flag.Stage)
+			if stage == "" {
+				return errors.New("stage is required")
+			}
+
+			if _, err = kargoSvcCli.ApproveFreight(ctx, connect.NewRequest(&v1alpha1.ApproveFreightRequest{
+				Project: project,
+				Id:      freight,
+				Stage:   stage,
+			})); err != nil {
+				return errors.Wrap(err, "approve freight")
+			}
+			fmt.Fprintf(opt.IOStreams.Out, "Freight Approved: %q for Stage %q\n", freight, stage)
+			return nil
+		},
+	}
+	option.Freight(&flag.Freight)(cmd.Flags())
+	option.Stage(&flag.Stage)(cmd.Flags())
+	return cmd
+}
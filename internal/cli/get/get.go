@@ -47,7 +47,10 @@ func printObjects[T runtime.Object](opt *option.Option, objects []T) error {
 		Items: items,
 	}
 
-	if pointer.StringDeref(opt.PrintFlags.OutputFormat, "") != "" {
+	// The wide output format is a table with additional columns rather than a
+	// format the generic printers know how to handle.
+	outputFormat := pointer.StringDeref(opt.PrintFlags.OutputFormat, "")
+	if outputFormat != "" && outputFormat != "wide" {
 		printer, err := opt.PrintFlags.ToPrinter()
 		if err != nil {
 			return errors.Wrap(err, "new printer")
@@ -58,15 +61,18 @@ func printObjects[T runtime.Object](opt *option.Option, objects []T) error {
 		return printer.PrintObj(list, opt.IOStreams.Out)
 	}
 
+	printOpts := printers.PrintOptions{
+		Wide: outputFormat == "wide",
+	}
 	var t T
 	switch any(t).(type) {
 	case *kargoapi.Stage:
 		table := newStageTable(list)
-		return printers.NewTablePrinter(printers.PrintOptions{}).PrintObj(table, opt.IOStreams.Out)
+		return printers.NewTablePrinter(printOpts).PrintObj(table, opt.IOStreams.Out)
 	case *kargoapi.Promotion:
 		table := newPromotionTable(list)
-		return printers.NewTablePrinter(printers.PrintOptions{}).PrintObj(table, opt.IOStreams.Out)
+		return printers.NewTablePrinter(printOpts).PrintObj(table, opt.IOStreams.Out)
 	default:
-		return printers.NewTablePrinter(printers.PrintOptions{}).PrintObj(list, opt.IOStreams.Out)
+		return printers.NewTablePrinter(printOpts).PrintObj(list, opt.IOStreams.Out)
 	}
 }
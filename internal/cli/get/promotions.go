@@ -2,6 +2,8 @@ package get
 
 import (
 	goerrors "errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"connectrpc.com/connect"
@@ -38,6 +40,9 @@ kargo get promotions --project=my-project
 # List all promotions in JSON output format
 kargo get promotions --project=my-project -o json
 
+# List all promotions along with the steps taken to execute them
+kargo get promotions --project=my-project -o wide
+
 # List all promotions for the stage
 kargo get promotions --project=my-project --stage=my-stage
 
@@ -108,8 +113,12 @@ func newPromotionTable(list *metav1.List) *metav1.Table {
 				promo.GetName(),
 				promo.Spec.Stage,
 				promo.Spec.Freight,
+				promo.Spec.Rollback,
 				promo.GetStatus().Phase,
 				duration.HumanDuration(time.Since(promo.CreationTimestamp.Time)),
+				getPromotionStepsSummary(promo.Status.Steps),
+				getPromotionStepsCommits(promo.Status.Steps),
+				getPromotionStepsApplications(promo.Status.Steps),
 			},
 			Object: list.Items[i],
 		}
@@ -119,9 +128,54 @@ func newPromotionTable(list *metav1.List) *metav1.Table {
 			{Name: "Name", Type: "string"},
 			{Name: "Stage", Type: "string"},
 			{Name: "Freight", Type: "string"},
+			{Name: "Rollback", Type: "boolean"},
 			{Name: "Phase", Type: "string"},
 			{Name: "Age", Type: "string"},
+			{Name: "Steps", Type: "string", Priority: 1},
+			{Name: "Commits", Type: "string", Priority: 1},
+			{Name: "Applications", Type: "string", Priority: 1},
 		},
 		Rows: rows,
 	}
 }
+
+// getPromotionStepsSummary returns the number of the provided steps that
+// succeeded out of the total number of steps, e.g. "2/3".
+func getPromotionStepsSummary(steps []kargoapi.PromotionStep) string {
+	var succeeded int
+	for _, step := range steps {
+		if step.Phase == kargoapi.PromotionStepPhaseSucceeded {
+			succeeded++
+		}
+	}
+	return fmt.Sprintf("%d/%d", succeeded, len(steps))
+}
+
+// getPromotionStepsCommits returns a comma-separated list of the abbreviated
+// IDs of any commits that resulted from the provided steps.
+func getPromotionStepsCommits(steps []kargoapi.PromotionStep) string {
+	commits := make([]string, 0, len(steps))
+	for _, step := range steps {
+		if step.CommitID == "" {
+			continue
+		}
+		commitID := step.CommitID
+		if len(commitID) > 7 {
+			commitID = commitID[:7]
+		}
+		commits = append(commits, commitID)
+	}
+	return strings.Join(commits, ",")
+}
+
+// getPromotionStepsApplications returns a comma-separated list of any Argo CD
+// Applications that were updated by the provided steps.
+func getPromotionStepsApplications(steps []kargoapi.PromotionStep) string {
+	apps := make([]string, 0, len(steps))
+	for _, step := range steps {
+		if step.Application != "" {
+			apps = append(apps, step.Application)
+		}
+	}
+	return strings.Join(apps, ",")
+}
@@ -43,3 +43,21 @@ func Freight(v *string) FlagFn {
 		fs.StringVar(v, "freight", "", "Freight ID")
 	}
 }
+
+func Stage(v *string) FlagFn {
+	return func(fs *pflag.FlagSet) {
+		fs.StringVar(v, "stage", "", "Stage")
+	}
+}
+
+func Priority(v *int32) FlagFn {
+	return func(fs *pflag.FlagSet) {
+		fs.Int32Var(v, "priority", 0, "Priority of the Promotion relative to other Pending Promotions")
+	}
+}
+
+func Steps(v *uint32) FlagFn {
+	return func(fs *pflag.FlagSet) {
+		fs.Uint32Var(v, "steps", 1, "Number of distinct Freight to step back through history")
+	}
+}
@@ -16,7 +16,8 @@ import (
 )
 
 type PromoteFlags struct {
-	Freight string
+	Freight  string
+	Priority int32
 }
 
 func newPromoteCommand(opt *option.Option) *cobra.Command {
@@ -24,7 +25,7 @@ func newPromoteCommand(opt *option.Option) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "promote",
 		Args:    cobra.ExactArgs(2),
-		Example: "kargo stage promote (PROJECT) (NAME) [(--freight=)freight-id]",
+		Example: "kargo stage promote (PROJECT) (NAME) [(--freight=)freight-id] [--priority=priority]",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			kargoSvcCli, err := client.GetClientFromConfig(ctx, opt)
@@ -47,9 +48,10 @@ func newPromoteCommand(opt *option.Option) *cobra.Command {
 			}
 
 			res, err := kargoSvcCli.PromoteStage(ctx, connect.NewRequest(&v1alpha1.PromoteStageRequest{
-				Project: project,
-				Name:    name,
-				Freight: freight,
+				Project:  project,
+				Name:     name,
+				Freight:  freight,
+				Priority: &flag.Priority,
 			}))
 			if err != nil {
 				return errors.Wrap(err, "promote stage")
@@ -70,5 +72,6 @@ func newPromoteCommand(opt *option.Option) *cobra.Command {
 	}
 	opt.PrintFlags.AddFlags(cmd)
 	option.Freight(&flag.Freight)(cmd.Flags())
+	option.Priority(&flag.Priority)(cmd.Flags())
 	return cmd
 }
@@ -0,0 +1,94 @@
+package stage
+
+import (
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/utils/pointer"
+
+	typesv1alpha1 "github.com/akuity/kargo/internal/api/types/v1alpha1"
+	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/option"
+	v1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+type RollbackFlags struct {
+	Steps   uint32
+	Freight string
+}
+
+func newRollbackCommand(opt *option.Option) *cobra.Command {
+	var flag RollbackFlags
+	cmd := &cobra.Command{
+		Use:  "rollback",
+		Args: cobra.ExactArgs(2),
+		Example: `
+# Roll back the stage to the freight it was in before its current freight
+kargo stage rollback (PROJECT) (NAME)
+
+# Roll back the stage two distinct freight back through its history, skipping
+# over its current freight and repeated entries
+kargo stage rollback (PROJECT) (NAME) --steps=2
+
+# Roll back the stage to specific freight from its history
+kargo stage rollback (PROJECT) (NAME) --freight=freight-id
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			project := strings.TrimSpace(args[0])
+			if project == "" {
+				return errors.New("project is required")
+			}
+			name := strings.TrimSpace(args[1])
+			if name == "" {
+				return errors.New("name is required")
+			}
+			req := &v1alpha1.RollbackStageRequest{
+				Project: project,
+				Name:    name,
+			}
+			freight := strings.TrimSpace(flag.Freight)
+			if cmd.Flags().Changed("steps") {
+				if freight != "" {
+					return errors.New("steps and freight are mutually exclusive")
+				}
+				req.Steps = proto.Uint32(flag.Steps)
+			}
+			if freight != "" {
+				req.Freight = proto.String(freight)
+			}
+
+			kargoSvcCli, err := client.GetClientFromConfig(ctx, opt)
+			if err != nil {
+				return err
+			}
+			res, err := kargoSvcCli.RollbackStage(ctx, connect.NewRequest(req))
+			if err != nil {
+				return errors.Wrap(err, "rollback stage")
+			}
+			if pointer.StringDeref(opt.PrintFlags.OutputFormat, "") == "" {
+				fmt.Fprintf(opt.IOStreams.Out,
+					"Rollback Promotion Created: %q (Freight %q)",
+					res.Msg.GetPromotion().GetMetadata().GetName(),
+					res.Msg.GetPromotion().GetSpec().GetFreight())
+				return nil
+			}
+			printer, err := opt.PrintFlags.ToPrinter()
+			if err != nil {
+				return errors.Wrap(err, "new printer")
+			}
+			promo := typesv1alpha1.FromPromotionProto(res.Msg.GetPromotion())
+			_ = printer.PrintObj(promo, opt.IOStreams.Out)
+			return nil
+		},
+	}
+	opt.PrintFlags.AddFlags(cmd)
+	option.Freight(&flag.Freight)(cmd.Flags())
+	option.Steps(&flag.Steps)(cmd.Flags())
+	return cmd
+}
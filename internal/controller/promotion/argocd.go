@@ -26,7 +26,7 @@ type argoCDMechanism struct {
 		ctx context.Context,
 		stageMeta metav1.ObjectMeta,
 		update kargoapi.ArgoCDAppUpdate,
-		newFreight kargoapi.Freight,
+		newFreight kargoapi.SimpleFreight,
 	) error
 	getArgoCDAppFn func(
 		ctx context.Context,
@@ -35,7 +35,7 @@ type argoCDMechanism struct {
 	) (*argocd.Application, error)
 	applyArgoCDSourceUpdateFn func(
 		argocd.ApplicationSource,
-		kargoapi.Freight,
+		kargoapi.SimpleFreight,
 		kargoapi.ArgoCDSourceUpdate,
 	) (argocd.ApplicationSource, error)
 	argoCDAppPatchFn func(
@@ -68,8 +68,8 @@ func (*argoCDMechanism) GetName() string {
 func (a *argoCDMechanism) Promote(
 	ctx context.Context,
 	stage *kargoapi.Stage,
-	newFreight kargoapi.Freight,
-) (kargoapi.Freight, error) {
+	newFreight kargoapi.SimpleFreight,
+) (kargoapi.SimpleFreight, error) {
 	updates := stage.Spec.PromotionMechanisms.ArgoCDAppUpdates
 
 	if len(updates) == 0 {
@@ -80,6 +80,14 @@ func (a *argoCDMechanism) Promote(
 	logger.Debug("executing Argo CD-based promotion mechanisms")
 
 	for _, update := range updates {
+		if err := ctx.Err(); err != nil {
+			return newFreight, errors.Wrapf(
+				err,
+				"interrupted before updating Argo CD Application %q in namespace %q",
+				update.AppName,
+				update.AppNamespaceOrDefault(),
+			)
+		}
 		if err := a.doSingleUpdateFn(
 			ctx,
 			stage.ObjectMeta,
@@ -99,8 +107,16 @@ func (a *argoCDMechanism) doSingleUpdate(
 	ctx context.Context,
 	stageMeta metav1.ObjectMeta,
 	update kargoapi.ArgoCDAppUpdate,
-	newFreight kargoapi.Freight,
-) error {
+	newFreight kargoapi.SimpleFreight,
+) (err error) {
+	step := startStep(a.GetName())
+	step.Application = fmt.Sprintf(
+		"%s/%s",
+		update.AppNamespaceOrDefault(),
+		update.AppName,
+	)
+	defer func() { finishStep(ctx, step, err) }()
+
 	app, err :=
 		a.getArgoCDAppFn(ctx, update.AppNamespaceOrDefault(), update.AppName)
 	if err != nil {
@@ -260,7 +276,7 @@ func authorizeArgoCDAppUpdate(
 // applyArgoCDSourceUpdate updates a single Argo CD ApplicationSource.
 func applyArgoCDSourceUpdate(
 	source argocd.ApplicationSource,
-	newFreight kargoapi.Freight,
+	newFreight kargoapi.SimpleFreight,
 	update kargoapi.ArgoCDSourceUpdate,
 ) (argocd.ApplicationSource, error) {
 	if source.RepoURL != update.RepoURL || source.Chart != update.Chart {
@@ -290,10 +306,18 @@ func applyArgoCDSourceUpdate(
 		if source.Kustomize == nil {
 			source.Kustomize = &argocd.ApplicationSourceKustomize{}
 		}
-		source.Kustomize.Images = buildKustomizeImagesForArgoCDAppSource(
+		images, err := buildKustomizeImagesForArgoCDAppSource(
 			newFreight.Images,
 			update.Kustomize.Images,
+			update.Kustomize.Value,
 		)
+		if err != nil {
+			return source, errors.Wrap(
+				err,
+				"error building Kustomize images for Argo CD Application source",
+			)
+		}
+		source.Kustomize.Images = images
 	}
 
 	if update.Helm != nil && len(update.Helm.Images) > 0 {
@@ -303,10 +327,16 @@ func applyArgoCDSourceUpdate(
 		if source.Helm.Parameters == nil {
 			source.Helm.Parameters = []argocd.HelmParameter{}
 		}
-		changes := buildHelmParamChangesForArgoCDAppSource(
+		changes, err := buildHelmParamChangesForArgoCDAppSource(
 			newFreight.Images,
 			update.Helm.Images,
 		)
+		if err != nil {
+			return source, errors.Wrap(
+				err,
+				"error building Helm parameters for Argo CD Application source",
+			)
+		}
 	imageUpdateLoop:
 		for k, v := range changes {
 			newParam := argocd.HelmParameter{
@@ -329,53 +359,49 @@ func applyArgoCDSourceUpdate(
 func buildKustomizeImagesForArgoCDAppSource(
 	images []kargoapi.Image,
 	imageUpdates []string,
-) argocd.KustomizeImages {
-	tagsByImage := map[string]string{}
-	for _, image := range images {
-		tagsByImage[image.RepoURL] = image.Tag
-	}
+	valueType kargoapi.ImageUpdateValueType,
+) (argocd.KustomizeImages, error) {
+	imgs := imagesByRepoURL(images)
 	kustomizeImages := make(argocd.KustomizeImages, 0, len(imageUpdates))
 	for _, imageUpdate := range imageUpdates {
-		tag, found := tagsByImage[imageUpdate]
+		image, found := imgs[imageUpdate]
 		if !found {
 			// There's no change to make in this case.
 			continue
 		}
+		ref, err := getImageRef(image, valueType)
+		if err != nil {
+			return nil, err
+		}
 		kustomizeImages = append(
 			kustomizeImages,
-			argocd.KustomizeImage(
-				fmt.Sprintf("%s=%s:%s", imageUpdate, imageUpdate, tag),
-			),
+			argocd.KustomizeImage(fmt.Sprintf("%s=%s", imageUpdate, ref)),
 		)
 	}
-	return kustomizeImages
+	return kustomizeImages, nil
 }
 
 func buildHelmParamChangesForArgoCDAppSource(
 	images []kargoapi.Image,
 	imageUpdates []kargoapi.ArgoCDHelmImageUpdate,
-) map[string]string {
-	tagsByImage := map[string]string{}
-	for _, image := range images {
-		tagsByImage[image.RepoURL] = image.Tag
-	}
+) (map[string]string, error) {
+	imgs := imagesByRepoURL(images)
 	changes := map[string]string{}
 	for _, imageUpdate := range imageUpdates {
-		if imageUpdate.Value != kargoapi.ImageUpdateValueTypeImage &&
-			imageUpdate.Value != kargoapi.ImageUpdateValueTypeTag {
-			// This really shouldn't happen, so we'll ignore it.
-			continue
-		}
-		tag, found := tagsByImage[imageUpdate.Image]
+		image, found := imgs[imageUpdate.Image]
 		if !found {
 			// There's no change to make in this case.
 			continue
 		}
-		if imageUpdate.Value == kargoapi.ImageUpdateValueTypeImage {
-			changes[imageUpdate.Key] = fmt.Sprintf("%s:%s", imageUpdate.Image, tag)
-		} else {
-			changes[imageUpdate.Key] = tag
+		value, err := getImageValue(image, imageUpdate.Value)
+		if err != nil {
+			return nil, errors.Wrapf(
+				err,
+				"error updating Helm parameter %q",
+				imageUpdate.Key,
+			)
 		}
+		changes[imageUpdate.Key] = value
 	}
-	return changes
+	return changes, nil
 }
@@ -35,8 +35,8 @@ func TestArgoCDPromote(t *testing.T) {
 		name       string
 		promoMech  *argoCDMechanism
 		stage      *kargoapi.Stage
-		newFreight kargoapi.Freight
-		assertions func(newFreightIn, newFreightOut kargoapi.Freight, err error)
+		newFreight kargoapi.SimpleFreight
+		assertions func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error)
 	}{
 		{
 			name:      "no updates",
@@ -46,7 +46,7 @@ func TestArgoCDPromote(t *testing.T) {
 					PromotionMechanisms: &kargoapi.PromotionMechanisms{},
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.NoError(t, err)
 				require.Equal(t, newFreightIn, newFreightOut)
 			},
@@ -58,7 +58,7 @@ func TestArgoCDPromote(t *testing.T) {
 					context.Context,
 					metav1.ObjectMeta,
 					kargoapi.ArgoCDAppUpdate,
-					kargoapi.Freight,
+					kargoapi.SimpleFreight,
 				) error {
 					return errors.New("something went wrong")
 				},
@@ -72,7 +72,7 @@ func TestArgoCDPromote(t *testing.T) {
 					},
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.Error(t, err)
 				require.Equal(
 					t,
@@ -89,7 +89,7 @@ func TestArgoCDPromote(t *testing.T) {
 					context.Context,
 					metav1.ObjectMeta,
 					kargoapi.ArgoCDAppUpdate,
-					kargoapi.Freight,
+					kargoapi.SimpleFreight,
 				) error {
 					return nil
 				},
@@ -103,7 +103,7 @@ func TestArgoCDPromote(t *testing.T) {
 					},
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.NoError(t, err)
 				require.Equal(t, newFreightIn, newFreightOut)
 			},
@@ -211,7 +211,7 @@ func TestArgoCDDoSingleUpdate(t *testing.T) {
 				},
 				applyArgoCDSourceUpdateFn: func(
 					argocd.ApplicationSource,
-					kargoapi.Freight,
+					kargoapi.SimpleFreight,
 					kargoapi.ArgoCDSourceUpdate,
 				) (argocd.ApplicationSource, error) {
 					return argocd.ApplicationSource{}, errors.New("something went wrong")
@@ -261,7 +261,7 @@ func TestArgoCDDoSingleUpdate(t *testing.T) {
 				},
 				applyArgoCDSourceUpdateFn: func(
 					argocd.ApplicationSource,
-					kargoapi.Freight,
+					kargoapi.SimpleFreight,
 					kargoapi.ArgoCDSourceUpdate,
 				) (argocd.ApplicationSource, error) {
 					return argocd.ApplicationSource{}, errors.New("something went wrong")
@@ -370,7 +370,7 @@ func TestArgoCDDoSingleUpdate(t *testing.T) {
 					context.Background(),
 					testCase.stageMeta,
 					testCase.update,
-					kargoapi.Freight{},
+					kargoapi.SimpleFreight{},
 				),
 			)
 		})
@@ -498,7 +498,7 @@ func TestApplyArgoCDSourceUpdate(t *testing.T) {
 	testCases := []struct {
 		name       string
 		source     argocd.ApplicationSource
-		newFreight kargoapi.Freight
+		newFreight kargoapi.SimpleFreight
 		update     kargoapi.ArgoCDSourceUpdate
 		assertions func(
 			originalSource argocd.ApplicationSource,
@@ -530,7 +530,7 @@ func TestApplyArgoCDSourceUpdate(t *testing.T) {
 			source: argocd.ApplicationSource{
 				RepoURL: "fake-url",
 			},
-			newFreight: kargoapi.Freight{
+			newFreight: kargoapi.SimpleFreight{
 				Commits: []kargoapi.GitCommit{
 					{
 						RepoURL: "fake-url",
@@ -562,7 +562,7 @@ func TestApplyArgoCDSourceUpdate(t *testing.T) {
 				RepoURL: "fake-url",
 				Chart:   "fake-chart",
 			},
-			newFreight: kargoapi.Freight{
+			newFreight: kargoapi.SimpleFreight{
 				Charts: []kargoapi.Chart{
 					{
 						RegistryURL: "fake-url",
@@ -595,7 +595,7 @@ func TestApplyArgoCDSourceUpdate(t *testing.T) {
 			source: argocd.ApplicationSource{
 				RepoURL: "fake-url",
 			},
-			newFreight: kargoapi.Freight{
+			newFreight: kargoapi.SimpleFreight{
 				Images: []kargoapi.Image{
 					{
 						RepoURL: "fake-image-url",
@@ -643,7 +643,7 @@ func TestApplyArgoCDSourceUpdate(t *testing.T) {
 			source: argocd.ApplicationSource{
 				RepoURL: "fake-url",
 			},
-			newFreight: kargoapi.Freight{
+			newFreight: kargoapi.SimpleFreight{
 				Images: []kargoapi.Image{
 					{
 						RepoURL: "fake-image-url",
@@ -711,10 +711,12 @@ func TestBuildKustomizeImagesForArgoCDAppSource(t *testing.T) {
 		{
 			RepoURL: "fake-url",
 			Tag:     "fake-tag",
+			Digest:  "fake-digest",
 		},
 		{
 			RepoURL: "another-fake-url",
 			Tag:     "another-fake-tag",
+			Digest:  "another-fake-digest",
 		},
 	}
 	imageUpdates := []string{
@@ -722,15 +724,46 @@ func TestBuildKustomizeImagesForArgoCDAppSource(t *testing.T) {
 		"another-fake-url",
 		"image-that-is-not-in-list",
 	}
-	result := buildKustomizeImagesForArgoCDAppSource(images, imageUpdates)
-	require.Equal(
-		t,
-		argocd.KustomizeImages{
-			"fake-url=fake-url:fake-tag",
-			"another-fake-url=another-fake-url:another-fake-tag",
+	testCases := []struct {
+		name      string
+		valueType kargoapi.ImageUpdateValueType
+		expected  argocd.KustomizeImages
+	}{
+		{
+			name: "value type unspecified",
+			expected: argocd.KustomizeImages{
+				"fake-url=fake-url:fake-tag",
+				"another-fake-url=another-fake-url:another-fake-tag",
+			},
 		},
-		result,
-	)
+		{
+			name:      "value type Digest",
+			valueType: kargoapi.ImageUpdateValueTypeDigest,
+			expected: argocd.KustomizeImages{
+				"fake-url=fake-url@fake-digest",
+				"another-fake-url=another-fake-url@another-fake-digest",
+			},
+		},
+		{
+			name:      "value type Tag@Digest",
+			valueType: kargoapi.ImageUpdateValueTypeTagAtDigest,
+			expected: argocd.KustomizeImages{
+				"fake-url=fake-url:fake-tag@fake-digest",
+				"another-fake-url=another-fake-url:another-fake-tag@another-fake-digest",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := buildKustomizeImagesForArgoCDAppSource(
+				images,
+				imageUpdates,
+				testCase.valueType,
+			)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, result)
+		})
+	}
 }
 
 func TestBuildHelmParamChangesForArgoCDAppSource(t *testing.T) {
@@ -738,6 +771,7 @@ func TestBuildHelmParamChangesForArgoCDAppSource(t *testing.T) {
 		{
 			RepoURL: "fake-url",
 			Tag:     "fake-tag",
+			Digest:  "fake-digest",
 		},
 		{
 			RepoURL: "another-fake-url",
@@ -755,19 +789,46 @@ func TestBuildHelmParamChangesForArgoCDAppSource(t *testing.T) {
 			Key:   "another-fake-key",
 			Value: "Tag",
 		},
+		{
+			Image: "fake-url",
+			Key:   "fake-digest-key",
+			Value: "Digest",
+		},
+		{
+			Image: "fake-url",
+			Key:   "fake-tag-at-digest-key",
+			Value: "Tag@Digest",
+		},
 		{
 			Image: "image-that-is-not-in-list",
 			Key:   "fake-key",
 			Value: "Tag",
 		},
 	}
-	result := buildHelmParamChangesForArgoCDAppSource(images, imageUpdates)
+	result, err := buildHelmParamChangesForArgoCDAppSource(images, imageUpdates)
+	require.NoError(t, err)
 	require.Equal(
 		t,
 		map[string]string{
-			"fake-key":         "fake-url:fake-tag",
-			"another-fake-key": "another-fake-tag",
+			"fake-key":               "fake-url:fake-tag",
+			"another-fake-key":       "another-fake-tag",
+			"fake-digest-key":        "fake-digest",
+			"fake-tag-at-digest-key": "fake-tag@fake-digest",
 		},
 		result,
 	)
+
+	// An image without a recorded digest cannot be referenced by digest
+	_, err = buildHelmParamChangesForArgoCDAppSource(
+		images,
+		[]kargoapi.ArgoCDHelmImageUpdate{
+			{
+				Image: "another-fake-url",
+				Key:   "fake-key",
+				Value: "Tag@Digest",
+			},
+		},
+	)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Freight records no digest for image")
 }
@@ -18,11 +18,11 @@ type bookkeeperMechanism struct {
 	// Overridable behaviors:
 	doSingleUpdateFn func(
 		ctx context.Context,
-		namespace string,
+		stage *kargoapi.Stage,
 		update kargoapi.GitRepoUpdate,
-		newFreight kargoapi.Freight,
+		newFreight kargoapi.SimpleFreight,
 		images []string,
-	) (kargoapi.Freight, error)
+	) (kargoapi.SimpleFreight, error)
 	getReadRefFn func(
 		update kargoapi.GitRepoUpdate,
 		commits []kargoapi.GitCommit,
@@ -62,8 +62,8 @@ func (*bookkeeperMechanism) GetName() string {
 func (b *bookkeeperMechanism) Promote(
 	ctx context.Context,
 	stage *kargoapi.Stage,
-	newFreight kargoapi.Freight,
-) (kargoapi.Freight, error) {
+	newFreight kargoapi.SimpleFreight,
+) (kargoapi.SimpleFreight, error) {
 	updates := make([]kargoapi.GitRepoUpdate, 0, len(stage.Spec.PromotionMechanisms.GitRepoUpdates))
 	for _, update := range stage.Spec.PromotionMechanisms.GitRepoUpdates {
 		if update.Bookkeeper != nil {
@@ -86,10 +86,17 @@ func (b *bookkeeperMechanism) Promote(
 	}
 
 	for _, update := range updates {
+		if err := ctx.Err(); err != nil {
+			return newFreight, errors.Wrapf(
+				err,
+				"interrupted before updating git repo %q",
+				update.RepoURL,
+			)
+		}
 		var err error
 		if newFreight, err = b.doSingleUpdateFn(
 			ctx,
-			stage.Namespace,
+			stage,
 			update,
 			newFreight,
 			images,
@@ -107,13 +114,41 @@ func (b *bookkeeperMechanism) Promote(
 // Bookkeeper.
 func (b *bookkeeperMechanism) doSingleUpdate(
 	ctx context.Context,
-	namespace string,
+	stage *kargoapi.Stage,
 	update kargoapi.GitRepoUpdate,
-	newFreight kargoapi.Freight,
+	newFreight kargoapi.SimpleFreight,
 	images []string,
-) (kargoapi.Freight, error) {
+) (_ kargoapi.SimpleFreight, err error) {
 	logger := logging.LoggerFromContext(ctx).WithField("repo", update.RepoURL)
 
+	step := startStep(b.GetName())
+	step.RepoURL = update.RepoURL
+	step.Branch = update.WriteBranch
+	defer func() { finishStep(ctx, step, err) }()
+
+	// Bookkeeper derives how to render manifests from the name of the branch it
+	// writes to, so it cannot write to a branch of Kargo's choosing for the
+	// sake of a pull request. Whether Bookkeeper opens pull requests is governed
+	// by the repository's own Bookkeeper configuration instead.
+	if update.PullRequest != nil {
+		err = errors.Errorf(
+			"pull requests are not supported for Bookkeeper-based updates to git "+
+				"repo %q; enable them in the repository's Bookkeeper configuration "+
+				"instead",
+			update.RepoURL,
+		)
+		return newFreight, err
+	}
+	// Bookkeeper commits under an identity of its own choosing.
+	if update.Author != nil {
+		err = errors.Errorf(
+			"a commit author is not supported for Bookkeeper-based updates to git "+
+				"repo %q",
+			update.RepoURL,
+		)
+		return newFreight, err
+	}
+
 	readRef, commitIndex, err := b.getReadRefFn(update, newFreight.Commits)
 	if err != nil {
 		return newFreight, err
@@ -121,7 +156,7 @@ func (b *bookkeeperMechanism) doSingleUpdate(
 
 	creds, ok, err := b.getCredentialsFn(
 		ctx,
-		namespace,
+		stage.Namespace,
 		credentials.TypeGit,
 		update.RepoURL,
 	)
@@ -132,6 +167,30 @@ func (b *bookkeeperMechanism) doSingleUpdate(
 			update.RepoURL,
 		)
 	}
+	// Bookkeeper cannot sign the commits it makes. Rather than silently make
+	// unsigned commits to a repo for which a signing key has been provided, we
+	// refuse to proceed.
+	_, signingKeyFound, err := b.getCredentialsFn(
+		ctx,
+		stage.Namespace,
+		credentials.TypeGitSigning,
+		update.RepoURL,
+	)
+	if err != nil {
+		return newFreight, errors.Wrapf(
+			err,
+			"error obtaining signing key for git repo %q",
+			update.RepoURL,
+		)
+	}
+	if signingKeyFound {
+		err = errors.Errorf(
+			"commit signing is not supported for Bookkeeper-based updates to git "+
+				"repo %q, but a signing key was found for it",
+			update.RepoURL,
+		)
+		return newFreight, err
+	}
 	repoCreds := bookkeeper.RepoCredentials{}
 	if ok {
 		repoCreds.Username = creds.Username
@@ -149,6 +208,22 @@ func (b *bookkeeperMechanism) doSingleUpdate(
 		Images:       images,
 		TargetBranch: update.WriteBranch,
 	}
+	if update.CommitMessageTemplate != "" {
+		if req.CommitMessage, err = renderCommitMessage(
+			update.CommitMessageTemplate,
+			commitMessageData{
+				Stage:     stage,
+				Promotion: PromotionFromContext(ctx),
+				Freight:   newFreight,
+			},
+		); err != nil {
+			return newFreight, errors.Wrapf(
+				err,
+				"error rendering commit message for git repo %q",
+				update.RepoURL,
+			)
+		}
+	}
 
 	res, err := b.renderManifestsFn(ctx, req)
 	if err != nil {
@@ -158,6 +233,7 @@ func (b *bookkeeperMechanism) doSingleUpdate(
 			update.RepoURL,
 		)
 	}
+	step.CommitID = res.CommitID
 	switch res.ActionTaken {
 	case bookkeeper.ActionTakenPushedDirectly:
 		logger.WithField("commit", res.CommitID).
@@ -170,8 +246,14 @@ func (b *bookkeeperMechanism) doSingleUpdate(
 		if commitIndex > -1 {
 			newFreight.Commits[commitIndex].HealthCheckCommit = res.CommitID
 		}
-	default:
-		// TODO: Not sure yet how to handle PRs.
+	case bookkeeper.ActionTakenOpenedPR, bookkeeper.ActionTakenUpdatedPR:
+		// Bookkeeper proposed the changes via a pull request because the
+		// repository's Bookkeeper configuration calls for it. That pull request
+		// isn't tracked, so the write branch won't reflect this Promotion until
+		// someone merges it.
+		logger.WithField("pullRequest", res.PullRequestURL).
+			Info("Bookkeeper proposed changes to repo via a pull request")
+		step.PullRequestURL = res.PullRequestURL
 	}
 
 	return newFreight, nil
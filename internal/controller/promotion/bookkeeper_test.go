@@ -6,6 +6,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/akuity/bookkeeper"
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
@@ -34,8 +35,8 @@ func TestBookkeeperPromote(t *testing.T) {
 		name       string
 		promoMech  *bookkeeperMechanism
 		stage      *kargoapi.Stage
-		newFreight kargoapi.Freight
-		assertions func(newFreightIn, newFreightOut kargoapi.Freight, err error)
+		newFreight kargoapi.SimpleFreight
+		assertions func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error)
 	}{
 		{
 			name:      "no updates",
@@ -45,7 +46,7 @@ func TestBookkeeperPromote(t *testing.T) {
 					PromotionMechanisms: &kargoapi.PromotionMechanisms{},
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.NoError(t, err)
 				require.Equal(t, newFreightIn, newFreightOut)
 			},
@@ -55,11 +56,11 @@ func TestBookkeeperPromote(t *testing.T) {
 			promoMech: &bookkeeperMechanism{
 				doSingleUpdateFn: func(
 					_ context.Context,
-					_ string,
+					_ *kargoapi.Stage,
 					_ kargoapi.GitRepoUpdate,
-					newFreight kargoapi.Freight,
+					newFreight kargoapi.SimpleFreight,
 					images []string,
-				) (kargoapi.Freight, error) {
+				) (kargoapi.SimpleFreight, error) {
 					require.Equal(t, []string{"fake-url:fake-tag"}, images)
 					return newFreight, errors.New("something went wrong")
 				},
@@ -75,7 +76,7 @@ func TestBookkeeperPromote(t *testing.T) {
 					},
 				},
 			},
-			newFreight: kargoapi.Freight{
+			newFreight: kargoapi.SimpleFreight{
 				Images: []kargoapi.Image{
 					{
 						RepoURL: "fake-url",
@@ -83,7 +84,7 @@ func TestBookkeeperPromote(t *testing.T) {
 					},
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.Error(t, err)
 				require.Equal(t, "something went wrong", err.Error())
 				require.Equal(t, newFreightIn, newFreightOut)
@@ -94,11 +95,11 @@ func TestBookkeeperPromote(t *testing.T) {
 			promoMech: &bookkeeperMechanism{
 				doSingleUpdateFn: func(
 					_ context.Context,
-					_ string,
+					_ *kargoapi.Stage,
 					_ kargoapi.GitRepoUpdate,
-					newFreight kargoapi.Freight,
+					newFreight kargoapi.SimpleFreight,
 					images []string,
-				) (kargoapi.Freight, error) {
+				) (kargoapi.SimpleFreight, error) {
 					require.Equal(t, []string{"fake-url:fake-tag"}, images)
 					return newFreight, nil
 				},
@@ -114,7 +115,7 @@ func TestBookkeeperPromote(t *testing.T) {
 					},
 				},
 			},
-			newFreight: kargoapi.Freight{
+			newFreight: kargoapi.SimpleFreight{
 				Images: []kargoapi.Image{
 					{
 						RepoURL: "fake-url",
@@ -122,7 +123,7 @@ func TestBookkeeperPromote(t *testing.T) {
 					},
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.NoError(t, err)
 				require.Equal(t, newFreightIn, newFreightOut)
 			},
@@ -146,8 +147,73 @@ func TestBookkeeperDoSingleUpdate(t *testing.T) {
 		name       string
 		promoMech  *bookkeeperMechanism
 		update     kargoapi.GitRepoUpdate
-		assertions func(newFreightIn, newFreightOut kargoapi.Freight, err error)
+		assertions func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error)
 	}{
+		{
+			name:      "pull request requested",
+			promoMech: &bookkeeperMechanism{},
+			update: kargoapi.GitRepoUpdate{
+				RepoURL:     "fake-url",
+				Bookkeeper:  &kargoapi.BookkeeperPromotionMechanism{},
+				PullRequest: &kargoapi.PullRequestPromotionMechanism{},
+			},
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
+				require.Error(t, err)
+				require.Contains(
+					t,
+					err.Error(),
+					"pull requests are not supported for Bookkeeper-based updates",
+				)
+				require.Equal(t, newFreightIn, newFreightOut)
+			},
+		},
+		{
+			name:      "author requested",
+			promoMech: &bookkeeperMechanism{},
+			update: kargoapi.GitRepoUpdate{
+				RepoURL:    "fake-url",
+				Bookkeeper: &kargoapi.BookkeeperPromotionMechanism{},
+				Author:     &kargoapi.GitAuthor{Name: "Fake Author"},
+			},
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
+				require.Error(t, err)
+				require.Contains(
+					t,
+					err.Error(),
+					"a commit author is not supported for Bookkeeper-based updates",
+				)
+				require.Equal(t, newFreightIn, newFreightOut)
+			},
+		},
+		{
+			name: "error rendering commit message",
+			promoMech: &bookkeeperMechanism{
+				getReadRefFn: func(
+					kargoapi.GitRepoUpdate,
+					[]kargoapi.GitCommit,
+				) (string, int, error) {
+					return testRef, 0, nil
+				},
+				getCredentialsFn: func(
+					context.Context,
+					string,
+					credentials.Type,
+					string,
+				) (credentials.Credentials, bool, error) {
+					return credentials.Credentials{}, false, nil
+				},
+			},
+			update: kargoapi.GitRepoUpdate{
+				RepoURL:               "fake-url",
+				Bookkeeper:            &kargoapi.BookkeeperPromotionMechanism{},
+				CommitMessageTemplate: "{{ .Bogus }}",
+			},
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error rendering commit message")
+				require.Equal(t, newFreightIn, newFreightOut)
+			},
+		},
 		{
 			name: "error getting readref",
 			promoMech: &bookkeeperMechanism{
@@ -158,7 +224,7 @@ func TestBookkeeperDoSingleUpdate(t *testing.T) {
 					return "", 0, errors.New("something went wrong")
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.Error(t, err)
 				require.Equal(t, "something went wrong", err.Error())
 				require.Equal(t, newFreightIn, newFreightOut)
@@ -184,7 +250,7 @@ func TestBookkeeperDoSingleUpdate(t *testing.T) {
 						errors.New("something went wrong")
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.Error(t, err)
 				require.Contains(
 					t,
@@ -196,7 +262,7 @@ func TestBookkeeperDoSingleUpdate(t *testing.T) {
 			},
 		},
 		{
-			name: "error rendering manifests",
+			name: "error getting signing key",
 			promoMech: &bookkeeperMechanism{
 				getReadRefFn: func(
 					kargoapi.GitRepoUpdate,
@@ -205,11 +271,88 @@ func TestBookkeeperDoSingleUpdate(t *testing.T) {
 					return testRef, 0, nil
 				},
 				getCredentialsFn: func(
+					_ context.Context,
+					_ string,
+					credType credentials.Type,
+					_ string,
+				) (credentials.Credentials, bool, error) {
+					if credType == credentials.TypeGitSigning {
+						return credentials.Credentials{},
+							false,
+							errors.New("something went wrong")
+					}
+					return credentials.Credentials{}, false, nil
+				},
+			},
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error obtaining signing key for git repo")
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Equal(t, newFreightIn, newFreightOut)
+			},
+		},
+		{
+			name: "signing key found",
+			promoMech: &bookkeeperMechanism{
+				getReadRefFn: func(
+					kargoapi.GitRepoUpdate,
+					[]kargoapi.GitCommit,
+				) (string, int, error) {
+					return testRef, 0, nil
+				},
+				getCredentialsFn: func(
+					_ context.Context,
+					_ string,
+					credType credentials.Type,
+					_ string,
+				) (credentials.Credentials, bool, error) {
+					if credType == credentials.TypeGitSigning {
+						return credentials.Credentials{
+							SigningKey: "fake-signing-key",
+						}, true, nil
+					}
+					return credentials.Credentials{}, false, nil
+				},
+				renderManifestsFn: func(
 					context.Context,
-					string,
-					credentials.Type,
-					string,
+					bookkeeper.RenderRequest,
+				) (bookkeeper.RenderResponse, error) {
+					require.FailNow(t, "manifests should not have been rendered")
+					return bookkeeper.RenderResponse{}, nil
+				},
+			},
+			update: kargoapi.GitRepoUpdate{
+				RepoURL:    "fake-url",
+				Bookkeeper: &kargoapi.BookkeeperPromotionMechanism{},
+			},
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
+				require.Error(t, err)
+				require.Contains(
+					t,
+					err.Error(),
+					"commit signing is not supported for Bookkeeper-based updates",
+				)
+				require.Equal(t, newFreightIn, newFreightOut)
+			},
+		},
+		{
+			name: "error rendering manifests",
+			promoMech: &bookkeeperMechanism{
+				getReadRefFn: func(
+					kargoapi.GitRepoUpdate,
+					[]kargoapi.GitCommit,
+				) (string, int, error) {
+					return testRef, 0, nil
+				},
+				getCredentialsFn: func(
+					_ context.Context,
+					_ string,
+					credType credentials.Type,
+					_ string,
 				) (credentials.Credentials, bool, error) {
+					if credType != credentials.TypeGit {
+						return credentials.Credentials{}, false, nil
+					}
 					return credentials.Credentials{
 						Username: "fake-username",
 						Password: "fake-personal-access-token",
@@ -222,7 +365,7 @@ func TestBookkeeperDoSingleUpdate(t *testing.T) {
 					return bookkeeper.RenderResponse{}, errors.New("something went wrong")
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.Error(t, err)
 				require.Contains(
 					t,
@@ -243,11 +386,14 @@ func TestBookkeeperDoSingleUpdate(t *testing.T) {
 					return testRef, 0, nil
 				},
 				getCredentialsFn: func(
-					context.Context,
-					string,
-					credentials.Type,
-					string,
+					_ context.Context,
+					_ string,
+					credType credentials.Type,
+					_ string,
 				) (credentials.Credentials, bool, error) {
+					if credType != credentials.TypeGit {
+						return credentials.Credentials{}, false, nil
+					}
 					return credentials.Credentials{
 						Username: "fake-username",
 						Password: "fake-personal-access-token",
@@ -263,7 +409,7 @@ func TestBookkeeperDoSingleUpdate(t *testing.T) {
 					}, nil
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.NoError(t, err)
 				require.Equal(
 					t,
@@ -277,6 +423,9 @@ func TestBookkeeperDoSingleUpdate(t *testing.T) {
 		},
 		{
 			name: "success -- commit",
+			update: kargoapi.GitRepoUpdate{
+				CommitMessageTemplate: "Promote {{ .Stage.Name }}",
+			},
 			promoMech: &bookkeeperMechanism{
 				getReadRefFn: func(
 					kargoapi.GitRepoUpdate,
@@ -285,27 +434,31 @@ func TestBookkeeperDoSingleUpdate(t *testing.T) {
 					return testRef, 0, nil
 				},
 				getCredentialsFn: func(
-					context.Context,
-					string,
-					credentials.Type,
-					string,
+					_ context.Context,
+					_ string,
+					credType credentials.Type,
+					_ string,
 				) (credentials.Credentials, bool, error) {
+					if credType != credentials.TypeGit {
+						return credentials.Credentials{}, false, nil
+					}
 					return credentials.Credentials{
 						Username: "fake-username",
 						Password: "fake-personal-access-token",
 					}, true, nil
 				},
 				renderManifestsFn: func(
-					context.Context,
-					bookkeeper.RenderRequest,
+					_ context.Context,
+					req bookkeeper.RenderRequest,
 				) (bookkeeper.RenderResponse, error) {
+					require.Equal(t, "Promote fake-stage", req.CommitMessage)
 					return bookkeeper.RenderResponse{
 						ActionTaken: bookkeeper.ActionTakenPushedDirectly,
 						CommitID:    "fake-commit-id",
 					}, nil
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.NoError(t, err)
 				require.Equal(
 					t,
@@ -320,12 +473,16 @@ func TestBookkeeperDoSingleUpdate(t *testing.T) {
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			newFreightIn := kargoapi.Freight{
+			newFreightIn := kargoapi.SimpleFreight{
 				Commits: []kargoapi.GitCommit{{}},
 			}
 			newFreightOut, err := testCase.promoMech.doSingleUpdate(
 				context.Background(),
-				"fake-namespace",
+				&kargoapi.Stage{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "fake-stage",
+					},
+				},
 				testCase.update,
 				newFreightIn,
 				nil, // Images
@@ -0,0 +1,128 @@
+package promotion
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	libExec "github.com/akuity/kargo/internal/exec"
+)
+
+// commitOptions describes how commits made by git-based promotion mechanisms
+// are to be authored, signed and described.
+type commitOptions struct {
+	// messageData is the data available to a GitRepoUpdate's commit message
+	// template.
+	messageData commitMessageData
+	// author, if non-nil, overrides the identity under which commits are
+	// authored.
+	author *kargoapi.GitAuthor
+	// signingKey, if non-nil, is the key with which commits are signed.
+	signingKey *signingKey
+}
+
+// commitMessageData is the data available to a GitRepoUpdate's commit message
+// template.
+type commitMessageData struct {
+	// Stage is the Stage being promoted.
+	Stage *kargoapi.Stage
+	// Promotion is the Promotion being executed. It may be nil.
+	Promotion *kargoapi.Promotion
+	// Freight is the Freight being promoted.
+	Freight kargoapi.SimpleFreight
+	// Changes summarizes the changes being committed.
+	Changes []string
+}
+
+// renderCommitMessage renders the provided commit message template using the
+// provided data. If the template is empty, a message is built from the summary
+// of changes found in the data instead.
+func renderCommitMessage(tmplStr string, data commitMessageData) (string, error) {
+	if tmplStr == "" {
+		return buildCommitMessage(data.Changes), nil
+	}
+	tmpl, err := template.New("commitMessage").Option("missingkey=error").
+		Parse(tmplStr)
+	if err != nil {
+		return "", errors.Wrap(err, "error parsing commit message template")
+	}
+	buf := &bytes.Buffer{}
+	if err = tmpl.Execute(buf, data); err != nil {
+		return "", errors.Wrap(err, "error executing commit message template")
+	}
+	msg := strings.TrimSpace(buf.String())
+	if msg == "" {
+		return "", errors.New("commit message template rendered an empty message")
+	}
+	return msg, nil
+}
+
+// getCommitAuthor returns the identity under which commits for the provided
+// update are to be authored. If the update calls for commits to be authored by
+// the user who created the provided Promotion (which may be nil) and that user
+// is known, that user's identity takes precedence over the name and email
+// address specified by the update. If no identity is specified, nil is
+// returned.
+func getCommitAuthor(
+	update kargoapi.GitRepoUpdate,
+	promo *kargoapi.Promotion,
+) *kargoapi.GitAuthor {
+	if update.Author == nil {
+		return nil
+	}
+	author := &kargoapi.GitAuthor{
+		Name:  update.Author.Name,
+		Email: update.Author.Email,
+	}
+	if update.Author.UsePromotionCreator && promo != nil {
+		if creator := promo.Annotations[kargoapi.AnnotationKeyCreateActor]; creator != "" {
+			author.Name = creator
+			if strings.Contains(creator, "@") {
+				author.Email = creator
+			}
+		}
+	}
+	if author.Name == "" && author.Email == "" {
+		return nil
+	}
+	return author
+}
+
+// configureCommitAuthor configures git, as used by the system user whose home
+// directory is specified, to author every commit it makes under the provided
+// identity.
+func configureCommitAuthor(homeDir string, author *kargoapi.GitAuthor) error {
+	var settings [][]string
+	if author.Name != "" {
+		settings = append(settings, []string{"author.name", author.Name})
+	}
+	if author.Email != "" {
+		settings = append(settings, []string{"author.email", author.Email})
+	}
+	return setGlobalGitConfig(homeDir, settings)
+}
+
+// setGlobalGitConfig applies the provided settings, each a name/value pair, to
+// the global git configuration of the system user whose home directory is
+// specified.
+func setGlobalGitConfig(homeDir string, settings [][]string) error {
+	for _, setting := range settings {
+		cmd := exec.Command( // nolint: gosec
+			"git",
+			"config",
+			"--global",
+			setting[0],
+			setting[1],
+		)
+		cmd.Env = []string{"HOME=" + homeDir}
+		cmd.Dir = homeDir
+		if _, err := libExec.Exec(cmd); err != nil {
+			return errors.Wrapf(err, "error configuring git setting %q", setting[0])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package promotion
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestRenderCommitMessage(t *testing.T) {
+	testData := commitMessageData{
+		Stage: &kargoapi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "fake-stage",
+			},
+		},
+		Promotion: &kargoapi.Promotion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "fake-promotion",
+			},
+		},
+		Freight: kargoapi.SimpleFreight{
+			ID: "fake-freight",
+			Images: []kargoapi.Image{
+				{
+					RepoURL: "fake-image",
+					Tag:     "fake-tag",
+				},
+			},
+		},
+		Changes: []string{"fake-change"},
+	}
+	testCases := []struct {
+		name       string
+		tmpl       string
+		assertions func(string, error)
+	}{
+		{
+			name: "no template",
+			assertions: func(msg string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "fake-change", msg)
+			},
+		},
+		{
+			name: "invalid template",
+			tmpl: "{{ .Freight.ID",
+			assertions: func(_ string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error parsing commit message template")
+			},
+		},
+		{
+			name: "error executing template",
+			tmpl: "{{ .Bogus }}",
+			assertions: func(_ string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error executing commit message template")
+			},
+		},
+		{
+			name: "template renders empty message",
+			tmpl: "  {{ if false }}nothing{{ end }}\n",
+			assertions: func(_ string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "empty message")
+			},
+		},
+		{
+			name: "success",
+			tmpl: "Promote {{ .Freight.ID }} to {{ .Stage.Name }}\n\n" +
+				"Promotion: {{ .Promotion.Name }}\n" +
+				"{{ range .Freight.Images }}* {{ .RepoURL }}:{{ .Tag }}{{ end }}\n",
+			assertions: func(msg string, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					"Promote fake-freight to fake-stage\n\n"+
+						"Promotion: fake-promotion\n"+
+						"* fake-image:fake-tag",
+					msg,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(renderCommitMessage(testCase.tmpl, testData))
+		})
+	}
+}
+
+func TestGetCommitAuthor(t *testing.T) {
+	testCases := []struct {
+		name       string
+		update     kargoapi.GitRepoUpdate
+		promo      *kargoapi.Promotion
+		assertions func(*kargoapi.GitAuthor)
+	}{
+		{
+			name:   "no author specified",
+			update: kargoapi.GitRepoUpdate{},
+			assertions: func(author *kargoapi.GitAuthor) {
+				require.Nil(t, author)
+			},
+		},
+		{
+			name: "empty author specified",
+			update: kargoapi.GitRepoUpdate{
+				Author: &kargoapi.GitAuthor{},
+			},
+			assertions: func(author *kargoapi.GitAuthor) {
+				require.Nil(t, author)
+			},
+		},
+		{
+			name: "author specified",
+			update: kargoapi.GitRepoUpdate{
+				Author: &kargoapi.GitAuthor{
+					Name:  "Fake Author",
+					Email: "author@example.com",
+				},
+			},
+			assertions: func(author *kargoapi.GitAuthor) {
+				require.Equal(
+					t,
+					&kargoapi.GitAuthor{
+						Name:  "Fake Author",
+						Email: "author@example.com",
+					},
+					author,
+				)
+			},
+		},
+		{
+			name: "Promotion creator unknown",
+			update: kargoapi.GitRepoUpdate{
+				Author: &kargoapi.GitAuthor{
+					Name:                "Fake Author",
+					Email:               "author@example.com",
+					UsePromotionCreator: true,
+				},
+			},
+			promo: &kargoapi.Promotion{},
+			assertions: func(author *kargoapi.GitAuthor) {
+				require.Equal(
+					t,
+					&kargoapi.GitAuthor{
+						Name:  "Fake Author",
+						Email: "author@example.com",
+					},
+					author,
+				)
+			},
+		},
+		{
+			name: "Promotion creator with email address",
+			update: kargoapi.GitRepoUpdate{
+				Author: &kargoapi.GitAuthor{
+					Name:                "Fake Author",
+					Email:               "author@example.com",
+					UsePromotionCreator: true,
+				},
+			},
+			promo: &kargoapi.Promotion{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						kargoapi.AnnotationKeyCreateActor: "creator@example.com",
+					},
+				},
+			},
+			assertions: func(author *kargoapi.GitAuthor) {
+				require.Equal(
+					t,
+					&kargoapi.GitAuthor{
+						Name:  "creator@example.com",
+						Email: "creator@example.com",
+					},
+					author,
+				)
+			},
+		},
+		{
+			name: "Promotion creator without email address",
+			update: kargoapi.GitRepoUpdate{
+				Author: &kargoapi.GitAuthor{
+					Email:               "author@example.com",
+					UsePromotionCreator: true,
+				},
+			},
+			promo: &kargoapi.Promotion{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						kargoapi.AnnotationKeyCreateActor: "admin",
+					},
+				},
+			},
+			assertions: func(author *kargoapi.GitAuthor) {
+				require.Equal(
+					t,
+					&kargoapi.GitAuthor{
+						Name:  "admin",
+						Email: "author@example.com",
+					},
+					author,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(getCommitAuthor(testCase.update, testCase.promo))
+		})
+	}
+}
+
+func TestConfigureCommitAuthor(t *testing.T) {
+	homeDir := t.TempDir()
+	err := configureCommitAuthor(
+		homeDir,
+		&kargoapi.GitAuthor{
+			Name:  "Fake Author",
+			Email: "author@example.com",
+		},
+	)
+	require.NoError(t, err)
+
+	getConfig := func(name string) string {
+		cmd := exec.Command("git", "config", "--global", name)
+		cmd.Env = []string{"HOME=" + homeDir}
+		res, err := cmd.Output()
+		require.NoError(t, err)
+		return strings.TrimSpace(string(res))
+	}
+	require.Equal(t, "Fake Author", getConfig("author.name"))
+	require.Equal(t, "author@example.com", getConfig("author.email"))
+}
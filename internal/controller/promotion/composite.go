@@ -41,8 +41,8 @@ func (c *compositeMechanism) GetName() string {
 func (c *compositeMechanism) Promote(
 	ctx context.Context,
 	stage *kargoapi.Stage,
-	newFreight kargoapi.Freight,
-) (kargoapi.Freight, error) {
+	newFreight kargoapi.SimpleFreight,
+) (kargoapi.SimpleFreight, error) {
 	if stage.Spec.PromotionMechanisms == nil {
 		return newFreight, nil
 	}
@@ -53,6 +53,14 @@ func (c *compositeMechanism) Promote(
 	logger.Debugf("executing %s", c.name)
 
 	for _, childMechanism := range c.childMechanisms {
+		if err := ctx.Err(); err != nil {
+			return newFreight, errors.Wrapf(
+				err,
+				"%s interrupted before executing %s",
+				c.name,
+				childMechanism.GetName(),
+			)
+		}
 		var err error
 		newFreight, err = childMechanism.Promote(ctx, stage, newFreight)
 		if err != nil {
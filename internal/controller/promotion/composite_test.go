@@ -41,8 +41,8 @@ func TestCompositePromote(t *testing.T) {
 	testCases := []struct {
 		name       string
 		promoMech  *compositeMechanism
-		newFreight kargoapi.Freight
-		assertions func(newFreightIn, newFreightOut kargoapi.Freight, err error)
+		newFreight kargoapi.SimpleFreight
+		assertions func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error)
 	}{
 		{
 			name: "error executing child promotion mechanism",
@@ -53,14 +53,14 @@ func TestCompositePromote(t *testing.T) {
 						PromoteFn: func(
 							context.Context,
 							*kargoapi.Stage,
-							kargoapi.Freight,
-						) (kargoapi.Freight, error) {
-							return kargoapi.Freight{}, errors.New("something went wrong")
+							kargoapi.SimpleFreight,
+						) (kargoapi.SimpleFreight, error) {
+							return kargoapi.SimpleFreight{}, errors.New("something went wrong")
 						},
 					},
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.Error(t, err)
 				require.Contains(
 					t,
@@ -79,8 +79,8 @@ func TestCompositePromote(t *testing.T) {
 						PromoteFn: func(
 							_ context.Context,
 							_ *kargoapi.Stage,
-							newFreight kargoapi.Freight,
-						) (kargoapi.Freight, error) {
+							newFreight kargoapi.SimpleFreight,
+						) (kargoapi.SimpleFreight, error) {
 							// This is not a realistic change that a child promotion mechanism
 							// would make, but for testing purposes, this is good enough to
 							// help us assert that the function under test does return all
@@ -91,7 +91,7 @@ func TestCompositePromote(t *testing.T) {
 					},
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.NoError(t, err)
 				// Verify that changes made by child promotion mechanism are returned
 				require.Equal(t, "fake-mutated-id", newFreightOut.ID)
@@ -116,3 +116,35 @@ func TestCompositePromote(t *testing.T) {
 		})
 	}
 }
+
+func TestCompositePromoteCanceled(t *testing.T) {
+	promoMech := &compositeMechanism{
+		name: "fake composite promotion mechanism",
+		childMechanisms: []Mechanism{
+			&FakeMechanism{
+				Name: "fake promotion mechanism",
+				PromoteFn: func(
+					context.Context,
+					*kargoapi.Stage,
+					kargoapi.SimpleFreight,
+				) (kargoapi.SimpleFreight, error) {
+					require.FailNow(t, "child promotion mechanism should not have been executed")
+					return kargoapi.SimpleFreight{}, nil
+				},
+			},
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := promoMech.Promote(
+		ctx,
+		&kargoapi.Stage{
+			Spec: &kargoapi.StageSpec{
+				PromotionMechanisms: &kargoapi.PromotionMechanisms{},
+			},
+		},
+		kargoapi.SimpleFreight{},
+	)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Contains(t, err.Error(), "interrupted before executing fake promotion mechanism")
+}
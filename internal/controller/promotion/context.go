@@ -0,0 +1,30 @@
+package promotion
+
+import (
+	"context"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+type promotionContextKey struct{}
+
+// ContextWithPromotion returns a context.Context that has been augmented with
+// the provided Promotion. Promotion mechanisms may use the Promotion bound to
+// the context passed to Promote() to learn more about the Promotion they are
+// executing, e.g. who created it.
+func ContextWithPromotion(
+	ctx context.Context,
+	promo *kargoapi.Promotion,
+) context.Context {
+	return context.WithValue(ctx, promotionContextKey{}, promo)
+}
+
+// PromotionFromContext extracts a *kargoapi.Promotion from the provided
+// context.Context and returns it. If no *kargoapi.Promotion is found, nil is
+// returned.
+func PromotionFromContext(ctx context.Context) *kargoapi.Promotion {
+	if promo := ctx.Value(promotionContextKey{}); promo != nil {
+		return promo.(*kargoapi.Promotion) // nolint: forcetypeassert
+	}
+	return nil
+}
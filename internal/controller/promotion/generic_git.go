@@ -3,16 +3,19 @@ package promotion
 import (
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	libGit "github.com/akuity/kargo/internal/git"
 )
 
 // newGenericGitMechanism returns a gitMechanism that only only selects and
 // performs updates that do not involve any configuration management tools.
 func newGenericGitMechanism(
 	credentialsDB credentials.Database,
+	gitCache *libGit.Cache,
 ) Mechanism {
 	return newGitMechanism(
 		"generic Git promotion mechanism",
 		credentialsDB,
+		gitCache,
 		selectGenericGitUpdates,
 		nil,
 	)
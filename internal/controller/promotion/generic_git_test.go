@@ -7,10 +7,11 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	libGit "github.com/akuity/kargo/internal/git"
 )
 
 func TestNewGenericGitMechanism(t *testing.T) {
-	pm := newGenericGitMechanism(&credentials.FakeDB{})
+	pm := newGenericGitMechanism(&credentials.FakeDB{}, &libGit.Cache{})
 	ggpm, ok := pm.(*gitMechanism)
 	require.True(t, ok)
 	require.NotNil(t, ggpm.selectUpdatesFn)
@@ -11,6 +11,8 @@ import (
 	"github.com/akuity/bookkeeper/pkg/git"
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	libGit "github.com/akuity/kargo/internal/git"
+	"github.com/akuity/kargo/internal/gitprovider"
 	"github.com/akuity/kargo/internal/logging"
 )
 
@@ -18,15 +20,16 @@ import (
 // update configuration in a repository. It is easily configured to support
 // different types of configuration management tools.
 type gitMechanism struct {
-	name string
+	name     string
+	gitCache *libGit.Cache
 	// Overridable behaviors:
 	selectUpdatesFn  func([]kargoapi.GitRepoUpdate) []kargoapi.GitRepoUpdate
 	doSingleUpdateFn func(
 		ctx context.Context,
-		namespace string,
+		stage *kargoapi.Stage,
 		update kargoapi.GitRepoUpdate,
-		newFreight kargoapi.Freight,
-	) (kargoapi.Freight, error)
+		newFreight kargoapi.SimpleFreight,
+	) (kargoapi.SimpleFreight, error)
 	getReadRefFn func(
 		update kargoapi.GitRepoUpdate,
 		commits []kargoapi.GitCommit,
@@ -37,15 +40,26 @@ type gitMechanism struct {
 		repoURL string,
 	) (*git.RepoCredentials, error)
 	gitCommitFn func(
+		ctx context.Context,
 		update kargoapi.GitRepoUpdate,
-		newFreight kargoapi.Freight,
+		newFreight kargoapi.SimpleFreight,
 		readRef string,
 		writeBranch string,
 		creds *git.RepoCredentials,
-	) (string, error)
+		opts commitOptions,
+	) (string, bool, error)
+	getSigningKeyFn func(
+		ctx context.Context,
+		namespace string,
+		repoURL string,
+	) (*signingKey, error)
+	getPullRequestProviderFn func(
+		update kargoapi.GitRepoUpdate,
+		creds *git.RepoCredentials,
+	) (gitprovider.Interface, error)
 	applyConfigManagementFn func(
 		update kargoapi.GitRepoUpdate,
-		newFreight kargoapi.Freight,
+		newFreight kargoapi.SimpleFreight,
 		homeDir string,
 		workingDir string,
 	) ([]string, error)
@@ -58,22 +72,26 @@ type gitMechanism struct {
 func newGitMechanism(
 	name string,
 	credentialsDB credentials.Database,
+	gitCache *libGit.Cache,
 	selectUpdatesFn func([]kargoapi.GitRepoUpdate) []kargoapi.GitRepoUpdate,
 	applyConfigManagementFn func(
 		update kargoapi.GitRepoUpdate,
-		newFreight kargoapi.Freight,
+		newFreight kargoapi.SimpleFreight,
 		homeDir string,
 		workingDir string,
 	) ([]string, error),
 ) Mechanism {
 	g := &gitMechanism{
-		name: name,
+		name:     name,
+		gitCache: gitCache,
 	}
 	g.selectUpdatesFn = selectUpdatesFn
 	g.doSingleUpdateFn = g.doSingleUpdate
 	g.getReadRefFn = getReadRef
 	g.getCredentialsFn = getRepoCredentialsFn(credentialsDB)
+	g.getSigningKeyFn = getSigningKeyFn(credentialsDB)
 	g.gitCommitFn = g.gitCommit
+	g.getPullRequestProviderFn = getPullRequestProvider
 	g.applyConfigManagementFn = applyConfigManagementFn
 	return g
 }
@@ -87,8 +105,8 @@ func (g *gitMechanism) GetName() string {
 func (g *gitMechanism) Promote(
 	ctx context.Context,
 	stage *kargoapi.Stage,
-	newFreight kargoapi.Freight,
-) (kargoapi.Freight, error) {
+	newFreight kargoapi.SimpleFreight,
+) (kargoapi.SimpleFreight, error) {
 	updates := g.selectUpdatesFn(stage.Spec.PromotionMechanisms.GitRepoUpdates)
 
 	if len(updates) == 0 {
@@ -101,10 +119,18 @@ func (g *gitMechanism) Promote(
 	logger.Debugf("executing %s", g.name)
 
 	for _, update := range updates {
+		if err := ctx.Err(); err != nil {
+			return newFreight, errors.Wrapf(
+				err,
+				"%s interrupted before updating git repo %q",
+				g.name,
+				update.RepoURL,
+			)
+		}
 		var err error
 		if newFreight, err = g.doSingleUpdateFn(
 			ctx,
-			stage.Namespace,
+			stage,
 			update,
 			newFreight,
 		); err != nil {
@@ -120,10 +146,15 @@ func (g *gitMechanism) Promote(
 // doSingleUpdate updates configuration in a single Git repository.
 func (g *gitMechanism) doSingleUpdate(
 	ctx context.Context,
-	namespace string,
+	stage *kargoapi.Stage,
 	update kargoapi.GitRepoUpdate,
-	newFreight kargoapi.Freight,
-) (kargoapi.Freight, error) {
+	newFreight kargoapi.SimpleFreight,
+) (_ kargoapi.SimpleFreight, err error) {
+	step := startStep(g.name)
+	step.RepoURL = update.RepoURL
+	step.Branch = update.WriteBranch
+	defer func() { finishStep(ctx, step, err) }()
+
 	readRef, commitIndex, err := g.getReadRefFn(update, newFreight.Commits)
 	if err != nil {
 		return newFreight, err
@@ -131,23 +162,102 @@ func (g *gitMechanism) doSingleUpdate(
 
 	creds, err := g.getCredentialsFn(
 		ctx,
-		namespace,
+		stage.Namespace,
 		update.RepoURL,
 	)
 	if err != nil {
 		return newFreight, err
 	}
 
-	commitID, err := g.gitCommitFn(
+	signingKey, err := g.getSigningKeyFn(ctx, stage.Namespace, update.RepoURL)
+	if err != nil {
+		return newFreight, err
+	}
+
+	promo := PromotionFromContext(ctx)
+	commitOpts := commitOptions{
+		messageData: commitMessageData{
+			Stage:     stage,
+			Promotion: promo,
+		},
+		author:     getCommitAuthor(update, promo),
+		signingKey: signingKey,
+	}
+
+	writeBranch := update.WriteBranch
+	var prProvider gitprovider.Interface
+	if update.PullRequest != nil {
+		// Changes are pushed to a branch of their own and proposed via a pull
+		// request. If that has already happened, there's nothing to do but check
+		// whether the pull request has been merged yet.
+		writeBranch = getPullRequestBranch(update, newFreight)
+		step.Branch = writeBranch
+		if prProvider, err = g.getPullRequestProviderFn(update, creds); err != nil {
+			return newFreight, err
+		}
+		var pr *gitprovider.PullRequest
+		if pr, err = findMergedPullRequest(
+			ctx,
+			prProvider,
+			update,
+			writeBranch,
+		); err != nil {
+			return newFreight, err
+		}
+		if pr != nil {
+			step.PullRequestURL = pr.URL
+			step.CommitID = pr.MergeCommitID
+			if commitIndex > -1 {
+				newFreight.Commits[commitIndex].HealthCheckCommit = pr.MergeCommitID
+			}
+			return newFreight, nil
+		}
+	}
+
+	commitID, changed, err := g.gitCommitFn(
+		ctx,
 		update,
 		newFreight,
 		readRef,
-		update.WriteBranch,
+		writeBranch,
 		creds,
+		commitOpts,
 	)
 	if err != nil {
 		return newFreight, err
 	}
+	step.CommitID = commitID
+	if signingKey != nil && changed {
+		step.SigningKeyFingerprint = signingKey.fingerprint
+	}
+
+	// If there were no changes to push, there is nothing to propose and the
+	// write branch already reflects the desired state.
+	if prProvider != nil && changed {
+		var pr *gitprovider.PullRequest
+		if pr, err = prProvider.CreatePullRequest(
+			ctx,
+			gitprovider.CreatePullRequestOpts{
+				Head:  writeBranch,
+				Base:  update.WriteBranch,
+				Title: fmt.Sprintf("Promote Freight %s", newFreight.ID),
+				Description: fmt.Sprintf(
+					"This pull request was opened by Kargo to promote Freight %s. "+
+						"The Promotion will proceed once it has been merged.",
+					newFreight.ID,
+				),
+			},
+		); err != nil {
+			return newFreight, errors.Wrapf(
+				err,
+				"error opening pull request for branch %q of git repo %q",
+				writeBranch,
+				update.RepoURL,
+			)
+		}
+		err = &PullRequestOpenError{URL: pr.URL}
+		return newFreight, err
+	}
 
 	if commitIndex > -1 {
 		newFreight.Commits[commitIndex].HealthCheckCommit = commitID
@@ -231,29 +341,61 @@ func getRepoCredentialsFn(
 // (which may be nil), checks out the specified readRef (if non-empty), applies
 // the provided update function to the cloned repository, and then commits and
 // pushes any changes to the specified writeBranch. The function returns the
-// commit ID of the last commit made to the repository, or an error if any of
-// the above fails.
+// commit ID of the last commit made to the repository and whether the
+// writeBranch holds changes that were not there before (or, for pull request
+// branches, that have yet to be proposed), or an error if any of the above
+// fails. If the provided context is canceled before changes are pushed,
+// nothing is pushed and an error is returned. If the provided update proposes
+// changes via a pull request and the writeBranch does not exist yet, it is
+// created from the update's write branch so the two share history. Any commit
+// made is authored, signed and described as the provided commitOptions dictate.
 func (g *gitMechanism) gitCommit(
+	ctx context.Context,
 	update kargoapi.GitRepoUpdate,
-	newFreight kargoapi.Freight,
+	newFreight kargoapi.SimpleFreight,
 	readRef string,
 	writeBranch string,
 	creds *git.RepoCredentials,
-) (string, error) {
+	opts commitOptions,
+) (string, bool, error) {
 	if creds == nil {
 		creds = &git.RepoCredentials{}
 	}
-	repo, err := git.Clone(update.RepoURL, *creds)
+	repo, err := g.gitCache.Clone(ctx, update.RepoURL, *creds, nil)
 	if err != nil {
-		return "", errors.Wrapf(err, "error cloning git repo %q", update.RepoURL)
+		return "", false, errors.Wrapf(err, "error cloning git repo %q", update.RepoURL)
 	}
 	defer repo.Close()
 
+	if opts.author != nil {
+		if err = configureCommitAuthor(repo.HomeDir(), opts.author); err != nil {
+			return "", false, errors.Wrapf(
+				err,
+				"error configuring commit author for git repo %q",
+				update.RepoURL,
+			)
+		}
+	}
+
+	if opts.signingKey != nil {
+		if err = configureCommitSigning(repo.HomeDir(), opts.signingKey); err != nil {
+			return "", false, errors.Wrapf(
+				err,
+				"error configuring commit signing for git repo %q",
+				update.RepoURL,
+			)
+		}
+	}
+
+	if err = ctx.Err(); err != nil {
+		return "", false, errors.Wrapf(err, "interrupted after cloning git repo %q", update.RepoURL)
+	}
+
 	// If readRef is non-empty, check out the specified commit or branch,
 	// otherwise just move using the repository's default branch as the source.
 	if readRef != "" {
 		if err = repo.Checkout(readRef); err != nil {
-			return "", errors.Wrapf(
+			return "", false, errors.Wrapf(
 				err,
 				"error checking out %q from git repo",
 				readRef,
@@ -261,6 +403,11 @@ func (g *gitMechanism) gitCommit(
 		}
 	}
 
+	// Whether the writeBranch holds changes that still need proposing via a
+	// pull request. A pull request branch that already exists holds changes
+	// pushed by a previous attempt that failed before opening a pull request.
+	var pendingPullRequest bool
+
 	var changes []string
 	if g.applyConfigManagementFn != nil {
 		if changes, err = g.applyConfigManagementFn(
@@ -269,17 +416,29 @@ func (g *gitMechanism) gitCommit(
 			repo.HomeDir(),
 			repo.WorkingDir(),
 		); err != nil {
-			return "", err
+			return "", false, err
 		}
 	}
-	commitMsg := buildCommitMessage(changes)
+	opts.messageData.Freight = newFreight
+	opts.messageData.Changes = changes
+	commitMsg, err := renderCommitMessage(
+		update.CommitMessageTemplate,
+		opts.messageData,
+	)
+	if err != nil {
+		return "", false, errors.Wrapf(
+			err,
+			"error rendering commit message for git repo %q",
+			update.RepoURL,
+		)
+	}
 
 	// Sometimes we don't write to the same branch we read from...
 	if readRef != writeBranch {
 		var tempDir string
 		tempDir, err = os.MkdirTemp("", "")
 		if err != nil {
-			return "", errors.Wrap(
+			return "", false, errors.Wrap(
 				err,
 				"error creating temp directory for pending changes",
 			)
@@ -287,27 +446,44 @@ func (g *gitMechanism) gitCommit(
 		defer os.RemoveAll(tempDir)
 
 		if err = moveRepoContents(repo.WorkingDir(), tempDir); err != nil {
-			return "", errors.Wrap(
+			return "", false, errors.Wrap(
 				err,
 				"error moving repository working tree to temporary location",
 			)
 		}
 
 		if err = repo.ResetHard(); err != nil {
-			return "", errors.Wrap(err, "error resetting repository working tree")
+			return "", false, errors.Wrap(err, "error resetting repository working tree")
 		}
 
 		var branchExists bool
 		if branchExists, err = repo.RemoteBranchExists(writeBranch); err != nil {
-			return "", errors.Wrapf(
+			return "", false, errors.Wrapf(
 				err,
 				"error checking for existence of branch %q in remote repo %q",
 				writeBranch,
 				update.RepoURL,
 			)
+		} else if !branchExists && update.PullRequest != nil {
+			if err = repo.Checkout(update.WriteBranch); err != nil {
+				return "", false, errors.Wrapf(
+					err,
+					"error checking out branch %q from git repo %q",
+					update.WriteBranch,
+					update.RepoURL,
+				)
+			}
+			if err = repo.CreateChildBranch(writeBranch); err != nil {
+				return "", false, errors.Wrapf(
+					err,
+					"error creating branch %q in repo %q",
+					writeBranch,
+					update.RepoURL,
+				)
+			}
 		} else if !branchExists {
 			if err = repo.CreateOrphanedBranch(writeBranch); err != nil {
-				return "", errors.Wrapf(
+				return "", false, errors.Wrapf(
 					err,
 					"error creating branch %q in repo %q",
 					writeBranch,
@@ -315,8 +491,9 @@ func (g *gitMechanism) gitCommit(
 				)
 			}
 		} else {
+			pendingPullRequest = update.PullRequest != nil
 			if err = repo.Checkout(writeBranch); err != nil {
-				return "", errors.Wrapf(
+				return "", false, errors.Wrapf(
 					err,
 					"error checking out branch %q from git repo %q",
 					writeBranch,
@@ -326,12 +503,12 @@ func (g *gitMechanism) gitCommit(
 		}
 
 		if err = deleteRepoContents(repo.WorkingDir()); err != nil {
-			return "",
+			return "", false,
 				errors.Wrap(err, "error clearing contents from repository working tree")
 		}
 
 		if err = moveRepoContents(tempDir, repo.WorkingDir()); err != nil {
-			return "", errors.Wrap(
+			return "", false, errors.Wrap(
 				err,
 				"error restoring repository working tree from temporary location",
 			)
@@ -340,7 +517,7 @@ func (g *gitMechanism) gitCommit(
 
 	hasDiffs, err := repo.HasDiffs()
 	if err != nil {
-		return "", errors.Wrapf(
+		return "", false, errors.Wrapf(
 			err,
 			"error checking for diffs in git repo %q",
 			update.RepoURL,
@@ -348,15 +525,24 @@ func (g *gitMechanism) gitCommit(
 	}
 
 	if hasDiffs {
+		// This is the last opportunity to stop without changing anything in the
+		// remote repository.
+		if err = ctx.Err(); err != nil {
+			return "", false, errors.Wrapf(
+				err,
+				"interrupted before pushing updates to git repo %q",
+				update.RepoURL,
+			)
+		}
 		if err = repo.AddAllAndCommit(commitMsg); err != nil {
-			return "", errors.Wrapf(
+			return "", false, errors.Wrapf(
 				err,
 				"error committing updates to git repo %q",
 				update.RepoURL,
 			)
 		}
 		if err = repo.Push(); err != nil {
-			return "", errors.Wrapf(
+			return "", false, errors.Wrapf(
 				err,
 				"error pushing updates to git repo %q",
 				update.RepoURL,
@@ -366,14 +552,14 @@ func (g *gitMechanism) gitCommit(
 
 	commitID, err := repo.LastCommitID()
 	if err != nil {
-		return "", errors.Wrapf(
+		return "", false, errors.Wrapf(
 			err,
 			"error getting last commit ID from git repo %q",
 			update.RepoURL,
 		)
 	}
 
-	return commitID, nil
+	return commitID, hasDiffs || pendingPullRequest, nil
 }
 
 // moveRepoContents transplants the entire contents of the source directory
@@ -14,18 +14,20 @@ import (
 	"github.com/akuity/bookkeeper/pkg/git"
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	libGit "github.com/akuity/kargo/internal/git"
 )
 
 func TestNewGitMechanism(t *testing.T) {
 	pm := newGitMechanism(
 		"fake-name",
 		&credentials.FakeDB{},
+		&libGit.Cache{},
 		func([]kargoapi.GitRepoUpdate) []kargoapi.GitRepoUpdate {
 			return nil
 		},
 		func(
 			update kargoapi.GitRepoUpdate,
-			newFreight kargoapi.Freight,
+			newFreight kargoapi.SimpleFreight,
 			homeDir string,
 			workingDir string,
 		) ([]string, error) {
@@ -35,17 +37,19 @@ func TestNewGitMechanism(t *testing.T) {
 	gpm, ok := pm.(*gitMechanism)
 	require.True(t, ok)
 	require.NotEmpty(t, gpm.name)
+	require.NotNil(t, gpm.gitCache)
 	require.NotNil(t, gpm.selectUpdatesFn)
 	require.NotNil(t, gpm.doSingleUpdateFn)
 	require.NotNil(t, gpm.getReadRefFn)
 	require.NotNil(t, gpm.getCredentialsFn)
 	require.NotNil(t, gpm.gitCommitFn)
+	require.NotNil(t, gpm.getPullRequestProviderFn)
 	require.NotNil(t, gpm.applyConfigManagementFn)
 }
 
 func TestGitGetName(t *testing.T) {
 	const testName = "fake name"
-	pm := newGitMechanism(testName, nil, nil, nil)
+	pm := newGitMechanism(testName, nil, nil, nil, nil)
 	require.Equal(t, testName, pm.GetName())
 }
 
@@ -53,7 +57,7 @@ func TestGitPromote(t *testing.T) {
 	testCases := []struct {
 		name       string
 		promoMech  *gitMechanism
-		assertions func(newFreightIn, newFreightOut kargoapi.Freight, err error)
+		assertions func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error)
 	}{
 		{
 			name: "no updates",
@@ -62,7 +66,7 @@ func TestGitPromote(t *testing.T) {
 					return nil
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.NoError(t, err)
 				require.Equal(t, newFreightIn, newFreightOut)
 			},
@@ -75,14 +79,14 @@ func TestGitPromote(t *testing.T) {
 				},
 				doSingleUpdateFn: func(
 					_ context.Context,
-					_ string,
+					_ *kargoapi.Stage,
 					_ kargoapi.GitRepoUpdate,
-					newFreight kargoapi.Freight,
-				) (kargoapi.Freight, error) {
+					newFreight kargoapi.SimpleFreight,
+				) (kargoapi.SimpleFreight, error) {
 					return newFreight, errors.New("something went wrong")
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.Error(t, err)
 				require.Equal(t, "something went wrong", err.Error())
 				require.Equal(t, newFreightIn, newFreightOut)
@@ -96,14 +100,14 @@ func TestGitPromote(t *testing.T) {
 				},
 				doSingleUpdateFn: func(
 					_ context.Context,
-					_ string,
+					_ *kargoapi.Stage,
 					_ kargoapi.GitRepoUpdate,
-					newFreight kargoapi.Freight,
-				) (kargoapi.Freight, error) {
+					newFreight kargoapi.SimpleFreight,
+				) (kargoapi.SimpleFreight, error) {
 					return newFreight, nil
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.NoError(t, err)
 				require.Equal(t, newFreightIn, newFreightOut)
 			},
@@ -111,7 +115,7 @@ func TestGitPromote(t *testing.T) {
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			newFreightIn := kargoapi.Freight{}
+			newFreightIn := kargoapi.SimpleFreight{}
 			newFreightOut, err := testCase.promoMech.Promote(
 				context.Background(),
 				&kargoapi.Stage{
@@ -131,7 +135,7 @@ func TestGitDoSingleUpdate(t *testing.T) {
 	testCases := []struct {
 		name       string
 		promoMech  *gitMechanism
-		assertions func(newFreightIn, newFreightOut kargoapi.Freight, err error)
+		assertions func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error)
 	}{
 		{
 			name: "error getting readref",
@@ -143,7 +147,7 @@ func TestGitDoSingleUpdate(t *testing.T) {
 					return "", 0, errors.New("something went wrong")
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.Error(t, err)
 				require.Equal(t, "something went wrong", err.Error())
 				require.Equal(t, newFreightIn, newFreightOut)
@@ -166,7 +170,37 @@ func TestGitDoSingleUpdate(t *testing.T) {
 					return nil, errors.New("something went wrong")
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
+				require.Error(t, err)
+				require.Equal(t, "something went wrong", err.Error())
+				require.Equal(t, newFreightIn, newFreightOut)
+			},
+		},
+		{
+			name: "error getting signing key",
+			promoMech: &gitMechanism{
+				getReadRefFn: func(
+					kargoapi.GitRepoUpdate,
+					[]kargoapi.GitCommit,
+				) (string, int, error) {
+					return testRef, 0, nil
+				},
+				getCredentialsFn: func(
+					context.Context,
+					string,
+					string,
+				) (*git.RepoCredentials, error) {
+					return nil, nil
+				},
+				getSigningKeyFn: func(
+					context.Context,
+					string,
+					string,
+				) (*signingKey, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.Error(t, err)
 				require.Equal(t, "something went wrong", err.Error())
 				require.Equal(t, newFreightIn, newFreightOut)
@@ -188,17 +222,26 @@ func TestGitDoSingleUpdate(t *testing.T) {
 				) (*git.RepoCredentials, error) {
 					return nil, nil
 				},
+				getSigningKeyFn: func(
+					context.Context,
+					string,
+					string,
+				) (*signingKey, error) {
+					return nil, nil
+				},
 				gitCommitFn: func(
+					_ context.Context,
 					update kargoapi.GitRepoUpdate,
-					newFreight kargoapi.Freight,
+					newFreight kargoapi.SimpleFreight,
 					readRef string,
 					writeBranch string,
 					creds *git.RepoCredentials,
-				) (string, error) {
-					return "", errors.New("something went wrong")
+					_ commitOptions,
+				) (string, bool, error) {
+					return "", false, errors.New("something went wrong")
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.Error(t, err)
 				require.Equal(t, "something went wrong", err.Error())
 				require.Equal(t, newFreightIn, newFreightOut)
@@ -220,17 +263,26 @@ func TestGitDoSingleUpdate(t *testing.T) {
 				) (*git.RepoCredentials, error) {
 					return nil, nil
 				},
+				getSigningKeyFn: func(
+					context.Context,
+					string,
+					string,
+				) (*signingKey, error) {
+					return &signingKey{fingerprint: "fake-fingerprint"}, nil
+				},
 				gitCommitFn: func(
+					_ context.Context,
 					update kargoapi.GitRepoUpdate,
-					newFreight kargoapi.Freight,
+					newFreight kargoapi.SimpleFreight,
 					readRef string,
 					writeBranch string,
 					creds *git.RepoCredentials,
-				) (string, error) {
-					return "fake-commit-id", nil
+					_ commitOptions,
+				) (string, bool, error) {
+					return "fake-commit-id", true, nil
 				},
 			},
-			assertions: func(newFreightIn, newFreightOut kargoapi.Freight, err error) {
+			assertions: func(newFreightIn, newFreightOut kargoapi.SimpleFreight, err error) {
 				require.NoError(t, err)
 				require.Equal(
 					t,
@@ -245,12 +297,12 @@ func TestGitDoSingleUpdate(t *testing.T) {
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			newFreightIn := kargoapi.Freight{
+			newFreightIn := kargoapi.SimpleFreight{
 				Commits: []kargoapi.GitCommit{{}},
 			}
 			newFreightOut, err := testCase.promoMech.doSingleUpdate(
 				context.Background(),
-				"fake-namespace",
+				&kargoapi.Stage{},
 				kargoapi.GitRepoUpdate{},
 				newFreightIn,
 			)
@@ -10,6 +10,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	libGit "github.com/akuity/kargo/internal/git"
 	"github.com/akuity/kargo/internal/helm"
 	libYAML "github.com/akuity/kargo/internal/yaml"
 )
@@ -18,10 +19,12 @@ import (
 // performs updates that involve Helm.
 func newHelmMechanism(
 	credentialsDB credentials.Database,
+	gitCache *libGit.Cache,
 ) Mechanism {
 	return newGitMechanism(
 		"Helm promotion mechanism",
 		credentialsDB,
+		gitCache,
 		selectHelmUpdates,
 		(&helmer{
 			buildValuesFilesChangesFn:     buildValuesFilesChanges,
@@ -49,7 +52,7 @@ type helmer struct {
 	buildValuesFilesChangesFn func(
 		[]kargoapi.Image,
 		[]kargoapi.HelmImageUpdate,
-	) (map[string]map[string]string, []string)
+	) (map[string]map[string]string, []string, error)
 	buildChartDependencyChangesFn func(
 		string,
 		[]kargoapi.Chart,
@@ -63,13 +66,19 @@ type helmer struct {
 // directory.
 func (h *helmer) apply(
 	update kargoapi.GitRepoUpdate,
-	newFreight kargoapi.Freight,
+	newFreight kargoapi.SimpleFreight,
 	homeDir string,
 	workingDir string,
 ) ([]string, error) {
 	// Image updates
-	changesByFile, imageChangeSummary :=
+	changesByFile, imageChangeSummary, err :=
 		h.buildValuesFilesChangesFn(newFreight.Images, update.Helm.Images)
+	if err != nil {
+		return nil, errors.Wrap(
+			err,
+			"error preparing changes to affected values files",
+		)
+	}
 	for file, changes := range changesByFile {
 		if err := h.setStringsInYAMLFileFn(
 			filepath.Join(workingDir, file),
@@ -121,46 +130,45 @@ func (h *helmer) apply(
 func buildValuesFilesChanges(
 	images []kargoapi.Image,
 	imageUpdates []kargoapi.HelmImageUpdate,
-) (map[string]map[string]string, []string) {
-	tagsByImage := map[string]string{}
-	for _, image := range images {
-		tagsByImage[image.RepoURL] = image.Tag
-	}
+) (map[string]map[string]string, []string, error) {
+	imgs := imagesByRepoURL(images)
 
 	changesByFile := make(map[string]map[string]string, len(imageUpdates))
 	changeSummary := make([]string, 0, len(imageUpdates))
 	for _, imageUpdate := range imageUpdates {
-		if imageUpdate.Value != kargoapi.ImageUpdateValueTypeImage &&
-			imageUpdate.Value != kargoapi.ImageUpdateValueTypeTag {
-			// This really shouldn't happen, so we'll ignore it.
-			continue
-		}
-		tag, found := tagsByImage[imageUpdate.Image]
+		image, found := imgs[imageUpdate.Image]
 		if !found {
 			// There's no change to make in this case.
 			continue
 		}
+		value, err := getImageValue(image, imageUpdate.Value)
+		if err != nil {
+			return nil, nil, errors.Wrapf(
+				err,
+				"error updating key %q in %s",
+				imageUpdate.Key,
+				imageUpdate.ValuesFilePath,
+			)
+		}
+		ref, err := getImageRef(image, imageUpdate.Value)
+		if err != nil {
+			return nil, nil, err
+		}
 		if _, found = changesByFile[imageUpdate.ValuesFilePath]; !found {
 			changesByFile[imageUpdate.ValuesFilePath] = map[string]string{}
 		}
-		if imageUpdate.Value == kargoapi.ImageUpdateValueTypeImage {
-			changesByFile[imageUpdate.ValuesFilePath][imageUpdate.Key] =
-				fmt.Sprintf("%s:%s", imageUpdate.Image, tag)
-		} else {
-			changesByFile[imageUpdate.ValuesFilePath][imageUpdate.Key] = tag
-		}
+		changesByFile[imageUpdate.ValuesFilePath][imageUpdate.Key] = value
 		changeSummary = append(
 			changeSummary,
 			fmt.Sprintf(
-				"updated %s to use image %s:%s",
+				"updated %s to use image %s",
 				imageUpdate.ValuesFilePath,
-				imageUpdate.Image,
-				tag,
+				ref,
 			),
 		)
 	}
 
-	return changesByFile, changeSummary
+	return changesByFile, changeSummary, nil
 }
 
 // buildChartDependencyChanges takes a list of charts and a list of instructions
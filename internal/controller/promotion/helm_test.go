@@ -10,10 +10,11 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	libGit "github.com/akuity/kargo/internal/git"
 )
 
 func TestNewHelmMechanism(t *testing.T) {
-	pm := newHelmMechanism(&credentials.FakeDB{})
+	pm := newHelmMechanism(&credentials.FakeDB{}, &libGit.Cache{})
 	hpm, ok := pm.(*gitMechanism)
 	require.True(t, ok)
 	require.NotNil(t, hpm.selectUpdatesFn)
@@ -81,18 +82,38 @@ func TestHelmerApply(t *testing.T) {
 		helmer     *helmer
 		assertions func(changes []string, err error)
 	}{
+		{
+			name: "error building values file changes",
+			helmer: &helmer{
+				buildValuesFilesChangesFn: func(
+					[]kargoapi.Image,
+					[]kargoapi.HelmImageUpdate,
+				) (map[string]map[string]string, []string, error) {
+					return nil, nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(_ []string, err error) {
+				require.Error(t, err)
+				require.Contains(
+					t,
+					err.Error(),
+					"error preparing changes to affected values files",
+				)
+				require.Contains(t, err.Error(), "something went wrong")
+			},
+		},
 		{
 			name: "error updating values file",
 			helmer: &helmer{
 				buildValuesFilesChangesFn: func(
 					[]kargoapi.Image,
 					[]kargoapi.HelmImageUpdate,
-				) (map[string]map[string]string, []string) {
+				) (map[string]map[string]string, []string, error) {
 					return map[string]map[string]string{
 						testValuesFile: {
 							testKey: testValue,
 						},
-					}, nil
+					}, nil, nil
 				},
 				setStringsInYAMLFileFn: func(string, map[string]string) error {
 					return errors.New("something went wrong")
@@ -110,11 +131,11 @@ func TestHelmerApply(t *testing.T) {
 				buildValuesFilesChangesFn: func(
 					[]kargoapi.Image,
 					[]kargoapi.HelmImageUpdate,
-				) (map[string]map[string]string, []string) {
+				) (map[string]map[string]string, []string, error) {
 					// This returns nothing so that the only calls to
 					// setStringsInYAMLFileFn will be for updating subcharts in
 					// Charts.yaml.
-					return nil, nil
+					return nil, nil, nil
 				},
 				buildChartDependencyChangesFn: func(
 					string,
@@ -140,11 +161,11 @@ func TestHelmerApply(t *testing.T) {
 				buildValuesFilesChangesFn: func(
 					[]kargoapi.Image,
 					[]kargoapi.HelmImageUpdate,
-				) (map[string]map[string]string, []string) {
+				) (map[string]map[string]string, []string, error) {
 					// This returns nothing so that the only calls to
 					// setStringsInYAMLFileFn will be for updating subcharts in
 					// Charts.yaml.
-					return nil, nil
+					return nil, nil, nil
 				},
 				buildChartDependencyChangesFn: func(
 					string,
@@ -177,8 +198,8 @@ func TestHelmerApply(t *testing.T) {
 				buildValuesFilesChangesFn: func(
 					[]kargoapi.Image,
 					[]kargoapi.HelmImageUpdate,
-				) (map[string]map[string]string, []string) {
-					return nil, nil
+				) (map[string]map[string]string, []string, error) {
+					return nil, nil, nil
 				},
 				buildChartDependencyChangesFn: func(
 					string,
@@ -214,12 +235,12 @@ func TestHelmerApply(t *testing.T) {
 				buildValuesFilesChangesFn: func(
 					[]kargoapi.Image,
 					[]kargoapi.HelmImageUpdate,
-				) (map[string]map[string]string, []string) {
+				) (map[string]map[string]string, []string, error) {
 					return map[string]map[string]string{
 						testValuesFile: {
 							testKey: testValue,
 						},
-					}, []string{"fake-image-update"}
+					}, []string{"fake-image-update"}, nil
 				},
 				buildChartDependencyChangesFn: func(
 					string,
@@ -252,7 +273,7 @@ func TestHelmerApply(t *testing.T) {
 					kargoapi.GitRepoUpdate{
 						Helm: &kargoapi.HelmPromotionMechanism{},
 					},
-					kargoapi.Freight{}, // The way the tests are structured, this value doesn't matter
+					kargoapi.SimpleFreight{}, // The way the tests are structured, this value doesn't matter
 					"",
 					"",
 				),
@@ -266,6 +287,7 @@ func TestBuildValuesFilesChanges(t *testing.T) {
 		{
 			RepoURL: "fake-url",
 			Tag:     "fake-tag",
+			Digest:  "fake-digest",
 		},
 		{
 			RepoURL: "another-fake-url",
@@ -291,6 +313,18 @@ func TestBuildValuesFilesChanges(t *testing.T) {
 			Key:            "fake-key",
 			Value:          "Tag",
 		},
+		{
+			ValuesFilePath: "another-fake-values.yaml",
+			Image:          "fake-url",
+			Key:            "fake-digest-key",
+			Value:          "Digest",
+		},
+		{
+			ValuesFilePath: "another-fake-values.yaml",
+			Image:          "fake-url",
+			Key:            "fake-tag-at-digest-key",
+			Value:          "Tag@Digest",
+		},
 		{
 			ValuesFilePath: "yet-another-fake-values.yaml",
 			Image:          "image-that-is-not-in-list",
@@ -298,7 +332,8 @@ func TestBuildValuesFilesChanges(t *testing.T) {
 			Value:          "Tag",
 		},
 	}
-	result, changeSummary := buildValuesFilesChanges(images, imageUpdates)
+	result, changeSummary, err := buildValuesFilesChanges(images, imageUpdates)
+	require.NoError(t, err)
 	require.Equal(
 		t,
 		map[string]map[string]string{
@@ -307,7 +342,9 @@ func TestBuildValuesFilesChanges(t *testing.T) {
 				"another-fake-key": "another-fake-url:another-fake-tag",
 			},
 			"another-fake-values.yaml": {
-				"fake-key": "fake-tag",
+				"fake-key":               "fake-tag",
+				"fake-digest-key":        "fake-digest",
+				"fake-tag-at-digest-key": "fake-tag@fake-digest",
 			},
 		},
 		result,
@@ -318,9 +355,26 @@ func TestBuildValuesFilesChanges(t *testing.T) {
 			"updated fake-values.yaml to use image fake-url:fake-tag",
 			"updated fake-values.yaml to use image another-fake-url:another-fake-tag",
 			"updated another-fake-values.yaml to use image fake-url:fake-tag",
+			"updated another-fake-values.yaml to use image fake-url@fake-digest",
+			"updated another-fake-values.yaml to use image fake-url:fake-tag@fake-digest",
 		},
 		changeSummary,
 	)
+
+	// An image without a recorded digest cannot be referenced by digest
+	_, _, err = buildValuesFilesChanges(
+		images,
+		[]kargoapi.HelmImageUpdate{
+			{
+				ValuesFilePath: "fake-values.yaml",
+				Image:          "another-fake-url",
+				Key:            "fake-key",
+				Value:          "Digest",
+			},
+		},
+	)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Freight records no digest for image")
 }
 
 func TestBuildChartDependencyChanges(t *testing.T) {
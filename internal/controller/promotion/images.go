@@ -0,0 +1,78 @@
+package promotion
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+// imagesByRepoURL indexes the provided images by repository URL.
+func imagesByRepoURL(images []kargoapi.Image) map[string]kargoapi.Image {
+	imgs := make(map[string]kargoapi.Image, len(images))
+	for _, image := range images {
+		imgs[image.RepoURL] = image
+	}
+	return imgs
+}
+
+// getImageValue returns the value that should be used to reference the
+// provided image given the provided ImageUpdateValueType. An error is returned
+// if the value type calls for a digest that was not recorded.
+func getImageValue(
+	image kargoapi.Image,
+	valueType kargoapi.ImageUpdateValueType,
+) (string, error) {
+	switch valueType {
+	case kargoapi.ImageUpdateValueTypeImage:
+		return fmt.Sprintf("%s:%s", image.RepoURL, image.Tag), nil
+	case kargoapi.ImageUpdateValueTypeTag:
+		return image.Tag, nil
+	case kargoapi.ImageUpdateValueTypeDigest:
+		if image.Digest == "" {
+			return "", noDigestError(image)
+		}
+		return image.Digest, nil
+	case kargoapi.ImageUpdateValueTypeTagAtDigest:
+		if image.Digest == "" {
+			return "", noDigestError(image)
+		}
+		return fmt.Sprintf("%s@%s", image.Tag, image.Digest), nil
+	default:
+		return "", errors.Errorf("unsupported image update value type %q", valueType)
+	}
+}
+
+// getImageRef returns a complete reference to the provided image, e.g. for use
+// with Kustomize, given the provided ImageUpdateValueType. Value types other
+// than Digest and Tag@Digest reference the image by tag. An error is returned
+// if the value type calls for a digest that was not recorded.
+func getImageRef(
+	image kargoapi.Image,
+	valueType kargoapi.ImageUpdateValueType,
+) (string, error) {
+	switch valueType {
+	case kargoapi.ImageUpdateValueTypeDigest:
+		digest, err := getImageValue(image, valueType)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s@%s", image.RepoURL, digest), nil
+	case kargoapi.ImageUpdateValueTypeTagAtDigest:
+		tagAtDigest, err := getImageValue(image, valueType)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s:%s", image.RepoURL, tagAtDigest), nil
+	default:
+		return fmt.Sprintf("%s:%s", image.RepoURL, image.Tag), nil
+	}
+}
+
+func noDigestError(image kargoapi.Image) error {
+	return errors.Errorf(
+		"Freight records no digest for image %q; it cannot be referenced by digest",
+		image.RepoURL,
+	)
+}
@@ -0,0 +1,153 @@
+package promotion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestGetImageValue(t *testing.T) {
+	image := kargoapi.Image{
+		RepoURL: "fake-url",
+		Tag:     "fake-tag",
+		Digest:  "fake-digest",
+	}
+	testCases := []struct {
+		name       string
+		image      kargoapi.Image
+		valueType  kargoapi.ImageUpdateValueType
+		assertions func(value string, err error)
+	}{
+		{
+			name:      "Image",
+			image:     image,
+			valueType: kargoapi.ImageUpdateValueTypeImage,
+			assertions: func(value string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "fake-url:fake-tag", value)
+			},
+		},
+		{
+			name:      "Tag",
+			image:     image,
+			valueType: kargoapi.ImageUpdateValueTypeTag,
+			assertions: func(value string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "fake-tag", value)
+			},
+		},
+		{
+			name:      "Digest",
+			image:     image,
+			valueType: kargoapi.ImageUpdateValueTypeDigest,
+			assertions: func(value string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "fake-digest", value)
+			},
+		},
+		{
+			name:      "Tag@Digest",
+			image:     image,
+			valueType: kargoapi.ImageUpdateValueTypeTagAtDigest,
+			assertions: func(value string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "fake-tag@fake-digest", value)
+			},
+		},
+		{
+			name: "digest not recorded",
+			image: kargoapi.Image{
+				RepoURL: "fake-url",
+				Tag:     "fake-tag",
+			},
+			valueType: kargoapi.ImageUpdateValueTypeDigest,
+			assertions: func(_ string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "Freight records no digest for image")
+			},
+		},
+		{
+			name:      "unsupported value type",
+			image:     image,
+			valueType: "bogus",
+			assertions: func(_ string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "unsupported image update value type")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(getImageValue(testCase.image, testCase.valueType))
+		})
+	}
+}
+
+func TestGetImageRef(t *testing.T) {
+	image := kargoapi.Image{
+		RepoURL: "fake-url",
+		Tag:     "fake-tag",
+		Digest:  "fake-digest",
+	}
+	testCases := []struct {
+		name       string
+		image      kargoapi.Image
+		valueType  kargoapi.ImageUpdateValueType
+		assertions func(ref string, err error)
+	}{
+		{
+			name:  "value type unspecified",
+			image: image,
+			assertions: func(ref string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "fake-url:fake-tag", ref)
+			},
+		},
+		{
+			name:      "Tag",
+			image:     image,
+			valueType: kargoapi.ImageUpdateValueTypeTag,
+			assertions: func(ref string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "fake-url:fake-tag", ref)
+			},
+		},
+		{
+			name:      "Digest",
+			image:     image,
+			valueType: kargoapi.ImageUpdateValueTypeDigest,
+			assertions: func(ref string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "fake-url@fake-digest", ref)
+			},
+		},
+		{
+			name:      "Tag@Digest",
+			image:     image,
+			valueType: kargoapi.ImageUpdateValueTypeTagAtDigest,
+			assertions: func(ref string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "fake-url:fake-tag@fake-digest", ref)
+			},
+		},
+		{
+			name: "digest not recorded",
+			image: kargoapi.Image{
+				RepoURL: "fake-url",
+				Tag:     "fake-tag",
+			},
+			valueType: kargoapi.ImageUpdateValueTypeTagAtDigest,
+			assertions: func(_ string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "Freight records no digest for image")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(getImageRef(testCase.image, testCase.valueType))
+		})
+	}
+}
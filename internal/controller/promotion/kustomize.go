@@ -8,6 +8,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	libGit "github.com/akuity/kargo/internal/git"
 	"github.com/akuity/kargo/internal/kustomize"
 )
 
@@ -15,10 +16,12 @@ import (
 // performs updates that involve Kustomize.
 func newKustomizeMechanism(
 	credentialsDB credentials.Database,
+	gitCache *libGit.Cache,
 ) Mechanism {
 	return newGitMechanism(
 		"Kustomize promotion mechanism",
 		credentialsDB,
+		gitCache,
 		selectKustomizeUpdates,
 		(&kustomizer{
 			setImageFn: kustomize.SetImage,
@@ -41,46 +44,48 @@ func selectKustomizeUpdates(updates []kargoapi.GitRepoUpdate) []kargoapi.GitRepo
 // kustomizer is a helper struct whose sole purpose is to close over several
 // other functions that are used in the implementation of the apply() function.
 type kustomizer struct {
-	setImageFn func(dir, image, tag string) error
+	setImageFn func(dir, repo, newImage string) error
 }
 
 // apply uses Kustomize to carry out the provided update in the specified
 // working directory.
 func (k *kustomizer) apply(
 	update kargoapi.GitRepoUpdate,
-	newFreight kargoapi.Freight,
+	newFreight kargoapi.SimpleFreight,
 	_ string,
 	workingDir string,
 ) ([]string, error) {
+	imgs := imagesByRepoURL(newFreight.Images)
 	changeSummary := make([]string, 0, len(update.Kustomize.Images))
 	for _, imgUpdate := range update.Kustomize.Images {
-		var tag string
-		for _, img := range newFreight.Images {
-			if img.RepoURL == imgUpdate.Image {
-				tag = img.Tag
-				break
-			}
-		}
-		if tag == "" {
+		img, found := imgs[imgUpdate.Image]
+		if !found || img.Tag == "" {
 			// TODO: Warn?
 			continue
 		}
+		newImage, err := getImageRef(img, imgUpdate.Value)
+		if err != nil {
+			return nil, errors.Wrapf(
+				err,
+				"error updating image %q using Kustomize",
+				imgUpdate.Image,
+			)
+		}
 		dir := filepath.Join(workingDir, imgUpdate.Path)
-		if err := k.setImageFn(dir, imgUpdate.Image, tag); err != nil {
+		if err = k.setImageFn(dir, imgUpdate.Image, newImage); err != nil {
 			return nil, errors.Wrapf(
 				err,
-				"error updating image %q to tag %q using Kustomize",
+				"error updating image %q to %q using Kustomize",
 				imgUpdate.Image,
-				tag,
+				newImage,
 			)
 		}
 		changeSummary = append(
 			changeSummary,
 			fmt.Sprintf(
-				"updated %s/kustomization.yaml to use image %s:%s",
+				"updated %s/kustomization.yaml to use image %s",
 				imgUpdate.Path,
-				imgUpdate.Image,
-				tag,
+				newImage,
 			),
 		)
 	}
@@ -8,10 +8,11 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	libGit "github.com/akuity/kargo/internal/git"
 )
 
 func TestNewKustomizeMechanism(t *testing.T) {
-	pm := newKustomizeMechanism(&credentials.FakeDB{})
+	pm := newKustomizeMechanism(&credentials.FakeDB{}, &libGit.Cache{})
 	kpm, ok := pm.(*gitMechanism)
 	require.True(t, ok)
 	require.NotNil(t, kpm.selectUpdatesFn)
@@ -70,12 +71,15 @@ func TestSelectKustomizeUpdates(t *testing.T) {
 
 func TestKustomizerApply(t *testing.T) {
 	const (
-		testImage = "fake-image"
-		testTag   = "fake-tag"
+		testImage  = "fake-image"
+		testTag    = "fake-tag"
+		testDigest = "fake-digest"
 	)
 	testCases := []struct {
 		name       string
-		setImageFn func(dir, image, tag string) error
+		value      kargoapi.ImageUpdateValueType
+		digest     string
+		setImageFn func(dir, repo, newImage string) error
 		assertions func(changes []string, err error)
 	}{
 		{
@@ -90,15 +94,50 @@ func TestKustomizerApply(t *testing.T) {
 			},
 		},
 		{
-			name: "success",
+			name:  "digest not recorded",
+			value: kargoapi.ImageUpdateValueTypeDigest,
 			setImageFn: func(string, string, string) error {
 				return nil
 			},
+			assertions: func(_ []string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error updating image")
+				require.Contains(t, err.Error(), "Freight records no digest for image")
+			},
+		},
+		{
+			name: "success",
+			setImageFn: func(_, repo, newImage string) error {
+				require.Equal(t, testImage, repo)
+				require.Equal(t, testImage+":"+testTag, newImage)
+				return nil
+			},
 			assertions: func(changes []string, err error) {
 				require.NoError(t, err)
 				require.Len(t, changes, 1)
 			},
 		},
+		{
+			name:   "success referencing image by digest",
+			value:  kargoapi.ImageUpdateValueTypeDigest,
+			digest: testDigest,
+			setImageFn: func(_, repo, newImage string) error {
+				require.Equal(t, testImage, repo)
+				require.Equal(t, testImage+"@"+testDigest, newImage)
+				return nil
+			},
+			assertions: func(changes []string, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]string{
+						"updated fake-path/kustomization.yaml to use image " +
+							testImage + "@" + testDigest,
+					},
+					changes,
+				)
+			},
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -112,15 +151,17 @@ func TestKustomizerApply(t *testing.T) {
 								{
 									Image: testImage,
 									Path:  "fake-path",
+									Value: testCase.value,
 								},
 							},
 						},
 					},
-					kargoapi.Freight{
+					kargoapi.SimpleFreight{
 						Images: []kargoapi.Image{
 							{
 								RepoURL: testImage,
 								Tag:     testTag,
+								Digest:  testCase.digest,
 							},
 						},
 					},
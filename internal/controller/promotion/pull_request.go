@@ -0,0 +1,95 @@
+package promotion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/akuity/bookkeeper/pkg/git"
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/gitprovider"
+)
+
+// PullRequestOpenError is returned by promotion mechanisms that have proposed
+// changes via a pull request that has not been merged yet. A Promotion cannot
+// complete until the pull request is merged, at which point executing the
+// promotion mechanisms again will pick up where they left off.
+type PullRequestOpenError struct {
+	// URL is the URL of the open pull request.
+	URL string
+}
+
+func (p *PullRequestOpenError) Error() string {
+	return fmt.Sprintf("waiting for pull request %s to be merged", p.URL)
+}
+
+// getPullRequestBranch returns the name of the branch to which changes that
+// are to be proposed via a pull request are pushed. The name is unique to the
+// provided update and Freight so that executing the same Promotion again will
+// find the pull request that was already opened for it.
+func getPullRequestBranch(
+	update kargoapi.GitRepoUpdate,
+	newFreight kargoapi.SimpleFreight,
+) string {
+	return fmt.Sprintf("kargo/promotion/%s/%s", update.WriteBranch, newFreight.ID)
+}
+
+// getPullRequestProvider returns a gitprovider.Interface for the repository
+// referenced by the provided update. The provided credentials, which may be
+// nil, are used to authenticate to the Git hosting provider.
+func getPullRequestProvider(
+	update kargoapi.GitRepoUpdate,
+	creds *git.RepoCredentials,
+) (gitprovider.Interface, error) {
+	opts := &gitprovider.Options{}
+	if creds != nil {
+		opts.Token = creds.Password
+	}
+	var providerName string
+	if update.PullRequest != nil {
+		providerName = update.PullRequest.Provider
+	}
+	provider, err := gitprovider.New(update.RepoURL, providerName, opts)
+	return provider, errors.Wrapf(
+		err,
+		"error getting Git hosting provider for git repo %q",
+		update.RepoURL,
+	)
+}
+
+// findMergedPullRequest looks for a pull request proposing that the provided
+// head branch be merged into the provided update's write branch. If there is
+// no such pull request, nil is returned. If there is one that is still open, a
+// *PullRequestOpenError is returned. If there is one that was closed without
+// being merged, an error is returned. Otherwise, the merged pull request is
+// returned.
+func findMergedPullRequest(
+	ctx context.Context,
+	provider gitprovider.Interface,
+	update kargoapi.GitRepoUpdate,
+	head string,
+) (*gitprovider.PullRequest, error) {
+	pr, err := provider.FindPullRequest(ctx, head, update.WriteBranch)
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error finding pull request for branch %q of git repo %q",
+			head,
+			update.RepoURL,
+		)
+	}
+	switch {
+	case pr == nil:
+		return nil, nil
+	case pr.Merged:
+		return pr, nil
+	case pr.Open:
+		return nil, &PullRequestOpenError{URL: pr.URL}
+	default:
+		return nil, errors.Errorf(
+			"pull request %s was closed without being merged",
+			pr.URL,
+		)
+	}
+}
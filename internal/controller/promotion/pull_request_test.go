@@ -0,0 +1,435 @@
+package promotion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/bookkeeper/pkg/git"
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/gitprovider"
+)
+
+type fakePullRequestProvider struct {
+	createPullRequestFn func(
+		context.Context,
+		gitprovider.CreatePullRequestOpts,
+	) (*gitprovider.PullRequest, error)
+	findPullRequestFn func(
+		ctx context.Context,
+		head string,
+		base string,
+	) (*gitprovider.PullRequest, error)
+}
+
+func (f *fakePullRequestProvider) CreatePullRequest(
+	ctx context.Context,
+	opts gitprovider.CreatePullRequestOpts,
+) (*gitprovider.PullRequest, error) {
+	return f.createPullRequestFn(ctx, opts)
+}
+
+func (f *fakePullRequestProvider) FindPullRequest(
+	ctx context.Context,
+	head string,
+	base string,
+) (*gitprovider.PullRequest, error) {
+	return f.findPullRequestFn(ctx, head, base)
+}
+
+func TestPullRequestOpenError(t *testing.T) {
+	err := errors.Wrap(&PullRequestOpenError{URL: "fake-url"}, "wrapped")
+	prErr := &PullRequestOpenError{}
+	require.True(t, errors.As(err, &prErr))
+	require.Equal(t, "fake-url", prErr.URL)
+	require.Equal(t, "waiting for pull request fake-url to be merged", prErr.Error())
+}
+
+func TestGetPullRequestBranch(t *testing.T) {
+	require.Equal(
+		t,
+		"kargo/promotion/fake-branch/fake-freight",
+		getPullRequestBranch(
+			kargoapi.GitRepoUpdate{WriteBranch: "fake-branch"},
+			kargoapi.SimpleFreight{ID: "fake-freight"},
+		),
+	)
+}
+
+func TestGetPullRequestProvider(t *testing.T) {
+	testCases := []struct {
+		name       string
+		update     kargoapi.GitRepoUpdate
+		assertions func(gitprovider.Interface, error)
+	}{
+		{
+			name: "provider inferred from URL",
+			update: kargoapi.GitRepoUpdate{
+				RepoURL:     "https://github.com/akuity/kargo",
+				PullRequest: &kargoapi.PullRequestPromotionMechanism{},
+			},
+			assertions: func(provider gitprovider.Interface, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, provider)
+			},
+		},
+		{
+			name: "provider specified explicitly",
+			update: kargoapi.GitRepoUpdate{
+				RepoURL: "https://git.example.com/akuity/kargo",
+				PullRequest: &kargoapi.PullRequestPromotionMechanism{
+					Provider: gitprovider.ProviderGitea,
+				},
+			},
+			assertions: func(provider gitprovider.Interface, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, provider)
+			},
+		},
+		{
+			name: "provider cannot be inferred",
+			update: kargoapi.GitRepoUpdate{
+				RepoURL:     "https://git.example.com/akuity/kargo",
+				PullRequest: &kargoapi.PullRequestPromotionMechanism{},
+			},
+			assertions: func(_ gitprovider.Interface, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error getting Git hosting provider")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				getPullRequestProvider(
+					testCase.update,
+					&git.RepoCredentials{Password: "fake-token"},
+				),
+			)
+		})
+	}
+}
+
+func TestFindMergedPullRequest(t *testing.T) {
+	testCases := []struct {
+		name       string
+		pr         *gitprovider.PullRequest
+		findErr    error
+		assertions func(*gitprovider.PullRequest, error)
+	}{
+		{
+			name:    "error finding pull request",
+			findErr: errors.New("something went wrong"),
+			assertions: func(_ *gitprovider.PullRequest, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error finding pull request")
+				require.Contains(t, err.Error(), "something went wrong")
+			},
+		},
+		{
+			name: "no pull request",
+			assertions: func(pr *gitprovider.PullRequest, err error) {
+				require.NoError(t, err)
+				require.Nil(t, pr)
+			},
+		},
+		{
+			name: "pull request open",
+			pr: &gitprovider.PullRequest{
+				URL:  "fake-url",
+				Open: true,
+			},
+			assertions: func(_ *gitprovider.PullRequest, err error) {
+				prErr := &PullRequestOpenError{}
+				require.True(t, errors.As(err, &prErr))
+				require.Equal(t, "fake-url", prErr.URL)
+			},
+		},
+		{
+			name: "pull request closed without being merged",
+			pr: &gitprovider.PullRequest{
+				URL: "fake-url",
+			},
+			assertions: func(_ *gitprovider.PullRequest, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "closed without being merged")
+			},
+		},
+		{
+			name: "pull request merged",
+			pr: &gitprovider.PullRequest{
+				URL:           "fake-url",
+				Merged:        true,
+				MergeCommitID: "fake-commit-id",
+			},
+			assertions: func(pr *gitprovider.PullRequest, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, pr)
+				require.Equal(t, "fake-commit-id", pr.MergeCommitID)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			provider := &fakePullRequestProvider{
+				findPullRequestFn: func(
+					_ context.Context,
+					head string,
+					base string,
+				) (*gitprovider.PullRequest, error) {
+					require.Equal(t, "fake-head", head)
+					require.Equal(t, "fake-branch", base)
+					return testCase.pr, testCase.findErr
+				},
+			}
+			testCase.assertions(
+				findMergedPullRequest(
+					context.Background(),
+					provider,
+					kargoapi.GitRepoUpdate{WriteBranch: "fake-branch"},
+					"fake-head",
+				),
+			)
+		})
+	}
+}
+
+func TestGitDoSingleUpdatePullRequest(t *testing.T) {
+	const testPRBranch = "kargo/promotion/fake-branch/fake-freight"
+	testCases := []struct {
+		name       string
+		provider   *fakePullRequestProvider
+		changed    bool
+		assertions func(
+			newFreightOut kargoapi.SimpleFreight,
+			step kargoapi.PromotionStep,
+			committed bool,
+			err error,
+		)
+	}{
+		{
+			name: "pull request already merged",
+			provider: &fakePullRequestProvider{
+				findPullRequestFn: func(
+					context.Context,
+					string,
+					string,
+				) (*gitprovider.PullRequest, error) {
+					return &gitprovider.PullRequest{
+						URL:           "fake-url",
+						Merged:        true,
+						MergeCommitID: "fake-merge-commit-id",
+					}, nil
+				},
+			},
+			assertions: func(
+				newFreightOut kargoapi.SimpleFreight,
+				step kargoapi.PromotionStep,
+				committed bool,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.False(t, committed)
+				require.Equal(
+					t,
+					"fake-merge-commit-id",
+					newFreightOut.Commits[0].HealthCheckCommit,
+				)
+				require.Equal(t, kargoapi.PromotionStepPhaseSucceeded, step.Phase)
+				require.Equal(t, "fake-url", step.PullRequestURL)
+				require.Equal(t, "fake-merge-commit-id", step.CommitID)
+			},
+		},
+		{
+			name: "pull request still open",
+			provider: &fakePullRequestProvider{
+				findPullRequestFn: func(
+					context.Context,
+					string,
+					string,
+				) (*gitprovider.PullRequest, error) {
+					return &gitprovider.PullRequest{
+						URL:  "fake-url",
+						Open: true,
+					}, nil
+				},
+			},
+			assertions: func(
+				newFreightOut kargoapi.SimpleFreight,
+				step kargoapi.PromotionStep,
+				committed bool,
+				err error,
+			) {
+				prErr := &PullRequestOpenError{}
+				require.True(t, errors.As(err, &prErr))
+				require.False(t, committed)
+				require.Empty(t, newFreightOut.Commits[0].HealthCheckCommit)
+				require.Equal(t, kargoapi.PromotionStepPhaseWaiting, step.Phase)
+				require.Equal(t, "fake-url", step.PullRequestURL)
+			},
+		},
+		{
+			name: "pull request opened for new changes",
+			provider: &fakePullRequestProvider{
+				findPullRequestFn: func(
+					context.Context,
+					string,
+					string,
+				) (*gitprovider.PullRequest, error) {
+					return nil, nil
+				},
+				createPullRequestFn: func(
+					_ context.Context,
+					opts gitprovider.CreatePullRequestOpts,
+				) (*gitprovider.PullRequest, error) {
+					require.Equal(t, testPRBranch, opts.Head)
+					require.Equal(t, "fake-branch", opts.Base)
+					return &gitprovider.PullRequest{
+						URL:  "fake-url",
+						Open: true,
+					}, nil
+				},
+			},
+			changed: true,
+			assertions: func(
+				newFreightOut kargoapi.SimpleFreight,
+				step kargoapi.PromotionStep,
+				committed bool,
+				err error,
+			) {
+				prErr := &PullRequestOpenError{}
+				require.True(t, errors.As(err, &prErr))
+				require.Equal(t, "fake-url", prErr.URL)
+				require.True(t, committed)
+				require.Empty(t, newFreightOut.Commits[0].HealthCheckCommit)
+				require.Equal(t, kargoapi.PromotionStepPhaseWaiting, step.Phase)
+				require.Equal(t, testPRBranch, step.Branch)
+				require.Equal(t, "fake-commit-id", step.CommitID)
+			},
+		},
+		{
+			name: "error opening pull request",
+			provider: &fakePullRequestProvider{
+				findPullRequestFn: func(
+					context.Context,
+					string,
+					string,
+				) (*gitprovider.PullRequest, error) {
+					return nil, nil
+				},
+				createPullRequestFn: func(
+					context.Context,
+					gitprovider.CreatePullRequestOpts,
+				) (*gitprovider.PullRequest, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			changed: true,
+			assertions: func(
+				_ kargoapi.SimpleFreight,
+				step kargoapi.PromotionStep,
+				committed bool,
+				err error,
+			) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error opening pull request")
+				require.True(t, committed)
+				require.Equal(t, kargoapi.PromotionStepPhaseErrored, step.Phase)
+			},
+		},
+		{
+			name: "nothing to propose",
+			provider: &fakePullRequestProvider{
+				findPullRequestFn: func(
+					context.Context,
+					string,
+					string,
+				) (*gitprovider.PullRequest, error) {
+					return nil, nil
+				},
+			},
+			assertions: func(
+				newFreightOut kargoapi.SimpleFreight,
+				step kargoapi.PromotionStep,
+				committed bool,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.True(t, committed)
+				require.Equal(
+					t,
+					"fake-commit-id",
+					newFreightOut.Commits[0].HealthCheckCommit,
+				)
+				require.Equal(t, kargoapi.PromotionStepPhaseSucceeded, step.Phase)
+				require.Empty(t, step.PullRequestURL)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var committed bool
+			promoMech := &gitMechanism{
+				name: "fake-mechanism",
+				getReadRefFn: func(
+					kargoapi.GitRepoUpdate,
+					[]kargoapi.GitCommit,
+				) (string, int, error) {
+					return "fake-ref", 0, nil
+				},
+				getCredentialsFn: func(
+					context.Context,
+					string,
+					string,
+				) (*git.RepoCredentials, error) {
+					return nil, nil
+				},
+				gitCommitFn: func(
+					_ context.Context,
+					_ kargoapi.GitRepoUpdate,
+					_ kargoapi.SimpleFreight,
+					_ string,
+					writeBranch string,
+					_ *git.RepoCredentials,
+					_ commitOptions,
+				) (string, bool, error) {
+					committed = true
+					require.Equal(t, testPRBranch, writeBranch)
+					return "fake-commit-id", testCase.changed, nil
+				},
+				getSigningKeyFn: func(
+					context.Context,
+					string,
+					string,
+				) (*signingKey, error) {
+					return nil, nil
+				},
+				getPullRequestProviderFn: func(
+					kargoapi.GitRepoUpdate,
+					*git.RepoCredentials,
+				) (gitprovider.Interface, error) {
+					return testCase.provider, nil
+				},
+			}
+			recorder := NewStepRecorder()
+			newFreightOut, err := promoMech.doSingleUpdate(
+				ContextWithStepRecorder(context.Background(), recorder),
+				&kargoapi.Stage{},
+				kargoapi.GitRepoUpdate{
+					RepoURL:     "fake-url",
+					WriteBranch: "fake-branch",
+					PullRequest: &kargoapi.PullRequestPromotionMechanism{},
+				},
+				kargoapi.SimpleFreight{
+					ID:      "fake-freight",
+					Commits: []kargoapi.GitCommit{{}},
+				},
+			)
+			steps := recorder.Steps()
+			require.Len(t, steps, 1)
+			testCase.assertions(newFreightOut, steps[0], committed, err)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/akuity/bookkeeper"
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	libGit "github.com/akuity/kargo/internal/git"
 )
 
 // Mechanism provides a consistent interface for all promotion mechanisms.
@@ -16,8 +17,10 @@ type Mechanism interface {
 	GetName() string
 	// Promote consults rules in the provided Stage to perform some portion of the
 	// transition into the specified Freight. It returns the Freight, which may
-	// possibly be updated by the process.
-	Promote(context.Context, *kargoapi.Stage, kargoapi.Freight) (kargoapi.Freight, error)
+	// possibly be updated by the process. Implementations MUST stop as soon as
+	// is safely possible and return an error if the provided context is
+	// canceled.
+	Promote(context.Context, *kargoapi.Stage, kargoapi.SimpleFreight) (kargoapi.SimpleFreight, error)
 }
 
 // NewMechanisms returns the entrypoint to a hierarchical tree of promotion
@@ -26,15 +29,16 @@ func NewMechanisms(
 	argoClient client.Client,
 	credentialsDB credentials.Database,
 	bookkeeperService bookkeeper.Service,
+	gitCache *libGit.Cache,
 ) Mechanism {
 	return newCompositeMechanism(
 		"promotion mechanisms",
 		newCompositeMechanism(
 			"Git-based promotion mechanisms",
-			newGenericGitMechanism(credentialsDB),
+			newGenericGitMechanism(credentialsDB, gitCache),
 			newBookkeeperMechanism(credentialsDB, bookkeeperService),
-			newKustomizeMechanism(credentialsDB),
-			newHelmMechanism(credentialsDB),
+			newKustomizeMechanism(credentialsDB, gitCache),
+			newHelmMechanism(credentialsDB, gitCache),
 		),
 		newArgoCDMechanism(argoClient),
 	)
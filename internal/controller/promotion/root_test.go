@@ -10,6 +10,7 @@ import (
 	"github.com/akuity/bookkeeper"
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	libGit "github.com/akuity/kargo/internal/git"
 )
 
 func TestNewMechanisms(t *testing.T) {
@@ -17,6 +18,7 @@ func TestNewMechanisms(t *testing.T) {
 		fake.NewClientBuilder().Build(),
 		credentials.NewKubernetesDatabase("", nil, nil),
 		bookkeeper.NewService(nil),
+		&libGit.Cache{},
 	)
 	require.IsType(t, &compositeMechanism{}, promoMechs)
 }
@@ -28,8 +30,8 @@ type FakeMechanism struct {
 	PromoteFn func(
 		context.Context,
 		*kargoapi.Stage,
-		kargoapi.Freight,
-	) (kargoapi.Freight, error)
+		kargoapi.SimpleFreight,
+	) (kargoapi.SimpleFreight, error)
 }
 
 // GetName implements the Mechanism interface.
@@ -41,7 +43,7 @@ func (f *FakeMechanism) GetName() string {
 func (f *FakeMechanism) Promote(
 	ctx context.Context,
 	stage *kargoapi.Stage,
-	freight kargoapi.Freight,
-) (kargoapi.Freight, error) {
+	freight kargoapi.SimpleFreight,
+) (kargoapi.SimpleFreight, error) {
 	return f.PromoteFn(ctx, stage, freight)
 }
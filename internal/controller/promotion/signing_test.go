@@ -0,0 +1,250 @@
+package promotion
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/akuity/kargo/internal/credentials"
+)
+
+func TestGetSigningKeyFn(t *testing.T) {
+	testCases := []struct {
+		name       string
+		db         credentials.Database
+		assertions func(*signingKey, error)
+	}{
+		{
+			name: "error getting credentials",
+			db: &credentials.FakeDB{
+				GetFn: func(
+					context.Context,
+					string,
+					credentials.Type,
+					string,
+				) (credentials.Credentials, bool, error) {
+					return credentials.Credentials{}, false, errors.New("something went wrong")
+				},
+			},
+			assertions: func(_ *signingKey, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error obtaining signing key")
+				require.Contains(t, err.Error(), "something went wrong")
+			},
+		},
+		{
+			name: "no signing key found",
+			db:   &credentials.FakeDB{},
+			assertions: func(key *signingKey, err error) {
+				require.NoError(t, err)
+				require.Nil(t, key)
+			},
+		},
+		{
+			name: "invalid signing key",
+			db: &credentials.FakeDB{
+				GetFn: func(
+					context.Context,
+					string,
+					credentials.Type,
+					string,
+				) (credentials.Credentials, bool, error) {
+					return credentials.Credentials{SigningKey: "bogus"}, true, nil
+				},
+			},
+			assertions: func(_ *signingKey, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error parsing signing key")
+			},
+		},
+		{
+			name: "signing key found",
+			db: &credentials.FakeDB{
+				GetFn: func(
+					_ context.Context,
+					_ string,
+					credType credentials.Type,
+					_ string,
+				) (credentials.Credentials, bool, error) {
+					require.Equal(t, credentials.TypeGitSigning, credType)
+					return credentials.Credentials{
+						SigningKey: newTestSSHKey(t),
+					}, true, nil
+				},
+			},
+			assertions: func(key *signingKey, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, key)
+				require.Equal(t, signingKeyFormatSSH, key.format)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				getSigningKeyFn(testCase.db)(
+					context.Background(),
+					"fake-namespace",
+					"fake-url",
+				),
+			)
+		})
+	}
+}
+
+func TestParseSigningKey(t *testing.T) {
+	gpgKey, gpgFingerprint := newTestGPGKey(t)
+	sshKey := newTestSSHKey(t)
+	testCases := []struct {
+		name       string
+		creds      credentials.Credentials
+		assertions func(*signingKey, error)
+	}{
+		{
+			name:  "GPG key",
+			creds: credentials.Credentials{SigningKey: gpgKey},
+			assertions: func(key *signingKey, err error) {
+				require.NoError(t, err)
+				require.Equal(t, signingKeyFormatOpenPGP, key.format)
+				require.Equal(t, gpgFingerprint, key.fingerprint)
+				// The committer identity defaults to the key's
+				require.Equal(t, "Fake Committer", key.committerName)
+				require.Equal(t, "fake@example.com", key.committerEmail)
+			},
+		},
+		{
+			name: "GPG key with committer identity",
+			creds: credentials.Credentials{
+				SigningKey:     gpgKey,
+				CommitterName:  "Another Committer",
+				CommitterEmail: "another@example.com",
+			},
+			assertions: func(key *signingKey, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "Another Committer", key.committerName)
+				require.Equal(t, "another@example.com", key.committerEmail)
+			},
+		},
+		{
+			name: "GPG public key",
+			creds: credentials.Credentials{
+				SigningKey: strings.Replace(
+					gpgKey,
+					pgpPrivateKeyBlockHeader,
+					"-----BEGIN PGP PUBLIC KEY BLOCK-----",
+					1,
+				),
+			},
+			assertions: func(_ *signingKey, err error) {
+				require.Error(t, err)
+			},
+		},
+		{
+			name:  "SSH key",
+			creds: credentials.Credentials{SigningKey: sshKey},
+			assertions: func(key *signingKey, err error) {
+				require.NoError(t, err)
+				require.Equal(t, signingKeyFormatSSH, key.format)
+				require.True(t, strings.HasPrefix(key.fingerprint, "SHA256:"))
+				require.Empty(t, key.committerName)
+				require.Empty(t, key.committerEmail)
+			},
+		},
+		{
+			name:  "invalid key",
+			creds: credentials.Credentials{SigningKey: "bogus"},
+			assertions: func(_ *signingKey, err error) {
+				require.Error(t, err)
+				require.Contains(
+					t,
+					err.Error(),
+					"neither an ASCII-armored GPG private key nor an SSH private key",
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(parseSigningKey(testCase.creds))
+		})
+	}
+}
+
+func TestConfigureCommitSigning(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen is not available")
+	}
+	homeDir := t.TempDir()
+	key, err := parseSigningKey(credentials.Credentials{
+		SigningKey:     newTestSSHKey(t),
+		CommitterName:  "Fake Committer",
+		CommitterEmail: "fake@example.com",
+	})
+	require.NoError(t, err)
+
+	err = configureCommitSigning(homeDir, key)
+	require.NoError(t, err)
+
+	keyPath := filepath.Join(homeDir, ".signing_key")
+	fileInfo, err := os.Stat(keyPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), fileInfo.Mode().Perm())
+	publicKey, err := os.ReadFile(keyPath + ".pub")
+	require.NoError(t, err)
+	require.Equal(t, key.publicKey, string(publicKey))
+
+	getConfig := func(name string) string {
+		cmd := exec.Command("git", "config", "--global", name)
+		cmd.Env = []string{"HOME=" + homeDir}
+		res, err := cmd.Output()
+		require.NoError(t, err)
+		return strings.TrimSpace(string(res))
+	}
+	require.Equal(t, signingKeyFormatSSH, getConfig("gpg.format"))
+	require.Equal(t, keyPath, getConfig("user.signingkey"))
+	require.Equal(t, "true", getConfig("commit.gpgsign"))
+	require.True(t, filepath.IsAbs(getConfig("gpg.ssh.program")))
+	require.Equal(t, "Fake Committer", getConfig("user.name"))
+	require.Equal(t, "fake@example.com", getConfig("user.email"))
+}
+
+func newTestGPGKey(t *testing.T) (string, string) {
+	entity, err := openpgp.NewEntity("Fake Committer", "", "fake@example.com", nil)
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	w, err := armor.Encode(buf, openpgp.PrivateKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.SerializePrivate(w, nil))
+	require.NoError(t, w.Close())
+	return buf.String(), strings.ToUpper(
+		fmt.Sprintf("%x", entity.PrimaryKey.Fingerprint),
+	)
+}
+
+func newTestSSHKey(t *testing.T) string {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(t, err)
+	key := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+	// Make sure the key is one that ssh can use.
+	_, err = ssh.ParsePrivateKey([]byte(key))
+	require.NoError(t, err)
+	return key
+}
@@ -0,0 +1,101 @@
+package promotion
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+type stepRecorderContextKey struct{}
+
+// StepRecorder collects records of the steps taken by promotion mechanisms
+// while executing a Promotion. It is safe for concurrent use.
+type StepRecorder struct {
+	steps   []kargoapi.PromotionStep
+	stepsMu sync.Mutex
+}
+
+// NewStepRecorder returns an empty StepRecorder.
+func NewStepRecorder() *StepRecorder {
+	return &StepRecorder{}
+}
+
+// Record appends the provided step to the steps collected so far.
+func (s *StepRecorder) Record(step kargoapi.PromotionStep) {
+	s.stepsMu.Lock()
+	defer s.stepsMu.Unlock()
+	s.steps = append(s.steps, step)
+}
+
+// Steps returns a copy of the steps collected so far, in the order in which
+// they were recorded.
+func (s *StepRecorder) Steps() []kargoapi.PromotionStep {
+	s.stepsMu.Lock()
+	defer s.stepsMu.Unlock()
+	if len(s.steps) == 0 {
+		return nil
+	}
+	steps := make([]kargoapi.PromotionStep, len(s.steps))
+	copy(steps, s.steps)
+	return steps
+}
+
+// ContextWithStepRecorder returns a context.Context that has been augmented
+// with the provided StepRecorder. Promotion mechanisms record each step they
+// take using the StepRecorder bound to the context passed to Promote().
+func ContextWithStepRecorder(
+	ctx context.Context,
+	recorder *StepRecorder,
+) context.Context {
+	return context.WithValue(ctx, stepRecorderContextKey{}, recorder)
+}
+
+// StepRecorderFromContext extracts a *StepRecorder from the provided
+// context.Context and returns it. If no *StepRecorder is found, nil is
+// returned.
+func StepRecorderFromContext(ctx context.Context) *StepRecorder {
+	if recorder := ctx.Value(stepRecorderContextKey{}); recorder != nil {
+		return recorder.(*StepRecorder) // nolint: forcetypeassert
+	}
+	return nil
+}
+
+// startStep returns a record of a step that the named promotion mechanism is
+// about to take.
+func startStep(mechanism string) *kargoapi.PromotionStep {
+	now := metav1.Now()
+	return &kargoapi.PromotionStep{
+		Mechanism: mechanism,
+		StartedAt: &now,
+	}
+}
+
+// finishStep completes the provided record of a step using the error, if any,
+// with which the step ended and records it using the StepRecorder bound to the
+// provided context. If no StepRecorder is bound to the context, the record is
+// discarded.
+func finishStep(ctx context.Context, step *kargoapi.PromotionStep, err error) {
+	recorder := StepRecorderFromContext(ctx)
+	if recorder == nil {
+		return
+	}
+	now := metav1.Now()
+	step.FinishedAt = &now
+	prErr := &PullRequestOpenError{}
+	switch {
+	case err == nil:
+		step.Phase = kargoapi.PromotionStepPhaseSucceeded
+	case errors.As(err, &prErr):
+		step.Phase = kargoapi.PromotionStepPhaseWaiting
+		step.PullRequestURL = prErr.URL
+		step.Message = err.Error()
+	default:
+		step.Phase = kargoapi.PromotionStepPhaseErrored
+		step.Message = err.Error()
+	}
+	recorder.Record(*step)
+}
@@ -0,0 +1,137 @@
+package promotion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/bookkeeper/pkg/git"
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestStepRecorder(t *testing.T) {
+	recorder := NewStepRecorder()
+	require.Nil(t, recorder.Steps())
+	recorder.Record(kargoapi.PromotionStep{Mechanism: "fake-mechanism"})
+	recorder.Record(kargoapi.PromotionStep{Mechanism: "another-fake-mechanism"})
+	steps := recorder.Steps()
+	require.Equal(
+		t,
+		[]kargoapi.PromotionStep{
+			{Mechanism: "fake-mechanism"},
+			{Mechanism: "another-fake-mechanism"},
+		},
+		steps,
+	)
+	// Modifying the returned steps should not affect the recorder
+	steps[0].Mechanism = "modified"
+	require.Equal(t, "fake-mechanism", recorder.Steps()[0].Mechanism)
+}
+
+func TestStepRecorderFromContext(t *testing.T) {
+	require.Nil(t, StepRecorderFromContext(context.Background()))
+	recorder := NewStepRecorder()
+	require.Same(
+		t,
+		recorder,
+		StepRecorderFromContext(
+			ContextWithStepRecorder(context.Background(), recorder),
+		),
+	)
+}
+
+func TestFinishStep(t *testing.T) {
+	testCases := []struct {
+		name       string
+		err        error
+		assertions func(kargoapi.PromotionStep)
+	}{
+		{
+			name: "step succeeded",
+			assertions: func(step kargoapi.PromotionStep) {
+				require.Equal(t, kargoapi.PromotionStepPhaseSucceeded, step.Phase)
+				require.Empty(t, step.Message)
+			},
+		},
+		{
+			name: "step errored",
+			err:  errors.New("something went wrong"),
+			assertions: func(step kargoapi.PromotionStep) {
+				require.Equal(t, kargoapi.PromotionStepPhaseErrored, step.Phase)
+				require.Equal(t, "something went wrong", step.Message)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			recorder := NewStepRecorder()
+			ctx := ContextWithStepRecorder(context.Background(), recorder)
+			finishStep(ctx, startStep("fake-mechanism"), testCase.err)
+			steps := recorder.Steps()
+			require.Len(t, steps, 1)
+			require.Equal(t, "fake-mechanism", steps[0].Mechanism)
+			require.NotNil(t, steps[0].StartedAt)
+			require.NotNil(t, steps[0].FinishedAt)
+			testCase.assertions(steps[0])
+		})
+	}
+}
+
+func TestGitDoSingleUpdateRecordsStep(t *testing.T) {
+	recorder := NewStepRecorder()
+	ctx := ContextWithStepRecorder(context.Background(), recorder)
+	g := &gitMechanism{
+		name: "fake-mechanism",
+		getReadRefFn: func(
+			kargoapi.GitRepoUpdate,
+			[]kargoapi.GitCommit,
+		) (string, int, error) {
+			return "fake-ref", -1, nil
+		},
+		getCredentialsFn: func(
+			context.Context,
+			string,
+			string,
+		) (*git.RepoCredentials, error) {
+			return nil, nil
+		},
+		gitCommitFn: func(
+			context.Context,
+			kargoapi.GitRepoUpdate,
+			kargoapi.SimpleFreight,
+			string,
+			string,
+			*git.RepoCredentials,
+			commitOptions,
+		) (string, bool, error) {
+			return "fake-commit-id", true, nil
+		},
+		getSigningKeyFn: func(
+			context.Context,
+			string,
+			string,
+		) (*signingKey, error) {
+			return &signingKey{fingerprint: "fake-fingerprint"}, nil
+		},
+	}
+	_, err := g.doSingleUpdate(
+		ctx,
+		&kargoapi.Stage{},
+		kargoapi.GitRepoUpdate{
+			RepoURL:     "fake-url",
+			WriteBranch: "fake-branch",
+		},
+		kargoapi.SimpleFreight{},
+	)
+	require.NoError(t, err)
+	steps := recorder.Steps()
+	require.Len(t, steps, 1)
+	require.Equal(t, "fake-mechanism", steps[0].Mechanism)
+	require.Equal(t, "fake-url", steps[0].RepoURL)
+	require.Equal(t, "fake-branch", steps[0].Branch)
+	require.Equal(t, "fake-commit-id", steps[0].CommitID)
+	require.Equal(t, "fake-fingerprint", steps[0].SigningKeyFingerprint)
+	require.Equal(t, kargoapi.PromotionStepPhaseSucceeded, steps[0].Phase)
+}
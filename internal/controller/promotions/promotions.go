@@ -3,12 +3,17 @@ package promotions
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
+	"github.com/oklog/ulid/v2"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -21,10 +26,34 @@ import (
 	"github.com/akuity/kargo/internal/controller/promotion"
 	"github.com/akuity/kargo/internal/controller/runtime"
 	"github.com/akuity/kargo/internal/credentials"
+	libGit "github.com/akuity/kargo/internal/git"
+	"github.com/akuity/kargo/internal/kargo"
 	"github.com/akuity/kargo/internal/kubeclient"
 	"github.com/akuity/kargo/internal/logging"
 )
 
+const (
+	defaultRetryBackoff    = 10 * time.Second
+	defaultRetryMaxBackoff = 5 * time.Minute
+
+	// requeueInterval is how long to wait before trying again to execute a
+	// Promotion that could not proceed, e.g. because it is not currently
+	// permitted to.
+	requeueInterval = 10 * time.Second
+
+	// promotionLeaseDuration is how long after its lease was last renewed a
+	// Running Promotion is considered to have been abandoned by the controller
+	// that was executing it.
+	promotionLeaseDuration = time.Minute
+	// promotionLeaseRenewInterval is how often the lease on a Running
+	// Promotion is renewed.
+	promotionLeaseRenewInterval = 15 * time.Second
+
+	// waitingPromotionCheckInterval is how often a Waiting Promotion is checked
+	// to see whether it can proceed.
+	waitingPromotionCheckInterval = time.Minute
+)
+
 // reconciler reconciles Promotion resources.
 type reconciler struct {
 	kargoClient     client.Client
@@ -34,11 +63,33 @@ type reconciler struct {
 	promoQueuesByStageMu sync.Mutex
 	initializeOnce       sync.Once
 
+	// stageQueue holds the keys of Stages whose Promotion queues need
+	// attention. Each key is handed to at most one worker at a time, which is
+	// what keeps the execution of each Stage's Promotions strictly serialized
+	// while Promotions for different Stages are executed concurrently.
+	stageQueue workqueue.DelayingInterface
+	// maxConcurrentPromotions is the number of workers executing Promotions.
+	maxConcurrentPromotions int
+
+	// leaseHolderIdentity uniquely identifies this controller, including across
+	// restarts, in the leases recorded on Running Promotions.
+	leaseHolderIdentity string
+
+	// activePromoCancelFns holds functions for canceling the contexts of
+	// Promotions that are currently being executed, indexed by Promotion.
+	activePromoCancelFns map[types.NamespacedName]context.CancelFunc
+	activePromosMu       sync.Mutex
+
 	// Overridable for testing:
 	promoteFn func(
 		ctx context.Context,
 		promo v1alpha1.Promotion,
 	) error
+
+	isPromotionPermittedFn func(
+		ctx context.Context,
+		promo *kargoapi.Promotion,
+	) (bool, string, error)
 }
 
 // SetupReconcilerWithManager initializes a reconciler for Promotion resources
@@ -48,7 +99,9 @@ func SetupReconcilerWithManager(
 	argoMgr manager.Manager,
 	credentialsDB credentials.Database,
 	bookkeeperService bookkeeper.Service,
+	gitCache *libGit.Cache,
 	shardName string,
+	maxConcurrentPromotions int,
 ) error {
 
 	shardPredicate, err := controller.GetShardPredicate(shardName)
@@ -59,7 +112,13 @@ func SetupReconcilerWithManager(
 	return errors.Wrap(
 		ctrl.NewControllerManagedBy(kargoMgr).
 			For(&kargoapi.Promotion{}).
-			WithEventFilter(predicate.GenerationChangedPredicate{}).
+			WithEventFilter(
+				predicate.Or(
+					predicate.GenerationChangedPredicate{},
+					// Abort requests are made by annotating the Promotion
+					predicate.AnnotationChangedPredicate{},
+				),
+			).
 			WithEventFilter(shardPredicate).
 			WithOptions(controller.CommonOptions()).
 			Complete(
@@ -68,6 +127,8 @@ func SetupReconcilerWithManager(
 					argoMgr.GetClient(),
 					credentialsDB,
 					bookkeeperService,
+					gitCache,
+					maxConcurrentPromotions,
 				),
 			),
 		"error registering Promotion reconciler",
@@ -79,31 +140,68 @@ func newReconciler(
 	argoClient client.Client,
 	credentialsDB credentials.Database,
 	bookkeeperService bookkeeper.Service,
+	gitCache *libGit.Cache,
+	maxConcurrentPromotions int,
 ) *reconciler {
+	if maxConcurrentPromotions < 1 {
+		maxConcurrentPromotions = 1
+	}
 	r := &reconciler{
-		kargoClient:        kargoClient,
-		promoQueuesByStage: map[types.NamespacedName]runtime.PriorityQueue{},
+		kargoClient:             kargoClient,
+		promoQueuesByStage:      map[types.NamespacedName]runtime.PriorityQueue{},
+		stageQueue:              workqueue.NewDelayingQueue(),
+		maxConcurrentPromotions: maxConcurrentPromotions,
+		activePromoCancelFns:    map[types.NamespacedName]context.CancelFunc{},
+		leaseHolderIdentity:     getLeaseHolderIdentity(),
 		promoMechanisms: promotion.NewMechanisms(
 			argoClient,
 			credentialsDB,
 			bookkeeperService,
+			gitCache,
 		),
 	}
 	r.promoteFn = r.promote
+	r.isPromotionPermittedFn = r.isPromotionPermitted
 	return r
 }
 
+// getLeaseHolderIdentity returns an identity for this controller that is unique
+// even among multiple incarnations of the controller running in the same pod.
+func getLeaseHolderIdentity() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "kargo-controller"
+	}
+	return fmt.Sprintf("%s_%s", hostname, ulid.Make())
+}
+
 func newPromotionsQueue() runtime.PriorityQueue {
 	// We can safely ignore errors here because the only error that can happen
 	// involves initializing the queue with a nil priority function, which we
 	// know we aren't doing.
 	pq, _ := runtime.NewPriorityQueue(func(left, right client.Object) bool {
+		leftPriority := getPromoPriority(left)
+		rightPriority := getPromoPriority(right)
+		if leftPriority != rightPriority {
+			return leftPriority > rightPriority
+		}
 		return left.GetCreationTimestamp().Time.
 			Before(right.GetCreationTimestamp().Time)
 	})
 	return pq
 }
 
+// getPromoPriority returns the user-specified priority of the provided
+// Promotion. Objects that are not Promotions and Promotions without a spec are
+// treated as having the default priority of 0.
+func getPromoPriority(obj client.Object) int32 {
+	promo, ok := obj.(*kargoapi.Promotion)
+	if !ok || promo.Spec == nil {
+		return 0
+	}
+	return promo.Spec.Priority
+}
+
 // Reconcile is part of the main Kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *reconciler) Reconcile(
@@ -136,8 +234,7 @@ func (r *reconciler) Reconcile(
 					"existing Promotions",
 			)
 		}
-		// TODO: Do not hardcode this interval
-		go r.serializedSync(ctx, 10*time.Second)
+		go r.runWorkers(ctx, r.maxConcurrentPromotions)
 	})
 	if err != nil {
 		return result, errors.Wrap(err, "error initializing Promotion queues")
@@ -221,6 +318,7 @@ func (r *reconciler) initializeQueues(ctx context.Context) error {
 		// The only error that can occur here happens when you push a nil and we
 		// know we're not doing that.
 		pq.Push(&promo) // nolint: errcheck
+		r.stageQueue.Add(stage)
 		logger.WithFields(log.Fields{
 			"promotion": promo.Name,
 			"namespace": promo.Namespace,
@@ -240,12 +338,17 @@ func (r *reconciler) initializeQueues(ctx context.Context) error {
 	return nil
 }
 
-// syncPromo enqueues Promotion requests to a Stage-specific priority queue. This
-// functions assumes the caller has obtained a lock on promoQueuesByStageMu.
+// syncPromo enqueues Promotion requests to a Stage-specific priority queue and
+// handles requests to abort Promotions. This functions assumes the caller has
+// obtained a lock on promoQueuesByStageMu.
 func (r *reconciler) syncPromo(
 	ctx context.Context,
 	promo *kargoapi.Promotion,
 ) kargoapi.PromotionStatus {
+	if promo.IsAbortRequested() && !promo.Status.Phase.IsTerminal() {
+		return r.abortPromo(ctx, promo)
+	}
+
 	status := *promo.Status.DeepCopy()
 
 	// Only deal with brand new Promotions
@@ -269,6 +372,9 @@ func (r *reconciler) syncPromo(
 	// Ignore any errors from this operation. Errors can only occur when you
 	// try to push a nil onto the queue and we know we're not doing that.
 	pq.Push(promo) // nolint: errcheck
+	// Dispatch immediately. If a worker is already busy with this Stage, the
+	// Stage will be handed out again once that worker is done with it.
+	r.stageQueue.Add(stage)
 
 	logging.LoggerFromContext(ctx).WithField("depth", pq.Depth()).
 		Infof("pushed Promotion %q to Queue for Stage %q in namespace %q ",
@@ -277,88 +383,620 @@ func (r *reconciler) syncPromo(
 			promo.Namespace,
 		)
 
+	r.supersedePromos(ctx, promo, pq)
+
 	return status
 }
 
-func (r *reconciler) serializedSync(
+// supersedePromos marks Promotions for the same Stage as the provided Promotion
+// that are older than it and still waiting in the provided queue as Superseded
+// and removes them from the queue, if the Stage's PromotionPolicy calls for
+// that. Promotions that are already being executed are left alone. Errors are
+// logged, but otherwise ignored, since failing to supersede older Promotions
+// only means that they will be executed. This function assumes the caller has
+// obtained a lock on promoQueuesByStageMu.
+func (r *reconciler) supersedePromos(
 	ctx context.Context,
-	interval time.Duration,
+	promo *kargoapi.Promotion,
+	pq runtime.PriorityQueue,
 ) {
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
-	for {
-		select {
-		case <-ticker.C:
-		case <-ctx.Done():
-			return
+	logger := logging.LoggerFromContext(ctx)
+
+	policy, err := r.getPromotionPolicy(ctx, promo)
+	if err != nil {
+		logger.Errorf("error finding PromotionPolicy: %s", err)
+		return
+	}
+	if policy == nil || !policy.Supersede {
+		return
+	}
+
+	promos := kargoapi.PromotionList{}
+	if err = r.kargoClient.List(
+		ctx,
+		&promos,
+		&client.ListOptions{
+			Namespace: promo.Namespace,
+			FieldSelector: fields.Set(map[string]string{
+				kubeclient.NonTerminalPromotionsByStageIndexField: promo.Spec.Stage,
+			}).AsSelector(),
+		},
+	); err != nil {
+		logger.Errorf(
+			"error listing Promotions in non-terminal phases for Stage %q in "+
+				"namespace %q: %s",
+			promo.Spec.Stage,
+			promo.Namespace,
+			err,
+		)
+		return
+	}
+
+	for i := range promos.Items {
+		olderPromo := &promos.Items[i]
+		if olderPromo.Name == promo.Name ||
+			olderPromo.Spec == nil ||
+			olderPromo.Spec.Stage != promo.Spec.Stage ||
+			olderPromo.Status.Phase != kargoapi.PromotionPhasePending ||
+			promo.CreationTimestamp.Before(&olderPromo.CreationTimestamp) {
+			continue
 		}
-		for _, pq := range r.promoQueuesByStage {
-			if popped := pq.Pop(); popped != nil {
-				promo := popped.(*kargoapi.Promotion) // nolint: forcetypeassert
-
-				logger := logging.LoggerFromContext(ctx).WithFields(log.Fields{
-					"promotion": promo.Name,
-					"namespace": promo.Namespace,
-				})
-
-				// Refresh promo instead of working with something stale
-				var err error
-				if promo, err = r.getPromo(
-					ctx,
-					types.NamespacedName{
-						Namespace: promo.Namespace,
-						Name:      promo.Name,
-					},
-				); err != nil {
-					logger.Error("error finding Promotion")
-					continue
-				}
-				if promo == nil || promo.Status.Phase != kargoapi.PromotionPhasePending {
-					continue
-				}
+		if !r.dequeuePromo(olderPromo, pq) {
+			continue
+		}
+		if err = kubeclient.PatchStatus(ctx, r.kargoClient, olderPromo, func(status *kargoapi.PromotionStatus) {
+			status.Phase = kargoapi.PromotionPhaseSuperseded
+			status.Error = ""
+			status.Message = fmt.Sprintf(
+				"Promotion was superseded by newer Promotion %q",
+				promo.Name,
+			)
+			status.NextAttemptAt = nil
+		}); err != nil {
+			logger.Errorf("error updating Promotion status: %s", err)
+			continue
+		}
+		logger.WithField("superseded", olderPromo.Name).
+			Debug("superseded older Pending Promotion")
+	}
+}
 
-				logger = logger.WithFields(log.Fields{
-					"stage":   promo.Spec.Stage,
-					"freight": promo.Spec.Freight,
-				})
-				logger.Debug("executing Promotion")
-
-				promoCtx := logging.ContextWithLogger(ctx, logger)
-
-				phase := kargoapi.PromotionPhaseSucceeded
-				phaseError := ""
-
-				func() {
-					defer func() {
-						if err := recover(); err != nil {
-							logger.Errorf("Promotion panic: %v", err)
-							phase = kargoapi.PromotionPhaseErrored
-							phaseError = fmt.Sprintf("%v", err)
-						}
-					}()
-					if err = r.promoteFn(
-						promoCtx,
-						*promo,
-					); err != nil {
-						phase = kargoapi.PromotionPhaseErrored
-						phaseError = err.Error()
-						logger.Errorf("error executing Promotion: %s", err)
-					}
-				}()
-
-				if err = kubeclient.PatchStatus(ctx, r.kargoClient, promo, func(status *kargoapi.PromotionStatus) {
-					status.Phase = phase
-					status.Error = phaseError
-				}); err != nil {
-					logger.Errorf("error updating Promotion status: %s", err)
-				}
+// dequeuePromo removes the provided Promotion from the provided queue unless
+// it is currently being executed. It returns true if the Promotion was found in
+// the queue and removed and false otherwise.
+func (r *reconciler) dequeuePromo(
+	promo *kargoapi.Promotion,
+	pq runtime.PriorityQueue,
+) bool {
+	r.activePromosMu.Lock()
+	defer r.activePromosMu.Unlock()
+	if _, ok := r.activePromoCancelFns[types.NamespacedName{
+		Namespace: promo.Namespace,
+		Name:      promo.Name,
+	}]; ok {
+		return false
+	}
+	return pq.Remove(promo)
+}
 
-				if promo.Status.Phase == kargoapi.PromotionPhaseSucceeded && err == nil {
-					logger.Debug("Promotion succeeded")
+// runWorkers starts the specified number of workers, each of which repeatedly
+// takes a Stage from stageQueue and executes the next Promotion in that Stage's
+// queue. It blocks until the provided context is canceled and all workers have
+// returned.
+func (r *reconciler) runWorkers(ctx context.Context, workers int) {
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r.processNextStage(ctx) {
+			}
+		}()
+	}
+	<-ctx.Done()
+	r.stageQueue.ShutDown()
+	wg.Wait()
+}
+
+// processNextStage takes the next Stage from stageQueue and executes the next
+// Promotion in that Stage's queue. If Promotions remain in that queue
+// afterwards, the Stage is returned to stageQueue, after a delay if the
+// Promotion at the head of the queue cannot proceed yet. It returns false when
+// stageQueue has been shut down.
+func (r *reconciler) processNextStage(ctx context.Context) bool {
+	item, shutdown := r.stageQueue.Get()
+	if shutdown {
+		return false
+	}
+	defer r.stageQueue.Done(item)
+	stage := item.(types.NamespacedName) // nolint: forcetypeassert
+
+	r.promoQueuesByStageMu.Lock()
+	pq, ok := r.promoQueuesByStage[stage]
+	r.promoQueuesByStageMu.Unlock()
+	if !ok {
+		return true
+	}
+
+	requeueAfter := r.executeNextPromo(ctx, pq)
+	if pq.Depth() == 0 || ctx.Err() != nil {
+		return true
+	}
+	if requeueAfter > 0 {
+		r.stageQueue.AddAfter(stage, requeueAfter)
+	} else {
+		r.stageQueue.Add(stage)
+	}
+	return true
+}
+
+// executeNextPromo pops the next Promotion, if any, from the provided queue and
+// executes it. While the Promotion is being executed, the function used to
+// cancel the context it is executed with is registered so that the Promotion
+// can be interrupted if a user requests that it be aborted. If the Promotion
+// could not proceed and was put back in the queue, the returned duration
+// indicates how long to wait before trying again.
+func (r *reconciler) executeNextPromo(
+	ctx context.Context,
+	pq runtime.PriorityQueue,
+) time.Duration {
+	// Popping the Promotion and registering the cancel function happen as a
+	// single critical section so that an abort request can never observe a
+	// Promotion that is in neither the queue nor the registry.
+	r.activePromosMu.Lock()
+	popped := pq.Pop()
+	if popped == nil {
+		r.activePromosMu.Unlock()
+		return 0
+	}
+	promo := popped.(*kargoapi.Promotion) // nolint: forcetypeassert
+	promoKey := types.NamespacedName{
+		Namespace: promo.Namespace,
+		Name:      promo.Name,
+	}
+	promoCtx, cancel := context.WithCancel(ctx)
+	r.activePromoCancelFns[promoKey] = cancel
+	r.activePromosMu.Unlock()
+	defer func() {
+		r.activePromosMu.Lock()
+		delete(r.activePromoCancelFns, promoKey)
+		r.activePromosMu.Unlock()
+		cancel()
+	}()
+
+	logger := logging.LoggerFromContext(ctx).WithFields(log.Fields{
+		"promotion": promo.Name,
+		"namespace": promo.Namespace,
+	})
+
+	// Refresh promo instead of working with something stale
+	var err error
+	if promo, err = r.getPromo(ctx, promoKey); err != nil {
+		logger.Error("error finding Promotion")
+		return 0
+	}
+	if promo == nil || (promo.Status.Phase != kargoapi.PromotionPhasePending &&
+		promo.Status.Phase != kargoapi.PromotionPhaseRunning &&
+		promo.Status.Phase != kargoapi.PromotionPhaseWaiting) {
+		return 0
+	}
+
+	if promo.IsAbortRequested() || promoCtx.Err() != nil {
+		logger.Debug("Promotion was aborted before it was executed")
+		if err = kubeclient.PatchStatus(ctx, r.kargoClient, promo, func(status *kargoapi.PromotionStatus) {
+			status.Phase = kargoapi.PromotionPhaseAborted
+			status.Error = ""
+			status.Message = getAbortedMessage(promo)
+			status.Lease = nil
+			status.NextAttemptAt = nil
+		}); err != nil {
+			logger.Errorf("error updating Promotion status: %s", err)
+		}
+		return 0
+	}
+
+	// This controller never queues a Promotion that it is executing, so a
+	// Running Promotion found in the queue was being executed by another
+	// controller, or by a previous incarnation of this one.
+	if promo.Status.Phase == kargoapi.PromotionPhaseRunning {
+		return r.recoverPromo(ctx, promo, pq)
+	}
+
+	// A failed Promotion that is awaiting a retry isn't attempted again until
+	// its backoff has elapsed. Likewise, a Waiting Promotion isn't checked again
+	// until it is due to be.
+	if promo.Status.NextAttemptAt != nil &&
+		time.Now().Before(promo.Status.NextAttemptAt.Time) {
+		pq.Push(promo) // nolint: errcheck
+		return time.Until(promo.Status.NextAttemptAt.Time)
+	}
+
+	permitted, reason, err := r.isPromotionPermittedFn(ctx, promo)
+	if err != nil {
+		logger.Errorf("error determining if Promotion is permitted: %s", err)
+	} else if !permitted {
+		logger.WithField("reason", reason).
+			Debug("Promotion is not currently permitted")
+	}
+	if err != nil || !permitted {
+		if reason != "" && reason != promo.Status.Message {
+			if err = kubeclient.PatchStatus(ctx, r.kargoClient, promo, func(status *kargoapi.PromotionStatus) {
+				status.Message = reason
+			}); err != nil {
+				logger.Errorf("error updating Promotion status: %s", err)
+			}
+		}
+		// Put the Promotion back so it will be attempted again later. The only
+		// error that can occur here happens when you push a nil and we know we're
+		// not doing that.
+		pq.Push(promo) // nolint: errcheck
+		return requeueInterval
+	}
+
+	policy, err := r.getPromotionPolicy(ctx, promo)
+	if err != nil {
+		logger.Errorf("error finding PromotionPolicy: %s", err)
+		pq.Push(promo) // nolint: errcheck
+		return requeueInterval
+	}
+	var retry *kargoapi.PromotionRetry
+	var timeout *metav1.Duration
+	if policy != nil {
+		retry = policy.Retry
+		timeout = policy.Timeout
+	}
+
+	// Checking on a Waiting Promotion continues the attempt that left it
+	// Waiting rather than counting as an attempt of its own.
+	resumingAttempt := promo.Status.Phase == kargoapi.PromotionPhaseWaiting &&
+		len(promo.Status.Attempts) > 0
+	attemptNumber := len(promo.Status.Attempts) + 1
+	if resumingAttempt {
+		attemptNumber = len(promo.Status.Attempts)
+	}
+	logger = logger.WithFields(log.Fields{
+		"stage":   promo.Spec.Stage,
+		"freight": promo.Spec.Freight,
+		"attempt": attemptNumber,
+	})
+
+	startedAt := metav1.Now()
+	attemptCtx := promoCtx
+	if timeout != nil {
+		// The timeout applies to the Promotion as a whole, so it is measured from
+		// the start of the first attempt.
+		firstStartedAt := startedAt
+		if len(promo.Status.Attempts) > 0 &&
+			promo.Status.Attempts[0].StartedAt != nil {
+			firstStartedAt = *promo.Status.Attempts[0].StartedAt
+		}
+		deadline := firstStartedAt.Add(timeout.Duration)
+		if !startedAt.Time.Before(deadline) {
+			logger.Debug("Promotion timed out before it could be attempted again")
+			if err = kubeclient.PatchStatus(ctx, r.kargoClient, promo, func(status *kargoapi.PromotionStatus) {
+				status.Phase = kargoapi.PromotionPhaseErrored
+				status.Error = fmt.Sprintf("Promotion timed out after %s", timeout.Duration)
+				status.Message = ""
+				status.NextAttemptAt = nil
+			}); err != nil {
+				logger.Errorf("error updating Promotion status: %s", err)
+			}
+			return 0
+		}
+		var cancelAttempt context.CancelFunc
+		attemptCtx, cancelAttempt = context.WithDeadline(promoCtx, deadline)
+		defer cancelAttempt()
+	}
+
+	// Record that this controller is executing the Promotion. The attempt is
+	// recorded up front so that, should this controller stop before the attempt
+	// concludes, whichever controller recovers the Promotion will account for
+	// it.
+	if err = kubeclient.PatchStatus(ctx, r.kargoClient, promo, func(status *kargoapi.PromotionStatus) {
+		status.Phase = kargoapi.PromotionPhaseRunning
+		status.Message = ""
+		status.NextAttemptAt = nil
+		status.Steps = nil
+		if !resumingAttempt {
+			status.Attempts = append(
+				status.Attempts,
+				kargoapi.PromotionAttempt{StartedAt: &startedAt},
+			)
+		}
+		status.Lease = &kargoapi.PromotionLease{
+			HolderIdentity: r.leaseHolderIdentity,
+			RenewTime:      &startedAt,
+		}
+	}); err != nil {
+		logger.Errorf("error updating Promotion status: %s", err)
+		pq.Push(promo) // nolint: errcheck
+		return requeueInterval
+	}
+
+	logger.Debug("executing Promotion")
+
+	attemptCtx = logging.ContextWithLogger(attemptCtx, logger)
+	// Promotion mechanisms record each step they take so the steps can be
+	// surfaced in the Promotion's status.
+	stepRecorder := promotion.NewStepRecorder()
+	attemptCtx = promotion.ContextWithStepRecorder(attemptCtx, stepRecorder)
+
+	phase := kargoapi.PromotionPhaseSucceeded
+	phaseError := ""
+	message := ""
+	var nextAttemptAt *metav1.Time
+
+	var promoteErr error
+	prErr := &promotion.PullRequestOpenError{}
+	stopLeaseRenewal := r.renewLease(ctx, promo)
+	func() {
+		defer func() {
+			if err := recover(); err != nil {
+				logger.Errorf("Promotion panic: %v", err)
+				phase = kargoapi.PromotionPhaseErrored
+				phaseError = fmt.Sprintf("%v", err)
+			}
+		}()
+		if promoteErr = r.promoteFn(
+			attemptCtx,
+			*promo,
+		); promoteErr != nil {
+			phase = kargoapi.PromotionPhaseErrored
+			phaseError = promoteErr.Error()
+			if !errors.As(promoteErr, &prErr) {
+				logger.Errorf("error executing Promotion: %s", promoteErr)
+			}
+		}
+	}()
+	stopLeaseRenewal()
+
+	attemptError := phaseError
+
+	switch {
+	case promoCtx.Err() != nil && ctx.Err() == nil:
+		// If the Promotion's own context was canceled while the controller's was
+		// not, the Promotion was aborted at a user's request.
+		logger.Debug("Promotion was aborted while it was being executed")
+		phase = kargoapi.PromotionPhaseAborted
+		phaseError = ""
+		message = "Promotion was aborted while it was being executed and may " +
+			"have been partially applied"
+	case errors.As(promoteErr, &prErr):
+		// The Promotion can't proceed until the pull request it opened has been
+		// merged. That is not a failure, so it is simply checked on again later.
+		next := metav1.NewTime(time.Now().Add(waitingPromotionCheckInterval))
+		nextAttemptAt = &next
+		logger.WithField("pullRequest", prErr.URL).
+			Debug("Promotion is waiting for a pull request to be merged")
+		phase = kargoapi.PromotionPhaseWaiting
+		phaseError = ""
+		attemptError = ""
+		message = prErr.Error()
+		defer pq.Push(promo) // nolint: errcheck
+	case phase == kargoapi.PromotionPhaseErrored &&
+		errors.Is(attemptCtx.Err(), context.DeadlineExceeded):
+		logger.Debug("Promotion timed out")
+		phaseError = fmt.Sprintf(
+			"Promotion timed out after %s: %s",
+			timeout.Duration,
+			phaseError,
+		)
+	case phase == kargoapi.PromotionPhaseErrored &&
+		attemptNumber < getMaxAttempts(retry):
+		// Leave the Promotion Pending and put it back in the queue so it will be
+		// attempted again once its backoff has elapsed.
+		next := metav1.NewTime(
+			time.Now().Add(getRetryBackoff(retry, attemptNumber)),
+		)
+		nextAttemptAt = &next
+		logger.WithField("nextAttemptAt", next.Time).
+			Debug("Promotion attempt failed; will retry")
+		phase = kargoapi.PromotionPhasePending
+		message = fmt.Sprintf(
+			"attempt %d of %d failed; will retry after %s",
+			attemptNumber,
+			getMaxAttempts(retry),
+			next.Time.Format(time.RFC3339),
+		)
+		phaseError = ""
+		defer pq.Push(promo) // nolint: errcheck
+	}
+
+	if err = kubeclient.PatchStatus(ctx, r.kargoClient, promo, func(status *kargoapi.PromotionStatus) {
+		status.Phase = phase
+		status.Error = phaseError
+		status.Message = message
+		status.Attempts[len(status.Attempts)-1].Error = attemptError
+		status.NextAttemptAt = nextAttemptAt
+		status.Lease = nil
+		status.Steps = stepRecorder.Steps()
+	}); err != nil {
+		logger.Errorf("error updating Promotion status: %s", err)
+	}
+
+	if promo.Status.Phase == kargoapi.PromotionPhaseSucceeded && err == nil {
+		logger.Debug("Promotion succeeded")
+	}
+
+	if nextAttemptAt != nil {
+		return time.Until(nextAttemptAt.Time)
+	}
+	return 0
+}
+
+// renewLease starts periodically renewing the lease recorded in the status of
+// the provided Running Promotion and returns a function that stops doing so.
+// The returned function does not return until renewal has stopped.
+func (r *reconciler) renewLease(
+	ctx context.Context,
+	promo *kargoapi.Promotion,
+) func() {
+	// Work with a copy so that renewal never races with updates to the
+	// caller's copy of the Promotion.
+	promo = promo.DeepCopy()
+	stopCh := make(chan struct{})
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		ticker := time.NewTicker(promotionLeaseRenewInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-stopCh:
+				return
+			case <-ctx.Done():
+				return
+			}
+			now := metav1.Now()
+			if err := kubeclient.PatchStatus(ctx, r.kargoClient, promo, func(status *kargoapi.PromotionStatus) {
+				if status.Lease != nil {
+					status.Lease.RenewTime = &now
 				}
+			}); err != nil {
+				logging.LoggerFromContext(ctx).
+					Errorf("error renewing Promotion lease: %s", err)
 			}
 		}
+	}()
+	return func() {
+		close(stopCh)
+		<-doneCh
+	}
+}
+
+// recoverPromo handles a Running Promotion that this controller is not
+// executing. If the Promotion's lease has not yet expired, the controller
+// executing it may still be alive, so the Promotion is put back in the queue
+// to be looked at again after the lease expires. Otherwise, the controller
+// that was executing the Promotion stopped before it concluded, and the
+// interrupted attempt is treated as a failed one: if the Stage's
+// PromotionPolicy permits another attempt, the Promotion is returned to
+// Pending to be retried. If not, it is marked as Errored.
+func (r *reconciler) recoverPromo(
+	ctx context.Context,
+	promo *kargoapi.Promotion,
+	pq runtime.PriorityQueue,
+) time.Duration {
+	logger := logging.LoggerFromContext(ctx)
+
+	if lease := promo.Status.Lease; lease != nil && lease.RenewTime != nil {
+		if expiresAt := lease.RenewTime.Add(promotionLeaseDuration); time.Now().Before(expiresAt) {
+			pq.Push(promo) // nolint: errcheck
+			return time.Until(expiresAt)
+		}
+	}
+
+	policy, err := r.getPromotionPolicy(ctx, promo)
+	if err != nil {
+		logger.Errorf("error finding PromotionPolicy: %s", err)
+		pq.Push(promo) // nolint: errcheck
+		return requeueInterval
+	}
+	var retry *kargoapi.PromotionRetry
+	if policy != nil {
+		retry = policy.Retry
+	}
+
+	const interruptedErr = "the controller executing the Promotion stopped " +
+		"before it concluded; the Promotion may have been partially applied"
+
+	attempts := len(promo.Status.Attempts)
+	if attempts == 0 {
+		// Account for the interrupted attempt even if it wasn't recorded
+		attempts = 1
+	}
+	var nextAttemptAt *metav1.Time
+	if attempts < getMaxAttempts(retry) {
+		next := metav1.NewTime(time.Now().Add(getRetryBackoff(retry, attempts)))
+		nextAttemptAt = &next
+	}
+
+	if err = kubeclient.PatchStatus(ctx, r.kargoClient, promo, func(status *kargoapi.PromotionStatus) {
+		if len(status.Attempts) == 0 {
+			status.Attempts = []kargoapi.PromotionAttempt{{}}
+		}
+		status.Attempts[len(status.Attempts)-1].Error = interruptedErr
+		status.Lease = nil
+		status.NextAttemptAt = nextAttemptAt
+		if nextAttemptAt != nil {
+			status.Phase = kargoapi.PromotionPhasePending
+			status.Error = ""
+			status.Message = fmt.Sprintf(
+				"attempt %d of %d was interrupted; will retry after %s",
+				attempts,
+				getMaxAttempts(retry),
+				nextAttemptAt.Time.Format(time.RFC3339),
+			)
+		} else {
+			status.Phase = kargoapi.PromotionPhaseErrored
+			status.Error = "Promotion was interrupted: " + interruptedErr
+			status.Message = ""
+		}
+	}); err != nil {
+		logger.Errorf("error updating Promotion status: %s", err)
+		pq.Push(promo) // nolint: errcheck
+		return requeueInterval
+	}
+
+	if nextAttemptAt == nil {
+		logger.Debug("marked interrupted Promotion as Errored")
+		return 0
+	}
+	logger.WithField("nextAttemptAt", nextAttemptAt.Time).
+		Debug("interrupted Promotion will be retried")
+	pq.Push(promo) // nolint: errcheck
+	return time.Until(nextAttemptAt.Time)
+}
+
+// abortPromo handles a user's request to abort the provided Promotion. If the
+// Promotion is currently being executed, it is interrupted and the outcome is
+// left to be recorded by executeNextPromo. Otherwise, it is removed from its
+// Stage's queue and its returned status is updated to reflect that it has been
+// aborted. This function assumes the caller has obtained a lock on
+// promoQueuesByStageMu.
+func (r *reconciler) abortPromo(
+	ctx context.Context,
+	promo *kargoapi.Promotion,
+) kargoapi.PromotionStatus {
+	status := *promo.Status.DeepCopy()
+	logger := logging.LoggerFromContext(ctx)
+
+	r.activePromosMu.Lock()
+	defer r.activePromosMu.Unlock()
+
+	if cancel, ok := r.activePromoCancelFns[types.NamespacedName{
+		Namespace: promo.Namespace,
+		Name:      promo.Name,
+	}]; ok {
+		logger.Debug("interrupting Promotion that is being executed")
+		cancel()
+		return status
+	}
+
+	if pq, ok := r.promoQueuesByStage[types.NamespacedName{
+		Namespace: promo.Namespace,
+		Name:      promo.Spec.Stage,
+	}]; ok && pq.Remove(promo) {
+		logger.WithField("depth", pq.Depth()).
+			Debug("removed Promotion from Stage-specific Promotion queue")
+	}
+
+	status.Phase = kargoapi.PromotionPhaseAborted
+	status.Error = ""
+	status.Message = getAbortedMessage(promo)
+	status.NextAttemptAt = nil
+	return status
+}
+
+// getAbortedMessage returns a message explaining that the provided Promotion,
+// which is not being executed, was aborted.
+func getAbortedMessage(promo *kargoapi.Promotion) string {
+	if promo.Status.Phase == kargoapi.PromotionPhaseWaiting {
+		return "Promotion was aborted while it was waiting and may have been " +
+			"partially applied"
 	}
+	return "Promotion was aborted before it was executed"
 }
 
 func (r *reconciler) promote(
@@ -401,11 +1039,39 @@ func (r *reconciler) promote(
 		return nil
 	}
 
-	var targetFreight *kargoapi.Freight
-	for _, availableFreight := range stage.Status.AvailableFreight {
-		if availableFreight.ID == freightID {
-			targetFreight = availableFreight.DeepCopy()
-			targetFreight.Qualified = false
+	var targetFreight *kargoapi.SimpleFreight
+	if promo.Spec.FreightSnapshot != nil {
+		targetFreight = promo.Spec.FreightSnapshot.DeepCopy()
+		targetFreight.Qualified = false
+		targetFreight.Rollback = false
+	} else {
+		// Promotions created before Freight snapshots were recorded have to be
+		// resolved against the Stage's available Freight, whose details are in
+		// the Freight resources it references.
+		for _, availableFreight := range stage.Status.AvailableFreight {
+			if availableFreight.ID != freightID {
+				continue
+			}
+			freight, err := kargoapi.GetFreight(
+				ctx,
+				r.kargoClient,
+				types.NamespacedName{
+					Namespace: stageNamespace,
+					Name:      freightID,
+				},
+			)
+			if err != nil {
+				return errors.Wrapf(
+					err,
+					"error finding Freight %q in namespace %q",
+					freightID,
+					stageNamespace,
+				)
+			}
+			if freight != nil {
+				simpleFreight := freight.ToSimpleFreight()
+				targetFreight = &simpleFreight
+			}
 			break
 		}
 	}
@@ -428,6 +1094,9 @@ func (r *reconciler) promote(
 		return err
 	}
 
+	// Promotion mechanisms may use details of the Promotion, such as who
+	// created it, when writing commit messages and the like.
+	ctx = promotion.ContextWithPromotion(ctx, &promo)
 	nextFreight, err := r.promoMechanisms.Promote(ctx, stage, *targetFreight)
 	if err != nil {
 		return err
@@ -442,8 +1111,13 @@ func (r *reconciler) promote(
 		// (Technically, we should prevent creating promotion jobs on
 		// control-flow stages in the first place)
 		if stage.Spec.PromotionMechanisms != nil {
+			// Rollbacks are recorded as such so they stand out in the Stage's
+			// history
+			nextFreight.Rollback = promo.Spec.Rollback
 			status.CurrentFreight = &nextFreight
 			status.History.Push(nextFreight)
+			// Any verification of the previous Freight no longer applies
+			status.Verification = nil
 		}
 	})
 
@@ -455,6 +1129,87 @@ func (r *reconciler) promote(
 	)
 }
 
+// isPromotionPermitted returns a boolean indicating whether the provided
+// Promotion is currently permitted to proceed according to the windows and
+// blackouts of any PromotionPolicy associated with the Promotion's Stage. If it
+// is not, a human-readable reason is also returned.
+func (r *reconciler) isPromotionPermitted(
+	ctx context.Context,
+	promo *kargoapi.Promotion,
+) (bool, string, error) {
+	policy, err := r.getPromotionPolicy(ctx, promo)
+	if err != nil || policy == nil {
+		return err == nil, "", err
+	}
+	permitted, reason := kargo.IsPromotionPermitted(policy, time.Now())
+	return permitted, reason, nil
+}
+
+// getPromotionPolicy returns the PromotionPolicy associated with the provided
+// Promotion's Stage. If there is no such PromotionPolicy, nil is returned
+// instead.
+func (r *reconciler) getPromotionPolicy(
+	ctx context.Context,
+	promo *kargoapi.Promotion,
+) (*kargoapi.PromotionPolicy, error) {
+	policies := kargoapi.PromotionPolicyList{}
+	if err := r.kargoClient.List(
+		ctx,
+		&policies,
+		&client.ListOptions{
+			Namespace: promo.Namespace,
+			FieldSelector: fields.Set(map[string]string{
+				kubeclient.PromotionPoliciesByStageIndexField: promo.Spec.Stage,
+			}).AsSelector(),
+		},
+	); err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error listing PromotionPolicies for Stage %q in namespace %q",
+			promo.Spec.Stage,
+			promo.Namespace,
+		)
+	}
+	// There should never be more than one PromotionPolicy per Stage
+	if len(policies.Items) == 0 {
+		return nil, nil
+	}
+	return &policies.Items[0], nil
+}
+
+// getMaxAttempts returns the maximum number of times a Promotion may be
+// attempted under the provided retry policy, which may be nil.
+func getMaxAttempts(retry *kargoapi.PromotionRetry) int {
+	if retry == nil || retry.MaxAttempts < 1 {
+		return 1
+	}
+	return int(retry.MaxAttempts)
+}
+
+// getRetryBackoff returns how long to wait, under the provided retry policy
+// (which may be nil), before attempting a Promotion again after the specified
+// number of failed attempts. The wait doubles after each failed attempt, up to
+// a maximum.
+func getRetryBackoff(retry *kargoapi.PromotionRetry, failures int) time.Duration {
+	backoff := defaultRetryBackoff
+	maxBackoff := defaultRetryMaxBackoff
+	if retry != nil {
+		if retry.Backoff != nil {
+			backoff = retry.Backoff.Duration
+		}
+		if retry.MaxBackoff != nil {
+			maxBackoff = retry.MaxBackoff.Duration
+		}
+	}
+	for i := 1; i < failures && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
 // getPromo returns a pointer to the Promotion resource specified by the
 // namespacedName argument. If no such resource is found, nil is returned
 // instead.
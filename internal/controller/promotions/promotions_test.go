@@ -2,21 +2,28 @@ package promotions
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/akuity/bookkeeper"
 	"github.com/akuity/kargo/api/v1alpha1"
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/controller/promotion"
 	"github.com/akuity/kargo/internal/controller/runtime"
 	"github.com/akuity/kargo/internal/credentials"
+	libGit "github.com/akuity/kargo/internal/git"
+	"github.com/akuity/kargo/internal/kubeclient"
 )
 
 func TestNewPromotionReconciler(t *testing.T) {
@@ -26,10 +33,16 @@ func TestNewPromotionReconciler(t *testing.T) {
 		kubeClient,
 		&credentials.FakeDB{},
 		bookkeeper.NewService(nil),
+		&libGit.Cache{},
+		4,
 	)
 	require.NotNil(t, r.kargoClient)
 	require.NotNil(t, r.promoQueuesByStage)
+	require.NotNil(t, r.stageQueue)
+	require.Equal(t, 4, r.maxConcurrentPromotions)
 	require.NotNil(t, r.promoteFn)
+	require.NotNil(t, r.isPromotionPermittedFn)
+	require.NotNil(t, r.activePromoCancelFns)
 }
 
 func TestInitializeQueues(t *testing.T) {
@@ -48,9 +61,17 @@ func TestInitializeQueues(t *testing.T) {
 			},
 		).Build(),
 		promoQueuesByStage: map[types.NamespacedName]runtime.PriorityQueue{},
+		stageQueue:         workqueue.NewDelayingQueue(),
 	}
 	err := r.initializeQueues(context.Background())
 	require.NoError(t, err)
+	// The Stage should have been dispatched to the workers
+	require.Equal(t, 1, r.stageQueue.Len())
+}
+
+func TestGetLeaseHolderIdentity(t *testing.T) {
+	// Every incarnation of the controller needs a distinct identity
+	require.NotEqual(t, getLeaseHolderIdentity(), getLeaseHolderIdentity())
 }
 
 func TestNewPromotionsQueue(t *testing.T) {
@@ -87,6 +108,55 @@ func TestNewPromotionsQueue(t *testing.T) {
 	}
 }
 
+func TestNewPromotionsQueuePriority(t *testing.T) {
+	pq := newPromotionsQueue()
+	now := time.Now()
+	for _, promo := range []*kargoapi.Promotion{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "oldest-default-priority",
+				CreationTimestamp: metav1.NewTime(now.Add(-3 * time.Minute)),
+			},
+			Spec: &kargoapi.PromotionSpec{},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "newest-high-priority",
+				CreationTimestamp: metav1.NewTime(now),
+			},
+			Spec: &kargoapi.PromotionSpec{Priority: 10},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "older-high-priority",
+				CreationTimestamp: metav1.NewTime(now.Add(-time.Minute)),
+			},
+			Spec: &kargoapi.PromotionSpec{Priority: 10},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "low-priority",
+				CreationTimestamp: metav1.NewTime(now.Add(-5 * time.Minute)),
+			},
+			Spec: &kargoapi.PromotionSpec{Priority: -1},
+		},
+	} {
+		require.NoError(t, pq.Push(promo))
+	}
+
+	// Objects should be prioritized by priority first, then by creation time
+	for _, expected := range []string{
+		"older-high-priority",
+		"newest-high-priority",
+		"oldest-default-priority",
+		"low-priority",
+	} {
+		object := pq.Pop()
+		require.NotNil(t, object)
+		require.Equal(t, expected, object.GetName())
+	}
+}
+
 func TestPromotionSync(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -190,8 +260,12 @@ func TestPromotionSync(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
+			scheme := k8sruntime.NewScheme()
+			require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(scheme))
 			r := reconciler{
+				kargoClient:        fake.NewClientBuilder().WithScheme(scheme).Build(),
 				promoQueuesByStage: testCase.pqs,
+				stageQueue:         workqueue.NewDelayingQueue(),
 			}
 			status := r.syncPromo(context.Background(), testCase.promo)
 			testCase.assertions(
@@ -202,7 +276,120 @@ func TestPromotionSync(t *testing.T) {
 	}
 }
 
-func TestSerializedSync(t *testing.T) {
+func TestSupersedePromos(t *testing.T) {
+	now := time.Now()
+	newPromo := func(name string, age time.Duration) *kargoapi.Promotion {
+		return &kargoapi.Promotion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "fake-namespace",
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Spec: &kargoapi.PromotionSpec{
+				Stage:   "fake-stage",
+				Freight: "fake-freight",
+			},
+			Status: kargoapi.PromotionStatus{
+				Phase: kargoapi.PromotionPhasePending,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		supersede  bool
+		assertions func(client.Client, runtime.PriorityQueue)
+	}{
+		{
+			name:      "superseding disabled",
+			supersede: false,
+			assertions: func(c client.Client, pq runtime.PriorityQueue) {
+				require.Equal(t, 3, pq.Depth())
+				for _, name := range []string{"older-promo", "running-promo"} {
+					promo := &kargoapi.Promotion{}
+					require.NoError(
+						t,
+						c.Get(
+							context.Background(),
+							types.NamespacedName{Namespace: "fake-namespace", Name: name},
+							promo,
+						),
+					)
+					require.Equal(t, kargoapi.PromotionPhasePending, promo.Status.Phase)
+				}
+			},
+		},
+
+		{
+			name:      "superseding enabled",
+			supersede: true,
+			assertions: func(c client.Client, pq runtime.PriorityQueue) {
+				// Only the older, queued Promotion should have been removed
+				require.Equal(t, 2, pq.Depth())
+				for name, phase := range map[string]kargoapi.PromotionPhase{
+					"older-promo":   kargoapi.PromotionPhaseSuperseded,
+					"running-promo": kargoapi.PromotionPhasePending,
+					"newer-promo":   kargoapi.PromotionPhasePending,
+				} {
+					promo := &kargoapi.Promotion{}
+					require.NoError(
+						t,
+						c.Get(
+							context.Background(),
+							types.NamespacedName{Namespace: "fake-namespace", Name: name},
+							promo,
+						),
+					)
+					require.Equal(t, phase, promo.Status.Phase, name)
+					if phase == kargoapi.PromotionPhaseSuperseded {
+						require.Contains(t, promo.Status.Message, "fake-promo")
+					}
+				}
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			promo := newPromo("fake-promo", time.Minute)
+			olderPromo := newPromo("older-promo", 2*time.Minute)
+			runningPromo := newPromo("running-promo", 3*time.Minute)
+			newerPromo := newPromo("newer-promo", 0)
+
+			scheme := k8sruntime.NewScheme()
+			require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(scheme))
+			kargoClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				promo,
+				olderPromo,
+				runningPromo,
+				newerPromo,
+				&kargoapi.PromotionPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "fake-policy",
+						Namespace: "fake-namespace",
+					},
+					Stage:     "fake-stage",
+					Supersede: testCase.supersede,
+				},
+			).Build()
+
+			pq := newPromotionsQueue()
+			for _, p := range []*kargoapi.Promotion{promo, olderPromo, newerPromo} {
+				require.NoError(t, pq.Push(p))
+			}
+
+			r := reconciler{
+				kargoClient: kargoClient,
+				activePromoCancelFns: map[types.NamespacedName]context.CancelFunc{
+					{Namespace: "fake-namespace", Name: "running-promo"}: func() {},
+				},
+			}
+			r.supersedePromos(context.Background(), promo, pq)
+			testCase.assertions(kargoClient, pq)
+		})
+	}
+}
+
+func TestRunWorkers(t *testing.T) {
 	promo := &kargoapi.Promotion{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "fake-promo",
@@ -231,16 +418,53 @@ func TestSerializedSync(t *testing.T) {
 		promoQueuesByStage: map[types.NamespacedName]runtime.PriorityQueue{
 			{Namespace: "fake-namespace", Name: "fake-stage"}: pq,
 		},
-		promoteFn: func(context.Context, v1alpha1.Promotion) error {
+		stageQueue:           workqueue.NewDelayingQueue(),
+		activePromoCancelFns: map[types.NamespacedName]context.CancelFunc{},
+		leaseHolderIdentity:  "fake-controller",
+		promoteFn: func(ctx context.Context, promo v1alpha1.Promotion) error {
+			// While the Promotion is being executed, it should be Running and
+			// leased to this controller
+			runningPromo := &kargoapi.Promotion{}
+			require.NoError(
+				t,
+				kargoClient.Get(
+					ctx,
+					types.NamespacedName{
+						Namespace: promo.Namespace,
+						Name:      promo.Name,
+					},
+					runningPromo,
+				),
+			)
+			require.Equal(t, kargoapi.PromotionPhaseRunning, runningPromo.Status.Phase)
+			require.NotNil(t, runningPromo.Status.Lease)
+			require.Equal(t, "fake-controller", runningPromo.Status.Lease.HolderIdentity)
+			// Steps recorded by promotion mechanisms should end up in the
+			// Promotion's status
+			recorder := promotion.StepRecorderFromContext(ctx)
+			require.NotNil(t, recorder)
+			recorder.Record(kargoapi.PromotionStep{
+				Mechanism: "fake-mechanism",
+				Phase:     kargoapi.PromotionStepPhaseSucceeded,
+			})
 			return nil
 		},
+		isPromotionPermittedFn: func(
+			context.Context,
+			*kargoapi.Promotion,
+		) (bool, string, error) {
+			return true, "", nil
+		},
 	}
 
 	// Force the infinite loop under test to shut down after 3 seconds. This
 	// should be plenty of time to handle the one Promotion we've given it.
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
-	r.serializedSync(ctx, time.Second)
+	r.stageQueue.Add(
+		types.NamespacedName{Namespace: "fake-namespace", Name: "fake-stage"},
+	)
+	r.runWorkers(ctx, 1)
 
 	// When we're done, the queue should be empty and the Promotion should be
 	// complete.
@@ -255,6 +479,714 @@ func TestSerializedSync(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, promo)
 	require.Equal(t, kargoapi.PromotionPhaseSucceeded, promo.Status.Phase)
+	require.Nil(t, promo.Status.Lease)
+	require.Len(t, promo.Status.Attempts, 1)
+	require.Equal(
+		t,
+		[]kargoapi.PromotionStep{{
+			Mechanism: "fake-mechanism",
+			Phase:     kargoapi.PromotionStepPhaseSucceeded,
+		}},
+		promo.Status.Steps,
+	)
+}
+
+func TestRunWorkersNotPermitted(t *testing.T) {
+	promo := &kargoapi.Promotion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-promo",
+			Namespace: "fake-namespace",
+		},
+		Spec: &kargoapi.PromotionSpec{
+			Stage:   "fake-stage",
+			Freight: "fake-freight",
+		},
+		Status: kargoapi.PromotionStatus{
+			Phase: kargoapi.PromotionPhasePending,
+		},
+	}
+
+	scheme := k8sruntime.NewScheme()
+	require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(scheme))
+	kargoClient := fake.NewClientBuilder().
+		WithScheme(scheme).WithObjects(promo).Build()
+
+	pq := newPromotionsQueue()
+	err := pq.Push(promo)
+	require.NoError(t, err)
+
+	r := reconciler{
+		kargoClient: kargoClient,
+		promoQueuesByStage: map[types.NamespacedName]runtime.PriorityQueue{
+			{Namespace: "fake-namespace", Name: "fake-stage"}: pq,
+		},
+		stageQueue:           workqueue.NewDelayingQueue(),
+		activePromoCancelFns: map[types.NamespacedName]context.CancelFunc{},
+		promoteFn: func(context.Context, v1alpha1.Promotion) error {
+			require.FailNow(t, "Promotion should not have been executed")
+			return nil
+		},
+		isPromotionPermittedFn: func(
+			context.Context,
+			*kargoapi.Promotion,
+		) (bool, string, error) {
+			return false, "fake reason", nil
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	r.stageQueue.Add(
+		types.NamespacedName{Namespace: "fake-namespace", Name: "fake-stage"},
+	)
+	r.runWorkers(ctx, 1)
+
+	// When we're done, the Promotion should still be queued and Pending, with
+	// the reason recorded in its status.
+	require.Equal(t, 1, pq.Depth())
+	promo, err = r.getPromo(
+		ctx,
+		types.NamespacedName{
+			Namespace: "fake-namespace",
+			Name:      "fake-promo",
+		},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, promo)
+	require.Equal(t, kargoapi.PromotionPhasePending, promo.Status.Phase)
+	require.Equal(t, "fake reason", promo.Status.Message)
+}
+
+func TestRunWorkersAborted(t *testing.T) {
+	promo := &kargoapi.Promotion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-promo",
+			Namespace: "fake-namespace",
+		},
+		Spec: &kargoapi.PromotionSpec{
+			Stage:   "fake-stage",
+			Freight: "fake-freight",
+		},
+		Status: kargoapi.PromotionStatus{
+			Phase: kargoapi.PromotionPhasePending,
+		},
+	}
+
+	scheme := k8sruntime.NewScheme()
+	require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(scheme))
+	kargoClient := fake.NewClientBuilder().
+		WithScheme(scheme).WithObjects(promo).Build()
+
+	pq := newPromotionsQueue()
+	err := pq.Push(promo)
+	require.NoError(t, err)
+
+	r := &reconciler{
+		kargoClient: kargoClient,
+		promoQueuesByStage: map[types.NamespacedName]runtime.PriorityQueue{
+			{Namespace: "fake-namespace", Name: "fake-stage"}: pq,
+		},
+		stageQueue:           workqueue.NewDelayingQueue(),
+		activePromoCancelFns: map[types.NamespacedName]context.CancelFunc{},
+		isPromotionPermittedFn: func(
+			context.Context,
+			*kargoapi.Promotion,
+		) (bool, string, error) {
+			return true, "", nil
+		},
+	}
+	r.promoteFn = func(ctx context.Context, promo v1alpha1.Promotion) error {
+		// Simulate an abort request arriving while the Promotion is executing
+		status := r.abortPromo(ctx, &promo)
+		require.Equal(t, kargoapi.PromotionPhaseRunning, status.Phase)
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	r.stageQueue.Add(
+		types.NamespacedName{Namespace: "fake-namespace", Name: "fake-stage"},
+	)
+	r.runWorkers(ctx, 1)
+
+	require.Equal(t, 0, pq.Depth())
+	require.Empty(t, r.activePromoCancelFns)
+	promo, err = r.getPromo(
+		ctx,
+		types.NamespacedName{
+			Namespace: "fake-namespace",
+			Name:      "fake-promo",
+		},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, promo)
+	require.Equal(t, kargoapi.PromotionPhaseAborted, promo.Status.Phase)
+	require.Empty(t, promo.Status.Error)
+	require.Contains(t, promo.Status.Message, "aborted while it was being executed")
+}
+
+func TestRunWorkersConcurrency(t *testing.T) {
+	scheme := k8sruntime.NewScheme()
+	require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(scheme))
+	kargoClientBuilder := fake.NewClientBuilder().WithScheme(scheme)
+
+	// Two Promotions for each of two Stages
+	pqs := map[types.NamespacedName]runtime.PriorityQueue{}
+	for _, stage := range []string{"fake-stage", "another-fake-stage"} {
+		pq := newPromotionsQueue()
+		for i := 0; i < 2; i++ {
+			promo := &kargoapi.Promotion{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s-promo-%d", stage, i),
+					Namespace: "fake-namespace",
+				},
+				Spec: &kargoapi.PromotionSpec{
+					Stage:   stage,
+					Freight: "fake-freight",
+				},
+				Status: kargoapi.PromotionStatus{
+					Phase: kargoapi.PromotionPhasePending,
+				},
+			}
+			kargoClientBuilder.WithObjects(promo)
+			require.NoError(t, pq.Push(promo))
+		}
+		pqs[types.NamespacedName{Namespace: "fake-namespace", Name: stage}] = pq
+	}
+
+	var mu sync.Mutex
+	activeByStage := map[string]int{}
+	var active, maxActive, maxActiveForOneStage int
+	r := reconciler{
+		kargoClient:          kargoClientBuilder.Build(),
+		promoQueuesByStage:   pqs,
+		stageQueue:           workqueue.NewDelayingQueue(),
+		activePromoCancelFns: map[types.NamespacedName]context.CancelFunc{},
+		promoteFn: func(_ context.Context, promo v1alpha1.Promotion) error {
+			mu.Lock()
+			active++
+			activeByStage[promo.Spec.Stage]++
+			if active > maxActive {
+				maxActive = active
+			}
+			if activeByStage[promo.Spec.Stage] > maxActiveForOneStage {
+				maxActiveForOneStage = activeByStage[promo.Spec.Stage]
+			}
+			mu.Unlock()
+			time.Sleep(200 * time.Millisecond)
+			mu.Lock()
+			active--
+			activeByStage[promo.Spec.Stage]--
+			mu.Unlock()
+			return nil
+		},
+		isPromotionPermittedFn: func(
+			context.Context,
+			*kargoapi.Promotion,
+		) (bool, string, error) {
+			return true, "", nil
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	for stage := range pqs {
+		// Dispatch each Stage more than once to make sure that doesn't result in
+		// concurrent execution of Promotions for the same Stage.
+		r.stageQueue.Add(stage)
+		r.stageQueue.Add(stage)
+	}
+	r.runWorkers(ctx, 4)
+
+	for _, pq := range pqs {
+		require.Equal(t, 0, pq.Depth())
+	}
+	// Promotions for different Stages ran in parallel...
+	require.Equal(t, 2, maxActive)
+	// ...but Promotions for the same Stage did not
+	require.Equal(t, 1, maxActiveForOneStage)
+}
+
+func TestRecoverPromo(t *testing.T) {
+	testCases := []struct {
+		name       string
+		renewTime  time.Time
+		retry      *kargoapi.PromotionRetry
+		assertions func(*kargoapi.Promotion, runtime.PriorityQueue, time.Duration)
+	}{
+		{
+			name:      "lease not expired",
+			renewTime: time.Now(),
+			assertions: func(
+				promo *kargoapi.Promotion,
+				pq runtime.PriorityQueue,
+				requeueAfter time.Duration,
+			) {
+				require.Equal(t, kargoapi.PromotionPhaseRunning, promo.Status.Phase)
+				require.NotNil(t, promo.Status.Lease)
+				require.Equal(t, 1, pq.Depth())
+				require.Greater(t, requeueAfter, time.Duration(0))
+				require.LessOrEqual(t, requeueAfter, promotionLeaseDuration)
+			},
+		},
+
+		{
+			name:      "lease expired; no attempts remaining",
+			renewTime: time.Now().Add(-2 * promotionLeaseDuration),
+			assertions: func(
+				promo *kargoapi.Promotion,
+				pq runtime.PriorityQueue,
+				requeueAfter time.Duration,
+			) {
+				require.Equal(t, kargoapi.PromotionPhaseErrored, promo.Status.Phase)
+				require.Contains(t, promo.Status.Error, "interrupted")
+				require.Nil(t, promo.Status.Lease)
+				require.Len(t, promo.Status.Attempts, 1)
+				require.NotEmpty(t, promo.Status.Attempts[0].Error)
+				require.Equal(t, 0, pq.Depth())
+				require.Zero(t, requeueAfter)
+			},
+		},
+
+		{
+			name:      "lease expired; attempts remaining",
+			renewTime: time.Now().Add(-2 * promotionLeaseDuration),
+			retry: &kargoapi.PromotionRetry{
+				MaxAttempts: 2,
+				Backoff:     &metav1.Duration{Duration: time.Minute},
+			},
+			assertions: func(
+				promo *kargoapi.Promotion,
+				pq runtime.PriorityQueue,
+				requeueAfter time.Duration,
+			) {
+				require.Equal(t, kargoapi.PromotionPhasePending, promo.Status.Phase)
+				require.Empty(t, promo.Status.Error)
+				require.Nil(t, promo.Status.Lease)
+				require.NotNil(t, promo.Status.NextAttemptAt)
+				require.Len(t, promo.Status.Attempts, 1)
+				require.NotEmpty(t, promo.Status.Attempts[0].Error)
+				require.Equal(t, 1, pq.Depth())
+				require.Greater(t, requeueAfter, time.Duration(0))
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			renewTime := metav1.NewTime(testCase.renewTime)
+			startedAt := metav1.NewTime(testCase.renewTime.Add(-time.Minute))
+			promo := &kargoapi.Promotion{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fake-promo",
+					Namespace: "fake-namespace",
+				},
+				Spec: &kargoapi.PromotionSpec{
+					Stage:   "fake-stage",
+					Freight: "fake-freight",
+				},
+				Status: kargoapi.PromotionStatus{
+					Phase: kargoapi.PromotionPhaseRunning,
+					Attempts: []kargoapi.PromotionAttempt{
+						{StartedAt: &startedAt},
+					},
+					Lease: &kargoapi.PromotionLease{
+						HolderIdentity: "another-fake-controller",
+						RenewTime:      &renewTime,
+					},
+				},
+			}
+			scheme := k8sruntime.NewScheme()
+			require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(scheme))
+			kargoClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				promo,
+				&kargoapi.PromotionPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "fake-policy",
+						Namespace: "fake-namespace",
+					},
+					Stage: "fake-stage",
+					Retry: testCase.retry,
+				},
+			).Build()
+
+			r := reconciler{
+				kargoClient: kargoClient,
+			}
+			pq := newPromotionsQueue()
+			requeueAfter := r.recoverPromo(context.Background(), promo, pq)
+
+			promo, err := r.getPromo(
+				context.Background(),
+				types.NamespacedName{
+					Namespace: "fake-namespace",
+					Name:      "fake-promo",
+				},
+			)
+			require.NoError(t, err)
+			require.NotNil(t, promo)
+			testCase.assertions(promo, pq, requeueAfter)
+		})
+	}
+}
+
+func TestAbortPromo(t *testing.T) {
+	promo := &kargoapi.Promotion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-promo",
+			Namespace: "fake-namespace",
+		},
+		Spec: &kargoapi.PromotionSpec{
+			Stage: "fake-stage",
+		},
+		Status: kargoapi.PromotionStatus{
+			Phase: kargoapi.PromotionPhasePending,
+		},
+	}
+	pq := newPromotionsQueue()
+	require.NoError(t, pq.Push(promo))
+	r := &reconciler{
+		promoQueuesByStage: map[types.NamespacedName]runtime.PriorityQueue{
+			{Namespace: "fake-namespace", Name: "fake-stage"}: pq,
+		},
+		activePromoCancelFns: map[types.NamespacedName]context.CancelFunc{},
+	}
+	status := r.abortPromo(context.Background(), promo)
+	require.Equal(t, kargoapi.PromotionPhaseAborted, status.Phase)
+	require.Equal(t, 0, pq.Depth())
+}
+
+func TestRunWorkersRetry(t *testing.T) {
+	testCases := []struct {
+		name       string
+		policy     *kargoapi.PromotionPolicy
+		promoteFn  func(int) func(context.Context, v1alpha1.Promotion) error
+		assertions func(*kargoapi.Promotion, int)
+	}{
+		{
+			name: "succeeds on retry",
+			policy: &kargoapi.PromotionPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fake-policy",
+					Namespace: "fake-namespace",
+				},
+				Stage: "fake-stage",
+				Retry: &kargoapi.PromotionRetry{
+					MaxAttempts: 3,
+					Backoff:     &metav1.Duration{Duration: time.Nanosecond},
+				},
+			},
+			promoteFn: func(attempt int) func(context.Context, v1alpha1.Promotion) error {
+				return func(context.Context, v1alpha1.Promotion) error {
+					if attempt == 1 {
+						return errors.New("something went wrong")
+					}
+					return nil
+				}
+			},
+			assertions: func(promo *kargoapi.Promotion, pqDepth int) {
+				require.Equal(t, 0, pqDepth)
+				require.Equal(t, kargoapi.PromotionPhaseSucceeded, promo.Status.Phase)
+				require.Empty(t, promo.Status.Error)
+				require.Nil(t, promo.Status.NextAttemptAt)
+				require.Len(t, promo.Status.Attempts, 2)
+				require.Equal(t, "something went wrong", promo.Status.Attempts[0].Error)
+				require.NotNil(t, promo.Status.Attempts[0].StartedAt)
+				require.Empty(t, promo.Status.Attempts[1].Error)
+			},
+		},
+
+		{
+			name: "timed out",
+			policy: &kargoapi.PromotionPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fake-policy",
+					Namespace: "fake-namespace",
+				},
+				Stage: "fake-stage",
+				Retry: &kargoapi.PromotionRetry{
+					MaxAttempts: 3,
+				},
+				Timeout: &metav1.Duration{Duration: time.Millisecond},
+			},
+			promoteFn: func(int) func(context.Context, v1alpha1.Promotion) error {
+				return func(ctx context.Context, _ v1alpha1.Promotion) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+			},
+			assertions: func(promo *kargoapi.Promotion, pqDepth int) {
+				require.Equal(t, 0, pqDepth)
+				require.Equal(t, kargoapi.PromotionPhaseErrored, promo.Status.Phase)
+				require.Contains(t, promo.Status.Error, "timed out")
+				// A timed out Promotion is not retried
+				require.Len(t, promo.Status.Attempts, 1)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			promo := &kargoapi.Promotion{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fake-promo",
+					Namespace: "fake-namespace",
+				},
+				Spec: &kargoapi.PromotionSpec{
+					Stage:   "fake-stage",
+					Freight: "fake-freight",
+				},
+				Status: kargoapi.PromotionStatus{
+					Phase: kargoapi.PromotionPhasePending,
+				},
+			}
+
+			scheme := k8sruntime.NewScheme()
+			require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(scheme))
+			kargoClient := fake.NewClientBuilder().
+				WithScheme(scheme).WithObjects(promo, testCase.policy).Build()
+
+			pq := newPromotionsQueue()
+			err := pq.Push(promo)
+			require.NoError(t, err)
+
+			var attempts int
+			r := reconciler{
+				kargoClient: kargoClient,
+				promoQueuesByStage: map[types.NamespacedName]runtime.PriorityQueue{
+					{Namespace: "fake-namespace", Name: "fake-stage"}: pq,
+				},
+				stageQueue:           workqueue.NewDelayingQueue(),
+				activePromoCancelFns: map[types.NamespacedName]context.CancelFunc{},
+				promoteFn: func(ctx context.Context, promo v1alpha1.Promotion) error {
+					attempts++
+					return testCase.promoteFn(attempts)(ctx, promo)
+				},
+				isPromotionPermittedFn: func(
+					context.Context,
+					*kargoapi.Promotion,
+				) (bool, string, error) {
+					return true, "", nil
+				},
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			r.stageQueue.Add(
+				types.NamespacedName{Namespace: "fake-namespace", Name: "fake-stage"},
+			)
+			r.runWorkers(ctx, 1)
+
+			promo, err = r.getPromo(
+				ctx,
+				types.NamespacedName{
+					Namespace: "fake-namespace",
+					Name:      "fake-promo",
+				},
+			)
+			require.NoError(t, err)
+			require.NotNil(t, promo)
+			testCase.assertions(promo, pq.Depth())
+		})
+	}
+}
+
+func TestExecuteNextPromoWaiting(t *testing.T) {
+	promoKey := types.NamespacedName{
+		Namespace: "fake-namespace",
+		Name:      "fake-promo",
+	}
+	promo := &kargoapi.Promotion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      promoKey.Name,
+			Namespace: promoKey.Namespace,
+		},
+		Spec: &kargoapi.PromotionSpec{
+			Stage:   "fake-stage",
+			Freight: "fake-freight",
+		},
+		Status: kargoapi.PromotionStatus{
+			Phase: kargoapi.PromotionPhasePending,
+		},
+	}
+
+	scheme := k8sruntime.NewScheme()
+	require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(scheme))
+	kargoClient := fake.NewClientBuilder().
+		WithScheme(scheme).WithObjects(promo).Build()
+
+	pq := newPromotionsQueue()
+	require.NoError(t, pq.Push(promo))
+
+	var merged bool
+	r := reconciler{
+		kargoClient:          kargoClient,
+		activePromoCancelFns: map[types.NamespacedName]context.CancelFunc{},
+		promoteFn: func(context.Context, v1alpha1.Promotion) error {
+			if !merged {
+				return &promotion.PullRequestOpenError{URL: "fake-url"}
+			}
+			return nil
+		},
+		isPromotionPermittedFn: func(
+			context.Context,
+			*kargoapi.Promotion,
+		) (bool, string, error) {
+			return true, "", nil
+		},
+	}
+	ctx := context.Background()
+
+	// The pull request is open, so the Promotion waits for it to be merged
+	r.executeNextPromo(ctx, pq)
+	promo, err := r.getPromo(ctx, promoKey)
+	require.NoError(t, err)
+	require.Equal(t, kargoapi.PromotionPhaseWaiting, promo.Status.Phase)
+	require.Contains(t, promo.Status.Message, "fake-url")
+	require.Empty(t, promo.Status.Error)
+	require.NotNil(t, promo.Status.NextAttemptAt)
+	require.Len(t, promo.Status.Attempts, 1)
+	require.Equal(t, 1, pq.Depth())
+
+	// The Promotion isn't checked again until it is due to be
+	requeueAfter := r.executeNextPromo(ctx, pq)
+	require.Greater(t, requeueAfter, time.Duration(0))
+	require.Equal(t, 1, pq.Depth())
+
+	// Once the pull request is merged, the Promotion completes without the
+	// check having counted as another attempt
+	merged = true
+	err = kubeclient.PatchStatus(ctx, kargoClient, promo, func(status *kargoapi.PromotionStatus) {
+		status.NextAttemptAt = &metav1.Time{Time: time.Now().Add(-time.Second)}
+	})
+	require.NoError(t, err)
+	r.executeNextPromo(ctx, pq)
+	promo, err = r.getPromo(ctx, promoKey)
+	require.NoError(t, err)
+	require.Equal(t, kargoapi.PromotionPhaseSucceeded, promo.Status.Phase)
+	require.Nil(t, promo.Status.NextAttemptAt)
+	require.Len(t, promo.Status.Attempts, 1)
+	require.Equal(t, 0, pq.Depth())
+}
+
+func TestGetRetryBackoff(t *testing.T) {
+	testCases := []struct {
+		name     string
+		retry    *kargoapi.PromotionRetry
+		failures int
+		expected time.Duration
+	}{
+		{
+			name:     "nil retry policy",
+			failures: 1,
+			expected: defaultRetryBackoff,
+		},
+		{
+			name: "first failure",
+			retry: &kargoapi.PromotionRetry{
+				Backoff: &metav1.Duration{Duration: time.Second},
+			},
+			failures: 1,
+			expected: time.Second,
+		},
+		{
+			name: "third failure",
+			retry: &kargoapi.PromotionRetry{
+				Backoff: &metav1.Duration{Duration: time.Second},
+			},
+			failures: 3,
+			expected: 4 * time.Second,
+		},
+		{
+			name: "capped at max backoff",
+			retry: &kargoapi.PromotionRetry{
+				Backoff:    &metav1.Duration{Duration: time.Second},
+				MaxBackoff: &metav1.Duration{Duration: 5 * time.Second},
+			},
+			failures: 10,
+			expected: 5 * time.Second,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expected,
+				getRetryBackoff(testCase.retry, testCase.failures),
+			)
+		})
+	}
+}
+
+func TestGetMaxAttempts(t *testing.T) {
+	require.Equal(t, 1, getMaxAttempts(nil))
+	require.Equal(t, 1, getMaxAttempts(&kargoapi.PromotionRetry{}))
+	require.Equal(t, 3, getMaxAttempts(&kargoapi.PromotionRetry{MaxAttempts: 3}))
+}
+
+func TestIsPromotionPermitted(t *testing.T) {
+	scheme := k8sruntime.NewScheme()
+	require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(scheme))
+
+	promo := &kargoapi.Promotion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-promo",
+			Namespace: "fake-namespace",
+		},
+		Spec: &kargoapi.PromotionSpec{
+			Stage: "fake-stage",
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		client     client.Client
+		assertions func(bool, string, error)
+	}{
+		{
+			name:   "no PromotionPolicy",
+			client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+			assertions: func(permitted bool, reason string, err error) {
+				require.NoError(t, err)
+				require.True(t, permitted)
+				require.Empty(t, reason)
+			},
+		},
+
+		{
+			name: "PromotionPolicy blackout active",
+			client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&kargoapi.PromotionPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "fake-policy",
+						Namespace: "fake-namespace",
+					},
+					Stage: "fake-stage",
+					Blackouts: []kargoapi.PromotionWindow{
+						{
+							// Always active
+							Schedule: "* * * * *",
+							Duration: metav1.Duration{Duration: time.Hour},
+						},
+					},
+				},
+			).Build(),
+			assertions: func(permitted bool, reason string, err error) {
+				require.NoError(t, err)
+				require.False(t, permitted)
+				require.Contains(t, reason, "blocked by blackout")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := reconciler{
+				kargoClient: testCase.client,
+			}
+			testCase.assertions(
+				r.isPromotionPermitted(context.Background(), promo),
+			)
+		})
+	}
 }
 
 func TestGetPromo(t *testing.T) {
@@ -19,6 +19,10 @@ type PriorityQueue interface {
 	Pop() client.Object
 	// Depth returns the depth of the PriorityQueue.
 	Depth() int
+	// Remove removes any client.Object with the same namespace and name as the
+	// provided client.Object from the priority queue. It returns true if such a
+	// client.Object was found and removed and false otherwise.
+	Remove(client.Object) bool
 }
 
 // ObjectCompareFn is the signature for any function that can compare the
@@ -106,6 +110,22 @@ func (p *priorityQueue) Depth() int {
 	return p.internalQueue.Len()
 }
 
+func (p *priorityQueue) Remove(item client.Object) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if item == nil {
+		return false
+	}
+	for i, object := range p.internalQueue.objects {
+		if object.GetNamespace() == item.GetNamespace() &&
+			object.GetName() == item.GetName() {
+			heap.Remove(p.internalQueue, i)
+			return true
+		}
+	}
+	return false
+}
+
 // internalPriorityQueue is the underlying data structure for priorityQueue. It
 // implements heap.Interface, which allows priorityQueue to offload ordering of
 // its client.Objects by priority to the heap package.
@@ -88,3 +88,51 @@ func TestPriorityQueue(t *testing.T) {
 		lastName = object.GetName()
 	}
 }
+
+func TestPriorityQueueRemove(t *testing.T) {
+	pq, err := NewPriorityQueue(
+		func(lhs client.Object, rhs client.Object) bool {
+			return lhs.GetName() < rhs.GetName()
+		},
+	)
+	require.NoError(t, err)
+	for _, name := range []string{"a", "b", "c"} {
+		err = pq.Push(
+			&kargoapi.Promotion{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "fake-namespace",
+				},
+			},
+		)
+		require.NoError(t, err)
+	}
+
+	require.False(t, pq.Remove(nil))
+	require.False(
+		t,
+		pq.Remove(
+			&kargoapi.Promotion{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "b",
+					Namespace: "another-fake-namespace",
+				},
+			},
+		),
+	)
+	require.True(
+		t,
+		pq.Remove(
+			&kargoapi.Promotion{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "b",
+					Namespace: "fake-namespace",
+				},
+			},
+		),
+	)
+	require.Equal(t, 2, pq.Depth())
+	require.Equal(t, "a", pq.Pop().GetName())
+	require.Equal(t, "c", pq.Pop().GetName())
+	require.Nil(t, pq.Pop())
+}
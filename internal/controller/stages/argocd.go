@@ -12,7 +12,7 @@ import (
 
 func (r *reconciler) checkHealth(
 	ctx context.Context,
-	currentFreight *kargoapi.Freight,
+	currentFreight *kargoapi.SimpleFreight,
 	argoCDAppUpdates []kargoapi.ArgoCDAppUpdate,
 ) *kargoapi.Health {
 	if len(argoCDAppUpdates) == 0 {
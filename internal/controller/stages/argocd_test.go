@@ -16,7 +16,7 @@ import (
 func TestCheckHealth(t *testing.T) {
 	testCases := []struct {
 		name             string
-		freight          *kargoapi.Freight
+		freight          *kargoapi.SimpleFreight
 		argoCDAppUpdates []kargoapi.ArgoCDAppUpdate
 		getArgoCDAppFn   func(
 			context.Context,
@@ -235,7 +235,7 @@ func TestCheckHealth(t *testing.T) {
 
 		{
 			name: "Argo CD App not synced",
-			freight: &kargoapi.Freight{
+			freight: &kargoapi.SimpleFreight{
 				Commits: []kargoapi.GitCommit{
 					{
 						RepoURL: "fake-url",
@@ -298,7 +298,7 @@ func TestCheckHealth(t *testing.T) {
 
 		{
 			name: "Argo CD App healthy and synced",
-			freight: &kargoapi.Freight{
+			freight: &kargoapi.SimpleFreight{
 				Commits: []kargoapi.GitCommit{
 					{
 						RepoURL: "fake-url",
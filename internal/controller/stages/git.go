@@ -2,13 +2,15 @@ package stages
 
 import (
 	"context"
-	"strings"
 
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/akuity/bookkeeper/pkg/git"
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/discovery"
+	libGit "github.com/akuity/kargo/internal/git"
 	"github.com/akuity/kargo/internal/logging"
 )
 
@@ -18,93 +20,145 @@ func (r *reconciler) getLatestCommits(
 	subs []kargoapi.GitSubscription,
 ) ([]kargoapi.GitCommit, error) {
 	latestCommits := make([]kargoapi.GitCommit, len(subs))
+	// Subscriptions are satisfied concurrently so that one slow repo does not
+	// hold up the others
+	g, ctx := errgroup.WithContext(ctx)
 	for i, sub := range subs {
-		logger := logging.LoggerFromContext(ctx).WithField("repo", sub.RepoURL)
-		creds, ok, err :=
-			r.credentialsDB.Get(ctx, namespace, credentials.TypeGit, sub.RepoURL)
-		if err != nil {
-			return nil, errors.Wrapf(
-				err,
-				"error obtaining credentials for git repo %q",
-				sub.RepoURL,
-			)
-		}
-		var repoCreds *git.RepoCredentials
-		if ok {
-			repoCreds = &git.RepoCredentials{
-				Username:      creds.Username,
-				Password:      creds.Password,
-				SSHPrivateKey: creds.SSHPrivateKey,
+		i, sub := i, sub
+		g.Go(func() error {
+			commit, err := r.getLatestCommit(ctx, namespace, sub)
+			if err != nil {
+				return err
 			}
-			logger.Debug("obtained credentials for git repo")
-		} else {
-			logger.Debug("found no credentials for git repo")
-		}
+			latestCommits[i] = *commit
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return latestCommits, nil
+}
 
-		gm, err := r.getLatestCommitMetaFn(ctx, sub.RepoURL, sub.Branch, repoCreds)
-		if err != nil {
-			return nil, errors.Wrapf(
-				err,
-				"error determining latest commit ID of git repo %q",
-				sub.RepoURL,
-			)
-		}
-		logger.WithField("commit", gm.Commit).
-			Debug("found latest commit from repo")
-		latestCommits[i] = kargoapi.GitCommit{
-			RepoURL: sub.RepoURL,
-			ID:      gm.Commit,
-			Branch:  sub.Branch,
-			Message: gm.Message,
+func (r *reconciler) getLatestCommit(
+	ctx context.Context,
+	namespace string,
+	sub kargoapi.GitSubscription,
+) (*kargoapi.GitCommit, error) {
+	logger := logging.LoggerFromContext(ctx).WithField("repo", sub.RepoURL)
+	creds, ok, err :=
+		r.credentialsDB.Get(ctx, namespace, credentials.TypeGit, sub.RepoURL)
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error obtaining credentials for git repo %q",
+			sub.RepoURL,
+		)
+	}
+	var repoCreds *git.RepoCredentials
+	if ok {
+		repoCreds = &git.RepoCredentials{
+			Username:      creds.Username,
+			Password:      creds.Password,
+			SSHPrivateKey: creds.SSHPrivateKey,
 		}
+		logger.Debug("obtained credentials for git repo")
+	} else {
+		logger.Debug("found no credentials for git repo")
 	}
-	return latestCommits, nil
+
+	// Stages subscribing to the same repo in the same way, with the same
+	// credentials, share the result
+	gm, err := discovery.Discover(
+		ctx,
+		r.discovery,
+		discovery.NewRequest(
+			discovery.HostOf(sub.RepoURL),
+			"git",
+			sub,
+			repoCreds,
+		),
+		func(ctx context.Context) (*gitMeta, error) {
+			return r.getLatestCommitMetaFn(ctx, sub, repoCreds)
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error determining latest commit ID of git repo %q",
+			sub.RepoURL,
+		)
+	}
+	logger.WithField("commit", gm.Commit).WithField("tag", gm.Tag).
+		Debug("found latest commit from repo")
+	return &kargoapi.GitCommit{
+		RepoURL: sub.RepoURL,
+		ID:      gm.Commit,
+		Branch:  sub.Branch,
+		Tag:     gm.Tag,
+		Message: gm.Message,
+		Author:  gm.Author,
+	}, nil
 }
 
-func getLatestCommitMeta(
+func (r *reconciler) getLatestCommitMeta(
 	ctx context.Context,
-	repoURL string,
-	branch string,
+	sub kargoapi.GitSubscription,
 	creds *git.RepoCredentials,
 ) (*gitMeta, error) {
-	logger := logging.LoggerFromContext(ctx).WithField("repo", repoURL)
 	if creds == nil {
 		creds = &git.RepoCredentials{}
 	}
-	repo, err := git.Clone(repoURL, *creds)
-	if err != nil {
-		return nil, errors.Wrapf(err, "error cloning git repo %q", repoURL)
-
-	}
-	if branch != "" {
-		if err = repo.Checkout(branch); err != nil {
+	var commit *libGit.Commit
+	var err error
+	switch sub.CommitSelectionStrategy {
+	case kargoapi.CommitSelectionStrategyNewestFromBranch, "":
+		// Resolving the branch's head this way is far cheaper than cloning the
+		// repository, especially when the repository is large.
+		commit, err = r.gitCache.GetLatestCommit(
+			ctx,
+			sub.RepoURL,
+			sub.Branch,
+			libGit.CommitFilters{
+				IncludePaths:   sub.IncludePaths,
+				ExcludePaths:   sub.ExcludePaths,
+				IgnoreMessages: sub.IgnoreMessages,
+				IgnoreAuthors:  sub.IgnoreAuthors,
+			},
+			*creds,
+		)
+		if err != nil {
 			return nil, errors.Wrapf(
 				err,
-				"error checking out branch %q from git repo",
-				repoURL,
+				"error determining latest commit of git repo %q (branch: %q)",
+				sub.RepoURL,
+				sub.Branch,
 			)
 		}
-	}
-	var gm gitMeta
-	gm.Commit, err = repo.LastCommitID()
-	if err != nil {
-		return nil, errors.Wrapf(
-			err,
-			"error determining last commit ID from git repo %q (branch: %q)",
-			repoURL,
-			branch,
+	default:
+		commit, err = r.gitCache.GetLatestTaggedCommit(
+			ctx,
+			sub.RepoURL,
+			libGit.TagSelectionOptions{
+				Strategy:         libGit.TagSelectionStrategy(sub.CommitSelectionStrategy),
+				SemverConstraint: sub.SemverConstraint,
+				AllowTags:        sub.AllowTags,
+				IgnoreTags:       sub.IgnoreTags,
+			},
+			*creds,
 		)
+		if err != nil {
+			return nil, errors.Wrapf(
+				err,
+				"error determining latest tagged commit of git repo %q",
+				sub.RepoURL,
+			)
+		}
 	}
-	msg, err := repo.CommitMessage(gm.Commit)
-	// Since we currently store commit messages in Stage status, we only capture
-	// the first line of the commit message for brevity
-	gm.Message = strings.Split(strings.TrimSpace(msg), "\n")[0]
-	if err != nil {
-		// This is best effort, so just log the error
-		logger.Warnf("failed to get message from commit %q: %v", gm.Commit, err)
-	}
-	// TODO: support git author
-	//gm.Author, err = repo.Author(gm.Commit)
-
-	return &gm, nil
+	return &gitMeta{
+		Commit:  commit.ID,
+		Tag:     commit.Tag,
+		Message: commit.Message,
+		Author:  commit.Author,
+	}, nil
 }
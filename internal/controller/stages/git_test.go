@@ -11,6 +11,8 @@ import (
 	"github.com/akuity/bookkeeper/pkg/git"
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/discovery"
+	libGit "github.com/akuity/kargo/internal/git"
 )
 
 func TestGetLatestCommits(t *testing.T) {
@@ -19,8 +21,7 @@ func TestGetLatestCommits(t *testing.T) {
 		credentialsDB         credentials.Database
 		getLatestCommitMetaFn func(
 			context.Context,
-			string,
-			string,
+			kargoapi.GitSubscription,
 			*git.RepoCredentials,
 		) (*gitMeta, error)
 		assertions func(commits []kargoapi.GitCommit, err error)
@@ -64,8 +65,7 @@ func TestGetLatestCommits(t *testing.T) {
 			},
 			getLatestCommitMetaFn: func(
 				context.Context,
-				string,
-				string,
+				kargoapi.GitSubscription,
 				*git.RepoCredentials,
 			) (*gitMeta, error) {
 				return nil, errors.New("something went wrong")
@@ -96,11 +96,15 @@ func TestGetLatestCommits(t *testing.T) {
 			},
 			getLatestCommitMetaFn: func(
 				context.Context,
-				string,
-				string,
+				kargoapi.GitSubscription,
 				*git.RepoCredentials,
 			) (*gitMeta, error) {
-				return &gitMeta{Commit: "fake-commit", Message: "message"}, nil
+				return &gitMeta{
+					Commit:  "fake-commit",
+					Tag:     "fake-tag",
+					Message: "message",
+					Author:  "Fake Author <author@example.com>",
+				}, nil
 			},
 			assertions: func(commits []kargoapi.GitCommit, err error) {
 				require.NoError(t, err)
@@ -110,7 +114,9 @@ func TestGetLatestCommits(t *testing.T) {
 					kargoapi.GitCommit{
 						RepoURL: "fake-url",
 						ID:      "fake-commit",
+						Tag:     "fake-tag",
 						Message: "message",
+						Author:  "Fake Author <author@example.com>",
 					},
 					commits[0],
 				)
@@ -122,6 +128,7 @@ func TestGetLatestCommits(t *testing.T) {
 			r := reconciler{
 				credentialsDB:         testCase.credentialsDB,
 				getLatestCommitMetaFn: testCase.getLatestCommitMetaFn,
+				discovery:             discovery.NewService(discovery.Config{}),
 			}
 			testCase.assertions(
 				r.getLatestCommits(
@@ -141,44 +148,81 @@ func TestGetLatestCommits(t *testing.T) {
 func TestGetLatestCommitID(t *testing.T) {
 	testCases := []struct {
 		name       string
-		repoURL    string
-		branch     string
+		sub        kargoapi.GitSubscription
 		assertions func(*gitMeta, error)
 	}{
 		{
-			name:    "error cloning repo",
-			repoURL: "fake-url", // This should force a failure
+			name: "error listing refs",
+			sub: kargoapi.GitSubscription{
+				RepoURL: "fake-url", // This should force a failure
+			},
 			assertions: func(_ *gitMeta, err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "error cloning git repo")
+				require.Contains(t, err.Error(), "error listing refs of repo")
 			},
 		},
 
 		{
-			name:    "error checking out branch",
-			repoURL: "https://github.com/akuity/kargo.git",
-			branch:  "bogus", // This should force a failure
+			name: "branch not found",
+			sub: kargoapi.GitSubscription{
+				RepoURL: "https://github.com/akuity/kargo.git",
+				Branch:  "bogus", // This should force a failure
+			},
 			assertions: func(_ *gitMeta, err error) {
 				require.Error(t, err)
-				require.Contains(t, err.Error(), "error checking out branch")
+				require.Contains(t, err.Error(), `branch "bogus" not found`)
 			},
 		},
 
 		{
-			name:    "success",
-			repoURL: "https://github.com/akuity/kargo.git",
+			name: "success",
+			sub: kargoapi.GitSubscription{
+				RepoURL: "https://github.com/akuity/kargo.git",
+			},
 			assertions: func(gm *gitMeta, err error) {
 				require.NoError(t, err)
 				require.NotEmpty(t, gm.Commit)
+				require.Empty(t, gm.Tag)
 				require.NotEmpty(t, gm.Message)
+				require.NotEmpty(t, gm.Author)
 				require.Len(t, strings.Split(gm.Message, "\n"), 1)
 			},
 		},
+
+		{
+			name: "error selecting tag",
+			sub: kargoapi.GitSubscription{
+				RepoURL:                 "https://github.com/akuity/kargo.git",
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategySemVer,
+				SemverConstraint:        "bogus", // This should force a failure
+			},
+			assertions: func(_ *gitMeta, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error determining latest tagged commit")
+			},
+		},
+
+		{
+			name: "success with tag",
+			sub: kargoapi.GitSubscription{
+				RepoURL:                 "https://github.com/akuity/kargo.git",
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategySemVer,
+				SemverConstraint:        "~0.1.0",
+			},
+			assertions: func(gm *gitMeta, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, gm.Commit)
+				require.Contains(t, gm.Tag, "0.1.")
+			},
+		},
 	}
+	gitCache, err := libGit.NewCache(t.TempDir(), 0)
+	require.NoError(t, err)
+	r := &reconciler{gitCache: gitCache}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			testCase.assertions(
-				getLatestCommitMeta(context.TODO(), testCase.repoURL, testCase.branch, nil),
+				r.getLatestCommitMeta(context.TODO(), testCase.sub, nil),
 			)
 		})
 	}
@@ -5,9 +5,11 @@ import (
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/discovery"
 	"github.com/akuity/kargo/internal/helm"
 	"github.com/akuity/kargo/internal/logging"
 )
@@ -18,67 +20,101 @@ func (r *reconciler) getLatestCharts(
 	subs []kargoapi.ChartSubscription,
 ) ([]kargoapi.Chart, error) {
 	charts := make([]kargoapi.Chart, len(subs))
-
+	// Subscriptions are satisfied concurrently so that one slow registry does
+	// not hold up the others
+	g, ctx := errgroup.WithContext(ctx)
 	for i, sub := range subs {
-		logger := logging.LoggerFromContext(ctx).WithFields(log.Fields{
-			"registry": sub.RegistryURL,
-			"chart":    sub.Name,
+		i, sub := i, sub
+		g.Go(func() error {
+			chart, err := r.getLatestChart(ctx, namespace, sub)
+			if err != nil {
+				return err
+			}
+			charts[i] = *chart
+			return nil
 		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return charts, nil
+}
 
-		creds, ok, err :=
-			r.credentialsDB.Get(ctx, namespace, credentials.TypeHelm, sub.RegistryURL)
-		if err != nil {
-			return nil, errors.Wrapf(
-				err,
-				"error obtaining credentials for chart registry %q",
-				sub.RegistryURL,
-			)
-		}
-
-		var helmCreds *helm.Credentials
-		if ok {
-			helmCreds = &helm.Credentials{
-				Username: creds.Username,
-				Password: creds.Password,
-			}
-			logger.Debug("obtained credentials for chart repo")
-		} else {
-			logger.Debug("found no credentials for chart repo")
-		}
+func (r *reconciler) getLatestChart(
+	ctx context.Context,
+	namespace string,
+	sub kargoapi.ChartSubscription,
+) (*kargoapi.Chart, error) {
+	logger := logging.LoggerFromContext(ctx).WithFields(log.Fields{
+		"registry": sub.RegistryURL,
+		"chart":    sub.Name,
+	})
 
-		vers, err := r.getLatestChartVersionFn(
-			ctx,
+	creds, ok, err :=
+		r.credentialsDB.Get(ctx, namespace, credentials.TypeHelm, sub.RegistryURL)
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error obtaining credentials for chart registry %q",
 			sub.RegistryURL,
-			sub.Name,
-			sub.SemverConstraint,
-			helmCreds,
 		)
-		if err != nil {
-			return nil, errors.Wrapf(
-				err,
-				"error searching for latest version of chart %q in registry %q",
-				sub.Name,
-				sub.RegistryURL,
-			)
+	}
+
+	var helmCreds *helm.Credentials
+	if ok {
+		helmCreds = &helm.Credentials{
+			Username: creds.Username,
+			Password: creds.Password,
 		}
+		logger.Debug("obtained credentials for chart repo")
+	} else {
+		logger.Debug("found no credentials for chart repo")
+	}
 
-		if vers == "" {
-			logger.Error("found no suitable chart version")
-			return nil, errors.Errorf(
-				"found no suitable version of chart %q in registry %q",
-				sub.Name,
+	// Stages subscribing to the same chart in the same way, with the same
+	// credentials, share the result
+	vers, err := discovery.Discover(
+		ctx,
+		r.discovery,
+		discovery.NewRequest(
+			discovery.HostOf(sub.RegistryURL),
+			"chart",
+			sub,
+			helmCreds,
+		),
+		func(ctx context.Context) (string, error) {
+			return r.getLatestChartVersionFn(
+				ctx,
 				sub.RegistryURL,
+				sub.Name,
+				sub.SemverConstraint,
+				helmCreds,
 			)
-		}
-		logger.WithField("version", vers).
-			Debug("found latest suitable chart version")
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error searching for latest version of chart %q in registry %q",
+			sub.Name,
+			sub.RegistryURL,
+		)
+	}
 
-		charts[i] = kargoapi.Chart{
-			RegistryURL: sub.RegistryURL,
-			Name:        sub.Name,
-			Version:     vers,
-		}
+	if vers == "" {
+		logger.Error("found no suitable chart version")
+		return nil, errors.Errorf(
+			"found no suitable version of chart %q in registry %q",
+			sub.Name,
+			sub.RegistryURL,
+		)
 	}
+	logger.WithField("version", vers).
+		Debug("found latest suitable chart version")
 
-	return charts, nil
+	return &kargoapi.Chart{
+		RegistryURL: sub.RegistryURL,
+		Name:        sub.Name,
+		Version:     vers,
+	}, nil
 }
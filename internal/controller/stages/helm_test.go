@@ -9,6 +9,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/discovery"
 	"github.com/akuity/kargo/internal/helm"
 )
 
@@ -155,6 +156,7 @@ func TestGetLatestCharts(t *testing.T) {
 			r := reconciler{
 				credentialsDB:           testCase.credentialsDB,
 				getLatestChartVersionFn: testCase.getLatestChartVersionFn,
+				discovery:               discovery.NewService(discovery.Config{}),
 			}
 			testCase.assertions(r.getLatestCharts(
 				context.Background(),
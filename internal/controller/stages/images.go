@@ -7,9 +7,12 @@ import (
 
 	"github.com/argoproj/argo-cd/v2/util/git"
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/discovery"
 	"github.com/akuity/kargo/internal/images"
 	"github.com/akuity/kargo/internal/logging"
 )
@@ -18,57 +21,187 @@ func (r *reconciler) getLatestImages(
 	ctx context.Context,
 	namespace string,
 	subs []kargoapi.ImageSubscription,
-) ([]kargoapi.Image, error) {
+) ([]kargoapi.Image, []kargoapi.RejectedImageTag, error) {
 	imgs := make([]kargoapi.Image, len(subs))
+	rejected := make([][]kargoapi.RejectedImageTag, len(subs))
+	// Subscriptions are satisfied concurrently so that one slow registry does
+	// not hold up the others
+	g, ctx := errgroup.WithContext(ctx)
 	for i, sub := range subs {
+		i, sub := i, sub
+		g.Go(func() error {
+			img, rejectedTags, err := r.getLatestImage(ctx, namespace, sub)
+			// Rejected tags are reported even if no tag was found at all
+			rejected[i] = rejectedTags
+			if err != nil {
+				return err
+			}
+			imgs[i] = *img
+			return nil
+		})
+	}
+	err := g.Wait()
+	var rejectedTags []kargoapi.RejectedImageTag
+	for _, tags := range rejected {
+		rejectedTags = append(rejectedTags, tags...)
+	}
+	if err != nil {
+		return nil, rejectedTags, err
+	}
+	return imgs, rejectedTags, nil
+}
 
-		logger := logging.LoggerFromContext(ctx).WithField("repo", sub.RepoURL)
+func (r *reconciler) getLatestImage(
+	ctx context.Context,
+	namespace string,
+	sub kargoapi.ImageSubscription,
+) (*kargoapi.Image, []kargoapi.RejectedImageTag, error) {
+	logger := logging.LoggerFromContext(ctx).WithField("repo", sub.RepoURL)
 
-		creds, ok, err :=
-			r.credentialsDB.Get(ctx, namespace, credentials.TypeImage, sub.RepoURL)
-		if err != nil {
-			return nil, errors.Wrapf(
-				err,
-				"error obtaining credentials for image repo %q",
-				sub.RepoURL,
-			)
-		}
-		var regCreds *images.Credentials
-		if ok {
-			regCreds = &images.Credentials{
-				Username: creds.Username,
-				Password: creds.Password,
-			}
-			logger.Debug("obtained credentials for image repo")
-		} else {
-			logger.Debug("found no credentials for image repo")
+	creds, ok, err :=
+		r.credentialsDB.Get(ctx, namespace, credentials.TypeImage, sub.RepoURL)
+	if err != nil {
+		return nil, nil, errors.Wrapf(
+			err,
+			"error obtaining credentials for image repo %q",
+			sub.RepoURL,
+		)
+	}
+	var regCreds *images.Credentials
+	if ok {
+		regCreds = &images.Credentials{
+			Username: creds.Username,
+			Password: creds.Password,
 		}
+		logger.Debug("obtained credentials for image repo")
+	} else {
+		logger.Debug("found no credentials for image repo")
+	}
 
-		tag, err := r.getLatestTagFn(
-			sub.RepoURL,
-			images.ImageUpdateStrategy(sub.UpdateStrategy),
-			sub.SemverConstraint,
-			sub.AllowTags,
-			sub.IgnoreTags,
-			sub.Platform,
+	// Stages subscribing to the same repo in the same way, with the same
+	// credentials, share the result
+	var rejected []kargoapi.RejectedImageTag
+	tag, err := discovery.Discover(
+		ctx,
+		r.discovery,
+		discovery.NewRequest(
+			discovery.HostOf(sub.RepoURL),
+			"image",
+			sub,
 			regCreds,
-		)
-		if err != nil {
-			return nil, errors.Wrapf(
-				err,
-				"error getting latest suitable tag for image %q",
+		),
+		func(ctx context.Context) (*images.Tag, error) {
+			return r.getLatestTagFn(
+				ctx,
 				sub.RepoURL,
+				images.ImageUpdateStrategy(sub.UpdateStrategy),
+				sub.SemverConstraint,
+				sub.AllowTags,
+				sub.IgnoreTags,
+				sub.Platform,
+				regCreds,
+				getVerificationPolicy(sub.Verification),
 			)
+		},
+	)
+	if err != nil {
+		var noVerifiedTagErr *images.NoVerifiedTagError
+		if errors.As(err, &noVerifiedTagErr) {
+			rejected = toRejectedImageTags(ctx, sub.RepoURL, noVerifiedTagErr.Rejected)
 		}
-		imgs[i] = kargoapi.Image{
-			RepoURL:    sub.RepoURL,
-			GitRepoURL: r.getImageSourceURL(sub.GitRepoURL, tag),
-			Tag:        tag,
+		return nil, rejected, errors.Wrapf(
+			err,
+			"error getting latest suitable tag for image %q",
+			sub.RepoURL,
+		)
+	}
+	logger.WithField("tag", tag.Name).WithField("digest", tag.Digest).
+		Debug("found latest suitable image tag")
+	rejected = toRejectedImageTags(ctx, sub.RepoURL, tag.Rejected)
+	// The image's own labels are a more reliable indication of where it came
+	// from than the subscription
+	sourceRepoURL := tag.Source.RepoURL
+	if sourceRepoURL == "" {
+		sourceRepoURL = sub.GitRepoURL
+	}
+	sourceRef := tag.Source.Revision
+	if sourceRef == "" {
+		sourceRef = tag.Name
+	}
+	var buildTime *metav1.Time
+	if tag.Source.Created != nil {
+		bt := metav1.NewTime(*tag.Source.Created)
+		buildTime = &bt
+	}
+	return &kargoapi.Image{
+		RepoURL:        sub.RepoURL,
+		GitRepoURL:     r.getImageSourceURL(sourceRepoURL, sourceRef),
+		Tag:            tag.Name,
+		Digest:         tag.Digest,
+		SourceRepoURL:  sourceRepoURL,
+		SourceCommitID: tag.Source.Revision,
+		BuildTime:      buildTime,
+	}, rejected, nil
+}
+
+// toRejectedImageTags converts the provided images.RejectedTags, which belong
+// to the image in the repository with the specified URL, to
+// kargoapi.RejectedImageTags.
+func toRejectedImageTags(
+	ctx context.Context,
+	repoURL string,
+	rejectedTags []images.RejectedTag,
+) []kargoapi.RejectedImageTag {
+	if len(rejectedTags) == 0 {
+		return nil
+	}
+	logger := logging.LoggerFromContext(ctx).WithField("repo", repoURL)
+	rejected := make([]kargoapi.RejectedImageTag, len(rejectedTags))
+	for i, rejectedTag := range rejectedTags {
+		logger.WithField("tag", rejectedTag.Name).
+			WithField("reason", rejectedTag.Reason).
+			Debug("passed over image tag that failed verification")
+		rejected[i] = kargoapi.RejectedImageTag{
+			RepoURL: repoURL,
+			Tag:     rejectedTag.Name,
+			Digest:  rejectedTag.Digest,
+			Reason:  rejectedTag.Reason,
+		}
+	}
+	return rejected
+}
+
+// getVerificationPolicy converts the provided ImageVerification, if any, to an
+// images.VerificationPolicy.
+func getVerificationPolicy(
+	verification *kargoapi.ImageVerification,
+) *images.VerificationPolicy {
+	if verification == nil {
+		return nil
+	}
+	policy := &images.VerificationPolicy{
+		PublicKeys:   verification.PublicKeys,
+		Attestations: verification.Attestations,
+	}
+	if keyless := verification.Keyless; keyless != nil {
+		policy.Keyless = &images.KeylessVerificationPolicy{
+			Identities: make(
+				[]images.KeylessIdentity,
+				len(keyless.Identities),
+			),
+			RootCertificates:         keyless.RootCertificates,
+			TransparencyLogPublicKey: keyless.TransparencyLogPublicKey,
+		}
+		for i, identity := range keyless.Identities {
+			policy.Keyless.Identities[i] = images.KeylessIdentity{
+				Issuer:        identity.Issuer,
+				IssuerRegexp:  identity.IssuerRegexp,
+				Subject:       identity.Subject,
+				SubjectRegexp: identity.SubjectRegexp,
+			}
 		}
-		logger.WithField("tag", tag).
-			Debug("found latest suitable image tag")
 	}
-	return imgs, nil
+	return policy
 }
 
 const (
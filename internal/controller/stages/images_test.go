@@ -3,21 +3,28 @@ package stages
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/discovery"
 	"github.com/akuity/kargo/internal/images"
 )
 
 func TestGetLatestImages(t *testing.T) {
+	testBuildTime := time.Date(2023, time.October, 1, 12, 0, 0, 0, time.UTC)
 	testCases := []struct {
 		name           string
 		credentialsDB  credentials.Database
 		getLatestTagFn func(
+			context.Context,
 			string,
 			images.ImageUpdateStrategy,
 			string,
@@ -25,8 +32,9 @@ func TestGetLatestImages(t *testing.T) {
 			[]string,
 			string,
 			*images.Credentials,
-		) (string, error)
-		assertions func([]kargoapi.Image, error)
+			*images.VerificationPolicy,
+		) (*images.Tag, error)
+		assertions func([]kargoapi.Image, []kargoapi.RejectedImageTag, error)
 	}{
 		{
 			name: "error getting latest version of an image",
@@ -41,6 +49,7 @@ func TestGetLatestImages(t *testing.T) {
 				},
 			},
 			getLatestTagFn: func(
+				_ context.Context,
 				repoURL string,
 				updateStrategy images.ImageUpdateStrategy,
 				semverConstraint string,
@@ -48,10 +57,11 @@ func TestGetLatestImages(t *testing.T) {
 				ignoreTags []string,
 				platform string,
 				creds *images.Credentials,
-			) (string, error) {
-				return "", errors.New("something went wrong")
+				verification *images.VerificationPolicy,
+			) (*images.Tag, error) {
+				return nil, errors.New("something went wrong")
 			},
-			assertions: func(_ []kargoapi.Image, err error) {
+			assertions: func(_ []kargoapi.Image, _ []kargoapi.RejectedImageTag, err error) {
 				require.Error(t, err)
 				require.Contains(
 					t,
@@ -62,6 +72,68 @@ func TestGetLatestImages(t *testing.T) {
 			},
 		},
 
+		{
+			name: "every tag rejected by verification",
+			credentialsDB: &credentials.FakeDB{
+				GetFn: func(
+					context.Context,
+					string,
+					credentials.Type,
+					string,
+				) (credentials.Credentials, bool, error) {
+					return credentials.Credentials{}, false, nil
+				},
+			},
+			getLatestTagFn: func(
+				_ context.Context,
+				repoURL string,
+				updateStrategy images.ImageUpdateStrategy,
+				semverConstraint string,
+				allowTags string,
+				ignoreTags []string,
+				platform string,
+				creds *images.Credentials,
+				verification *images.VerificationPolicy,
+			) (*images.Tag, error) {
+				return nil, &images.NoVerifiedTagError{
+					RepoURL: repoURL,
+					Rejected: []images.RejectedTag{
+						{
+							Name:   "fake-tag",
+							Digest: "fake-digest",
+							Reason: "image is not signed",
+						},
+					},
+				}
+			},
+			assertions: func(
+				images []kargoapi.Image,
+				rejected []kargoapi.RejectedImageTag,
+				err error,
+			) {
+				require.Error(t, err)
+				require.Contains(
+					t,
+					err.Error(),
+					"found no version of image \"fake-url\" that satisfies its "+
+						"verification policy",
+				)
+				require.Nil(t, images)
+				require.Equal(
+					t,
+					[]kargoapi.RejectedImageTag{
+						{
+							RepoURL: "fake-url",
+							Tag:     "fake-tag",
+							Digest:  "fake-digest",
+							Reason:  "image is not signed",
+						},
+					},
+					rejected,
+				)
+			},
+		},
+
 		{
 			name: "success",
 			credentialsDB: &credentials.FakeDB{
@@ -75,6 +147,7 @@ func TestGetLatestImages(t *testing.T) {
 				},
 			},
 			getLatestTagFn: func(
+				_ context.Context,
 				repoURL string,
 				updateStrategy images.ImageUpdateStrategy,
 				semverConstraint string,
@@ -82,10 +155,18 @@ func TestGetLatestImages(t *testing.T) {
 				ignoreTags []string,
 				platform string,
 				creds *images.Credentials,
-			) (string, error) {
-				return "fake-tag", nil
+				verification *images.VerificationPolicy,
+			) (*images.Tag, error) {
+				return &images.Tag{
+					Name:   "fake-tag",
+					Digest: "fake-digest",
+				}, nil
 			},
-			assertions: func(images []kargoapi.Image, err error) {
+			assertions: func(
+				images []kargoapi.Image,
+				rejected []kargoapi.RejectedImageTag,
+				err error,
+			) {
 				require.NoError(t, err)
 				require.Len(t, images, 1)
 				require.Equal(
@@ -93,6 +174,122 @@ func TestGetLatestImages(t *testing.T) {
 					kargoapi.Image{
 						RepoURL: "fake-url",
 						Tag:     "fake-tag",
+						Digest:  "fake-digest",
+					},
+					images[0],
+				)
+				require.Empty(t, rejected)
+			},
+		},
+
+		{
+			name: "success with tags rejected by verification",
+			credentialsDB: &credentials.FakeDB{
+				GetFn: func(
+					context.Context,
+					string,
+					credentials.Type,
+					string,
+				) (credentials.Credentials, bool, error) {
+					return credentials.Credentials{}, false, nil
+				},
+			},
+			getLatestTagFn: func(
+				_ context.Context,
+				repoURL string,
+				updateStrategy images.ImageUpdateStrategy,
+				semverConstraint string,
+				allowTags string,
+				ignoreTags []string,
+				platform string,
+				creds *images.Credentials,
+				verification *images.VerificationPolicy,
+			) (*images.Tag, error) {
+				return &images.Tag{
+					Name:   "fake-tag",
+					Digest: "fake-digest",
+					Rejected: []images.RejectedTag{
+						{
+							Name:   "newer-fake-tag",
+							Digest: "newer-fake-digest",
+							Reason: "image is not signed",
+						},
+					},
+				}, nil
+			},
+			assertions: func(
+				images []kargoapi.Image,
+				rejected []kargoapi.RejectedImageTag,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Len(t, images, 1)
+				require.Equal(t, "fake-tag", images[0].Tag)
+				require.Equal(
+					t,
+					[]kargoapi.RejectedImageTag{
+						{
+							RepoURL: "fake-url",
+							Tag:     "newer-fake-tag",
+							Digest:  "newer-fake-digest",
+							Reason:  "image is not signed",
+						},
+					},
+					rejected,
+				)
+			},
+		},
+
+		{
+			name: "success with source metadata",
+			credentialsDB: &credentials.FakeDB{
+				GetFn: func(
+					context.Context,
+					string,
+					credentials.Type,
+					string,
+				) (credentials.Credentials, bool, error) {
+					return credentials.Credentials{}, false, nil
+				},
+			},
+			getLatestTagFn: func(
+				_ context.Context,
+				repoURL string,
+				updateStrategy images.ImageUpdateStrategy,
+				semverConstraint string,
+				allowTags string,
+				ignoreTags []string,
+				platform string,
+				creds *images.Credentials,
+				verification *images.VerificationPolicy,
+			) (*images.Tag, error) {
+				return &images.Tag{
+					Name:   "fake-tag",
+					Digest: "fake-digest",
+					Source: images.SourceMetadata{
+						RepoURL:  "https://github.com/example/app",
+						Revision: "fake-commit",
+						Created:  &testBuildTime,
+					},
+				}, nil
+			},
+			assertions: func(
+				images []kargoapi.Image,
+				_ []kargoapi.RejectedImageTag,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Len(t, images, 1)
+				require.Equal(
+					t,
+					kargoapi.Image{
+						RepoURL:        "fake-url",
+						GitRepoURL:     "https://github.com/example/app/tree/fake-commit",
+						Tag:            "fake-tag",
+						Digest:         "fake-digest",
+						SourceRepoURL:  "https://github.com/example/app",
+						SourceCommitID: "fake-commit",
+						BuildTime:      &metav1.Time{Time: testBuildTime},
 					},
 					images[0],
 				)
@@ -109,6 +306,10 @@ func TestGetLatestImages(t *testing.T) {
 			r := reconciler{
 				credentialsDB:  testCase.credentialsDB,
 				getLatestTagFn: testCase.getLatestTagFn,
+				discovery:      discovery.NewService(discovery.Config{}),
+				imageSourceURLFnsByBaseURL: map[string]func(string, string) string{
+					githubURLPrefix: getGithubImageSourceURL,
+				},
 			}
 			testCase.assertions(
 				r.getLatestImages(
@@ -121,6 +322,61 @@ func TestGetLatestImages(t *testing.T) {
 	}
 }
 
+func TestGetLatestImagesSharedAcrossStages(t *testing.T) {
+	var queries int32
+	r := reconciler{
+		credentialsDB: &credentials.FakeDB{
+			GetFn: func(
+				context.Context,
+				string,
+				credentials.Type,
+				string,
+			) (credentials.Credentials, bool, error) {
+				return credentials.Credentials{}, false, nil
+			},
+		},
+		getLatestTagFn: func(
+			context.Context,
+			string,
+			images.ImageUpdateStrategy,
+			string,
+			string,
+			[]string,
+			string,
+			*images.Credentials,
+			*images.VerificationPolicy,
+		) (*images.Tag, error) {
+			atomic.AddInt32(&queries, 1)
+			return &images.Tag{
+				Name:   "fake-tag",
+				Digest: "fake-digest",
+			}, nil
+		},
+		discovery: discovery.NewService(discovery.Config{CacheTTL: time.Minute}),
+	}
+	// Two Stages subscribing to the same image repository in the same way
+	subs := []kargoapi.ImageSubscription{
+		{
+			RepoURL:          "fake-url",
+			SemverConstraint: "^1.0.0",
+		},
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			imgs, _, err :=
+				r.getLatestImages(context.Background(), "fake-namespace", subs)
+			require.NoError(t, err)
+			require.Len(t, imgs, 1)
+			require.Equal(t, "fake-tag", imgs[0].Tag)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), atomic.LoadInt32(&queries))
+}
+
 func TestFetImageSourceURL(t *testing.T) {
 	const testURLPrefix = "fake-url-prefix"
 	testCases := []struct {
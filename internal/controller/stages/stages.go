@@ -2,11 +2,14 @@ package stages
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	argocd "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+	batchv1 "k8s.io/api/batch/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -25,6 +28,8 @@ import (
 	libArgoCD "github.com/akuity/kargo/internal/argocd"
 	"github.com/akuity/kargo/internal/controller"
 	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/discovery"
+	libGit "github.com/akuity/kargo/internal/git"
 	"github.com/akuity/kargo/internal/helm"
 	"github.com/akuity/kargo/internal/images"
 	"github.com/akuity/kargo/internal/kargo"
@@ -32,21 +37,28 @@ import (
 	"github.com/akuity/kargo/internal/logging"
 )
 
+// freightRetentionLimit is the number of the Freight resources most recently
+// discovered by a Stage that are retained even if no Stage references them any
+// longer, so that they remain available for promotion.
+const freightRetentionLimit = 50
+
 // reconciler reconciles Stage resources.
 type reconciler struct {
 	kargoClient                client.Client
 	argoClient                 client.Client
 	credentialsDB              credentials.Database
+	gitCache                   *libGit.Cache
+	discovery                  *discovery.Service
 	imageSourceURLFnsByBaseURL map[string]func(string, string) string
 
 	// The following behaviors are overridable for testing purposes:
 
 	// Loop guard
-	hasNonTerminalPromotionsFn func(
+	getNonTerminalPromotionsFn func(
 		ctx context.Context,
 		stageNamespace string,
 		stageName string,
-	) (bool, error)
+	) ([]kargoapi.Promotion, error)
 
 	// Common:
 	getArgoCDAppFn func(
@@ -56,25 +68,54 @@ type reconciler struct {
 		name string,
 	) (*argocd.Application, error)
 
+	// Freight:
+	createFreightFn func(
+		ctx context.Context,
+		stage *kargoapi.Stage,
+		freight kargoapi.SimpleFreight,
+	) error
+
+	pruneFreightFn func(
+		ctx context.Context,
+		stage *kargoapi.Stage,
+		status kargoapi.StageStatus,
+	) error
+
+	verifyFreightInStageFn func(
+		ctx context.Context,
+		namespace string,
+		freightID string,
+		stageName string,
+	) error
+
 	// Health checks:
 	checkHealthFn func(
 		context.Context,
-		*kargoapi.Freight,
+		*kargoapi.SimpleFreight,
 		[]kargoapi.ArgoCDAppUpdate,
 	) *kargoapi.Health
 
+	// Verification:
+	verifyFn func(
+		ctx context.Context,
+		stage *kargoapi.Stage,
+		freightID string,
+		verificationInfo *kargoapi.VerificationInfo,
+	) (*kargoapi.VerificationInfo, error)
+
 	// Syncing:
 	getLatestFreightFromReposFn func(
 		ctx context.Context,
 		namespace string,
 		subs kargoapi.RepoSubscriptions,
-	) (*kargoapi.Freight, error)
+	) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error)
 
 	getAvailableFreightFromUpstreamStagesFn func(
 		ctx context.Context,
 		namespace string,
 		subs []kargoapi.StageSubscription,
-	) ([]kargoapi.Freight, error)
+		joinStrategy kargoapi.JoinStrategy,
+	) ([]kargoapi.SimpleFreight, error)
 
 	getLatestCommitsFn func(
 		ctx context.Context,
@@ -86,9 +127,10 @@ type reconciler struct {
 		ctx context.Context,
 		namespace string,
 		subs []kargoapi.ImageSubscription,
-	) ([]kargoapi.Image, error)
+	) ([]kargoapi.Image, []kargoapi.RejectedImageTag, error)
 
 	getLatestTagFn func(
+		ctx context.Context,
 		repoURL string,
 		updateStrategy images.ImageUpdateStrategy,
 		semverConstraint string,
@@ -96,7 +138,8 @@ type reconciler struct {
 		ignoreTags []string,
 		platform string,
 		creds *images.Credentials,
-	) (string, error)
+		verification *images.VerificationPolicy,
+	) (*images.Tag, error)
 
 	getLatestChartsFn func(
 		ctx context.Context,
@@ -114,14 +157,14 @@ type reconciler struct {
 
 	getLatestCommitMetaFn func(
 		ctx context.Context,
-		repoURL string,
-		branch string,
+		sub kargoapi.GitSubscription,
 		creds *git.RepoCredentials,
 	) (*gitMeta, error)
 }
 
 type gitMeta struct {
 	Commit  string
+	Tag     string
 	Message string
 	Author  string
 }
@@ -133,6 +176,8 @@ func SetupReconcilerWithManager(
 	kargoMgr manager.Manager,
 	argoMgr manager.Manager,
 	credentialsDB credentials.Database,
+	gitCache *libGit.Cache,
+	discoverySvc *discovery.Service,
 	shardName string,
 ) error {
 	// Index Promotions in non-terminal states by Stage
@@ -173,6 +218,8 @@ func SetupReconcilerWithManager(
 				kargoMgr.GetClient(),
 				argoMgr.GetClient(),
 				credentialsDB,
+				gitCache,
+				discoverySvc,
 			),
 		)
 	if err != nil {
@@ -197,6 +244,15 @@ func SetupReconcilerWithManager(
 	if err := c.Watch(&source.Kind{Type: &v1alpha1.Stage{}}, downstreamEvtHandler); err != nil {
 		return errors.Wrap(err, "unable to watch Stages")
 	}
+
+	// Watch verification Jobs that completed and enqueue owning Stage key
+	jobOwnerHandler := &handler.EnqueueRequestForOwner{OwnerType: &v1alpha1.Stage{}, IsController: true}
+	jobWentTerminal := JobWentTerminal{
+		logger: logger,
+	}
+	if err := c.Watch(&source.Kind{Type: &batchv1.Job{}}, jobOwnerHandler, jobWentTerminal); err != nil {
+		return errors.Wrap(err, "unable to watch Jobs")
+	}
 	return nil
 }
 
@@ -204,11 +260,15 @@ func newReconciler(
 	kargoClient client.Client,
 	argoClient client.Client,
 	credentialsDB credentials.Database,
+	gitCache *libGit.Cache,
+	discoverySvc *discovery.Service,
 ) *reconciler {
 	r := &reconciler{
 		kargoClient:   kargoClient,
 		argoClient:    argoClient,
 		credentialsDB: credentialsDB,
+		gitCache:      gitCache,
+		discovery:     discoverySvc,
 		imageSourceURLFnsByBaseURL: map[string]func(string, string) string{
 			githubURLPrefix: getGithubImageSourceURL,
 		},
@@ -217,14 +277,22 @@ func newReconciler(
 	// The following default behaviors are overridable for testing purposes:
 
 	// Loop guard:
-	r.hasNonTerminalPromotionsFn = r.hasNonTerminalPromotions
+	r.getNonTerminalPromotionsFn = r.getNonTerminalPromotions
 
 	// Common:
 	r.getArgoCDAppFn = libArgoCD.GetApplication
 
+	// Freight:
+	r.createFreightFn = r.createFreight
+	r.pruneFreightFn = r.pruneFreight
+	r.verifyFreightInStageFn = r.verifyFreightInStage
+
 	// Health checks:
 	r.checkHealthFn = r.checkHealth
 
+	// Verification:
+	r.verifyFn = r.verify
+
 	// Syncing:
 	r.getLatestFreightFromReposFn = r.getLatestFreightFromRepos
 	r.getAvailableFreightFromUpstreamStagesFn = r.getAvailableFreightFromUpstreamStages
@@ -233,7 +301,7 @@ func newReconciler(
 	r.getLatestTagFn = images.GetLatestTag
 	r.getLatestChartsFn = r.getLatestCharts
 	r.getLatestChartVersionFn = helm.GetLatestChartVersion
-	r.getLatestCommitMetaFn = getLatestCommitMeta
+	r.getLatestCommitMetaFn = r.getLatestCommitMeta
 
 	return r
 }
@@ -272,6 +340,12 @@ func (r *reconciler) Reconcile(
 	}
 	logger.Debug("found Stage")
 
+	if _, ok := stage.Annotations[kargoapi.AnnotationKeyRefresh]; ok {
+		// Whoever requested the refresh, e.g. a webhook notifying us of a push,
+		// expects Freight that did not exist a moment ago to be discovered
+		ctx = discovery.ContextWithRefresh(ctx)
+	}
+
 	var newStatus kargoapi.StageStatus
 	newStatus, err = r.syncStage(ctx, stage)
 	if err != nil {
@@ -318,26 +392,36 @@ func (r *reconciler) syncStage(
 
 	logger := logging.LoggerFromContext(ctx)
 
-	// Skip the entire reconciliation loop if there are Promotions associate with
-	// this Stage in a non-terminal state. The promotion process and this
-	// reconciliation loop BOTH update Stage status, so this check helps us
-	// to avoid race conditions that may otherwise arise.
-	hasNonTerminalPromos, err :=
-		r.hasNonTerminalPromotionsFn(ctx, stage.Namespace, stage.Name)
+	nonTerminalPromos, err :=
+		r.getNonTerminalPromotionsFn(ctx, stage.Namespace, stage.Name)
+	if err != nil {
+		return status, err
+	}
+	// The promotion process and this reconciliation loop BOTH update Stage
+	// status. While a Promotion is in progress, this loop leaves the parts of
+	// the status that the promotion process updates -- the current Promotion,
+	// the current Freight, its verification, and the Stage's history -- alone
+	// to avoid race conditions that may otherwise arise. Health checks and
+	// Freight discovery carry on regardless.
+	promoInProgress, err := r.isPromotionInProgress(ctx, stage, nonTerminalPromos)
 	if err != nil {
 		return status, err
 	}
-	if hasNonTerminalPromos {
+	if promoInProgress {
 		logger.Debug(
-			"Stage has one or more Promotions in a non-terminal phase; skipping " +
-				"this reconciliation loop",
+			"Stage has a Promotion in progress; current Freight and history " +
+				"will not be updated",
 		)
-		return status, nil
 	}
 
 	status.ObservedGeneration = stage.Generation
 	status.Health = nil // Reset health
-	status.CurrentPromotion = nil
+	if len(nonTerminalPromos) == 0 {
+		status.CurrentPromotion = nil
+	}
+	if stage.Spec.Verification == nil || stage.Spec.PromotionMechanisms == nil {
+		status.Verification = nil
+	}
 
 	if stage.Spec.PromotionMechanisms != nil {
 		status.Health = r.checkHealthFn(
@@ -354,25 +438,61 @@ func (r *reconciler) syncStage(
 		status.History = status.AvailableFreight.DeepCopy()
 		for i := range status.History {
 			status.History[i].Qualified = true
+			if err = r.verifyFreightInStageFn(
+				ctx,
+				stage.Namespace,
+				status.History[i].ID,
+				stage.Name,
+			); err != nil {
+				return status, err
+			}
 		}
 		// Also, a Stage without promotion mechanisms doesn't have
 		// a "current" freight. Make sure this is empty to avoid confusion
 		status.CurrentFreight = nil
 	}
-	if status.CurrentFreight != nil &&
+	if !promoInProgress && status.CurrentFreight != nil &&
 		(status.Health == nil || status.Health.Status == kargoapi.HealthStateHealthy) {
-		status.CurrentFreight.Qualified = true
-		status.History.Pop()
-		status.History.Push(*status.CurrentFreight)
+		verified := true
+		if stage.Spec.Verification != nil && !status.CurrentFreight.Qualified {
+			// The Stage is healthy, but its current Freight cannot be qualified
+			// until it has also been successfully verified.
+			if status.Verification, err = r.verifyFn(
+				ctx,
+				stage,
+				status.CurrentFreight.ID,
+				status.Verification,
+			); err != nil {
+				return status, err
+			}
+			logger.WithField("phase", status.Verification.Phase).
+				Debug("verified current Freight")
+			verified =
+				status.Verification.Phase == kargoapi.VerificationPhaseSucceeded
+		}
+		if verified {
+			status.CurrentFreight.Qualified = true
+			status.History.Pop()
+			status.History.Push(*status.CurrentFreight)
+			if err = r.verifyFreightInStageFn(
+				ctx,
+				stage.Namespace,
+				status.CurrentFreight.ID,
+				stage.Name,
+			); err != nil {
+				return status, err
+			}
+		}
 	}
 
 	if stage.Spec.Subscriptions.Repos != nil {
 
-		latestFreight, err := r.getLatestFreightFromReposFn(
+		latestFreight, rejectedImageTags, err := r.getLatestFreightFromReposFn(
 			ctx,
 			stage.Namespace,
 			*stage.Spec.Subscriptions.Repos,
 		)
+		status.RejectedImageTags = rejectedImageTags
 		if err != nil {
 			return status, err
 		}
@@ -382,6 +502,10 @@ func (r *reconciler) syncStage(
 		}
 		logger.Debug("got latest Freight from upstream repositories")
 
+		if err = r.createFreightFn(ctx, stage, *latestFreight); err != nil {
+			return status, err
+		}
+
 		// latestFreight from upstream repos will always have a shiny new ID. To
 		// determine if this is actually new and needs to be pushed onto the
 		// status.AvailableFreight stack, either that stack needs to be empty or
@@ -395,22 +519,29 @@ func (r *reconciler) syncStage(
 		status.AvailableFreight.Push(*latestFreight)
 		logger.Debug("latest Freight is new; added to available Freight")
 
+		// Failing to prune old Freight is not a reason to fail the sync
+		if err = r.pruneFreightFn(ctx, stage, status); err != nil {
+			logger.Errorf("error pruning Freight: %s", err)
+		}
+
 	} else if len(stage.Spec.Subscriptions.UpstreamStages) > 0 {
 
 		// Grab the latest known Freight before we overwrite status.AvailableFreight
-		var latestKnownFreight *kargoapi.Freight
+		var latestKnownFreight *kargoapi.SimpleFreight
 		if lks, ok := status.AvailableFreight.Top(); ok {
 			latestKnownFreight = &lks
 		}
 
-		// This returns de-duped, healthy Freight only from all upstream Stages.
-		// There could be up to ten per upstream Stage. This is more than the usual
-		// quantity we permit in status.AvailableFreight, but we'll allow it.
+		// This returns de-duped, healthy Freight only from all upstream Stages,
+		// joined according to the Stage's join strategy. There could be up to ten
+		// per upstream Stage. This is more than the usual quantity we permit in
+		// status.AvailableFreight, but we'll allow it.
 		var err error
 		if status.AvailableFreight, err = r.getAvailableFreightFromUpstreamStagesFn(
 			ctx,
 			stage.Namespace,
 			stage.Spec.Subscriptions.UpstreamStages,
+			stage.Spec.Subscriptions.UpstreamJoinStrategy,
 		); err != nil {
 			return status, err
 		}
@@ -421,9 +552,11 @@ func (r *reconciler) syncStage(
 		}
 		logger.Debug("got available Freight from upstream Stages")
 
-		if len(stage.Spec.Subscriptions.UpstreamStages) > 1 {
+		if len(stage.Spec.Subscriptions.UpstreamStages) > 1 &&
+			stage.Spec.Subscriptions.UpstreamJoinStrategy == "" {
 			logger.Debug(
-				"auto-promotion cannot proceed due to multiple upstream Stages",
+				"auto-promotion cannot proceed due to multiple upstream Stages " +
+					"without a join strategy",
 			)
 			return status, nil
 		}
@@ -452,6 +585,19 @@ func (r *reconciler) syncStage(
 		return status, nil
 	}
 	nextFreight := nextFreightCandidate
+	// Promotions that have yet to conclude don't stop newer Freight from being
+	// queued up behind them. That is what allows a PromotionPolicy's
+	// supersede setting to coalesce auto-promotions. But there is no call to
+	// queue up the same Freight twice.
+	for _, promo := range nonTerminalPromos {
+		if promo.Spec != nil && promo.Spec.Freight == nextFreight.ID {
+			logger.WithField("promotion", promo.Name).Debug(
+				"a Promotion of the newest available Freight already exists; " +
+					"auto-promotion will not proceed",
+			)
+			return status, nil
+		}
+	}
 
 	// If we get to here, we've determined that auto-promotion is a possibility.
 	// See if it's actually allowed...
@@ -488,6 +634,14 @@ func (r *reconciler) syncStage(
 		)
 		return status, nil
 	}
+	if permitted, reason :=
+		kargo.IsPromotionPermitted(&policies.Items[0], time.Now()); !permitted {
+		logger.WithField("reason", reason).Debug(
+			"PromotionPolicy does not currently permit promotion; auto-promotion " +
+				"will not proceed",
+		)
+		return status, nil
+	}
 
 	logger = logger.WithField("freight", nextFreight.ID)
 	logger.Debug("auto-promotion will proceed")
@@ -513,11 +667,11 @@ func (r *reconciler) syncStage(
 	return status, nil
 }
 
-func (r *reconciler) hasNonTerminalPromotions(
+func (r *reconciler) getNonTerminalPromotions(
 	ctx context.Context,
 	stageNamespace string,
 	stageName string,
-) (bool, error) {
+) ([]kargoapi.Promotion, error) {
 	promos := kargoapi.PromotionList{}
 	if err := r.kargoClient.List(
 		ctx,
@@ -529,72 +683,418 @@ func (r *reconciler) hasNonTerminalPromotions(
 			}).AsSelector(),
 		},
 	); err != nil {
-		return false, errors.Wrapf(
+		return nil, errors.Wrapf(
 			err,
 			"error listing Promotions in non-terminal phases for Stage %q in "+
 				"namespace %q",
-			stageNamespace,
 			stageName,
+			stageNamespace,
+		)
+	}
+	return promos.Items, nil
+}
+
+// isPromotionInProgress returns true if any of the provided non-terminal
+// Promotions of the provided Stage may update the Stage's status at any
+// moment. Promotions that cannot proceed for some time to come do not count:
+//   - A Waiting Promotion, whose pull request may go unmerged for days.
+//   - A Pending Promotion that is waiting out the backoff before its next
+//     attempt, which may be lengthy.
+//   - A Pending Promotion that the Stage's PromotionPolicy does not currently
+//     permit, e.g. because of a blackout over the weekend.
+//
+// Each of these updates the Stage's status only once it is executed, at which point it
+// is Running.
+func (r *reconciler) isPromotionInProgress(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	promos []kargoapi.Promotion,
+) (bool, error) {
+	var policy *kargoapi.PromotionPolicy
+	var policyFound bool
+	now := time.Now()
+	for _, promo := range promos {
+		switch promo.Status.Phase {
+		case kargoapi.PromotionPhaseWaiting:
+			continue
+		case kargoapi.PromotionPhasePending, "":
+			if promo.Status.NextAttemptAt != nil &&
+				now.Before(promo.Status.NextAttemptAt.Time) {
+				continue
+			}
+			if !policyFound {
+				var err error
+				if policy, err = r.getPromotionPolicy(ctx, stage); err != nil {
+					return false, err
+				}
+				policyFound = true
+			}
+			if permitted, _ := kargo.IsPromotionPermitted(policy, now); !permitted {
+				continue
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// getPromotionPolicy returns the PromotionPolicy associated with the provided
+// Stage. If there is no such PromotionPolicy, nil is returned instead.
+func (r *reconciler) getPromotionPolicy(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+) (*kargoapi.PromotionPolicy, error) {
+	policies := kargoapi.PromotionPolicyList{}
+	if err := r.kargoClient.List(
+		ctx,
+		&policies,
+		&client.ListOptions{
+			Namespace: stage.Namespace,
+			FieldSelector: fields.Set(map[string]string{
+				kubeclient.PromotionPoliciesByStageIndexField: stage.Name,
+			}).AsSelector(),
+		},
+	); err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error listing PromotionPolicies for Stage %q in namespace %q",
+			stage.Name,
+			stage.Namespace,
 		)
 	}
-	return len(promos.Items) > 0, nil
+	// There should never be more than one PromotionPolicy per Stage
+	if len(policies.Items) == 0 {
+		return nil, nil
+	}
+	return &policies.Items[0], nil
 }
 
 func (r *reconciler) getLatestFreightFromRepos(
 	ctx context.Context,
 	namespace string,
 	repoSubs kargoapi.RepoSubscriptions,
-) (*kargoapi.Freight, error) {
+) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error) {
 	logger := logging.LoggerFromContext(ctx)
 
-	latestCommits, err := r.getLatestCommitsFn(ctx, namespace, repoSubs.Git)
-	if err != nil {
-		return nil, errors.Wrap(err, "error syncing git repo subscriptions")
+	// Git, image and chart repositories are hosted separately, so there is no
+	// reason for any of them to wait on the others
+	var latestCommits []kargoapi.GitCommit
+	var latestImages []kargoapi.Image
+	var rejectedImageTags []kargoapi.RejectedImageTag
+	var latestCharts []kargoapi.Chart
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		var err error
+		if latestCommits, err =
+			r.getLatestCommitsFn(gctx, namespace, repoSubs.Git); err != nil {
+			return errors.Wrap(err, "error syncing git repo subscriptions")
+		}
+		if len(repoSubs.Git) > 0 {
+			logger.Debug("synced git repo subscriptions")
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		if latestImages, rejectedImageTags, err =
+			r.getLatestImagesFn(gctx, namespace, repoSubs.Images); err != nil {
+			return errors.Wrap(err, "error syncing image repo subscriptions")
+		}
+		if len(repoSubs.Images) > 0 {
+			logger.Debug("synced image repo subscriptions")
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		if latestCharts, err =
+			r.getLatestChartsFn(gctx, namespace, repoSubs.Charts); err != nil {
+			return errors.Wrap(err, "error syncing chart repo subscriptions")
+		}
+		if len(repoSubs.Charts) > 0 {
+			logger.Debug("synced chart repo subscriptions")
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		// Image tags that were rejected are worth reporting regardless
+		return nil, rejectedImageTags, err
 	}
-	if len(repoSubs.Git) > 0 {
-		logger.Debug("synced git repo subscriptions")
+
+	now := metav1.Now()
+	freight := &kargoapi.SimpleFreight{
+		FirstSeen: &now,
+		Commits:   latestCommits,
+		Images:    latestImages,
+		Charts:    latestCharts,
+		Qualified: true,
 	}
+	freight.UpdateFreightID()
+	return freight, rejectedImageTags, nil
+}
 
-	latestImages, err := r.getLatestImagesFn(ctx, namespace, repoSubs.Images)
+// createFreight creates a Freight resource corresponding to the provided
+// SimpleFreight, unless one already exists. Either way, the Freight resource is
+// owned by the provided Stage, which discovered it, so that it is garbage
+// collected once every Stage that discovered it has been deleted.
+func (r *reconciler) createFreight(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	simpleFreight kargoapi.SimpleFreight,
+) error {
+	ownerRef := metav1.OwnerReference{
+		APIVersion: kargoapi.GroupVersion.String(),
+		Kind:       "Stage",
+		Name:       stage.Name,
+		UID:        stage.UID,
+	}
+	existing, err := kargoapi.GetFreight(
+		ctx,
+		r.kargoClient,
+		types.NamespacedName{
+			Namespace: stage.Namespace,
+			Name:      simpleFreight.ID,
+		},
+	)
 	if err != nil {
-		return nil, errors.Wrap(err, "error syncing image repo subscriptions")
+		return err
+	}
+	if existing != nil {
+		if isOwnedBy(existing, stage) {
+			return nil
+		}
+		patch := client.MergeFrom(existing.DeepCopy())
+		existing.OwnerReferences = append(existing.OwnerReferences, ownerRef)
+		return errors.Wrapf(
+			r.kargoClient.Patch(ctx, existing, patch),
+			"error adding owner reference to Freight %q in namespace %q",
+			simpleFreight.ID,
+			stage.Namespace,
+		)
 	}
-	if len(repoSubs.Images) > 0 {
-		logger.Debug("synced image repo subscriptions")
+	freight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            simpleFreight.ID,
+			Namespace:       stage.Namespace,
+			OwnerReferences: []metav1.OwnerReference{ownerRef},
+		},
+		Commits: simpleFreight.Commits,
+		Images:  simpleFreight.Images,
+		Charts:  simpleFreight.Charts,
 	}
+	if err = r.kargoClient.Create(ctx, freight); err != nil &&
+		!apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(
+			err,
+			"error creating Freight %q in namespace %q",
+			simpleFreight.ID,
+			stage.Namespace,
+		)
+	}
+	logging.LoggerFromContext(ctx).WithField("freight", simpleFreight.ID).
+		Debug("created Freight resource")
+	return nil
+}
 
-	latestCharts, err := r.getLatestChartsFn(ctx, namespace, repoSubs.Charts)
-	if err != nil {
-		return nil, errors.Wrap(err, "error syncing chart repo subscriptions")
+// pruneFreight deletes Freight resources discovered by the provided Stage,
+// beyond the newest freightRetentionLimit of them, provided no Stage in the
+// namespace still references them. Freight also discovered by other Stages is
+// only disowned by the provided Stage. The provided StageStatus is
+// used in place of the provided Stage's own status, which may not yet have been
+// updated.
+func (r *reconciler) pruneFreight(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	status kargoapi.StageStatus,
+) error {
+	freightList := kargoapi.FreightList{}
+	if err := r.kargoClient.List(
+		ctx,
+		&freightList,
+		client.InNamespace(stage.Namespace),
+	); err != nil {
+		return errors.Wrapf(
+			err,
+			"error listing Freight in namespace %q",
+			stage.Namespace,
+		)
 	}
-	if len(repoSubs.Charts) > 0 {
-		logger.Debug("synced chart repo subscriptions")
+	owned := make([]kargoapi.Freight, 0, len(freightList.Items))
+	for _, freight := range freightList.Items {
+		if isOwnedBy(&freight, stage) {
+			owned = append(owned, freight)
+		}
+	}
+	if len(owned) <= freightRetentionLimit {
+		return nil
 	}
+	sort.SliceStable(owned, func(i, j int) bool {
+		return owned[j].CreationTimestamp.Before(&owned[i].CreationTimestamp)
+	})
 
-	now := metav1.Now()
-	freight := &kargoapi.Freight{
-		FirstSeen: &now,
-		Commits:   latestCommits,
-		Images:    latestImages,
-		Charts:    latestCharts,
-		Qualified: true,
+	stageList := kargoapi.StageList{}
+	if err := r.kargoClient.List(
+		ctx,
+		&stageList,
+		client.InNamespace(stage.Namespace),
+	); err != nil {
+		return errors.Wrapf(
+			err,
+			"error listing Stages in namespace %q",
+			stage.Namespace,
+		)
 	}
-	freight.UpdateFreightID()
-	return freight, nil
+	referenced := map[string]struct{}{}
+	for _, s := range stageList.Items {
+		if s.Name != stage.Name {
+			addReferencedFreight(referenced, s.Status)
+		}
+	}
+	addReferencedFreight(referenced, status)
+
+	logger := logging.LoggerFromContext(ctx)
+	for _, freight := range owned[freightRetentionLimit:] {
+		freight := freight
+		if _, ok := referenced[freight.Name]; ok {
+			continue
+		}
+		// Freight that was approved for a Stage was deliberately singled out
+		if len(freight.Status.ApprovedFor) > 0 {
+			continue
+		}
+		// Freight that other Stages also discovered is merely disowned, leaving
+		// it for the last of them to prune
+		if len(freight.OwnerReferences) > 1 {
+			patch := client.MergeFrom(freight.DeepCopy())
+			ownerRefs := make([]metav1.OwnerReference, 0, len(freight.OwnerReferences))
+			for _, ownerRef := range freight.OwnerReferences {
+				if ownerRef.UID != stage.UID {
+					ownerRefs = append(ownerRefs, ownerRef)
+				}
+			}
+			freight.OwnerReferences = ownerRefs
+			if err := r.kargoClient.Patch(ctx, &freight, patch); err != nil {
+				return errors.Wrapf(
+					err,
+					"error removing owner reference from Freight %q in namespace %q",
+					freight.Name,
+					freight.Namespace,
+				)
+			}
+			continue
+		}
+		if err := r.kargoClient.Delete(ctx, &freight); err != nil &&
+			!apierrors.IsNotFound(err) {
+			return errors.Wrapf(
+				err,
+				"error deleting Freight %q in namespace %q",
+				freight.Name,
+				freight.Namespace,
+			)
+		}
+		logger.WithField("freight", freight.Name).Debug("pruned Freight")
+	}
+	return nil
+}
+
+// isOwnedBy returns true if the provided Freight is owned by the provided
+// Stage.
+func isOwnedBy(freight *kargoapi.Freight, stage *kargoapi.Stage) bool {
+	for _, ownerRef := range freight.OwnerReferences {
+		if ownerRef.UID == stage.UID {
+			return true
+		}
+	}
+	return false
+}
+
+// addReferencedFreight adds the IDs of all Freight referenced by the provided
+// StageStatus to the provided set.
+func addReferencedFreight(
+	referenced map[string]struct{},
+	status kargoapi.StageStatus,
+) {
+	for _, freight := range status.AvailableFreight {
+		referenced[freight.ID] = struct{}{}
+	}
+	for _, freight := range status.History {
+		referenced[freight.ID] = struct{}{}
+	}
+	if status.CurrentFreight != nil {
+		referenced[status.CurrentFreight.ID] = struct{}{}
+	}
+	if status.CurrentPromotion != nil {
+		referenced[status.CurrentPromotion.Freight.ID] = struct{}{}
+	}
+}
+
+// verifyFreightInStage records, in the status of the Freight resource
+// identified by the freightID argument, that the Freight has been verified in
+// the specified Stage. If no such Freight resource exists, this is a no-op.
+func (r *reconciler) verifyFreightInStage(
+	ctx context.Context,
+	namespace string,
+	freightID string,
+	stageName string,
+) error {
+	freight, err := kargoapi.GetFreight(
+		ctx,
+		r.kargoClient,
+		types.NamespacedName{
+			Namespace: namespace,
+			Name:      freightID,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if freight == nil {
+		return nil
+	}
+	if freight.IsVerifiedIn(stageName) {
+		return nil
+	}
+	return errors.Wrapf(
+		kubeclient.PatchStatus(
+			ctx,
+			r.kargoClient,
+			freight,
+			func(status *kargoapi.FreightStatus) {
+				if status.VerifiedIn == nil {
+					status.VerifiedIn = map[string]kargoapi.VerifiedStage{}
+				}
+				now := metav1.Now()
+				status.VerifiedIn[stageName] = kargoapi.VerifiedStage{
+					VerifiedAt: &now,
+				}
+			},
+		),
+		"error updating status of Freight %q in namespace %q",
+		freightID,
+		namespace,
+	)
 }
 
-// TODO: Test this
+// getAvailableFreightFromUpstreamStages returns de-duped, qualified Freight
+// from the specified upstream Stages. When the JoinStrategyAll strategy is
+// specified, only Freight that has been qualified in every one of the upstream
+// Stages is returned. Otherwise, Freight that has been qualified in any one of
+// them is returned. When there are multiple upstream Stages, the Freight
+// returned is ordered from newest to oldest.
 func (r *reconciler) getAvailableFreightFromUpstreamStages(
 	ctx context.Context,
 	namespace string,
 	subs []kargoapi.StageSubscription,
-) ([]kargoapi.Freight, error) {
+	joinStrategy kargoapi.JoinStrategy,
+) ([]kargoapi.SimpleFreight, error) {
 	if len(subs) == 0 {
 		return nil, nil
 	}
 
-	availableFreight := make([]kargoapi.Freight, 0, len(subs))
-	freightSet := map[string]struct{}{} // We'll use this to de-dupe
+	availableFreight := make([]kargoapi.SimpleFreight, 0, len(subs))
+	// We'll use this to de-dupe and to count the number of upstream Stages in
+	// which each piece of Freight has been qualified
+	qualifiedCounts := map[string]int{}
 	for _, sub := range subs {
 		upstreamStage, err := kargoapi.GetStage(
 			ctx,
@@ -619,17 +1119,45 @@ func (r *reconciler) getAvailableFreightFromUpstreamStages(
 				namespace,
 			)
 		}
+		// An upstream Stage's history could conceivably contain the same Freight
+		// more than once, so we de-dupe within each upstream Stage as well.
+		upstreamFreightSet := map[string]struct{}{}
 		for _, freight := range upstreamStage.Status.History {
-			if _, ok := freightSet[freight.ID]; !ok && freight.Qualified {
-				freight.Provenance = upstreamStage.Name
-				for i := range freight.Commits {
-					freight.Commits[i].HealthCheckCommit = ""
-				}
-				availableFreight = append(availableFreight, freight)
-				freightSet[freight.ID] = struct{}{}
+			if !freight.Qualified {
+				continue
+			}
+			if _, ok := upstreamFreightSet[freight.ID]; ok {
+				continue
+			}
+			upstreamFreightSet[freight.ID] = struct{}{}
+			qualifiedCounts[freight.ID]++
+			if qualifiedCounts[freight.ID] > 1 {
+				continue
 			}
+			freight = freight.Reference()
+			freight.Provenance = upstreamStage.Name
+			freight.Rollback = false
+			availableFreight = append(availableFreight, freight)
 		}
 	}
 
+	if joinStrategy == kargoapi.JoinStrategyAll {
+		joinedFreight := make([]kargoapi.SimpleFreight, 0, len(availableFreight))
+		for _, freight := range availableFreight {
+			if qualifiedCounts[freight.ID] == len(subs) {
+				joinedFreight = append(joinedFreight, freight)
+			}
+		}
+		availableFreight = joinedFreight
+	}
+
+	if len(subs) > 1 {
+		// Freight from different upstream Stages is interleaved so that the
+		// newest Freight is always at the top of the stack.
+		sort.SliceStable(availableFreight, func(i, j int) bool {
+			return availableFreight[j].FirstSeen.Before(availableFreight[i].FirstSeen)
+		})
+	}
+
 	return availableFreight, nil
 }
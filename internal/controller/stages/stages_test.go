@@ -2,17 +2,22 @@ package stages
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/discovery"
+	libGit "github.com/akuity/kargo/internal/git"
 )
 
 func TestNewStageReconciler(t *testing.T) {
@@ -21,22 +26,33 @@ func TestNewStageReconciler(t *testing.T) {
 		kubeClient,
 		kubeClient,
 		&credentials.FakeDB{},
+		&libGit.Cache{},
+		discovery.NewService(discovery.Config{}),
 	)
 	require.NotNil(t, e.kargoClient)
 	require.NotNil(t, e.argoClient)
 	require.NotNil(t, e.credentialsDB)
+	require.NotNil(t, e.gitCache)
+	require.NotNil(t, e.discovery)
 
 	// Assert that all overridable behaviors were initialized to a default:
 
 	// Loop guard:
-	require.NotNil(t, e.hasNonTerminalPromotionsFn)
+	require.NotNil(t, e.getNonTerminalPromotionsFn)
 
 	// Common:
 	require.NotNil(t, e.getArgoCDAppFn)
 
+	// Freight:
+	require.NotNil(t, e.createFreightFn)
+	require.NotNil(t, e.verifyFreightInStageFn)
+
 	// Health checks:
 	require.NotNil(t, e.checkHealthFn)
 
+	// Verification:
+	require.NotNil(t, e.verifyFn)
+
 	// Syncing:
 	require.NotNil(t, e.getLatestFreightFromReposFn)
 	require.NotNil(t, e.getAvailableFreightFromUpstreamStagesFn)
@@ -56,8 +72,33 @@ func TestSync(t *testing.T) {
 		context.Context,
 		string,
 		string,
-	) (bool, error) {
-		return false, nil
+	) ([]kargoapi.Promotion, error) {
+		return nil, nil
+	}
+
+	noOpVerifyFreightInStageFn := func(
+		context.Context,
+		string,
+		string,
+		string,
+	) error {
+		return nil
+	}
+
+	noOpCreateFreightFn := func(
+		context.Context,
+		*kargoapi.Stage,
+		kargoapi.SimpleFreight,
+	) error {
+		return nil
+	}
+
+	noOpPruneFreightFn := func(
+		context.Context,
+		*kargoapi.Stage,
+		kargoapi.StageStatus,
+	) error {
+		return nil
 	}
 
 	testCases := []struct {
@@ -75,12 +116,12 @@ func TestSync(t *testing.T) {
 			name:  "error checking for non-terminal promotions",
 			stage: &kargoapi.Stage{},
 			reconciler: &reconciler{
-				hasNonTerminalPromotionsFn: func(
+				getNonTerminalPromotionsFn: func(
 					context.Context,
 					string,
 					string,
-				) (bool, error) {
-					return false, errors.New("something went wrong")
+				) ([]kargoapi.Promotion, error) {
+					return nil, errors.New("something went wrong")
 				},
 			},
 			assertions: func(
@@ -97,24 +138,51 @@ func TestSync(t *testing.T) {
 		},
 
 		{
-			name: "non-terminal promotions found",
+			name: "promotion in progress",
 			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions:       &kargoapi.Subscriptions{},
+					PromotionMechanisms: &kargoapi.PromotionMechanisms{},
+				},
 				Status: kargoapi.StageStatus{
 					CurrentPromotion: &kargoapi.PromotionInfo{
 						Name: "dev.abc123.def456",
-						Freight: kargoapi.Freight{
+						Freight: kargoapi.SimpleFreight{
 							ID: "xyz789",
 						},
 					},
+					CurrentFreight: &kargoapi.SimpleFreight{
+						ID: "abc123",
+					},
+					History: kargoapi.FreightStack{{ID: "abc123"}},
 				},
 			},
 			reconciler: &reconciler{
-				hasNonTerminalPromotionsFn: func(
+				getNonTerminalPromotionsFn: func(
+					context.Context,
+					string,
+					string,
+				) ([]kargoapi.Promotion, error) {
+					return []kargoapi.Promotion{{
+						Status: kargoapi.PromotionStatus{
+							Phase: kargoapi.PromotionPhaseRunning,
+						},
+					}}, nil
+				},
+				checkHealthFn: func(
+					context.Context,
+					*kargoapi.SimpleFreight,
+					[]kargoapi.ArgoCDAppUpdate,
+				) *kargoapi.Health {
+					return &kargoapi.Health{Status: kargoapi.HealthStateHealthy}
+				},
+				verifyFreightInStageFn: func(
 					context.Context,
 					string,
 					string,
-				) (bool, error) {
-					return true, nil
+					string,
+				) error {
+					return errors.New("current Freight should not have been verified")
 				},
 			},
 			assertions: func(
@@ -124,49 +192,72 @@ func TestSync(t *testing.T) {
 				err error,
 			) {
 				require.NoError(t, err)
-				// Status should be returned unchanged
-				require.Equal(t, initialStatus, newStatus)
+				// Health is still checked...
+				require.Equal(
+					t,
+					&kargoapi.Health{Status: kargoapi.HealthStateHealthy},
+					newStatus.Health,
+				)
+				// ...but what the Promotion updates is left alone
+				require.Equal(t, initialStatus.CurrentPromotion, newStatus.CurrentPromotion)
+				require.Equal(t, initialStatus.CurrentFreight, newStatus.CurrentFreight)
+				require.Equal(t, initialStatus.History, newStatus.History)
 			},
 		},
 
 		{
-			name: "no non-terminal promotions found",
+			name: "waiting promotion does not stop Freight discovery",
 			stage: &kargoapi.Stage{
 				Spec: &kargoapi.StageSpec{
-					Subscriptions: &kargoapi.Subscriptions{},
+					Subscriptions: &kargoapi.Subscriptions{
+						Repos: &kargoapi.RepoSubscriptions{},
+					},
+					PromotionMechanisms: &kargoapi.PromotionMechanisms{},
 				},
 				Status: kargoapi.StageStatus{
-					CurrentPromotion: &kargoapi.PromotionInfo{ // This should get cleared
+					CurrentPromotion: &kargoapi.PromotionInfo{
 						Name: "dev.abc123.def456",
-						Freight: kargoapi.Freight{
+						Freight: kargoapi.SimpleFreight{
 							ID: "xyz789",
 						},
 					},
+					CurrentFreight: &kargoapi.SimpleFreight{
+						ID: "abc123",
+					},
+					AvailableFreight: kargoapi.FreightStack{{ID: "abc123"}},
+					History:          kargoapi.FreightStack{{ID: "abc123"}},
 				},
 			},
 			reconciler: &reconciler{
-				hasNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
-			},
-			assertions: func(
-				initialStatus kargoapi.StageStatus,
-				newStatus kargoapi.StageStatus,
-				_ client.Client,
-				err error,
-			) {
-				require.NoError(t, err)
-				require.Nil(t, newStatus.CurrentPromotion)
-			},
-		},
-
-		{
-			name: "no subscriptions",
-			stage: &kargoapi.Stage{
-				Spec: &kargoapi.StageSpec{
-					Subscriptions: &kargoapi.Subscriptions{},
+				kargoClient: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				getNonTerminalPromotionsFn: func(
+					context.Context,
+					string,
+					string,
+				) ([]kargoapi.Promotion, error) {
+					return []kargoapi.Promotion{{
+						Status: kargoapi.PromotionStatus{
+							Phase: kargoapi.PromotionPhaseWaiting,
+						},
+					}}, nil
+				},
+				checkHealthFn: func(
+					context.Context,
+					*kargoapi.SimpleFreight,
+					[]kargoapi.ArgoCDAppUpdate,
+				) *kargoapi.Health {
+					return &kargoapi.Health{Status: kargoapi.HealthStateHealthy}
+				},
+				verifyFreightInStageFn: noOpVerifyFreightInStageFn,
+				createFreightFn:        noOpCreateFreightFn,
+				pruneFreightFn:         noOpPruneFreightFn,
+				getLatestFreightFromReposFn: func(
+					context.Context,
+					string,
+					kargoapi.RepoSubscriptions,
+				) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error) {
+					return &kargoapi.SimpleFreight{ID: "def456"}, nil, nil
 				},
-			},
-			reconciler: &reconciler{
-				hasNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
 			},
 			assertions: func(
 				initialStatus kargoapi.StageStatus,
@@ -175,169 +266,207 @@ func TestSync(t *testing.T) {
 				err error,
 			) {
 				require.NoError(t, err)
-				// Status should be returned unchanged
-				require.Equal(t, initialStatus, newStatus)
+				require.Equal(
+					t,
+					&kargoapi.Health{Status: kargoapi.HealthStateHealthy},
+					newStatus.Health,
+				)
+				// The current Freight was qualified
+				require.True(t, newStatus.CurrentFreight.Qualified)
+				// New Freight was discovered
+				require.Equal(
+					t,
+					kargoapi.FreightStack{{ID: "def456"}, {ID: "abc123"}},
+					newStatus.AvailableFreight,
+				)
+				// The Waiting Promotion is still the current one
+				require.Equal(t, initialStatus.CurrentPromotion, newStatus.CurrentPromotion)
 			},
 		},
 
 		{
-			name: "error getting latest Freight from repos",
+			name: "pending promotion blocked by policy does not stop Freight discovery",
 			stage: &kargoapi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fake-stage",
+					Namespace: "fake-namespace",
+				},
 				Spec: &kargoapi.StageSpec{
 					Subscriptions: &kargoapi.Subscriptions{
 						Repos: &kargoapi.RepoSubscriptions{},
 					},
+					PromotionMechanisms: &kargoapi.PromotionMechanisms{},
+				},
+				Status: kargoapi.StageStatus{
+					CurrentFreight: &kargoapi.SimpleFreight{
+						ID: "abc123",
+					},
+					AvailableFreight: kargoapi.FreightStack{{ID: "abc123"}},
+					History:          kargoapi.FreightStack{{ID: "abc123"}},
 				},
 			},
 			reconciler: &reconciler{
-				hasNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				kargoClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					&kargoapi.PromotionPolicy{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "fake-policy",
+							Namespace: "fake-namespace",
+						},
+						Stage: "fake-stage",
+						Blackouts: []kargoapi.PromotionWindow{{
+							Schedule: "* * * * *",
+							Duration: metav1.Duration{Duration: time.Hour},
+						}},
+					},
+				).Build(),
+				getNonTerminalPromotionsFn: func(
+					context.Context,
+					string,
+					string,
+				) ([]kargoapi.Promotion, error) {
+					return []kargoapi.Promotion{{
+						Status: kargoapi.PromotionStatus{
+							Phase: kargoapi.PromotionPhasePending,
+						},
+					}}, nil
+				},
+				checkHealthFn: func(
+					context.Context,
+					*kargoapi.SimpleFreight,
+					[]kargoapi.ArgoCDAppUpdate,
+				) *kargoapi.Health {
+					return &kargoapi.Health{Status: kargoapi.HealthStateHealthy}
+				},
+				verifyFreightInStageFn: noOpVerifyFreightInStageFn,
+				createFreightFn:        noOpCreateFreightFn,
+				pruneFreightFn:         noOpPruneFreightFn,
 				getLatestFreightFromReposFn: func(
 					context.Context,
 					string,
 					kargoapi.RepoSubscriptions,
-				) (*kargoapi.Freight, error) {
-					return nil, errors.New("something went wrong")
+				) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error) {
+					return &kargoapi.SimpleFreight{ID: "def456"}, nil, nil
 				},
 			},
 			assertions: func(
-				initialStatus kargoapi.StageStatus,
+				_ kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
 				_ client.Client,
 				err error,
 			) {
-				require.Error(t, err)
-				require.Equal(t, "something went wrong", err.Error())
-				// Status should be unchanged
-				require.Equal(t, initialStatus, newStatus)
+				require.NoError(t, err)
+				require.True(t, newStatus.CurrentFreight.Qualified)
+				require.Equal(
+					t,
+					kargoapi.FreightStack{{ID: "def456"}, {ID: "abc123"}},
+					newStatus.AvailableFreight,
+				)
 			},
 		},
 
 		{
-			name: "no latest Freight from repos",
+			name: "pending promotion awaiting retry does not stop Freight discovery",
 			stage: &kargoapi.Stage{
 				Spec: &kargoapi.StageSpec{
 					Subscriptions: &kargoapi.Subscriptions{
 						Repos: &kargoapi.RepoSubscriptions{},
 					},
+					PromotionMechanisms: &kargoapi.PromotionMechanisms{},
+				},
+				Status: kargoapi.StageStatus{
+					CurrentFreight: &kargoapi.SimpleFreight{
+						ID: "abc123",
+					},
+					AvailableFreight: kargoapi.FreightStack{{ID: "abc123"}},
+					History:          kargoapi.FreightStack{{ID: "abc123"}},
 				},
 			},
 			reconciler: &reconciler{
-				hasNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				kargoClient: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				getNonTerminalPromotionsFn: func(
+					context.Context,
+					string,
+					string,
+				) ([]kargoapi.Promotion, error) {
+					nextAttemptAt := metav1.NewTime(time.Now().Add(time.Hour))
+					return []kargoapi.Promotion{{
+						Status: kargoapi.PromotionStatus{
+							Phase:         kargoapi.PromotionPhasePending,
+							NextAttemptAt: &nextAttemptAt,
+						},
+					}}, nil
+				},
+				checkHealthFn: func(
+					context.Context,
+					*kargoapi.SimpleFreight,
+					[]kargoapi.ArgoCDAppUpdate,
+				) *kargoapi.Health {
+					return &kargoapi.Health{Status: kargoapi.HealthStateHealthy}
+				},
+				verifyFreightInStageFn: noOpVerifyFreightInStageFn,
+				createFreightFn:        noOpCreateFreightFn,
+				pruneFreightFn:         noOpPruneFreightFn,
 				getLatestFreightFromReposFn: func(
 					context.Context,
 					string,
 					kargoapi.RepoSubscriptions,
-				) (*kargoapi.Freight, error) {
-					return nil, nil
+				) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error) {
+					return &kargoapi.SimpleFreight{ID: "def456"}, nil, nil
 				},
 			},
 			assertions: func(
-				initialStatus kargoapi.StageStatus,
+				_ kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
 				_ client.Client,
 				err error,
 			) {
 				require.NoError(t, err)
-				// Status should be returned unchanged
-				require.Equal(t, initialStatus, newStatus)
+				require.True(t, newStatus.CurrentFreight.Qualified)
+				require.Equal(
+					t,
+					kargoapi.FreightStack{{ID: "def456"}, {ID: "abc123"}},
+					newStatus.AvailableFreight,
+				)
 			},
 		},
 
 		{
-			name: "latest Freight from repos isn't new",
+			name: "pending promotion permitted by policy is in progress",
 			stage: &kargoapi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fake-stage",
+					Namespace: "fake-namespace",
+				},
 				Spec: &kargoapi.StageSpec{
-					Subscriptions: &kargoapi.Subscriptions{
-						Repos: &kargoapi.RepoSubscriptions{},
-					},
+					Subscriptions:       &kargoapi.Subscriptions{},
 					PromotionMechanisms: &kargoapi.PromotionMechanisms{},
-					// TODO: I'm not sure about this change
-					// HealthChecks: &kargoapi.HealthChecks{},
 				},
 				Status: kargoapi.StageStatus{
-					Health: &kargoapi.Health{
-						Status: kargoapi.HealthStateHealthy,
-					},
-					AvailableFreight: []kargoapi.Freight{
-						{
-							Commits: []kargoapi.GitCommit{
-								{
-									RepoURL: "fake-url",
-									ID:      "fake-commit",
-								},
-							},
-							Images: []kargoapi.Image{
-								{
-									RepoURL: "fake-url",
-									Tag:     "fake-tag",
-								},
-							},
-						},
-					},
-					CurrentFreight: &kargoapi.Freight{
-						Commits: []kargoapi.GitCommit{
-							{
-								RepoURL: "fake-url",
-								ID:      "fake-commit",
-							},
-						},
-						Images: []kargoapi.Image{
-							{
-								RepoURL: "fake-url",
-								Tag:     "fake-tag",
-							},
-						},
-						Qualified: true,
-					},
-					History: []kargoapi.Freight{
-						{
-							Commits: []kargoapi.GitCommit{
-								{
-									RepoURL: "fake-url",
-									ID:      "fake-commit",
-								},
-							},
-							Images: []kargoapi.Image{
-								{
-									RepoURL: "fake-url",
-									Tag:     "fake-tag",
-								},
-							},
-							Qualified: true,
-						},
+					CurrentFreight: &kargoapi.SimpleFreight{
+						ID: "abc123",
 					},
+					History: kargoapi.FreightStack{{ID: "abc123"}},
 				},
 			},
 			reconciler: &reconciler{
-				hasNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				kargoClient: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				getNonTerminalPromotionsFn: func(
+					context.Context,
+					string,
+					string,
+				) ([]kargoapi.Promotion, error) {
+					return []kargoapi.Promotion{{
+						Status: kargoapi.PromotionStatus{
+							Phase: kargoapi.PromotionPhasePending,
+						},
+					}}, nil
+				},
 				checkHealthFn: func(
 					context.Context,
-					*kargoapi.Freight,
+					*kargoapi.SimpleFreight,
 					[]kargoapi.ArgoCDAppUpdate,
 				) *kargoapi.Health {
-					return &kargoapi.Health{
-						Status: kargoapi.HealthStateHealthy,
-					}
-				},
-				getLatestFreightFromReposFn: func(
-					context.Context,
-					string,
-					kargoapi.RepoSubscriptions,
-				) (*kargoapi.Freight, error) {
-					return &kargoapi.Freight{
-						Commits: []kargoapi.GitCommit{
-							{
-								RepoURL: "fake-url",
-								ID:      "fake-commit",
-							},
-						},
-						Images: []kargoapi.Image{
-							{
-								RepoURL: "fake-url",
-								Tag:     "fake-tag",
-							},
-						},
-					}, nil
+					return &kargoapi.Health{Status: kargoapi.HealthStateHealthy}
 				},
 			},
 			assertions: func(
@@ -347,33 +476,28 @@ func TestSync(t *testing.T) {
 				err error,
 			) {
 				require.NoError(t, err)
-				// Status should be returned unchanged
-				require.Equal(t, initialStatus, newStatus)
+				require.Equal(t, initialStatus.CurrentFreight, newStatus.CurrentFreight)
+				require.Equal(t, initialStatus.History, newStatus.History)
 			},
 		},
 
 		{
-			name: "error getting available Freight from upstream Stages",
+			name: "no non-terminal promotions found",
 			stage: &kargoapi.Stage{
 				Spec: &kargoapi.StageSpec{
-					Subscriptions: &kargoapi.Subscriptions{
-						UpstreamStages: []kargoapi.StageSubscription{
-							{
-								Name: "fake-name",
-							},
+					Subscriptions: &kargoapi.Subscriptions{},
+				},
+				Status: kargoapi.StageStatus{
+					CurrentPromotion: &kargoapi.PromotionInfo{ // This should get cleared
+						Name: "dev.abc123.def456",
+						Freight: kargoapi.SimpleFreight{
+							ID: "xyz789",
 						},
 					},
 				},
 			},
 			reconciler: &reconciler{
-				hasNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
-				getAvailableFreightFromUpstreamStagesFn: func(
-					context.Context,
-					string,
-					[]kargoapi.StageSubscription,
-				) ([]kargoapi.Freight, error) {
-					return nil, errors.New("something went wrong")
-				},
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
 			},
 			assertions: func(
 				initialStatus kargoapi.StageStatus,
@@ -381,20 +505,444 @@ func TestSync(t *testing.T) {
 				_ client.Client,
 				err error,
 			) {
-				require.Error(t, err)
-				require.Equal(t, "something went wrong", err.Error())
-				// Status should be unchanged
-				require.Equal(t, initialStatus, newStatus)
+				require.NoError(t, err)
+				require.Nil(t, newStatus.CurrentPromotion)
 			},
 		},
 
 		{
-			name: "no latest Freight from upstream Stages",
+			name: "no subscriptions",
 			stage: &kargoapi.Stage{
 				Spec: &kargoapi.StageSpec{
-					Subscriptions: &kargoapi.Subscriptions{
-						UpstreamStages: []kargoapi.StageSubscription{
-							{
+					Subscriptions: &kargoapi.Subscriptions{},
+				},
+			},
+			reconciler: &reconciler{
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+			},
+			assertions: func(
+				initialStatus kargoapi.StageStatus,
+				newStatus kargoapi.StageStatus,
+				_ client.Client,
+				err error,
+			) {
+				require.NoError(t, err)
+				// Status should be returned unchanged
+				require.Equal(t, initialStatus, newStatus)
+			},
+		},
+
+		{
+			name: "error getting latest Freight from repos",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						Repos: &kargoapi.RepoSubscriptions{},
+					},
+				},
+			},
+			reconciler: &reconciler{
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				createFreightFn:            noOpCreateFreightFn,
+				pruneFreightFn:             noOpPruneFreightFn,
+				getLatestFreightFromReposFn: func(
+					context.Context,
+					string,
+					kargoapi.RepoSubscriptions,
+				) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error) {
+					return nil, nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(
+				initialStatus kargoapi.StageStatus,
+				newStatus kargoapi.StageStatus,
+				_ client.Client,
+				err error,
+			) {
+				require.Error(t, err)
+				require.Equal(t, "something went wrong", err.Error())
+				// Status should be unchanged
+				require.Equal(t, initialStatus, newStatus)
+			},
+		},
+
+		{
+			name: "every image tag rejected by verification",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						Repos: &kargoapi.RepoSubscriptions{},
+					},
+				},
+				Status: kargoapi.StageStatus{
+					RejectedImageTags: []kargoapi.RejectedImageTag{
+						{
+							RepoURL: "fake-url",
+							Tag:     "stale-fake-tag",
+						},
+					},
+				},
+			},
+			reconciler: &reconciler{
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				createFreightFn:            noOpCreateFreightFn,
+				pruneFreightFn:             noOpPruneFreightFn,
+				getLatestFreightFromReposFn: func(
+					context.Context,
+					string,
+					kargoapi.RepoSubscriptions,
+				) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error) {
+					return nil,
+						[]kargoapi.RejectedImageTag{
+							{
+								RepoURL: "fake-url",
+								Tag:     "fake-tag",
+								Reason:  "image is not signed",
+							},
+						},
+						errors.New("something went wrong")
+				},
+			},
+			assertions: func(
+				initialStatus kargoapi.StageStatus,
+				newStatus kargoapi.StageStatus,
+				_ client.Client,
+				err error,
+			) {
+				require.Error(t, err)
+				require.Equal(t, "something went wrong", err.Error())
+				// Rejected tags should be reported in spite of the error
+				require.Equal(
+					t,
+					[]kargoapi.RejectedImageTag{
+						{
+							RepoURL: "fake-url",
+							Tag:     "fake-tag",
+							Reason:  "image is not signed",
+						},
+					},
+					newStatus.RejectedImageTags,
+				)
+				// Status should be otherwise unchanged
+				newStatus.RejectedImageTags = initialStatus.RejectedImageTags
+				require.Equal(t, initialStatus, newStatus)
+			},
+		},
+
+		{
+			name: "no latest Freight from repos",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						Repos: &kargoapi.RepoSubscriptions{},
+					},
+				},
+			},
+			reconciler: &reconciler{
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				createFreightFn:            noOpCreateFreightFn,
+				pruneFreightFn:             noOpPruneFreightFn,
+				getLatestFreightFromReposFn: func(
+					context.Context,
+					string,
+					kargoapi.RepoSubscriptions,
+				) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error) {
+					return nil, nil, nil
+				},
+			},
+			assertions: func(
+				initialStatus kargoapi.StageStatus,
+				newStatus kargoapi.StageStatus,
+				_ client.Client,
+				err error,
+			) {
+				require.NoError(t, err)
+				// Status should be returned unchanged
+				require.Equal(t, initialStatus, newStatus)
+			},
+		},
+
+		{
+			name: "latest Freight from repos isn't new",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						Repos: &kargoapi.RepoSubscriptions{},
+					},
+					PromotionMechanisms: &kargoapi.PromotionMechanisms{},
+					// TODO: I'm not sure about this change
+					// HealthChecks: &kargoapi.HealthChecks{},
+				},
+				Status: kargoapi.StageStatus{
+					Health: &kargoapi.Health{
+						Status: kargoapi.HealthStateHealthy,
+					},
+					AvailableFreight: []kargoapi.SimpleFreight{
+						{
+							ID: "fake-id",
+						},
+					},
+					CurrentFreight: &kargoapi.SimpleFreight{
+						ID: "fake-id",
+						Commits: []kargoapi.GitCommit{
+							{
+								RepoURL: "fake-url",
+								ID:      "fake-commit",
+							},
+						},
+						Images: []kargoapi.Image{
+							{
+								RepoURL: "fake-url",
+								Tag:     "fake-tag",
+							},
+						},
+						Qualified: true,
+					},
+					History: []kargoapi.SimpleFreight{
+						{
+							ID:        "fake-id",
+							Qualified: true,
+						},
+					},
+				},
+			},
+			reconciler: &reconciler{
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				verifyFreightInStageFn:     noOpVerifyFreightInStageFn,
+				checkHealthFn: func(
+					context.Context,
+					*kargoapi.SimpleFreight,
+					[]kargoapi.ArgoCDAppUpdate,
+				) *kargoapi.Health {
+					return &kargoapi.Health{
+						Status: kargoapi.HealthStateHealthy,
+					}
+				},
+				createFreightFn: noOpCreateFreightFn,
+				pruneFreightFn:  noOpPruneFreightFn,
+				getLatestFreightFromReposFn: func(
+					context.Context,
+					string,
+					kargoapi.RepoSubscriptions,
+				) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error) {
+					return &kargoapi.SimpleFreight{
+						ID: "fake-id",
+						Commits: []kargoapi.GitCommit{
+							{
+								RepoURL: "fake-url",
+								ID:      "fake-commit",
+							},
+						},
+						Images: []kargoapi.Image{
+							{
+								RepoURL: "fake-url",
+								Tag:     "fake-tag",
+							},
+						},
+					}, nil, nil
+				},
+			},
+			assertions: func(
+				initialStatus kargoapi.StageStatus,
+				newStatus kargoapi.StageStatus,
+				_ client.Client,
+				err error,
+			) {
+				require.NoError(t, err)
+				// Status should be returned unchanged
+				require.Equal(t, initialStatus, newStatus)
+			},
+		},
+
+		{
+			name: "current Freight not yet verified",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						Repos: &kargoapi.RepoSubscriptions{},
+					},
+					PromotionMechanisms: &kargoapi.PromotionMechanisms{},
+					Verification:        &kargoapi.Verification{},
+				},
+				Status: kargoapi.StageStatus{
+					CurrentFreight: &kargoapi.SimpleFreight{
+						ID: "fake-freight",
+					},
+					History: []kargoapi.SimpleFreight{
+						{
+							ID: "fake-freight",
+						},
+					},
+				},
+			},
+			reconciler: &reconciler{
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				checkHealthFn: func(
+					context.Context,
+					*kargoapi.SimpleFreight,
+					[]kargoapi.ArgoCDAppUpdate,
+				) *kargoapi.Health {
+					return &kargoapi.Health{
+						Status: kargoapi.HealthStateHealthy,
+					}
+				},
+				verifyFn: func(
+					_ context.Context,
+					_ *kargoapi.Stage,
+					freightID string,
+					_ *kargoapi.VerificationInfo,
+				) (*kargoapi.VerificationInfo, error) {
+					return &kargoapi.VerificationInfo{
+						FreightID: freightID,
+						Phase:     kargoapi.VerificationPhaseRunning,
+					}, nil
+				},
+				createFreightFn: noOpCreateFreightFn,
+				pruneFreightFn:  noOpPruneFreightFn,
+				getLatestFreightFromReposFn: func(
+					context.Context,
+					string,
+					kargoapi.RepoSubscriptions,
+				) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error) {
+					return nil, nil, nil
+				},
+			},
+			assertions: func(
+				_ kargoapi.StageStatus,
+				newStatus kargoapi.StageStatus,
+				_ client.Client,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					&kargoapi.VerificationInfo{
+						FreightID: "fake-freight",
+						Phase:     kargoapi.VerificationPhaseRunning,
+					},
+					newStatus.Verification,
+				)
+				// Freight should not be qualified until it has been verified
+				require.False(t, newStatus.CurrentFreight.Qualified)
+				require.False(t, newStatus.History[0].Qualified)
+			},
+		},
+
+		{
+			name: "current Freight verified",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						Repos: &kargoapi.RepoSubscriptions{},
+					},
+					PromotionMechanisms: &kargoapi.PromotionMechanisms{},
+					Verification:        &kargoapi.Verification{},
+				},
+				Status: kargoapi.StageStatus{
+					CurrentFreight: &kargoapi.SimpleFreight{
+						ID: "fake-freight",
+					},
+					History: []kargoapi.SimpleFreight{
+						{
+							ID: "fake-freight",
+						},
+					},
+				},
+			},
+			reconciler: &reconciler{
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				verifyFreightInStageFn:     noOpVerifyFreightInStageFn,
+				checkHealthFn: func(
+					context.Context,
+					*kargoapi.SimpleFreight,
+					[]kargoapi.ArgoCDAppUpdate,
+				) *kargoapi.Health {
+					return &kargoapi.Health{
+						Status: kargoapi.HealthStateHealthy,
+					}
+				},
+				verifyFn: func(
+					_ context.Context,
+					_ *kargoapi.Stage,
+					freightID string,
+					_ *kargoapi.VerificationInfo,
+				) (*kargoapi.VerificationInfo, error) {
+					return &kargoapi.VerificationInfo{
+						FreightID: freightID,
+						Phase:     kargoapi.VerificationPhaseSucceeded,
+					}, nil
+				},
+				createFreightFn: noOpCreateFreightFn,
+				pruneFreightFn:  noOpPruneFreightFn,
+				getLatestFreightFromReposFn: func(
+					context.Context,
+					string,
+					kargoapi.RepoSubscriptions,
+				) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error) {
+					return nil, nil, nil
+				},
+			},
+			assertions: func(
+				_ kargoapi.StageStatus,
+				newStatus kargoapi.StageStatus,
+				_ client.Client,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					kargoapi.VerificationPhaseSucceeded,
+					newStatus.Verification.Phase,
+				)
+				require.True(t, newStatus.CurrentFreight.Qualified)
+				require.True(t, newStatus.History[0].Qualified)
+			},
+		},
+
+		{
+			name: "error getting available Freight from upstream Stages",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						UpstreamStages: []kargoapi.StageSubscription{
+							{
+								Name: "fake-name",
+							},
+						},
+					},
+				},
+			},
+			reconciler: &reconciler{
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				getAvailableFreightFromUpstreamStagesFn: func(
+					context.Context,
+					string,
+					[]kargoapi.StageSubscription,
+					kargoapi.JoinStrategy,
+				) ([]kargoapi.SimpleFreight, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(
+				initialStatus kargoapi.StageStatus,
+				newStatus kargoapi.StageStatus,
+				_ client.Client,
+				err error,
+			) {
+				require.Error(t, err)
+				require.Equal(t, "something went wrong", err.Error())
+				// Status should be unchanged
+				require.Equal(t, initialStatus, newStatus)
+			},
+		},
+
+		{
+			name: "no latest Freight from upstream Stages",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						UpstreamStages: []kargoapi.StageSubscription{
+							{
 								Name: "fake-name",
 							},
 						},
@@ -402,12 +950,13 @@ func TestSync(t *testing.T) {
 				},
 			},
 			reconciler: &reconciler{
-				hasNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
 				getAvailableFreightFromUpstreamStagesFn: func(
 					context.Context,
 					string,
 					[]kargoapi.StageSubscription,
-				) ([]kargoapi.Freight, error) {
+					kargoapi.JoinStrategy,
+				) ([]kargoapi.SimpleFreight, error) {
 					return nil, nil
 				},
 			},
@@ -442,13 +991,14 @@ func TestSync(t *testing.T) {
 				},
 			},
 			reconciler: &reconciler{
-				hasNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
 				getAvailableFreightFromUpstreamStagesFn: func(
 					context.Context,
 					string,
 					[]kargoapi.StageSubscription,
-				) ([]kargoapi.Freight, error) {
-					return []kargoapi.Freight{
+					kargoapi.JoinStrategy,
+				) ([]kargoapi.SimpleFreight, error) {
+					return []kargoapi.SimpleFreight{
 						{},
 						{},
 					}, nil
@@ -462,19 +1012,286 @@ func TestSync(t *testing.T) {
 			) {
 				require.NoError(t, err)
 				// Status should have updated AvailableFreight and otherwise be
-				// unchanged
+				// unchanged
+				require.Equal(
+					t,
+					kargoapi.FreightStack{{}, {}},
+					newStatus.AvailableFreight,
+				)
+				newStatus.AvailableFreight = initialStatus.AvailableFreight
+				require.Equal(t, initialStatus, newStatus)
+			},
+		},
+
+		{
+			name: "multiple upstream Stages with join strategy",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						UpstreamStages: []kargoapi.StageSubscription{
+							{
+								Name: "fake-name",
+							},
+							{
+								Name: "another-fake-name",
+							},
+						},
+						UpstreamJoinStrategy: kargoapi.JoinStrategyAll,
+					},
+				},
+			},
+			reconciler: &reconciler{
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				getAvailableFreightFromUpstreamStagesFn: func(
+					context.Context,
+					string,
+					[]kargoapi.StageSubscription,
+					kargoapi.JoinStrategy,
+				) ([]kargoapi.SimpleFreight, error) {
+					return []kargoapi.SimpleFreight{
+						{
+							ID: "fake-freight",
+						},
+					}, nil
+				},
+				kargoClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					&kargoapi.PromotionPolicy{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "fake-policy",
+							Namespace: "fake-namespace",
+						},
+						Stage:               "fake-stage",
+						EnableAutoPromotion: true,
+					},
+				).Build(),
+			},
+			assertions: func(
+				_ kargoapi.StageStatus,
+				newStatus kargoapi.StageStatus,
+				client client.Client,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					kargoapi.FreightStack{{ID: "fake-freight"}},
+					newStatus.AvailableFreight,
+				)
+				// A Promotion should have been created
+				promos := kargoapi.PromotionList{}
+				err = client.List(context.Background(), &promos)
+				require.NoError(t, err)
+				require.Len(t, promos.Items, 1)
+				require.Equal(t, "fake-freight", promos.Items[0].Spec.Freight)
+			},
+		},
+
+		{
+			name: "no promotion policy found",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						Repos: &kargoapi.RepoSubscriptions{},
+					},
+				},
+			},
+			reconciler: &reconciler{
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				createFreightFn:            noOpCreateFreightFn,
+				pruneFreightFn:             noOpPruneFreightFn,
+				getLatestFreightFromReposFn: func(
+					context.Context,
+					string,
+					kargoapi.RepoSubscriptions,
+				) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error) {
+					return &kargoapi.SimpleFreight{
+						ID: "fake-id",
+						Commits: []kargoapi.GitCommit{
+							{
+								RepoURL: "fake-url",
+								ID:      "fake-commit",
+							},
+						},
+						Images: []kargoapi.Image{
+							{
+								RepoURL: "fake-url",
+								Tag:     "fake-tag",
+							},
+						},
+					}, nil, nil
+				},
+				kargoClient: fake.NewClientBuilder().WithScheme(scheme).Build(),
+			},
+			assertions: func(
+				initialStatus kargoapi.StageStatus,
+				newStatus kargoapi.StageStatus,
+				client client.Client,
+				err error,
+			) {
+				require.NoError(t, err)
+				// Status should have updated AvailableFreight and otherwise be
+				// unchanged
+				require.Equal(
+					t,
+					kargoapi.FreightStack{{ID: "fake-id"}},
+					newStatus.AvailableFreight,
+				)
+				newStatus.AvailableFreight = initialStatus.AvailableFreight
+				require.Equal(t, initialStatus, newStatus)
+				// And no Promotion should have been created
+				promos := kargoapi.PromotionList{}
+				err = client.List(context.Background(), &promos)
+				require.NoError(t, err)
+				require.Empty(t, promos.Items)
+			},
+		},
+
+		{
+			name: "multiple promotion policies found",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						Repos: &kargoapi.RepoSubscriptions{},
+					},
+				},
+			},
+			reconciler: &reconciler{
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				createFreightFn:            noOpCreateFreightFn,
+				pruneFreightFn:             noOpPruneFreightFn,
+				getLatestFreightFromReposFn: func(
+					context.Context,
+					string,
+					kargoapi.RepoSubscriptions,
+				) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error) {
+					return &kargoapi.SimpleFreight{
+						ID: "fake-id",
+						Commits: []kargoapi.GitCommit{
+							{
+								RepoURL: "fake-url",
+								ID:      "fake-commit",
+							},
+						},
+						Images: []kargoapi.Image{
+							{
+								RepoURL: "fake-url",
+								Tag:     "fake-tag",
+							},
+						},
+					}, nil, nil
+				},
+				kargoClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					&kargoapi.PromotionPolicy{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "fake-policy",
+							Namespace: "fake-namespace",
+						},
+						Stage: "fake-stage",
+					},
+					&kargoapi.PromotionPolicy{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "another-fake-policy",
+							Namespace: "fake-namespace",
+						},
+						Stage: "fake-stage",
+					},
+				).Build(),
+			},
+			assertions: func(
+				initialStatus kargoapi.StageStatus,
+				newStatus kargoapi.StageStatus,
+				client client.Client,
+				err error,
+			) {
+				require.NoError(t, err)
+				// Status should have updated AvailableFreight and otherwise be
+				// unchanged
+				require.Equal(
+					t,
+					kargoapi.FreightStack{{ID: "fake-id"}},
+					newStatus.AvailableFreight,
+				)
+				newStatus.AvailableFreight = initialStatus.AvailableFreight
+				require.Equal(t, initialStatus, newStatus)
+				// And no Promotion should have been created
+				promos := kargoapi.PromotionList{}
+				err = client.List(context.Background(), &promos)
+				require.NoError(t, err)
+				require.Empty(t, promos.Items)
+			},
+		},
+
+		{
+			name: "auto-promotion not enabled",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						Repos: &kargoapi.RepoSubscriptions{},
+					},
+				},
+			},
+			reconciler: &reconciler{
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				createFreightFn:            noOpCreateFreightFn,
+				pruneFreightFn:             noOpPruneFreightFn,
+				getLatestFreightFromReposFn: func(
+					context.Context,
+					string,
+					kargoapi.RepoSubscriptions,
+				) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error) {
+					return &kargoapi.SimpleFreight{
+						ID: "fake-id",
+						Commits: []kargoapi.GitCommit{
+							{
+								RepoURL: "fake-url",
+								ID:      "fake-commit",
+							},
+						},
+						Images: []kargoapi.Image{
+							{
+								RepoURL: "fake-url",
+								Tag:     "fake-tag",
+							},
+						},
+					}, nil, nil
+				},
+				kargoClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					&kargoapi.PromotionPolicy{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "fake-policy",
+							Namespace: "fake-namespace",
+						},
+						Stage: "fake-stage",
+					},
+				).Build(),
+			},
+			assertions: func(
+				initialStatus kargoapi.StageStatus,
+				newStatus kargoapi.StageStatus,
+				client client.Client,
+				err error,
+			) {
+				require.NoError(t, err)
+				// Status should have updated AvailableFreight and otherwise be
+				// unchanged. AvailableFreight references the new Freight without
+				// copying its materials.
 				require.Equal(
 					t,
-					kargoapi.FreightStack{{}, {}},
+					kargoapi.FreightStack{{ID: "fake-id"}},
 					newStatus.AvailableFreight,
 				)
 				newStatus.AvailableFreight = initialStatus.AvailableFreight
 				require.Equal(t, initialStatus, newStatus)
+				// And no Promotion should have been created
+				promos := kargoapi.PromotionList{}
+				err = client.List(context.Background(), &promos)
+				require.NoError(t, err)
+				require.Empty(t, promos.Items)
 			},
 		},
 
 		{
-			name: "no promotion policy found",
+			name: "auto-promotion blocked by blackout",
 			stage: &kargoapi.Stage{
 				Spec: &kargoapi.StageSpec{
 					Subscriptions: &kargoapi.Subscriptions{
@@ -483,61 +1300,45 @@ func TestSync(t *testing.T) {
 				},
 			},
 			reconciler: &reconciler{
-				hasNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				createFreightFn:            noOpCreateFreightFn,
+				pruneFreightFn:             noOpPruneFreightFn,
 				getLatestFreightFromReposFn: func(
 					context.Context,
 					string,
 					kargoapi.RepoSubscriptions,
-				) (*kargoapi.Freight, error) {
-					return &kargoapi.Freight{
-						Commits: []kargoapi.GitCommit{
-							{
-								RepoURL: "fake-url",
-								ID:      "fake-commit",
-							},
+				) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error) {
+					return &kargoapi.SimpleFreight{
+						ID: "fake-freight",
+					}, nil, nil
+				},
+				kargoClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					&kargoapi.PromotionPolicy{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "fake-policy",
+							Namespace: "fake-namespace",
 						},
-						Images: []kargoapi.Image{
+						Stage:               "fake-stage",
+						EnableAutoPromotion: true,
+						Blackouts: []kargoapi.PromotionWindow{
 							{
-								RepoURL: "fake-url",
-								Tag:     "fake-tag",
+								// Always active
+								Schedule: "* * * * *",
+								Duration: metav1.Duration{Duration: time.Hour},
 							},
 						},
-					}, nil
-				},
-				kargoClient: fake.NewClientBuilder().WithScheme(scheme).Build(),
+					},
+				).Build(),
 			},
 			assertions: func(
-				initialStatus kargoapi.StageStatus,
+				_ kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
 				client client.Client,
 				err error,
 			) {
 				require.NoError(t, err)
-				// Status should have updated AvailableFreight and otherwise be
-				// unchanged
-				require.Equal(
-					t,
-					kargoapi.FreightStack{
-						{
-							Commits: []kargoapi.GitCommit{
-								{
-									RepoURL: "fake-url",
-									ID:      "fake-commit",
-								},
-							},
-							Images: []kargoapi.Image{
-								{
-									RepoURL: "fake-url",
-									Tag:     "fake-tag",
-								},
-							},
-						},
-					},
-					newStatus.AvailableFreight,
-				)
-				newStatus.AvailableFreight = initialStatus.AvailableFreight
-				require.Equal(t, initialStatus, newStatus)
-				// And no Promotion should have been created
+				require.Len(t, newStatus.AvailableFreight, 1)
+				// No Promotion should have been created
 				promos := kargoapi.PromotionList{}
 				err = client.List(context.Background(), &promos)
 				require.NoError(t, err)
@@ -546,7 +1347,7 @@ func TestSync(t *testing.T) {
 		},
 
 		{
-			name: "multiple promotion policies found",
+			name: "auto-promotion enabled",
 			stage: &kargoapi.Stage{
 				Spec: &kargoapi.StageSpec{
 					Subscriptions: &kargoapi.Subscriptions{
@@ -555,13 +1356,16 @@ func TestSync(t *testing.T) {
 				},
 			},
 			reconciler: &reconciler{
-				hasNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				createFreightFn:            noOpCreateFreightFn,
+				pruneFreightFn:             noOpPruneFreightFn,
 				getLatestFreightFromReposFn: func(
 					context.Context,
 					string,
 					kargoapi.RepoSubscriptions,
-				) (*kargoapi.Freight, error) {
-					return &kargoapi.Freight{
+				) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error) {
+					return &kargoapi.SimpleFreight{
+						ID: "fake-id",
 						Commits: []kargoapi.GitCommit{
 							{
 								RepoURL: "fake-url",
@@ -574,7 +1378,7 @@ func TestSync(t *testing.T) {
 								Tag:     "fake-tag",
 							},
 						},
-					}, nil
+					}, nil, nil
 				},
 				kargoClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
 					&kargoapi.PromotionPolicy{
@@ -582,14 +1386,8 @@ func TestSync(t *testing.T) {
 							Name:      "fake-policy",
 							Namespace: "fake-namespace",
 						},
-						Stage: "fake-stage",
-					},
-					&kargoapi.PromotionPolicy{
-						ObjectMeta: metav1.ObjectMeta{
-							Name:      "another-fake-policy",
-							Namespace: "fake-namespace",
-						},
-						Stage: "fake-stage",
+						Stage:               "fake-stage",
+						EnableAutoPromotion: true,
 					},
 				).Build(),
 			},
@@ -601,40 +1399,30 @@ func TestSync(t *testing.T) {
 			) {
 				require.NoError(t, err)
 				// Status should have updated AvailableFreight and otherwise be
-				// unchanged
+				// unchanged. AvailableFreight references the new Freight without
+				// copying its materials.
 				require.Equal(
 					t,
-					kargoapi.FreightStack{
-						{
-							Commits: []kargoapi.GitCommit{
-								{
-									RepoURL: "fake-url",
-									ID:      "fake-commit",
-								},
-							},
-							Images: []kargoapi.Image{
-								{
-									RepoURL: "fake-url",
-									Tag:     "fake-tag",
-								},
-							},
-						},
-					},
+					kargoapi.FreightStack{{ID: "fake-id"}},
 					newStatus.AvailableFreight,
 				)
 				newStatus.AvailableFreight = initialStatus.AvailableFreight
 				require.Equal(t, initialStatus, newStatus)
-				// And no Promotion should have been created
+				// And a Promotion should have been created
 				promos := kargoapi.PromotionList{}
 				err = client.List(context.Background(), &promos)
 				require.NoError(t, err)
-				require.Empty(t, promos.Items)
+				require.Len(t, promos.Items, 1)
 			},
 		},
 
 		{
-			name: "auto-promotion not enabled",
+			name: "auto-promotion queues newer Freight behind a non-terminal Promotion",
 			stage: &kargoapi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fake-stage",
+					Namespace: "fake-namespace",
+				},
 				Spec: &kargoapi.StageSpec{
 					Subscriptions: &kargoapi.Subscriptions{
 						Repos: &kargoapi.RepoSubscriptions{},
@@ -642,26 +1430,33 @@ func TestSync(t *testing.T) {
 				},
 			},
 			reconciler: &reconciler{
-				hasNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
-				getLatestFreightFromReposFn: func(
+				getNonTerminalPromotionsFn: func(
 					context.Context,
 					string,
-					kargoapi.RepoSubscriptions,
-				) (*kargoapi.Freight, error) {
-					return &kargoapi.Freight{
-						Commits: []kargoapi.GitCommit{
-							{
-								RepoURL: "fake-url",
-								ID:      "fake-commit",
-							},
+					string,
+				) ([]kargoapi.Promotion, error) {
+					return []kargoapi.Promotion{{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "fake-promotion",
+							Namespace: "fake-namespace",
 						},
-						Images: []kargoapi.Image{
-							{
-								RepoURL: "fake-url",
-								Tag:     "fake-tag",
-							},
+						Spec: &kargoapi.PromotionSpec{
+							Stage:   "fake-stage",
+							Freight: "older-fake-id",
 						},
-					}, nil
+						Status: kargoapi.PromotionStatus{
+							Phase: kargoapi.PromotionPhaseRunning,
+						},
+					}}, nil
+				},
+				createFreightFn: noOpCreateFreightFn,
+				pruneFreightFn:  noOpPruneFreightFn,
+				getLatestFreightFromReposFn: func(
+					context.Context,
+					string,
+					kargoapi.RepoSubscriptions,
+				) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error) {
+					return &kargoapi.SimpleFreight{ID: "fake-id"}, nil, nil
 				},
 				kargoClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
 					&kargoapi.PromotionPolicy{
@@ -669,52 +1464,41 @@ func TestSync(t *testing.T) {
 							Name:      "fake-policy",
 							Namespace: "fake-namespace",
 						},
-						Stage: "fake-stage",
+						Stage:               "fake-stage",
+						EnableAutoPromotion: true,
+						Supersede:           true,
 					},
 				).Build(),
 			},
 			assertions: func(
-				initialStatus kargoapi.StageStatus,
+				_ kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
 				client client.Client,
 				err error,
 			) {
 				require.NoError(t, err)
-				// Status should have updated AvailableFreight and otherwise be
-				// unchanged
 				require.Equal(
 					t,
-					kargoapi.FreightStack{
-						{
-							Commits: []kargoapi.GitCommit{
-								{
-									RepoURL: "fake-url",
-									ID:      "fake-commit",
-								},
-							},
-							Images: []kargoapi.Image{
-								{
-									RepoURL: "fake-url",
-									Tag:     "fake-tag",
-								},
-							},
-						},
-					},
+					kargoapi.FreightStack{{ID: "fake-id"}},
 					newStatus.AvailableFreight,
 				)
-				newStatus.AvailableFreight = initialStatus.AvailableFreight
-				require.Equal(t, initialStatus, newStatus)
-				// And no Promotion should have been created
 				promos := kargoapi.PromotionList{}
 				err = client.List(context.Background(), &promos)
 				require.NoError(t, err)
-				require.Empty(t, promos.Items)
+				// A Promotion of the newer Freight should have been queued up so that
+				// it can supersede anything queued before it
+				require.Len(t, promos.Items, 1)
+				require.Equal(t, "fake-id", promos.Items[0].Spec.Freight)
 			},
 		},
 
 		{
-			name: "auto-promotion enabled",
+			name: "auto-promotion does not queue the same Freight twice",
 			stage: &kargoapi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fake-stage",
+					Namespace: "fake-namespace",
+				},
 				Spec: &kargoapi.StageSpec{
 					Subscriptions: &kargoapi.Subscriptions{
 						Repos: &kargoapi.RepoSubscriptions{},
@@ -722,26 +1506,33 @@ func TestSync(t *testing.T) {
 				},
 			},
 			reconciler: &reconciler{
-				hasNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
-				getLatestFreightFromReposFn: func(
+				getNonTerminalPromotionsFn: func(
 					context.Context,
 					string,
-					kargoapi.RepoSubscriptions,
-				) (*kargoapi.Freight, error) {
-					return &kargoapi.Freight{
-						Commits: []kargoapi.GitCommit{
-							{
-								RepoURL: "fake-url",
-								ID:      "fake-commit",
-							},
+					string,
+				) ([]kargoapi.Promotion, error) {
+					return []kargoapi.Promotion{{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "fake-promotion",
+							Namespace: "fake-namespace",
 						},
-						Images: []kargoapi.Image{
-							{
-								RepoURL: "fake-url",
-								Tag:     "fake-tag",
-							},
+						Spec: &kargoapi.PromotionSpec{
+							Stage:   "fake-stage",
+							Freight: "fake-id",
 						},
-					}, nil
+						Status: kargoapi.PromotionStatus{
+							Phase: kargoapi.PromotionPhasePending,
+						},
+					}}, nil
+				},
+				createFreightFn: noOpCreateFreightFn,
+				pruneFreightFn:  noOpPruneFreightFn,
+				getLatestFreightFromReposFn: func(
+					context.Context,
+					string,
+					kargoapi.RepoSubscriptions,
+				) (*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error) {
+					return &kargoapi.SimpleFreight{ID: "fake-id"}, nil, nil
 				},
 				kargoClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
 					&kargoapi.PromotionPolicy{
@@ -751,45 +1542,27 @@ func TestSync(t *testing.T) {
 						},
 						Stage:               "fake-stage",
 						EnableAutoPromotion: true,
+						Supersede:           true,
 					},
 				).Build(),
 			},
 			assertions: func(
-				initialStatus kargoapi.StageStatus,
+				_ kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
 				client client.Client,
 				err error,
 			) {
 				require.NoError(t, err)
-				// Status should have updated AvailableFreight and otherwise be
-				// unchanged
 				require.Equal(
 					t,
-					kargoapi.FreightStack{
-						{
-							Commits: []kargoapi.GitCommit{
-								{
-									RepoURL: "fake-url",
-									ID:      "fake-commit",
-								},
-							},
-							Images: []kargoapi.Image{
-								{
-									RepoURL: "fake-url",
-									Tag:     "fake-tag",
-								},
-							},
-						},
-					},
+					kargoapi.FreightStack{{ID: "fake-id"}},
 					newStatus.AvailableFreight,
 				)
-				newStatus.AvailableFreight = initialStatus.AvailableFreight
-				require.Equal(t, initialStatus, newStatus)
-				// And a Promotion should have been created
 				promos := kargoapi.PromotionList{}
 				err = client.List(context.Background(), &promos)
 				require.NoError(t, err)
-				require.Len(t, promos.Items, 1)
+				// No Promotion should have been created
+				require.Empty(t, promos.Items)
 			},
 		},
 
@@ -807,7 +1580,7 @@ func TestSync(t *testing.T) {
 					PromotionMechanisms: nil,
 				},
 				Status: kargoapi.StageStatus{
-					AvailableFreight: []kargoapi.Freight{
+					AvailableFreight: []kargoapi.SimpleFreight{
 						{
 							Commits: []kargoapi.GitCommit{
 								{
@@ -817,16 +1590,18 @@ func TestSync(t *testing.T) {
 							},
 						},
 					},
-					CurrentFreight: &kargoapi.Freight{},
+					CurrentFreight: &kargoapi.SimpleFreight{},
 				},
 			},
 			reconciler: &reconciler{
-				hasNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				getNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				verifyFreightInStageFn:     noOpVerifyFreightInStageFn,
 				getAvailableFreightFromUpstreamStagesFn: func(
 					context.Context,
 					string,
 					[]kargoapi.StageSubscription,
-				) ([]kargoapi.Freight, error) {
+					kargoapi.JoinStrategy,
+				) ([]kargoapi.SimpleFreight, error) {
 					return nil, nil
 				},
 			},
@@ -837,30 +1612,158 @@ func TestSync(t *testing.T) {
 				err error,
 			) {
 				require.NoError(t, err)
-				require.Nil(t, newStatus.CurrentFreight)
-				require.Len(t, newStatus.History, len(initialStatus.AvailableFreight))
-				for i, f := range newStatus.History {
-					require.Equal(t, initialStatus.AvailableFreight[i].ID, f.ID)
-					require.True(t, f.Qualified)
-				}
+				require.Nil(t, newStatus.CurrentFreight)
+				require.Len(t, newStatus.History, len(initialStatus.AvailableFreight))
+				for i, f := range newStatus.History {
+					require.Equal(t, initialStatus.AvailableFreight[i].ID, f.ID)
+					require.True(t, f.Qualified)
+				}
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.stage.ObjectMeta = metav1.ObjectMeta{
+				Name:      "fake-stage",
+				Namespace: "fake-namespace",
+			}
+			// nolint: lll
+			newStatus, err := tc.reconciler.syncStage(context.Background(), tc.stage)
+			tc.assertions(
+				tc.stage.Status,
+				newStatus,
+				tc.reconciler.kargoClient,
+				err,
+			)
+		})
+	}
+}
+
+func TestGetAvailableFreightFromUpstreamStages(t *testing.T) {
+	scheme := k8sruntime.NewScheme()
+	require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(scheme))
+
+	older := metav1.NewTime(time.Now().Add(-time.Hour))
+	newer := metav1.NewTime(time.Now())
+
+	testSubs := []kargoapi.StageSubscription{
+		{
+			Name: "fake-stage",
+		},
+		{
+			Name: "another-fake-stage",
+		},
+	}
+	testClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&kargoapi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "fake-stage",
+				Namespace: "fake-namespace",
+			},
+			Status: kargoapi.StageStatus{
+				History: kargoapi.FreightStack{
+					{
+						ID:        "shared-freight",
+						FirstSeen: &older,
+						Qualified: true,
+					},
+					{
+						ID:        "unqualified-freight",
+						FirstSeen: &newer,
+					},
+				},
+			},
+		},
+		&kargoapi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "another-fake-stage",
+				Namespace: "fake-namespace",
+			},
+			Status: kargoapi.StageStatus{
+				History: kargoapi.FreightStack{
+					{
+						ID:        "newer-freight",
+						FirstSeen: &newer,
+						Qualified: true,
+					},
+					{
+						ID:        "shared-freight",
+						FirstSeen: &older,
+						Qualified: true,
+					},
+				},
+			},
+		},
+	).Build()
+
+	testCases := []struct {
+		name         string
+		subs         []kargoapi.StageSubscription
+		joinStrategy kargoapi.JoinStrategy
+		assertions   func([]kargoapi.SimpleFreight, error)
+	}{
+		{
+			name: "upstream Stage not found",
+			subs: []kargoapi.StageSubscription{
+				{
+					Name: "nonexistent-stage",
+				},
+			},
+			assertions: func(_ []kargoapi.SimpleFreight, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "found no upstream Stage")
+			},
+		},
+
+		{
+			name: "single upstream Stage",
+			subs: testSubs[:1],
+			assertions: func(freight []kargoapi.SimpleFreight, err error) {
+				require.NoError(t, err)
+				require.Len(t, freight, 1)
+				require.Equal(t, "shared-freight", freight[0].ID)
+				require.Equal(t, "fake-stage", freight[0].Provenance)
+			},
+		},
+
+		{
+			name:         "any upstream Stage",
+			subs:         testSubs,
+			joinStrategy: kargoapi.JoinStrategyAny,
+			assertions: func(freight []kargoapi.SimpleFreight, err error) {
+				require.NoError(t, err)
+				require.Len(t, freight, 2)
+				// Newest Freight should be at the top
+				require.Equal(t, "newer-freight", freight[0].ID)
+				require.Equal(t, "shared-freight", freight[1].ID)
+			},
+		},
+
+		{
+			name:         "all upstream Stages",
+			subs:         testSubs,
+			joinStrategy: kargoapi.JoinStrategyAll,
+			assertions: func(freight []kargoapi.SimpleFreight, err error) {
+				require.NoError(t, err)
+				require.Len(t, freight, 1)
+				require.Equal(t, "shared-freight", freight[0].ID)
 			},
 		},
 	}
-	for _, tc := range testCases {
-		tc := tc
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
-			tc.stage.ObjectMeta = metav1.ObjectMeta{
-				Name:      "fake-stage",
-				Namespace: "fake-namespace",
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := reconciler{
+				kargoClient: testClient,
 			}
-			// nolint: lll
-			newStatus, err := tc.reconciler.syncStage(context.Background(), tc.stage)
-			tc.assertions(
-				tc.stage.Status,
-				newStatus,
-				tc.reconciler.kargoClient,
-				err,
+			testCase.assertions(
+				r.getAvailableFreightFromUpstreamStages(
+					context.Background(),
+					"fake-namespace",
+					testCase.subs,
+					testCase.joinStrategy,
+				),
 			)
 		})
 	}
@@ -878,13 +1781,13 @@ func TestGetLatestFreightFromRepos(t *testing.T) {
 			context.Context,
 			string,
 			[]kargoapi.ImageSubscription,
-		) ([]kargoapi.Image, error)
+		) ([]kargoapi.Image, []kargoapi.RejectedImageTag, error)
 		getLatestChartsFn func(
 			context.Context,
 			string,
 			[]kargoapi.ChartSubscription,
 		) ([]kargoapi.Chart, error)
-		assertions func(*kargoapi.Freight, error)
+		assertions func(*kargoapi.SimpleFreight, []kargoapi.RejectedImageTag, error)
 	}{
 		{
 			name: "error getting latest git commit",
@@ -895,7 +1798,25 @@ func TestGetLatestFreightFromRepos(t *testing.T) {
 			) ([]kargoapi.GitCommit, error) {
 				return nil, errors.New("something went wrong")
 			},
-			assertions: func(freight *kargoapi.Freight, err error) {
+			getLatestImagesFn: func(
+				context.Context,
+				string,
+				[]kargoapi.ImageSubscription,
+			) ([]kargoapi.Image, []kargoapi.RejectedImageTag, error) {
+				return nil, nil, nil
+			},
+			getLatestChartsFn: func(
+				context.Context,
+				string,
+				[]kargoapi.ChartSubscription,
+			) ([]kargoapi.Chart, error) {
+				return nil, nil
+			},
+			assertions: func(
+				freight *kargoapi.SimpleFreight,
+				rejected []kargoapi.RejectedImageTag,
+				err error,
+			) {
 				require.Error(t, err)
 				require.Contains(t, err.Error(), "error syncing git repo subscription")
 				require.Contains(t, err.Error(), "something went wrong")
@@ -915,10 +1836,21 @@ func TestGetLatestFreightFromRepos(t *testing.T) {
 				context.Context,
 				string,
 				[]kargoapi.ImageSubscription,
-			) ([]kargoapi.Image, error) {
-				return nil, errors.New("something went wrong")
+			) ([]kargoapi.Image, []kargoapi.RejectedImageTag, error) {
+				return nil, nil, errors.New("something went wrong")
+			},
+			getLatestChartsFn: func(
+				context.Context,
+				string,
+				[]kargoapi.ChartSubscription,
+			) ([]kargoapi.Chart, error) {
+				return nil, nil
 			},
-			assertions: func(freight *kargoapi.Freight, err error) {
+			assertions: func(
+				freight *kargoapi.SimpleFreight,
+				rejected []kargoapi.RejectedImageTag,
+				err error,
+			) {
 				require.Error(t, err)
 				require.Contains(
 					t,
@@ -930,7 +1862,7 @@ func TestGetLatestFreightFromRepos(t *testing.T) {
 		},
 
 		{
-			name: "error getting latest charts",
+			name: "every image tag rejected by verification",
 			getLatestCommitsFn: func(
 				context.Context,
 				string,
@@ -942,9 +1874,62 @@ func TestGetLatestFreightFromRepos(t *testing.T) {
 				context.Context,
 				string,
 				[]kargoapi.ImageSubscription,
-			) ([]kargoapi.Image, error) {
+			) ([]kargoapi.Image, []kargoapi.RejectedImageTag, error) {
+				return nil,
+					[]kargoapi.RejectedImageTag{
+						{
+							RepoURL: "fake-url",
+							Tag:     "fake-tag",
+							Reason:  "image is not signed",
+						},
+					},
+					errors.New("something went wrong")
+			},
+			getLatestChartsFn: func(
+				context.Context,
+				string,
+				[]kargoapi.ChartSubscription,
+			) ([]kargoapi.Chart, error) {
 				return nil, nil
 			},
+			assertions: func(
+				freight *kargoapi.SimpleFreight,
+				rejected []kargoapi.RejectedImageTag,
+				err error,
+			) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Nil(t, freight)
+				require.Equal(
+					t,
+					[]kargoapi.RejectedImageTag{
+						{
+							RepoURL: "fake-url",
+							Tag:     "fake-tag",
+							Reason:  "image is not signed",
+						},
+					},
+					rejected,
+				)
+			},
+		},
+
+		{
+			name: "error getting latest charts",
+			getLatestCommitsFn: func(
+				context.Context,
+				string,
+				[]kargoapi.GitSubscription,
+			) ([]kargoapi.GitCommit, error) {
+				return nil, nil
+			},
+			getLatestImagesFn: func(
+				context.Context,
+				string,
+				[]kargoapi.ImageSubscription,
+			) ([]kargoapi.Image, []kargoapi.RejectedImageTag, error) {
+				return nil, nil, nil
+			},
 			getLatestChartsFn: func(
 				context.Context,
 				string,
@@ -952,7 +1937,11 @@ func TestGetLatestFreightFromRepos(t *testing.T) {
 			) ([]kargoapi.Chart, error) {
 				return nil, errors.New("something went wrong")
 			},
-			assertions: func(freight *kargoapi.Freight, err error) {
+			assertions: func(
+				freight *kargoapi.SimpleFreight,
+				rejected []kargoapi.RejectedImageTag,
+				err error,
+			) {
 				require.Error(t, err)
 				require.Contains(
 					t,
@@ -963,6 +1952,44 @@ func TestGetLatestFreightFromRepos(t *testing.T) {
 			},
 		},
 
+		{
+			name: "error getting latest git commit while other repos are still being synced",
+			getLatestCommitsFn: func(
+				context.Context,
+				string,
+				[]kargoapi.GitSubscription,
+			) ([]kargoapi.GitCommit, error) {
+				return nil, errors.New("something went wrong")
+			},
+			getLatestImagesFn: func(
+				ctx context.Context,
+				_ string,
+				_ []kargoapi.ImageSubscription,
+			) ([]kargoapi.Image, []kargoapi.RejectedImageTag, error) {
+				// Never finishes unless canceled
+				<-ctx.Done()
+				return nil, nil, ctx.Err()
+			},
+			getLatestChartsFn: func(
+				ctx context.Context,
+				_ string,
+				_ []kargoapi.ChartSubscription,
+			) ([]kargoapi.Chart, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+			assertions: func(
+				freight *kargoapi.SimpleFreight,
+				rejected []kargoapi.RejectedImageTag,
+				err error,
+			) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error syncing git repo subscription")
+				require.Contains(t, err.Error(), "something went wrong")
+				require.Nil(t, freight)
+			},
+		},
+
 		{
 			name: "success",
 			getLatestCommitsFn: func(
@@ -981,12 +2008,18 @@ func TestGetLatestFreightFromRepos(t *testing.T) {
 				context.Context,
 				string,
 				[]kargoapi.ImageSubscription,
-			) ([]kargoapi.Image, error) {
+			) ([]kargoapi.Image, []kargoapi.RejectedImageTag, error) {
 				return []kargoapi.Image{
 					{
 						RepoURL: "fake-url",
 						Tag:     "fake-tag",
 					},
+				}, []kargoapi.RejectedImageTag{
+					{
+						RepoURL: "fake-url",
+						Tag:     "newer-fake-tag",
+						Reason:  "image is not signed",
+					},
 				}, nil
 			},
 			getLatestChartsFn: func(
@@ -1002,7 +2035,11 @@ func TestGetLatestFreightFromRepos(t *testing.T) {
 					},
 				}, nil
 			},
-			assertions: func(freight *kargoapi.Freight, err error) {
+			assertions: func(
+				freight *kargoapi.SimpleFreight,
+				rejected []kargoapi.RejectedImageTag,
+				err error,
+			) {
 				require.NoError(t, err)
 				require.NotNil(t, freight)
 				require.NotEmpty(t, freight.ID)
@@ -1012,7 +2049,7 @@ func TestGetLatestFreightFromRepos(t *testing.T) {
 				freight.FirstSeen = nil
 				require.Equal(
 					t,
-					&kargoapi.Freight{
+					&kargoapi.SimpleFreight{
 						Commits: []kargoapi.GitCommit{
 							{
 								RepoURL: "fake-url",
@@ -1036,6 +2073,17 @@ func TestGetLatestFreightFromRepos(t *testing.T) {
 					},
 					freight,
 				)
+				require.Equal(
+					t,
+					[]kargoapi.RejectedImageTag{
+						{
+							RepoURL: "fake-url",
+							Tag:     "newer-fake-tag",
+							Reason:  "image is not signed",
+						},
+					},
+					rejected,
+				)
 			},
 		},
 	}
@@ -1056,3 +2104,312 @@ func TestGetLatestFreightFromRepos(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateFreight(t *testing.T) {
+	scheme := k8sruntime.NewScheme()
+	require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(scheme))
+
+	testStage := &kargoapi.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-stage",
+			Namespace: "fake-namespace",
+			UID:       "fake-uid",
+		},
+	}
+	testOwnerRef := metav1.OwnerReference{
+		APIVersion: kargoapi.GroupVersion.String(),
+		Kind:       "Stage",
+		Name:       "fake-stage",
+		UID:        "fake-uid",
+	}
+	otherOwnerRef := metav1.OwnerReference{
+		APIVersion: kargoapi.GroupVersion.String(),
+		Kind:       "Stage",
+		Name:       "other-fake-stage",
+		UID:        "other-fake-uid",
+	}
+
+	testFreight := kargoapi.SimpleFreight{
+		ID: "fake-id",
+		Commits: []kargoapi.GitCommit{
+			{
+				RepoURL: "fake-url",
+				ID:      "fake-commit",
+			},
+		},
+	}
+
+	getFreight := func(c client.Client) *kargoapi.Freight {
+		freight, err := kargoapi.GetFreight(
+			context.Background(),
+			c,
+			types.NamespacedName{
+				Namespace: "fake-namespace",
+				Name:      "fake-id",
+			},
+		)
+		require.NoError(t, err)
+		require.NotNil(t, freight)
+		return freight
+	}
+
+	testCases := []struct {
+		name       string
+		client     client.Client
+		assertions func(client.Client, error)
+	}{
+		{
+			name:   "Freight does not exist yet",
+			client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+			assertions: func(c client.Client, err error) {
+				require.NoError(t, err)
+				freight := getFreight(c)
+				require.Equal(t, testFreight.Commits, freight.Commits)
+				require.Equal(
+					t,
+					[]metav1.OwnerReference{testOwnerRef},
+					freight.OwnerReferences,
+				)
+			},
+		},
+
+		{
+			name: "Freight already exists",
+			client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&kargoapi.Freight{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "fake-id",
+						Namespace:       "fake-namespace",
+						OwnerReferences: []metav1.OwnerReference{testOwnerRef},
+					},
+				},
+			).Build(),
+			assertions: func(c client.Client, err error) {
+				require.NoError(t, err)
+				freight := getFreight(c)
+				// The existing Freight should not have been modified
+				require.Empty(t, freight.Commits)
+				require.Equal(
+					t,
+					[]metav1.OwnerReference{testOwnerRef},
+					freight.OwnerReferences,
+				)
+			},
+		},
+
+		{
+			name: "Freight already exists and was discovered by another Stage",
+			client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&kargoapi.Freight{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "fake-id",
+						Namespace:       "fake-namespace",
+						OwnerReferences: []metav1.OwnerReference{otherOwnerRef},
+					},
+				},
+			).Build(),
+			assertions: func(c client.Client, err error) {
+				require.NoError(t, err)
+				freight := getFreight(c)
+				require.Empty(t, freight.Commits)
+				require.Equal(
+					t,
+					[]metav1.OwnerReference{otherOwnerRef, testOwnerRef},
+					freight.OwnerReferences,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := &reconciler{
+				kargoClient: testCase.client,
+			}
+			testCase.assertions(
+				testCase.client,
+				r.createFreight(
+					context.Background(),
+					testStage,
+					testFreight,
+				),
+			)
+		})
+	}
+}
+
+func TestPruneFreight(t *testing.T) {
+	scheme := k8sruntime.NewScheme()
+	require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(scheme))
+
+	testStage := &kargoapi.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-stage",
+			Namespace: "fake-namespace",
+			UID:       "fake-uid",
+		},
+	}
+	ownedBy := func(uids ...types.UID) []metav1.OwnerReference {
+		refs := make([]metav1.OwnerReference, len(uids))
+		for i, uid := range uids {
+			refs[i] = metav1.OwnerReference{
+				APIVersion: kargoapi.GroupVersion.String(),
+				Kind:       "Stage",
+				Name:       string(uid),
+				UID:        uid,
+			}
+		}
+		return refs
+	}
+	// Freight discovered by the Stage, from oldest to newest, so that the
+	// first few exceed the retention limit
+	const excess = 5
+	start := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+	objects := make([]client.Object, 0, freightRetentionLimit+excess+1)
+	for i := 0; i < freightRetentionLimit+excess; i++ {
+		freight := &kargoapi.Freight{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fmt.Sprintf("freight-%02d", i),
+				Namespace:         "fake-namespace",
+				CreationTimestamp: metav1.NewTime(start.Add(time.Duration(i) * time.Hour)),
+				OwnerReferences:   ownedBy(testStage.UID),
+			},
+		}
+		switch i {
+		case 1:
+			// Also discovered by another Stage
+			freight.OwnerReferences = ownedBy(testStage.UID, "other-fake-uid")
+		case 2:
+			freight.Status.ApprovedFor = map[string]kargoapi.ApprovedStage{
+				"fake-downstream-stage": {},
+			}
+		}
+		objects = append(objects, freight)
+	}
+	objects = append(
+		objects,
+		// Not discovered by the Stage at all
+		&kargoapi.Freight{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "unowned-freight",
+				Namespace:         "fake-namespace",
+				CreationTimestamp: metav1.NewTime(start.Add(-time.Hour)),
+			},
+		},
+		// Another Stage still references freight-03
+		&kargoapi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "fake-downstream-stage",
+				Namespace: "fake-namespace",
+			},
+			Status: kargoapi.StageStatus{
+				History: kargoapi.FreightStack{{ID: "freight-03"}},
+			},
+		},
+	)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	r := &reconciler{
+		kargoClient: c,
+	}
+	err := r.pruneFreight(
+		context.Background(),
+		testStage,
+		kargoapi.StageStatus{
+			// The Stage's own status still references freight-04
+			AvailableFreight: kargoapi.FreightStack{{ID: "freight-04"}},
+		},
+	)
+	require.NoError(t, err)
+
+	freightList := kargoapi.FreightList{}
+	require.NoError(t, c.List(context.Background(), &freightList))
+	remaining := map[string]kargoapi.Freight{}
+	for _, freight := range freightList.Items {
+		remaining[freight.Name] = freight
+	}
+	require.Len(t, remaining, freightRetentionLimit+excess)
+	require.NotContains(t, remaining, "freight-00")
+	for _, name := range []string{
+		"freight-01",
+		"freight-02",
+		"freight-03",
+		"freight-04",
+		"unowned-freight",
+	} {
+		require.Contains(t, remaining, name)
+	}
+	// Freight also discovered by another Stage should have been disowned
+	require.Equal(
+		t,
+		ownedBy("other-fake-uid"),
+		remaining["freight-01"].OwnerReferences,
+	)
+}
+
+func TestVerifyFreightInStage(t *testing.T) {
+	scheme := k8sruntime.NewScheme()
+	require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(scheme))
+
+	testCases := []struct {
+		name       string
+		client     client.Client
+		assertions func(client.Client, error)
+	}{
+		{
+			name:   "Freight does not exist",
+			client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+			assertions: func(_ client.Client, err error) {
+				require.NoError(t, err)
+			},
+		},
+
+		{
+			name: "success",
+			client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&kargoapi.Freight{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "fake-id",
+						Namespace: "fake-namespace",
+					},
+					Status: kargoapi.FreightStatus{
+						VerifiedIn: map[string]kargoapi.VerifiedStage{
+							"fake-upstream-stage": {},
+						},
+					},
+				},
+			).Build(),
+			assertions: func(c client.Client, err error) {
+				require.NoError(t, err)
+				freight, err := kargoapi.GetFreight(
+					context.Background(),
+					c,
+					types.NamespacedName{
+						Namespace: "fake-namespace",
+						Name:      "fake-id",
+					},
+				)
+				require.NoError(t, err)
+				require.NotNil(t, freight)
+				require.Contains(t, freight.Status.VerifiedIn, "fake-upstream-stage")
+				require.Contains(t, freight.Status.VerifiedIn, "fake-stage")
+				require.NotNil(t, freight.Status.VerifiedIn["fake-stage"].VerifiedAt)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := &reconciler{
+				kargoClient: testCase.client,
+			}
+			testCase.assertions(
+				testCase.client,
+				r.verifyFreightInStage(
+					context.Background(),
+					"fake-namespace",
+					"fake-id",
+					"fake-stage",
+				),
+			)
+		})
+	}
+}
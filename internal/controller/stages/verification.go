@@ -0,0 +1,168 @@
+package stages
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+// verify creates Kubernetes Jobs from the templates in the provided Stage's
+// verification specification, if they have not already been created to verify
+// the Freight identified by the freightID argument, and returns a summary of
+// the progress of all such Jobs. The verificationInfo argument is the result
+// of any previous invocation and is used to determine which Jobs have already
+// been created. A partial summary is returned along with any error so that
+// Jobs that were already created are not orphaned.
+func (r *reconciler) verify(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	freightID string,
+	verificationInfo *kargoapi.VerificationInfo,
+) (*kargoapi.VerificationInfo, error) {
+	info := &kargoapi.VerificationInfo{
+		FreightID: freightID,
+		Phase:     kargoapi.VerificationPhaseRunning,
+	}
+	if verificationInfo != nil && verificationInfo.FreightID == freightID {
+		info = verificationInfo.DeepCopy()
+	}
+	if info.Phase.IsTerminal() {
+		return info, nil
+	}
+
+	logger := logging.LoggerFromContext(ctx).WithField("freight", freightID)
+
+	jobStatusIndicesByName := make(map[string]int, len(info.Jobs))
+	for i := range info.Jobs {
+		jobStatusIndicesByName[info.Jobs[i].Name] = i
+	}
+
+	for _, verificationJob := range stage.Spec.Verification.Jobs {
+		i, ok := jobStatusIndicesByName[verificationJob.Name]
+		if !ok {
+			job := newVerificationJob(stage, freightID, verificationJob)
+			if err := r.kargoClient.Create(ctx, job); err != nil {
+				return info, errors.Wrapf(
+					err,
+					"error creating verification Job %q for Stage %q in namespace %q",
+					verificationJob.Name,
+					stage.Name,
+					stage.Namespace,
+				)
+			}
+			logger.WithField("job", job.Name).Debug("created verification Job")
+			info.Jobs = append(
+				info.Jobs,
+				kargoapi.VerificationJobStatus{
+					Name:    verificationJob.Name,
+					JobName: job.Name,
+					Phase:   kargoapi.VerificationPhaseRunning,
+					LogsRef: fmt.Sprintf("job/%s", job.Name),
+				},
+			)
+			continue
+		}
+		jobStatus := &info.Jobs[i]
+		if jobStatus.Phase.IsTerminal() {
+			continue
+		}
+		job := &batchv1.Job{}
+		if err := r.kargoClient.Get(
+			ctx,
+			types.NamespacedName{
+				Namespace: stage.Namespace,
+				Name:      jobStatus.JobName,
+			},
+			job,
+		); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return info, errors.Wrapf(
+					err,
+					"error getting verification Job %q in namespace %q",
+					jobStatus.JobName,
+					stage.Namespace,
+				)
+			}
+			jobStatus.Phase = kargoapi.VerificationPhaseFailed
+			jobStatus.Message = "Job no longer exists"
+			continue
+		}
+		jobStatus.Phase, jobStatus.Message = getVerificationJobPhase(job)
+	}
+
+	info.Phase = getVerificationPhase(info.Jobs)
+	return info, nil
+}
+
+// newVerificationJob returns a Kubernetes Job, owned by the provided Stage,
+// that is created from the provided VerificationJob's template to verify the
+// Freight identified by the freightID argument.
+func newVerificationJob(
+	stage *kargoapi.Stage,
+	freightID string,
+	verificationJob kargoapi.VerificationJob,
+) *batchv1.Job {
+	// The API server truncates generated names as necessary, so there is no
+	// need to worry about the length of the Stage's name here.
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-%s-", stage.Name, verificationJob.Name),
+			Namespace:    stage.Namespace,
+			Labels: map[string]string{
+				kargoapi.LabelFreightKey: freightID,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(
+					stage,
+					kargoapi.GroupVersion.WithKind("Stage"),
+				),
+			},
+		},
+		Spec: *verificationJob.Template.DeepCopy(),
+	}
+}
+
+// getVerificationJobPhase returns the VerificationPhase corresponding to the
+// provided Job's conditions, along with any message explaining that phase.
+func getVerificationJobPhase(job *batchv1.Job) (kargoapi.VerificationPhase, string) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return kargoapi.VerificationPhaseSucceeded, condition.Message
+		case batchv1.JobFailed:
+			return kargoapi.VerificationPhaseFailed, condition.Message
+		}
+	}
+	return kargoapi.VerificationPhaseRunning, ""
+}
+
+// getVerificationPhase returns the overall VerificationPhase for the provided
+// Job statuses. Verification has failed if any one Job has failed and has
+// succeeded only if every Job has succeeded.
+func getVerificationPhase(
+	jobStatuses []kargoapi.VerificationJobStatus,
+) kargoapi.VerificationPhase {
+	phase := kargoapi.VerificationPhaseSucceeded
+	for _, jobStatus := range jobStatuses {
+		switch jobStatus.Phase {
+		case kargoapi.VerificationPhaseFailed:
+			return kargoapi.VerificationPhaseFailed
+		case kargoapi.VerificationPhaseSucceeded:
+		default:
+			phase = kargoapi.VerificationPhaseRunning
+		}
+	}
+	return phase
+}
@@ -0,0 +1,339 @@
+package stages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestVerify(t *testing.T) {
+	scheme := k8sruntime.NewScheme()
+	require.NoError(t, batchv1.AddToScheme(scheme))
+	require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(scheme))
+
+	testStage := &kargoapi.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-stage",
+			Namespace: "fake-namespace",
+		},
+		Spec: &kargoapi.StageSpec{
+			Verification: &kargoapi.Verification{
+				Jobs: []kargoapi.VerificationJob{
+					{
+						Name: "smoke-tests",
+					},
+					{
+						Name: "integration-tests",
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		client           client.Client
+		verificationInfo *kargoapi.VerificationInfo
+		assertions       func(*kargoapi.VerificationInfo, client.Client, error)
+	}{
+		{
+			name:   "no previous verification",
+			client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+			assertions: func(
+				info *kargoapi.VerificationInfo,
+				c client.Client,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, "fake-freight", info.FreightID)
+				require.Equal(t, kargoapi.VerificationPhaseRunning, info.Phase)
+				require.Len(t, info.Jobs, 2)
+				for i, name := range []string{"smoke-tests", "integration-tests"} {
+					jobStatus := info.Jobs[i]
+					require.Equal(t, name, jobStatus.Name)
+					require.NotEmpty(t, jobStatus.JobName)
+					require.Equal(t, kargoapi.VerificationPhaseRunning, jobStatus.Phase)
+					require.Equal(t, "job/"+jobStatus.JobName, jobStatus.LogsRef)
+					job := &batchv1.Job{}
+					require.NoError(
+						t,
+						c.Get(
+							context.Background(),
+							types.NamespacedName{
+								Namespace: "fake-namespace",
+								Name:      jobStatus.JobName,
+							},
+							job,
+						),
+					)
+					require.Equal(
+						t,
+						"fake-freight",
+						job.Labels[kargoapi.LabelFreightKey],
+					)
+					require.Len(t, job.OwnerReferences, 1)
+					require.Equal(t, "fake-stage", job.OwnerReferences[0].Name)
+				}
+			},
+		},
+
+		{
+			name:   "previous verification of different Freight",
+			client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+			verificationInfo: &kargoapi.VerificationInfo{
+				FreightID: "another-fake-freight",
+				Phase:     kargoapi.VerificationPhaseFailed,
+			},
+			assertions: func(
+				info *kargoapi.VerificationInfo,
+				_ client.Client,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, "fake-freight", info.FreightID)
+				require.Equal(t, kargoapi.VerificationPhaseRunning, info.Phase)
+				require.Len(t, info.Jobs, 2)
+			},
+		},
+
+		{
+			name:   "previous verification already terminal",
+			client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+			verificationInfo: &kargoapi.VerificationInfo{
+				FreightID: "fake-freight",
+				Phase:     kargoapi.VerificationPhaseSucceeded,
+			},
+			assertions: func(
+				info *kargoapi.VerificationInfo,
+				_ client.Client,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					&kargoapi.VerificationInfo{
+						FreightID: "fake-freight",
+						Phase:     kargoapi.VerificationPhaseSucceeded,
+					},
+					info,
+				)
+			},
+		},
+
+		{
+			name: "Jobs completed",
+			client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "fake-stage-smoke-tests-abcde",
+						Namespace: "fake-namespace",
+					},
+					Status: batchv1.JobStatus{
+						Conditions: []batchv1.JobCondition{
+							{
+								Type:   batchv1.JobComplete,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+				&batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "fake-stage-integration-tests-abcde",
+						Namespace: "fake-namespace",
+					},
+					Status: batchv1.JobStatus{
+						Conditions: []batchv1.JobCondition{
+							{
+								Type:   batchv1.JobComplete,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			).Build(),
+			verificationInfo: &kargoapi.VerificationInfo{
+				FreightID: "fake-freight",
+				Phase:     kargoapi.VerificationPhaseRunning,
+				Jobs: []kargoapi.VerificationJobStatus{
+					{
+						Name:    "smoke-tests",
+						JobName: "fake-stage-smoke-tests-abcde",
+						Phase:   kargoapi.VerificationPhaseRunning,
+					},
+					{
+						Name:    "integration-tests",
+						JobName: "fake-stage-integration-tests-abcde",
+						Phase:   kargoapi.VerificationPhaseRunning,
+					},
+				},
+			},
+			assertions: func(
+				info *kargoapi.VerificationInfo,
+				_ client.Client,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.VerificationPhaseSucceeded, info.Phase)
+				for _, jobStatus := range info.Jobs {
+					require.Equal(
+						t,
+						kargoapi.VerificationPhaseSucceeded,
+						jobStatus.Phase,
+					)
+				}
+			},
+		},
+
+		{
+			name: "Job failed",
+			client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "fake-stage-smoke-tests-abcde",
+						Namespace: "fake-namespace",
+					},
+					Status: batchv1.JobStatus{
+						Conditions: []batchv1.JobCondition{
+							{
+								Type:    batchv1.JobFailed,
+								Status:  corev1.ConditionTrue,
+								Message: "Job has reached the specified backoff limit",
+							},
+						},
+					},
+				},
+			).Build(),
+			verificationInfo: &kargoapi.VerificationInfo{
+				FreightID: "fake-freight",
+				Phase:     kargoapi.VerificationPhaseRunning,
+				Jobs: []kargoapi.VerificationJobStatus{
+					{
+						Name:    "smoke-tests",
+						JobName: "fake-stage-smoke-tests-abcde",
+						Phase:   kargoapi.VerificationPhaseRunning,
+					},
+					{
+						Name:    "integration-tests",
+						JobName: "fake-stage-integration-tests-abcde",
+						Phase:   kargoapi.VerificationPhaseSucceeded,
+					},
+				},
+			},
+			assertions: func(
+				info *kargoapi.VerificationInfo,
+				_ client.Client,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.VerificationPhaseFailed, info.Phase)
+				require.Equal(
+					t,
+					kargoapi.VerificationPhaseFailed,
+					info.Jobs[0].Phase,
+				)
+				require.Equal(
+					t,
+					"Job has reached the specified backoff limit",
+					info.Jobs[0].Message,
+				)
+			},
+		},
+
+		{
+			name:   "Job no longer exists",
+			client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+			verificationInfo: &kargoapi.VerificationInfo{
+				FreightID: "fake-freight",
+				Phase:     kargoapi.VerificationPhaseRunning,
+				Jobs: []kargoapi.VerificationJobStatus{
+					{
+						Name:    "smoke-tests",
+						JobName: "fake-stage-smoke-tests-abcde",
+						Phase:   kargoapi.VerificationPhaseRunning,
+					},
+					{
+						Name:    "integration-tests",
+						JobName: "fake-stage-integration-tests-abcde",
+						Phase:   kargoapi.VerificationPhaseSucceeded,
+					},
+				},
+			},
+			assertions: func(
+				info *kargoapi.VerificationInfo,
+				_ client.Client,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.VerificationPhaseFailed, info.Phase)
+				require.Equal(t, "Job no longer exists", info.Jobs[0].Message)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := reconciler{
+				kargoClient: testCase.client,
+			}
+			info, err := r.verify(
+				context.Background(),
+				testStage,
+				"fake-freight",
+				testCase.verificationInfo,
+			)
+			testCase.assertions(info, testCase.client, err)
+		})
+	}
+}
+
+func TestGetVerificationPhase(t *testing.T) {
+	testCases := []struct {
+		name        string
+		jobStatuses []kargoapi.VerificationJobStatus
+		expected    kargoapi.VerificationPhase
+	}{
+		{
+			name: "all succeeded",
+			jobStatuses: []kargoapi.VerificationJobStatus{
+				{Phase: kargoapi.VerificationPhaseSucceeded},
+				{Phase: kargoapi.VerificationPhaseSucceeded},
+			},
+			expected: kargoapi.VerificationPhaseSucceeded,
+		},
+		{
+			name: "some still running",
+			jobStatuses: []kargoapi.VerificationJobStatus{
+				{Phase: kargoapi.VerificationPhaseSucceeded},
+				{Phase: kargoapi.VerificationPhaseRunning},
+			},
+			expected: kargoapi.VerificationPhaseRunning,
+		},
+		{
+			name: "one failed",
+			jobStatuses: []kargoapi.VerificationJobStatus{
+				{Phase: kargoapi.VerificationPhaseRunning},
+				{Phase: kargoapi.VerificationPhaseFailed},
+			},
+			expected: kargoapi.VerificationPhaseFailed,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expected,
+				getVerificationPhase(testCase.jobStatuses),
+			)
+		})
+	}
+}
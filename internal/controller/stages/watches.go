@@ -4,6 +4,7 @@ import (
 	"context"
 
 	log "github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -94,7 +95,7 @@ func newQualifiedFreight(old, new *v1alpha1.Stage) bool {
 	}
 
 	for _, f := range new.Status.History {
-		if f.Qualified && !oldQualified[f.ID] {
+		if f.Qualified && !oldQualified[f.ID] && !failedVerification(new, f.ID) {
 			// something just got qualified
 			return true
 		}
@@ -102,6 +103,14 @@ func newQualifiedFreight(old, new *v1alpha1.Stage) bool {
 	return false
 }
 
+// failedVerification returns whether or not the Freight with the specified ID
+// failed verification in the Stage
+func failedVerification(stage *v1alpha1.Stage, freightID string) bool {
+	return stage.Status.Verification != nil &&
+		stage.Status.Verification.FreightID == freightID &&
+		stage.Status.Verification.Phase == v1alpha1.VerificationPhaseFailed
+}
+
 // PromoWentTerminal is a predicate that returns true if a promotion went terminal
 type PromoWentTerminal struct {
 	predicate.Funcs
@@ -149,3 +158,55 @@ func (p PromoWentTerminal) Update(e event.UpdateEvent) bool {
 	}
 	return false
 }
+
+// JobWentTerminal is a predicate that returns true if a Job completed or failed
+type JobWentTerminal struct {
+	predicate.Funcs
+	logger *log.Entry
+}
+
+func (j JobWentTerminal) Create(_ event.CreateEvent) bool {
+	return false
+}
+
+func (j JobWentTerminal) Delete(e event.DeleteEvent) bool {
+	job, ok := e.Object.(*batchv1.Job)
+	// if a Job is deleted before it went terminal, we want to enqueue the Stage
+	// so it can record the verification as failed
+	return ok && !jobIsTerminal(job)
+}
+
+func (j JobWentTerminal) Generic(_ event.GenericEvent) bool {
+	// we should never get here
+	return true
+}
+
+// Update implements default UpdateEvent filter for checking if a Job went
+// terminal
+func (j JobWentTerminal) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil {
+		j.logger.Errorf("Update event has no old object to update: %v", e)
+		return false
+	}
+	if e.ObjectNew == nil {
+		j.logger.Errorf("Update event has no new object for update: %v", e)
+		return false
+	}
+	newJob, ok := e.ObjectNew.(*batchv1.Job)
+	if !ok {
+		j.logger.Errorf("Failed to convert new job: %v", e.ObjectNew)
+		return false
+	}
+	oldJob, ok := e.ObjectOld.(*batchv1.Job)
+	if !ok {
+		j.logger.Errorf("Failed to convert old job: %v", e.ObjectOld)
+		return false
+	}
+	return jobIsTerminal(newJob) && !jobIsTerminal(oldJob)
+}
+
+// jobIsTerminal returns whether or not the Job has completed or failed
+func jobIsTerminal(job *batchv1.Job) bool {
+	phase, _ := getVerificationJobPhase(job)
+	return phase.IsTerminal()
+}
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/util/workqueue"
@@ -79,7 +81,7 @@ func TestEnqueueDownstreamStagesHandler(t *testing.T) {
 						},
 					},
 					Status: v1alpha1.StageStatus{
-						History: []v1alpha1.Freight{
+						History: []v1alpha1.SimpleFreight{
 							{
 								ID: "abc123",
 							},
@@ -111,7 +113,7 @@ func TestEnqueueDownstreamStagesHandler(t *testing.T) {
 						},
 					},
 					Status: v1alpha1.StageStatus{
-						History: []v1alpha1.Freight{
+						History: []v1alpha1.SimpleFreight{
 							{
 								ID:        "abc123",
 								Qualified: true,
@@ -143,7 +145,7 @@ func TestEnqueueDownstreamStagesHandler(t *testing.T) {
 						},
 					},
 					Status: v1alpha1.StageStatus{
-						History: []v1alpha1.Freight{
+						History: []v1alpha1.SimpleFreight{
 							{
 								ID: "abc123",
 							},
@@ -165,7 +167,7 @@ func TestEnqueueDownstreamStagesHandler(t *testing.T) {
 						},
 					},
 					Status: v1alpha1.StageStatus{
-						History: []v1alpha1.Freight{
+						History: []v1alpha1.SimpleFreight{
 							{
 								ID: "def456",
 							},
@@ -196,7 +198,7 @@ func TestEnqueueDownstreamStagesHandler(t *testing.T) {
 						},
 					},
 					Status: v1alpha1.StageStatus{
-						History: []v1alpha1.Freight{
+						History: []v1alpha1.SimpleFreight{
 							{
 								ID:        "abc123",
 								Qualified: true,
@@ -219,7 +221,7 @@ func TestEnqueueDownstreamStagesHandler(t *testing.T) {
 						},
 					},
 					Status: v1alpha1.StageStatus{
-						History: []v1alpha1.Freight{
+						History: []v1alpha1.SimpleFreight{
 							{
 								ID:        "abc123",
 								Qualified: true,
@@ -251,7 +253,7 @@ func TestEnqueueDownstreamStagesHandler(t *testing.T) {
 						},
 					},
 					Status: v1alpha1.StageStatus{
-						History: []v1alpha1.Freight{
+						History: []v1alpha1.SimpleFreight{
 							{
 								ID: "abc123",
 							},
@@ -273,7 +275,7 @@ func TestEnqueueDownstreamStagesHandler(t *testing.T) {
 						},
 					},
 					Status: v1alpha1.StageStatus{
-						History: []v1alpha1.Freight{
+						History: []v1alpha1.SimpleFreight{
 							{
 								ID:        "abc123",
 								Qualified: true,
@@ -284,6 +286,43 @@ func TestEnqueueDownstreamStagesHandler(t *testing.T) {
 			},
 			enqueued: true,
 		},
+		{
+			name: "qualified freight failed verification",
+			stages: []*v1alpha1.Stage{
+				&downstreamStage,
+			},
+			updateEvt: &event.UpdateEvent{
+				ObjectOld: &v1alpha1.Stage{},
+				ObjectNew: &v1alpha1.Stage{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "upstream",
+						Namespace: "kargo-test",
+					},
+					Spec: &v1alpha1.StageSpec{
+						Subscriptions: &v1alpha1.Subscriptions{
+							UpstreamStages: []v1alpha1.StageSubscription{
+								{
+									Name: "downstream",
+								},
+							},
+						},
+					},
+					Status: v1alpha1.StageStatus{
+						History: []v1alpha1.SimpleFreight{
+							{
+								ID:        "abc123",
+								Qualified: true,
+							},
+						},
+						Verification: &v1alpha1.VerificationInfo{
+							FreightID: "abc123",
+							Phase:     v1alpha1.VerificationPhaseFailed,
+						},
+					},
+				},
+			},
+			enqueued: false,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -440,3 +479,103 @@ func TestPromoWentTerminal(t *testing.T) {
 		})
 	}
 }
+
+func TestJobWentTerminal(t *testing.T) {
+	completeJob := &batchv1.Job{
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{
+				{
+					Type:   batchv1.JobComplete,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+	testCases := []struct {
+		name      string
+		updateEvt *event.UpdateEvent
+		deleteEvt *event.DeleteEvent
+		expected  bool
+	}{
+		{
+			name: "went terminal",
+			updateEvt: &event.UpdateEvent{
+				ObjectOld: &batchv1.Job{},
+				ObjectNew: completeJob,
+			},
+			expected: true,
+		},
+		{
+			name: "stayed running",
+			updateEvt: &event.UpdateEvent{
+				ObjectOld: &batchv1.Job{},
+				ObjectNew: &batchv1.Job{},
+			},
+			expected: false,
+		},
+		{
+			name: "stayed terminal",
+			updateEvt: &event.UpdateEvent{
+				ObjectOld: completeJob,
+				ObjectNew: completeJob,
+			},
+			expected: false,
+		},
+		{
+			name: "new is nil",
+			updateEvt: &event.UpdateEvent{
+				ObjectOld: &batchv1.Job{},
+				ObjectNew: nil,
+			},
+			expected: false,
+		},
+		{
+			name: "old is nil",
+			updateEvt: &event.UpdateEvent{
+				ObjectOld: nil,
+				ObjectNew: completeJob,
+			},
+			expected: false,
+		},
+		{
+			name: "deleted already terminal",
+			deleteEvt: &event.DeleteEvent{
+				Object: completeJob,
+			},
+			expected: false,
+		},
+		{
+			name: "deleted non-terminal",
+			deleteEvt: &event.DeleteEvent{
+				Object: &batchv1.Job{},
+			},
+			expected: true,
+		},
+		{
+			name: "deleted object is nil",
+			deleteEvt: &event.DeleteEvent{
+				Object: nil,
+			},
+			expected: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			jt := JobWentTerminal{logger: logging.LoggerFromContext(context.TODO())}
+			if testCase.updateEvt != nil {
+				require.Equal(
+					t,
+					testCase.expected,
+					jt.Update(*testCase.updateEvt),
+				)
+			}
+			if testCase.deleteEvt != nil {
+				require.Equal(
+					t,
+					testCase.expected,
+					jt.Delete(*testCase.deleteEvt),
+				)
+			}
+		})
+	}
+}
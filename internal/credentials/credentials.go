@@ -24,6 +24,9 @@ const (
 	TypeHelm Type = "helm"
 	// TypeImage represents credentials for an image repository.
 	TypeImage Type = "image"
+	// TypeGitSigning represents a key for signing commits made to a Git
+	// repository.
+	TypeGitSigning Type = "git-signing"
 
 	kargoSecretTypeLabel = "kargo.akuity.io/secret-type" // nolint: gosec
 )
@@ -39,6 +42,16 @@ type Credentials struct {
 	// SSHPrivateKey is a private key that can be used for access to some remote
 	// repository. This is primarily applicable for Git repositories.
 	SSHPrivateKey string
+	// SigningKey is an ASCII-armored GPG private key or an OpenSSH private key
+	// that can be used to sign commits. This is applicable only to Git
+	// repositories.
+	SigningKey string
+	// CommitterName is the name of the committer under which commits signed
+	// using the key in the SigningKey field are made.
+	CommitterName string
+	// CommitterEmail is the email address of the committer under which commits
+	// signed using the key in the SigningKey field are made.
+	CommitterEmail string
 }
 
 // Database is an interface for a Credentials store.
@@ -191,7 +204,7 @@ func getCredentialsSecret(
 	repoURL string,
 	acceptPrefixMatch bool,
 ) (*corev1.Secret, error) {
-	if credType == TypeGit {
+	if credType == TypeGit || credType == TypeGitSigning {
 		// This is important. We don't want the presence or absence of ".git" at the
 		// end of the URL to affect credential lookups.
 		repoURL = git.NormalizeGitURL(repoURL)
@@ -233,8 +246,11 @@ func getCredentialsSecret(
 
 func secretToCreds(secret *corev1.Secret) Credentials {
 	return Credentials{
-		Username:      string(secret.Data["username"]),
-		Password:      string(secret.Data["password"]),
-		SSHPrivateKey: string(secret.Data["sshPrivateKey"]),
+		Username:       string(secret.Data["username"]),
+		Password:       string(secret.Data["password"]),
+		SSHPrivateKey:  string(secret.Data["sshPrivateKey"]),
+		SigningKey:     string(secret.Data["signingKey"]),
+		CommitterName:  string(secret.Data["committerName"]),
+		CommitterEmail: string(secret.Data["committerEmail"]),
 	}
 }
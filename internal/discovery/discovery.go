@@ -0,0 +1,273 @@
+package discovery
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
+)
+
+// Config is configuration for the discovery Service.
+type Config struct {
+	// CacheTTL specifies how long the result of a query is reused before the
+	// same query is performed again. Zero disables caching.
+	CacheTTL time.Duration `envconfig:"DISCOVERY_CACHE_TTL" default:"1m"`
+	// Timeout specifies how long a query may take before it is abandoned. Zero
+	// disables the timeout.
+	Timeout time.Duration `envconfig:"DISCOVERY_TIMEOUT" default:"2m"`
+	// RequestsPerHostPerSecond specifies the maximum sustained rate at which
+	// queries are made against any one host. Zero disables rate limiting.
+	RequestsPerHostPerSecond float64 `envconfig:"DISCOVERY_REQUESTS_PER_HOST_PER_SECOND" default:"5"`
+	// RequestsPerHostBurst specifies the number of queries that may be made
+	// against any one host in excess of RequestsPerHostPerSecond in a burst.
+	RequestsPerHostBurst int `envconfig:"DISCOVERY_REQUESTS_PER_HOST_BURST" default:"10"`
+}
+
+// ConfigFromEnv returns a Config populated from environment variables.
+func ConfigFromEnv() Config {
+	cfg := Config{}
+	envconfig.MustProcess("", &cfg)
+	return cfg
+}
+
+// Request identifies a query, such as a search for the latest suitable tag
+// of an image repository, made against a host.
+type Request struct {
+	// Host is the host the query is made against. Rate limits apply per host.
+	Host string
+	// Key uniquely identifies the query. Queries with equal keys share results.
+	Key string
+}
+
+// NewRequest returns a Request against the specified host whose key is derived
+// from the provided kind of query and values, e.g. a subscription and the
+// credentials used to satisfy it. The values must be serializable as JSON.
+func NewRequest(host string, kind string, vals ...any) Request {
+	h := sha256.New()
+	// Subscriptions and credentials are plain data, so encoding cannot fail
+	_ = json.NewEncoder(h).Encode(vals) // nolint: errcheck
+	return Request{
+		Host: host,
+		Key:  kind + ":" + hex.EncodeToString(h.Sum(nil)),
+	}
+}
+
+// Service performs queries on behalf of any number of Stages. Identical
+// queries made concurrently are performed only once and their results are
+// reused for a while afterwards, which spares hosts from being queried once
+// per Stage subscribing to the same repository.
+type Service struct {
+	cfg   Config
+	group singleflight.Group
+
+	mu       sync.Mutex
+	results  map[string]result
+	limiters map[string]*rate.Limiter
+
+	// nowFn is overridable for testing purposes
+	nowFn func() time.Time
+}
+
+type result struct {
+	val       any
+	expiresAt time.Time
+}
+
+// NewService returns a Service configured using the provided Config.
+func NewService(cfg Config) *Service {
+	return &Service{
+		cfg:      cfg,
+		results:  map[string]result{},
+		limiters: map[string]*rate.Limiter{},
+		nowFn:    time.Now,
+	}
+}
+
+// Discover returns the result of the query identified by the provided Request,
+// performing the query by calling the provided function only if no result of
+// an identical query is cached or already being awaited. Errors are never
+// cached.
+func Discover[T any](
+	ctx context.Context,
+	s *Service,
+	req Request,
+	fn func(context.Context) (T, error),
+) (T, error) {
+	val, err := s.discover(
+		ctx,
+		req,
+		func(ctx context.Context) (any, error) {
+			return fn(ctx)
+		},
+	)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return val.(T), nil // nolint: forcetypeassert
+}
+
+func (s *Service) discover(
+	ctx context.Context,
+	req Request,
+	fn func(context.Context) (any, error),
+) (any, error) {
+	refresh := refreshRequested(ctx)
+	if !refresh {
+		if val, ok := s.getCachedResult(req.Key); ok {
+			return val, nil
+		}
+	}
+
+	groupKey := req.Key
+	if refresh {
+		// A query already in progress may have begun before whatever prompted
+		// the refresh, so its result is not shared with a refresh.
+		groupKey = "refresh:" + groupKey
+	}
+	// The query's result may be shared with other callers, so it must not be
+	// canceled just because the caller that happened to initiate it gives up.
+	// It is canceled once it times out, however.
+	queryCtx := context.WithoutCancel(ctx)
+	resCh := s.group.DoChan(groupKey, func() (any, error) {
+		ctx := queryCtx
+		if s.cfg.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, s.cfg.Timeout)
+			defer cancel()
+		}
+		if err := s.getLimiter(req.Host).Wait(ctx); err != nil {
+			return nil, errors.Wrapf(
+				err,
+				"error waiting to query host %q",
+				req.Host,
+			)
+		}
+		val, err := fn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.cacheResult(req.Key, val)
+		return val, nil
+	})
+
+	// Not every query can be canceled promptly, e.g. requests made by the image
+	// updater's registry client, so the timeout is also enforced here.
+	var timeoutCh <-chan time.Time
+	if s.cfg.Timeout > 0 {
+		timer := time.NewTimer(s.cfg.Timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+	select {
+	case res := <-resCh:
+		return res.Val, res.Err
+	case <-timeoutCh:
+		return nil, errors.Errorf(
+			"timed out after %s waiting for a response from host %q",
+			s.cfg.Timeout,
+			req.Host,
+		)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *Service) getCachedResult(key string) (any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res, ok := s.results[key]
+	if !ok || !s.nowFn().Before(res.expiresAt) {
+		return nil, false
+	}
+	return res.val, true
+}
+
+func (s *Service) cacheResult(key string, val any) {
+	if s.cfg.CacheTTL <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.nowFn()
+	// Results of queries no Stage makes anymore would otherwise be retained
+	// forever
+	for k, res := range s.results {
+		if !now.Before(res.expiresAt) {
+			delete(s.results, k)
+		}
+	}
+	s.results[key] = result{
+		val:       val,
+		expiresAt: now.Add(s.cfg.CacheTTL),
+	}
+}
+
+func (s *Service) getLimiter(host string) *rate.Limiter {
+	if s.cfg.RequestsPerHostPerSecond <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	limiter, ok := s.limiters[host]
+	if !ok {
+		burst := s.cfg.RequestsPerHostBurst
+		if burst < 1 {
+			burst = 1
+		}
+		limiter = rate.NewLimiter(rate.Limit(s.cfg.RequestsPerHostPerSecond), burst)
+		s.limiters[host] = limiter
+	}
+	return limiter
+}
+
+// HostOf returns the host portion of the provided git, image or chart
+// repository URL. Image repository URLs that do not specify a registry are
+// assumed to refer to Docker Hub.
+func HostOf(repoURL string) string {
+	url := strings.ToLower(repoURL)
+	var hasScheme, hasUser bool
+	if i := strings.Index(url, "://"); i >= 0 {
+		url = url[i+3:]
+		hasScheme = true
+	}
+	host := url
+	if i := strings.Index(host, "/"); i >= 0 {
+		host = host[:i]
+	}
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		host = host[i+1:]
+		hasUser = true
+	}
+	if i := strings.Index(host, ":"); i >= 0 {
+		host = host[:i]
+	}
+	// Image repository URLs, e.g. nginx or example/app, need not begin with a
+	// host name
+	if !hasScheme && !hasUser && (!strings.Contains(url, "/") ||
+		(!strings.Contains(host, ".") && host != "localhost")) {
+		return "docker.io"
+	}
+	return host
+}
+
+type refreshContextKey struct{}
+
+// ContextWithRefresh returns a context.Context that causes Discover to disregard
+// cached results and to query hosts anew.
+func ContextWithRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, refreshContextKey{}, true)
+}
+
+func refreshRequested(ctx context.Context) bool {
+	refresh, _ := ctx.Value(refreshContextKey{}).(bool)
+	return refresh
+}
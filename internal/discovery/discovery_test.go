@@ -0,0 +1,201 @@
+package discovery
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscover(t *testing.T) {
+	now := time.Now()
+	svc := NewService(Config{CacheTTL: time.Minute})
+	svc.nowFn = func() time.Time {
+		return now
+	}
+	req := NewRequest("example.com", "fake", "fake-sub")
+
+	var calls int
+	fn := func(context.Context) (string, error) {
+		calls++
+		return "fake-result", nil
+	}
+
+	res, err := Discover(context.Background(), svc, req, fn)
+	require.NoError(t, err)
+	require.Equal(t, "fake-result", res)
+	require.Equal(t, 1, calls)
+
+	t.Run("cached result is reused", func(t *testing.T) {
+		res, err := Discover(context.Background(), svc, req, fn)
+		require.NoError(t, err)
+		require.Equal(t, "fake-result", res)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("different request is not served from cache", func(t *testing.T) {
+		_, err := Discover(
+			context.Background(),
+			svc,
+			NewRequest("example.com", "fake", "other-sub"),
+			fn,
+		)
+		require.NoError(t, err)
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("refresh disregards cached result", func(t *testing.T) {
+		_, err := Discover(ContextWithRefresh(context.Background()), svc, req, fn)
+		require.NoError(t, err)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("expired result is not reused", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+		_, err := Discover(context.Background(), svc, req, fn)
+		require.NoError(t, err)
+		require.Equal(t, 4, calls)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		req := NewRequest("example.com", "fake", "failing-sub")
+		_, err := Discover(
+			context.Background(),
+			svc,
+			req,
+			func(context.Context) (string, error) {
+				return "", errors.New("something went wrong")
+			},
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "something went wrong")
+		res, err := Discover(context.Background(), svc, req, fn)
+		require.NoError(t, err)
+		require.Equal(t, "fake-result", res)
+	})
+}
+
+func TestDiscoverConcurrently(t *testing.T) {
+	svc := NewService(Config{})
+	req := NewRequest("example.com", "fake", "fake-sub")
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fn := func(context.Context) (string, error) {
+		calls.Add(1)
+		<-release
+		return "fake-result", nil
+	}
+
+	const numCallers = 5
+	var started, done sync.WaitGroup
+	started.Add(numCallers)
+	done.Add(numCallers)
+	for i := 0; i < numCallers; i++ {
+		go func() {
+			defer done.Done()
+			started.Done()
+			res, err := Discover(context.Background(), svc, req, fn)
+			require.NoError(t, err)
+			require.Equal(t, "fake-result", res)
+		}()
+	}
+	started.Wait()
+	// Give every caller a chance to join the query in progress
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	done.Wait()
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestDiscoverTimeout(t *testing.T) {
+	svc := NewService(Config{Timeout: 10 * time.Millisecond})
+	release := make(chan struct{})
+	defer close(release)
+	_, err := Discover(
+		context.Background(),
+		svc,
+		NewRequest("example.com", "fake", "fake-sub"),
+		// Deliberately ignores its context
+		func(context.Context) (string, error) {
+			<-release
+			return "fake-result", nil
+		},
+	)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "timed out")
+
+	t.Run("query is canceled", func(t *testing.T) {
+		canceled := make(chan error, 1)
+		_, err := Discover(
+			context.Background(),
+			svc,
+			NewRequest("example.com", "fake", "other-fake-sub"),
+			func(ctx context.Context) (string, error) {
+				<-ctx.Done()
+				canceled <- ctx.Err()
+				return "", ctx.Err()
+			},
+		)
+		require.Error(t, err)
+		select {
+		case err = <-canceled:
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+		case <-time.After(time.Second):
+			require.Fail(t, "query was not canceled")
+		}
+	})
+}
+
+func TestHostOf(t *testing.T) {
+	testCases := []struct {
+		repoURL  string
+		expected string
+	}{
+		{
+			repoURL:  "https://github.com/example/repo.git",
+			expected: "github.com",
+		},
+		{
+			repoURL:  "git@github.com:example/repo.git",
+			expected: "github.com",
+		},
+		{
+			repoURL:  "ssh://git@gitlab.example.com:2222/example/repo.git",
+			expected: "gitlab.example.com",
+		},
+		{
+			repoURL:  "nginx",
+			expected: "docker.io",
+		},
+		{
+			repoURL:  "example/app",
+			expected: "docker.io",
+		},
+		{
+			repoURL:  "ghcr.io/example/app",
+			expected: "ghcr.io",
+		},
+		{
+			repoURL:  "localhost:5000/app",
+			expected: "localhost",
+		},
+		{
+			repoURL:  "oci://registry.example.com/charts",
+			expected: "registry.example.com",
+		},
+		{
+			repoURL:  "https://charts.example.com",
+			expected: "charts.example.com",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.repoURL, func(t *testing.T) {
+			require.Equal(t, testCase.expected, HostOf(testCase.repoURL))
+		})
+	}
+}
@@ -0,0 +1,425 @@
+package git
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	argogit "github.com/argoproj/argo-cd/v2/util/git"
+	"github.com/pkg/errors"
+	"k8s.io/utils/lru"
+
+	"github.com/akuity/bookkeeper/pkg/git"
+	libExec "github.com/akuity/kargo/internal/exec"
+)
+
+// defaultBranchRef is the ref under which a cached repository records the head
+// of the remote repository's default branch each time it is fetched.
+const defaultBranchRef = "refs/kargo/default-branch"
+
+// CloneOptions represents options for obtaining a working copy of a remote git
+// repository from a Cache.
+type CloneOptions struct {
+	// Branch, if specified, is the branch to check out. If not specified, the
+	// remote repository's default branch is checked out.
+	Branch string
+	// NoCheckout, if true, skips populating the working tree. This is useful
+	// when only the repository's history is of interest.
+	NoCheckout bool
+}
+
+// Cache is a cache of bare clones of remote git repositories, keyed by
+// repository URL. Working copies of a repository are obtained from the cache
+// by fetching into the cached clone and then adding a worktree to it, which is
+// far cheaper than cloning the remote repository anew each time. Only one
+// working copy of any given repository may exist at a time. The total size of
+// all cached clones is kept within a configurable limit by evicting those
+// least recently used. Details of commits the Cache has seen are remembered so
+// that they need not be fetched again. A Cache is safe for concurrent use.
+type Cache struct {
+	rootDir  string
+	maxBytes int64
+	commits  *lru.Cache
+
+	reposMu sync.Mutex
+	repos   map[string]*cachedRepo
+}
+
+// cachedRepo is a bare clone of a remote git repository held in a Cache.
+type cachedRepo struct {
+	dir string
+	// lock is held by whomever is using the cached clone. It is a channel
+	// instead of a mutex so that waiting for it can be abandoned.
+	lock chan struct{}
+	// The following fields are guarded by the Cache's reposMu.
+	lastUsed  time.Time
+	sizeBytes int64
+}
+
+// NewCache returns a Cache that stores bare clones of remote git repositories
+// in the specified directory, which is created if it does not exist. Clones
+// already present in the directory, for instance because they were cached by
+// an earlier incarnation of the process, are reused. If maxBytes is positive,
+// least recently used clones are evicted from the cache whenever the total size
+// of all clones exceeds it.
+func NewCache(rootDir string, maxBytes int64) (*Cache, error) {
+	if err := os.MkdirAll(rootDir, 0700); err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error creating git repository cache directory %q",
+			rootDir,
+		)
+	}
+	c := &Cache{
+		rootDir:  rootDir,
+		maxBytes: maxBytes,
+		repos:    map[string]*cachedRepo{},
+		commits:  lru.New(maxCachedCommits),
+	}
+	entries, err := os.ReadDir(rootDir)
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error reading git repository cache directory %q",
+			rootDir,
+		)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		repo := newCachedRepo(filepath.Join(rootDir, entry.Name()))
+		if info, err := entry.Info(); err == nil {
+			repo.lastUsed = info.ModTime()
+		}
+		if repo.sizeBytes, err = dirSize(repo.dir); err != nil {
+			return nil, err
+		}
+		c.repos[entry.Name()] = repo
+	}
+	return c, nil
+}
+
+func newCachedRepo(dir string) *cachedRepo {
+	return &cachedRepo{
+		dir:  dir,
+		lock: make(chan struct{}, 1),
+	}
+}
+
+// Clone returns a working copy of the remote git repository at the specified
+// URL. The repository's cached clone is brought up to date using the provided
+// credentials before the working copy is created, so a cached clone is never
+// used by anyone who could not have cloned the remote repository themselves.
+// If another working copy of the same repository is in use, Clone waits for it
+// to be closed or for the provided context to be canceled. The working copy
+// MUST be closed when it is no longer needed.
+func (c *Cache) Clone(
+	ctx context.Context,
+	repoURL string,
+	creds git.RepoCredentials,
+	opts *CloneOptions,
+) (git.Repo, error) {
+	return c.clone(ctx, repoURL, creds, opts)
+}
+
+// clone does the heavy lifting for Clone.
+func (c *Cache) clone(
+	ctx context.Context,
+	repoURL string,
+	creds git.RepoCredentials,
+	opts *CloneOptions,
+) (*worktree, error) {
+	if opts == nil {
+		opts = &CloneOptions{}
+	}
+	cached, err := c.lockCachedRepo(ctx, getCacheKey(repoURL))
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error waiting for cached clone of git repo %q",
+			repoURL,
+		)
+	}
+	r, err := c.newWorktree(ctx, cached, repoURL, creds, opts)
+	if err != nil {
+		<-cached.lock
+		return nil, err
+	}
+	return r, nil
+}
+
+// lockCachedRepo acquires the lock on the cachedRepo with the specified key,
+// registering a new cachedRepo if necessary, and returns it.
+func (c *Cache) lockCachedRepo(
+	ctx context.Context,
+	key string,
+) (*cachedRepo, error) {
+	for {
+		c.reposMu.Lock()
+		cached, ok := c.repos[key]
+		if !ok {
+			cached = newCachedRepo(filepath.Join(c.rootDir, key))
+			c.repos[key] = cached
+		}
+		cached.lastUsed = time.Now()
+		c.reposMu.Unlock()
+
+		select {
+		case cached.lock <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		// The cachedRepo may have been evicted while we were waiting for it. If
+		// so, start over.
+		c.reposMu.Lock()
+		current := c.repos[key]
+		c.reposMu.Unlock()
+		if current == cached {
+			return cached, nil
+		}
+		<-cached.lock
+	}
+}
+
+// newWorktree brings the provided cached clone up to date with the remote
+// repository at the specified URL and adds a worktree to it. The git commands
+// the worktree executes, including those that bring the cached clone up to
+// date, are bound to the provided context. The caller MUST hold the cached
+// clone's lock.
+func (c *Cache) newWorktree(
+	ctx context.Context,
+	cached *cachedRepo,
+	repoURL string,
+	creds git.RepoCredentials,
+	opts *CloneOptions,
+) (*worktree, error) {
+	homeDir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error creating home directory for repo %q",
+			repoURL,
+		)
+	}
+	r := &worktree{
+		ctx:           ctx,
+		url:           repoURL,
+		homeDir:       homeDir,
+		dir:           filepath.Join(homeDir, "repo"),
+		currentBranch: "HEAD",
+		cached:        cached,
+	}
+	if err = c.prepare(r, creds, opts); err != nil {
+		os.RemoveAll(homeDir) // nolint: errcheck
+		return nil, err
+	}
+	return r, nil
+}
+
+// prepare does the heavy lifting for newWorktree.
+func (c *Cache) prepare(
+	r *worktree,
+	creds git.RepoCredentials,
+	opts *CloneOptions,
+) error {
+	if err := setupAuth(r.homeDir, r.url, creds); err != nil {
+		return err
+	}
+	if err := r.initCachedRepo(); err != nil {
+		return err
+	}
+	if err := r.fetch(); err != nil {
+		return err
+	}
+	size, err := dirSize(r.cached.dir)
+	if err != nil {
+		return err
+	}
+	c.reposMu.Lock()
+	r.cached.sizeBytes = size
+	c.reposMu.Unlock()
+	c.evict()
+
+	startPoint := defaultBranchRef
+	if opts.Branch != "" {
+		startPoint = "refs/remotes/origin/" + opts.Branch
+	}
+	args := []string{"worktree", "add", "--detach"}
+	if opts.NoCheckout {
+		args = append(args, "--no-checkout")
+	}
+	args = append(args, r.dir, startPoint)
+	cmd := r.buildCachedRepoCommand(args...)
+	if _, err = libExec.Exec(cmd); err != nil {
+		if opts.Branch != "" {
+			return errors.Wrapf(
+				err,
+				"error checking out branch %q from repo %q",
+				opts.Branch,
+				r.url,
+			)
+		}
+		return errors.Wrapf(err, "error adding worktree for repo %q", r.url)
+	}
+	if opts.Branch != "" && !opts.NoCheckout {
+		return r.Checkout(opts.Branch)
+	}
+	r.currentBranch = opts.Branch
+	if r.currentBranch == "" {
+		r.currentBranch = "HEAD"
+	}
+	return nil
+}
+
+// evict removes least recently used cached clones, other than those currently
+// in use, until the total size of all cached clones is within the Cache's
+// limit.
+func (c *Cache) evict() {
+	if c.maxBytes <= 0 {
+		return
+	}
+	c.reposMu.Lock()
+	defer c.reposMu.Unlock()
+	var totalBytes int64
+	keys := make([]string, 0, len(c.repos))
+	for key, repo := range c.repos {
+		totalBytes += repo.sizeBytes
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return c.repos[keys[i]].lastUsed.Before(c.repos[keys[j]].lastUsed)
+	})
+	for _, key := range keys {
+		if totalBytes <= c.maxBytes {
+			return
+		}
+		repo := c.repos[key]
+		select {
+		case repo.lock <- struct{}{}:
+		default:
+			continue // In use
+		}
+		os.RemoveAll(repo.dir) // nolint: errcheck
+		delete(c.repos, key)
+		totalBytes -= repo.sizeBytes
+		<-repo.lock
+	}
+}
+
+// getCacheKey returns the key under which the remote repository at the
+// specified URL is cached. The key is also the name of the directory holding
+// the cached clone.
+func getCacheKey(repoURL string) string {
+	if normalized := argogit.NormalizeGitURL(repoURL); normalized != "" {
+		repoURL = normalized
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(repoURL)))
+}
+
+// dirSize returns the total size of all files in the specified directory. If
+// the directory does not exist, zero is returned.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, errors.Wrapf(err, "error determining size of directory %q", dir)
+}
+
+// setupAuth configures the git CLI, as used by the system user whose home
+// directory is specified, for authentication to the remote repository at the
+// specified URL using either SSH or the "store" (username/password-based)
+// credential helper.
+func setupAuth(homeDir, repoURL string, creds git.RepoCredentials) error {
+	runGit := func(args ...string) error {
+		cmd := exec.Command("git", args...)
+		cmd.Env = []string{"HOME=" + homeDir}
+		cmd.Dir = homeDir
+		_, err := libExec.Exec(cmd)
+		return err
+	}
+	// Unless configured otherwise, commits are made under the same identity
+	// Bookkeeper's git client uses.
+	if err := runGit("config", "--global", "user.name", "Bookkeeper"); err != nil {
+		return errors.Wrap(err, "error configuring git username")
+	}
+	if err := runGit(
+		"config", "--global", "user.email", "bookkeeper@akuity.io",
+	); err != nil {
+		return errors.Wrap(err, "error configuring git user email address")
+	}
+
+	if creds.SSHPrivateKey != "" {
+		sshDir := filepath.Join(homeDir, ".ssh")
+		if err := os.MkdirAll(sshDir, 0700); err != nil {
+			return errors.Wrapf(err, "error creating SSH directory %q", sshDir)
+		}
+		sshConfigPath := filepath.Join(sshDir, "config")
+		// nolint: lll
+		const sshConfig = "Host *\n  StrictHostKeyChecking no\n  UserKnownHostsFile=/dev/null"
+		if err := os.WriteFile(sshConfigPath, []byte(sshConfig), 0600); err != nil {
+			return errors.Wrapf(err, "error writing SSH config to %q", sshConfigPath)
+		}
+		keyPath := filepath.Join(sshDir, "id_rsa")
+		if err := os.WriteFile(
+			keyPath,
+			[]byte(creds.SSHPrivateKey),
+			0600,
+		); err != nil {
+			return errors.Wrapf(err, "error writing SSH key to %q", keyPath)
+		}
+		return nil
+	}
+
+	if creds.Username == "" && creds.Password == "" {
+		return nil
+	}
+	if err := runGit("config", "--global", "credential.helper", "store"); err != nil {
+		return errors.Wrap(err, "error configuring git credential helper")
+	}
+	credentialURL, err := url.Parse(repoURL)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing URL %q", repoURL)
+	}
+	credentialURL.Path = ""
+	credentialURL.RawQuery = ""
+	// If the username is the empty string, we assume we're working with a git
+	// provider like GitHub that only requires the username to be non-empty.
+	username := creds.Username
+	if username == "" {
+		username = "git"
+	}
+	credentialURL.User = url.UserPassword(username, creds.Password)
+	credentialsPath := filepath.Join(homeDir, ".git-credentials")
+	if err = os.WriteFile(
+		credentialsPath,
+		[]byte(credentialURL.String()),
+		0600,
+	); err != nil {
+		return errors.Wrapf(err, "error writing credentials to %q", credentialsPath)
+	}
+	return nil
+}
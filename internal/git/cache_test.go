@@ -0,0 +1,257 @@
+package git
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/bookkeeper/pkg/git"
+)
+
+func TestCacheClone(t *testing.T) {
+	remoteURL := newTestRemoteRepo(t)
+	mainCommit := commitToTestRemoteRepo(t, remoteURL, "main", "a.txt")
+	otherCommit := commitToTestRemoteRepo(t, remoteURL, "other", "b.txt")
+
+	cache, err := NewCache(t.TempDir(), 0)
+	require.NoError(t, err)
+
+	t.Run("default branch", func(t *testing.T) {
+		repo, err := cache.Clone(context.Background(), remoteURL, git.RepoCredentials{}, nil)
+		require.NoError(t, err)
+		defer repo.Close()
+		commitID, err := repo.LastCommitID()
+		require.NoError(t, err)
+		require.Equal(t, mainCommit, commitID)
+		require.FileExists(t, filepath.Join(repo.WorkingDir(), "a.txt"))
+	})
+
+	t.Run("specific branch without checkout", func(t *testing.T) {
+		repo, err := cache.Clone(
+			context.Background(),
+			remoteURL,
+			git.RepoCredentials{},
+			&CloneOptions{
+				Branch:     "other",
+				NoCheckout: true,
+			},
+		)
+		require.NoError(t, err)
+		defer repo.Close()
+		commitID, err := repo.LastCommitID()
+		require.NoError(t, err)
+		require.Equal(t, otherCommit, commitID)
+		msg, err := repo.CommitMessage(commitID)
+		require.NoError(t, err)
+		require.Equal(t, "add b.txt", msg)
+		require.NoFileExists(t, filepath.Join(repo.WorkingDir(), "b.txt"))
+	})
+
+	t.Run("non-existent branch", func(t *testing.T) {
+		_, err := cache.Clone(
+			context.Background(),
+			remoteURL,
+			git.RepoCredentials{},
+			&CloneOptions{Branch: "bogus"},
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `error checking out branch "bogus"`)
+	})
+
+	t.Run("commit and push", func(t *testing.T) {
+		repo, err := cache.Clone(context.Background(), remoteURL, git.RepoCredentials{}, nil)
+		require.NoError(t, err)
+		defer repo.Close()
+		require.NoError(t, repo.Checkout("main"))
+		require.NoError(
+			t,
+			os.WriteFile(filepath.Join(repo.WorkingDir(), "c.txt"), []byte("c"), 0600),
+		)
+		require.NoError(t, repo.AddAllAndCommit("add c.txt"))
+		require.NoError(t, repo.Push())
+		commitID, err := repo.LastCommitID()
+		require.NoError(t, err)
+		require.Equal(t, commitID, runTestGit(t, "", "ls-remote", remoteURL, "refs/heads/main")[:40])
+	})
+
+	t.Run("picks up changes to remote", func(t *testing.T) {
+		newCommit := commitToTestRemoteRepo(t, remoteURL, "main", "d.txt")
+		repo, err := cache.Clone(context.Background(), remoteURL, git.RepoCredentials{}, nil)
+		require.NoError(t, err)
+		defer repo.Close()
+		// A local branch left over from the previous test must not be stale
+		require.NoError(t, repo.Checkout("main"))
+		commitID, err := repo.LastCommitID()
+		require.NoError(t, err)
+		require.Equal(t, newCommit, commitID)
+		require.FileExists(t, filepath.Join(repo.WorkingDir(), "d.txt"))
+	})
+}
+
+func TestCacheCloneWaitsForOtherUsers(t *testing.T) {
+	remoteURL := newTestRemoteRepo(t)
+	commitToTestRemoteRepo(t, remoteURL, "main", "a.txt")
+
+	cache, err := NewCache(t.TempDir(), 0)
+	require.NoError(t, err)
+
+	repo, err := cache.Clone(context.Background(), remoteURL, git.RepoCredentials{}, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = cache.Clone(ctx, remoteURL, git.RepoCredentials{}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error waiting for cached clone")
+
+	require.NoError(t, repo.Close())
+	// Closing more than once is harmless
+	require.NoError(t, repo.Close())
+
+	repo, err = cache.Clone(context.Background(), remoteURL, git.RepoCredentials{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.Close())
+}
+
+func TestCacheCloneCanceledDuringFetch(t *testing.T) {
+	// A remote repository that never responds keeps the fetch running until it
+	// is aborted
+	unblock := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(_ http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-unblock:
+			}
+		},
+	))
+	defer srv.Close()
+	defer close(unblock)
+
+	cache, err := NewCache(t.TempDir(), 0)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = cache.Clone(ctx, srv.URL+"/repo.git", git.RepoCredentials{}, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Contains(t, err.Error(), "error fetching from repo")
+	require.Less(t, time.Since(start), 10*time.Second)
+
+	// The cached clone should have been released
+	ctx, cancel = context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	_, err = cache.Clone(ctx, srv.URL+"/repo.git", git.RepoCredentials{}, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NotContains(t, err.Error(), "error waiting for cached clone")
+}
+
+func TestCacheEviction(t *testing.T) {
+	remoteURL1 := newTestRemoteRepo(t)
+	commitToTestRemoteRepo(t, remoteURL1, "main", "a.txt")
+	remoteURL2 := newTestRemoteRepo(t)
+	commitToTestRemoteRepo(t, remoteURL2, "main", "a.txt")
+
+	rootDir := t.TempDir()
+	// Every cached clone will exceed this limit
+	cache, err := NewCache(rootDir, 1)
+	require.NoError(t, err)
+
+	repo, err := cache.Clone(context.Background(), remoteURL1, git.RepoCredentials{}, nil)
+	require.NoError(t, err)
+	// A cached clone that is in use is never evicted
+	require.DirExists(t, filepath.Join(rootDir, getCacheKey(remoteURL1)))
+	require.NoError(t, repo.Close())
+
+	repo, err = cache.Clone(context.Background(), remoteURL2, git.RepoCredentials{}, nil)
+	require.NoError(t, err)
+	defer repo.Close()
+	require.NoDirExists(t, filepath.Join(rootDir, getCacheKey(remoteURL1)))
+	require.DirExists(t, filepath.Join(rootDir, getCacheKey(remoteURL2)))
+	require.Len(t, cache.repos, 1)
+}
+
+func TestNewCacheReusesClones(t *testing.T) {
+	remoteURL := newTestRemoteRepo(t)
+	commitToTestRemoteRepo(t, remoteURL, "main", "a.txt")
+
+	rootDir := t.TempDir()
+	cache, err := NewCache(rootDir, 0)
+	require.NoError(t, err)
+	repo, err := cache.Clone(context.Background(), remoteURL, git.RepoCredentials{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.Close())
+
+	cache, err = NewCache(rootDir, 0)
+	require.NoError(t, err)
+	cached, ok := cache.repos[getCacheKey(remoteURL)]
+	require.True(t, ok)
+	require.Positive(t, cached.sizeBytes)
+}
+
+func TestGetCacheKey(t *testing.T) {
+	require.Equal(
+		t,
+		getCacheKey("https://github.com/akuity/kargo"),
+		getCacheKey("https://github.com/akuity/kargo.git"),
+	)
+	require.NotEqual(
+		t,
+		getCacheKey("https://github.com/akuity/kargo"),
+		getCacheKey("https://github.com/akuity/bookkeeper"),
+	)
+}
+
+// newTestRemoteRepo creates an empty bare repository whose default branch is
+// main and returns its URL.
+func newTestRemoteRepo(t *testing.T) string {
+	dir := filepath.Join(t.TempDir(), "remote.git")
+	runTestGit(t, "", "init", "--bare", "--quiet", dir)
+	runTestGit(t, dir, "symbolic-ref", "HEAD", "refs/heads/main")
+	return "file://" + dir
+}
+
+// commitToTestRemoteRepo adds a commit that adds the named file to the
+// specified branch of the remote repository at the specified URL and returns
+// the commit's ID.
+func commitToTestRemoteRepo(t *testing.T, remoteURL, branch, file string) string {
+	dir := t.TempDir()
+	runTestGit(t, dir, "init", "--quiet")
+	if out := runTestGit(
+		t, dir, "ls-remote", "--heads", remoteURL, branch,
+	); out != "" {
+		runTestGit(t, dir, "fetch", "--quiet", remoteURL, branch)
+		runTestGit(t, dir, "checkout", "--quiet", "FETCH_HEAD")
+	}
+	path := filepath.Join(dir, file)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, []byte(file), 0600))
+	runTestGit(t, dir, "add", ".")
+	runTestGit(t, dir, "commit", "--quiet", "-m", "add "+file)
+	runTestGit(t, dir, "push", "--quiet", remoteURL, "HEAD:refs/heads/"+branch)
+	return runTestGit(t, dir, "rev-parse", "HEAD")
+}
+
+func runTestGit(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command(
+		"git",
+		append(
+			[]string{"-c", "user.name=Test", "-c", "user.email=test@example.com"},
+			args...,
+		)...,
+	)
+	cmd.Dir = dir
+	cmd.Env = []string{"HOME=" + t.TempDir()}
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
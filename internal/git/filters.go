@@ -0,0 +1,198 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/akuity/bookkeeper/pkg/git"
+)
+
+// CommitFilters represents criteria by which commits that are not of interest
+// are disregarded when searching a remote git repository's history for the
+// newest commit of interest.
+type CommitFilters struct {
+	// IncludePaths is a list of git glob pathspecs. If specified, commits that
+	// do not change any file matching at least one of them are disregarded.
+	IncludePaths []string
+	// ExcludePaths is a list of git glob pathspecs. Changes to files matching
+	// any of them are disregarded.
+	ExcludePaths []string
+	// IgnoreMessages is a list of regular expressions. Commits whose message
+	// matches any of them are disregarded.
+	IgnoreMessages []string
+	// IgnoreAuthors is a list of regular expressions. Commits whose author, in
+	// the form "Name <email>", matches any of them are disregarded.
+	IgnoreAuthors []string
+}
+
+// isEmpty returns a bool indicating whether the CommitFilters disregard no
+// commits at all.
+func (f CommitFilters) isEmpty() bool {
+	return len(f.IncludePaths) == 0 && len(f.ExcludePaths) == 0 &&
+		len(f.IgnoreMessages) == 0 && len(f.IgnoreAuthors) == 0
+}
+
+// pathspecs returns the git pathspecs that limit history to commits changing
+// files of interest.
+func (f CommitFilters) pathspecs() []string {
+	pathspecs := make([]string, 0, len(f.IncludePaths)+len(f.ExcludePaths))
+	for _, path := range f.IncludePaths {
+		pathspecs = append(pathspecs, ":(glob)"+path)
+	}
+	for _, path := range f.ExcludePaths {
+		pathspecs = append(pathspecs, ":(glob,exclude)"+path)
+	}
+	return pathspecs
+}
+
+// compiledCommitFilters holds the compiled regular expressions of a
+// CommitFilters.
+type compiledCommitFilters struct {
+	ignoreMessages []*regexp.Regexp
+	ignoreAuthors  []*regexp.Regexp
+}
+
+func (f CommitFilters) compile() (*compiledCommitFilters, error) {
+	c := &compiledCommitFilters{}
+	var err error
+	if c.ignoreMessages, err = compileRegexes(f.IgnoreMessages); err != nil {
+		return nil, err
+	}
+	if c.ignoreAuthors, err = compileRegexes(f.IgnoreAuthors); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ignores returns a bool indicating whether a commit with the provided author
+// and full message is to be disregarded.
+func (c *compiledCommitFilters) ignores(author, message string) bool {
+	for _, regex := range c.ignoreAuthors {
+		if regex.MatchString(author) {
+			return true
+		}
+	}
+	for _, regex := range c.ignoreMessages {
+		if regex.MatchString(message) {
+			return true
+		}
+	}
+	return false
+}
+
+func compileRegexes(exprs []string) ([]*regexp.Regexp, error) {
+	regexes := make([]*regexp.Regexp, len(exprs))
+	for i, expr := range exprs {
+		var err error
+		if regexes[i], err = regexp.Compile(expr); err != nil {
+			return nil, errors.Wrapf(err, "error parsing regular expression %q", expr)
+		}
+	}
+	return regexes, nil
+}
+
+// getMatchingCommit searches the history of the remote repository at the
+// specified URL, beginning with the commit with the specified ID, for the
+// newest commit not disregarded by the provided filters and returns it. The
+// result is remembered so that, for as long as the starting commit remains the
+// same, the history need not be searched again.
+func (c *Cache) getMatchingCommit(
+	ctx context.Context,
+	repoURL string,
+	id string,
+	filters CommitFilters,
+	creds git.RepoCredentials,
+) (*Commit, error) {
+	key := fmt.Sprintf("%s:%s:%q", getCacheKey(repoURL), id, filters)
+	if c.commits != nil {
+		if commit, ok := c.commits.Get(key); ok {
+			res := commit.(Commit) // nolint: forcetypeassert
+			return &res, nil
+		}
+	}
+	compiled, err := filters.compile()
+	if err != nil {
+		return nil, err
+	}
+	// Only the repository's history is of interest, so there is no need to
+	// populate a working tree.
+	repo, err := c.clone(ctx, repoURL, creds, &CloneOptions{NoCheckout: true})
+	if err != nil {
+		return nil, err
+	}
+	defer repo.Close()
+	commit, err := repo.findCommit(id, filters.pathspecs(), compiled)
+	if err != nil {
+		return nil, err
+	}
+	if c.commits != nil {
+		c.commits.Add(key, *commit)
+	}
+	return commit, nil
+}
+
+// findCommit walks history, beginning with the commit with the specified ID,
+// and returns the newest commit that changes files matching the provided
+// pathspecs, if any, and that is not disregarded by the provided filters.
+// History is read only for as long as it takes to find such a commit.
+func (w *worktree) findCommit(
+	id string,
+	pathspecs []string,
+	filters *compiledCommitFilters,
+) (*Commit, error) {
+	args := []string{"log", "-z", "--pretty=format:%H%n%an <%ae>%n%B", id, "--"}
+	cmd := w.buildCommand(append(args, pathspecs...)...)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading git log")
+	}
+	if err = cmd.Start(); err != nil {
+		return nil, errors.Wrapf(err, "error walking history of repo %q", w.url)
+	}
+	reader := bufio.NewReader(stdout)
+	for {
+		// Commits are separated by NUL characters
+		record, readErr := reader.ReadString(0)
+		fields := strings.SplitN(strings.TrimSuffix(record, "\x00"), "\n", 3)
+		if len(fields) == 3 && !filters.ignores(fields[1], fields[2]) {
+			// Nothing more needs to be read
+			cmd.Process.Kill() // nolint: errcheck
+			cmd.Wait()         // nolint: errcheck
+			return &Commit{
+				ID:     fields[0],
+				Author: fields[1],
+				// Only the first line of the message is captured for brevity
+				Message: strings.Split(strings.TrimSpace(fields[2]), "\n")[0],
+			}, nil
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			cmd.Process.Kill() // nolint: errcheck
+			cmd.Wait()         // nolint: errcheck
+			return nil, errors.Wrapf(readErr, "error reading history of repo %q", w.url)
+		}
+	}
+	if err = cmd.Wait(); err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error walking history of repo %q: %s",
+			w.url,
+			strings.TrimSpace(stderr.String()),
+		)
+	}
+	return nil, errors.Errorf(
+		"found no commit in repo %q that is not disregarded by filters",
+		w.url,
+	)
+}
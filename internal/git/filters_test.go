@@ -0,0 +1,125 @@
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/bookkeeper/pkg/git"
+)
+
+func TestCacheGetLatestCommitWithFilters(t *testing.T) {
+	remoteURL := newTestRemoteRepo(t)
+	appCommit := commitToTestRemoteRepo(t, remoteURL, "main", "apps/a.txt")
+	skipCommit := commitToTestRemoteRepo(t, remoteURL, "main", "apps/skip.txt")
+	commitToTestRemoteRepo(t, remoteURL, "main", "docs/readme.md")
+
+	cache, err := NewCache(t.TempDir(), 0)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		filters    CommitFilters
+		assertions func(*Commit, error)
+	}{
+		{
+			name: "invalid regular expression",
+			filters: CommitFilters{
+				IgnoreMessages: []string{"("},
+			},
+			assertions: func(_ *Commit, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error parsing regular expression")
+			},
+		},
+		{
+			name: "included paths",
+			filters: CommitFilters{
+				IncludePaths: []string{"apps/**"},
+			},
+			assertions: func(commit *Commit, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					&Commit{
+						ID:      skipCommit,
+						Message: "add apps/skip.txt",
+						Author:  "Test <test@example.com>",
+					},
+					commit,
+				)
+			},
+		},
+		{
+			name: "excluded paths",
+			filters: CommitFilters{
+				ExcludePaths: []string{"docs/**"},
+			},
+			assertions: func(commit *Commit, err error) {
+				require.NoError(t, err)
+				require.Equal(t, skipCommit, commit.ID)
+			},
+		},
+		{
+			name: "ignored messages",
+			filters: CommitFilters{
+				IncludePaths:   []string{"apps/**"},
+				IgnoreMessages: []string{`skip\.txt`},
+			},
+			assertions: func(commit *Commit, err error) {
+				require.NoError(t, err)
+				require.Equal(t, appCommit, commit.ID)
+			},
+		},
+		{
+			name: "every commit ignored",
+			filters: CommitFilters{
+				IgnoreAuthors: []string{"^Test <"},
+			},
+			assertions: func(_ *Commit, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "found no commit")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				cache.GetLatestCommit(
+					context.Background(),
+					remoteURL,
+					"",
+					testCase.filters,
+					git.RepoCredentials{},
+				),
+			)
+		})
+	}
+
+	t.Run("results are remembered", func(t *testing.T) {
+		filters := CommitFilters{IncludePaths: []string{"apps/**"}}
+		_, err := cache.GetLatestCommit(
+			context.Background(),
+			remoteURL,
+			"",
+			filters,
+			git.RepoCredentials{},
+		)
+		require.NoError(t, err)
+		// Evicting the cached clone proves it is not needed again
+		cache.maxBytes = 1
+		cache.evict()
+		require.Empty(t, cache.repos)
+		commit, err := cache.GetLatestCommit(
+			context.Background(),
+			remoteURL,
+			"",
+			filters,
+			git.RepoCredentials{},
+		)
+		require.NoError(t, err)
+		require.Equal(t, skipCommit, commit.ID)
+		require.Empty(t, cache.repos)
+	})
+}
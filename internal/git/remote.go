@@ -0,0 +1,239 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/akuity/bookkeeper/pkg/git"
+	libExec "github.com/akuity/kargo/internal/exec"
+)
+
+// maxCachedCommits is the number of commits whose details a Cache remembers.
+const maxCachedCommits = 1000
+
+// Commit describes a single commit in a remote git repository.
+type Commit struct {
+	// ID is the commit's ID.
+	ID string
+	// Message is the first line of the commit's message.
+	Message string
+	// Author is the commit's author, in the form "Name <email>".
+	Author string
+	// Tag is the tag through which the commit was selected, if any.
+	Tag string
+}
+
+// GetLatestCommit returns the commit at the head of the specified branch of
+// the remote git repository at the specified URL. If no branch is specified,
+// the head of the remote repository's default branch is returned. The head is
+// resolved by querying the remote repository's refs, without cloning it.
+// Details of commits not seen before are obtained by fetching that single
+// commit, without any of its history, trees or blobs. If the provided filters
+// disregard any commits, the newest commit not disregarded by them is returned
+// instead. Finding that commit requires the repository's history, so the
+// repository's cached clone is used, but only when the head has changed since
+// the last time the same search was made.
+func (c *Cache) GetLatestCommit(
+	ctx context.Context,
+	repoURL string,
+	branch string,
+	filters CommitFilters,
+	creds git.RepoCredentials,
+) (*Commit, error) {
+	homeDir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error creating home directory for repo %q",
+			repoURL,
+		)
+	}
+	defer os.RemoveAll(homeDir) // nolint: errcheck
+	if err = setupAuth(homeDir, repoURL, creds); err != nil {
+		return nil, err
+	}
+	id, err := resolveRemoteRef(ctx, homeDir, repoURL, branch)
+	if err != nil {
+		return nil, err
+	}
+	if filters.isEmpty() {
+		return c.getCommit(ctx, homeDir, repoURL, id)
+	}
+	return c.getMatchingCommit(ctx, repoURL, id, filters, creds)
+}
+
+// getCommit returns details of the commit with the specified ID from the
+// remote repository at the specified URL, fetching the commit only if its
+// details are not already known. git, as used by the system user whose home
+// directory is specified, MUST already be configured for authentication to the
+// remote repository.
+func (c *Cache) getCommit(
+	ctx context.Context,
+	homeDir string,
+	repoURL string,
+	id string,
+) (*Commit, error) {
+	key := getCacheKey(repoURL) + ":" + id
+	if c.commits != nil {
+		if commit, ok := c.commits.Get(key); ok {
+			res := commit.(Commit) // nolint: forcetypeassert
+			return &res, nil
+		}
+	}
+	commit, err := fetchCommit(ctx, homeDir, repoURL, id)
+	if err != nil {
+		return nil, err
+	}
+	if c.commits != nil {
+		c.commits.Add(key, *commit)
+	}
+	return commit, nil
+}
+
+// resolveRemoteRef returns the ID of the commit at the head of the specified
+// branch of the remote repository at the specified URL, or of its default
+// branch if no branch is specified.
+func resolveRemoteRef(
+	ctx context.Context,
+	homeDir string,
+	repoURL string,
+	branch string,
+) (string, error) {
+	ref := "HEAD"
+	if branch != "" {
+		ref = "refs/heads/" + branch
+	}
+	res, err := libExec.Exec(buildRemoteCommand(
+		ctx,
+		homeDir,
+		"ls-remote",
+		"--exit-code", // Return 2 if not found
+		repoURL,
+		ref,
+	))
+	if exitErr, ok := err.(*libExec.ExitError); ok && exitErr.ExitCode == 2 {
+		if branch == "" {
+			return "", errors.Errorf(
+				"default branch of repo %q could not be determined",
+				repoURL,
+			)
+		}
+		return "", errors.Errorf(
+			"branch %q not found in repo %q",
+			branch,
+			repoURL,
+		)
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "error listing refs of repo %q", repoURL)
+	}
+	// The ref is a pattern matched against the tail of every ref name, so more
+	// than just the ref we asked for may be listed.
+	scanner := bufio.NewScanner(bytes.NewReader(res))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == ref {
+			return fields[0], nil
+		}
+	}
+	return "", errors.Errorf("ref %q not found in repo %q", ref, repoURL)
+}
+
+// fetchCommit fetches the commit with the specified ID, and nothing else, from
+// the remote repository at the specified URL into a scratch repository within
+// the specified home directory and returns its details.
+func fetchCommit(
+	ctx context.Context,
+	homeDir string,
+	repoURL string,
+	id string,
+) (*Commit, error) {
+	dir := filepath.Join(homeDir, "repo")
+	if _, err := libExec.Exec(
+		buildRemoteCommand(ctx, homeDir, "init", "--bare", "--quiet", dir),
+	); err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error initializing repository for commit %q from repo %q",
+			id,
+			repoURL,
+		)
+	}
+	cmd := buildRemoteCommand(
+		ctx,
+		homeDir,
+		"fetch",
+		"--quiet",
+		"--depth=1",
+		// Servers that do not support partial clones ignore this and send the
+		// commit's tree along with it.
+		"--filter=tree:0",
+		"--no-tags",
+		repoURL,
+		id,
+	)
+	cmd.Dir = dir
+	if _, err := libExec.Exec(cmd); err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error fetching commit %q from repo %q",
+			id,
+			repoURL,
+		)
+	}
+	cmd = buildRemoteCommand(
+		ctx,
+		homeDir,
+		"log",
+		"-n",
+		"1",
+		"--pretty=format:%H%x00%an <%ae>%x00%s",
+		id,
+	)
+	cmd.Dir = dir
+	res, err := libExec.Exec(cmd)
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error obtaining details of commit %q from repo %q",
+			id,
+			repoURL,
+		)
+	}
+	fields := strings.SplitN(string(res), "\x00", 3)
+	if len(fields) != 3 {
+		return nil, errors.Errorf(
+			"unexpected details of commit %q from repo %q: %q",
+			id,
+			repoURL,
+			string(res),
+		)
+	}
+	return &Commit{
+		ID:     fields[0],
+		Author: fields[1],
+		// Only the first line of the message is captured for brevity
+		Message: strings.Split(strings.TrimSpace(fields[2]), "\n")[0],
+	}, nil
+}
+
+// buildRemoteCommand returns a command that executes git, as the system user
+// whose home directory is specified, within that home directory. The command
+// is killed if the provided context is done before it completes.
+func buildRemoteCommand(
+	ctx context.Context,
+	homeDir string,
+	arg ...string,
+) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "git", arg...)
+	cmd.Env = []string{"HOME=" + homeDir}
+	cmd.Dir = homeDir
+	return cmd
+}
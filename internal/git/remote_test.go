@@ -0,0 +1,114 @@
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/bookkeeper/pkg/git"
+)
+
+func TestCacheGetLatestCommit(t *testing.T) {
+	remoteURL := newTestRemoteRepo(t)
+	mainCommit := commitToTestRemoteRepo(t, remoteURL, "main", "a.txt")
+	otherCommit := commitToTestRemoteRepo(t, remoteURL, "other", "b.txt")
+
+	cache, err := NewCache(t.TempDir(), 0)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		branch     string
+		assertions func(*Commit, error)
+	}{
+		{
+			name: "default branch",
+			assertions: func(commit *Commit, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					&Commit{
+						ID:      mainCommit,
+						Message: "add a.txt",
+						Author:  "Test <test@example.com>",
+					},
+					commit,
+				)
+			},
+		},
+		{
+			name:   "specific branch",
+			branch: "other",
+			assertions: func(commit *Commit, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					&Commit{
+						ID:      otherCommit,
+						Message: "add b.txt",
+						Author:  "Test <test@example.com>",
+					},
+					commit,
+				)
+			},
+		},
+		{
+			name:   "non-existent branch",
+			branch: "bogus",
+			assertions: func(_ *Commit, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), `branch "bogus" not found`)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				cache.GetLatestCommit(
+					context.Background(),
+					remoteURL,
+					testCase.branch,
+					CommitFilters{},
+					git.RepoCredentials{},
+				),
+			)
+		})
+	}
+
+	// Details of both commits are remembered and nothing was cloned
+	require.Equal(t, 2, cache.commits.Len())
+	require.Empty(t, cache.repos)
+
+	t.Run("picks up changes to remote", func(t *testing.T) {
+		newCommit := commitToTestRemoteRepo(t, remoteURL, "main", "c.txt")
+		commit, err := cache.GetLatestCommit(
+			context.Background(),
+			remoteURL,
+			"",
+			CommitFilters{},
+			git.RepoCredentials{},
+		)
+		require.NoError(t, err)
+		require.Equal(t, newCommit, commit.ID)
+		require.Equal(t, "add c.txt", commit.Message)
+	})
+}
+
+func TestCacheGetLatestCommitCanceled(t *testing.T) {
+	remoteURL := newTestRemoteRepo(t)
+	commitToTestRemoteRepo(t, remoteURL, "main", "a.txt")
+	cache, err := NewCache(t.TempDir(), 0)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = cache.GetLatestCommit(
+		ctx,
+		remoteURL,
+		"",
+		CommitFilters{},
+		git.RepoCredentials{},
+	)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error listing refs")
+}
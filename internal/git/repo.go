@@ -0,0 +1,366 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/akuity/bookkeeper/pkg/git"
+	libExec "github.com/akuity/kargo/internal/exec"
+)
+
+// commandWaitDelay is how long the git commands a worktree executes are waited
+// on to close their output after they have been killed.
+const commandWaitDelay = time.Second
+
+// worktree is an implementation of the git.Repo interface backed by a worktree
+// of a clone held in a Cache. It is stateful and NOT suitable for use across
+// multiple goroutines.
+type worktree struct {
+	// ctx is the context the worktree was cloned with. The git.Repo interface
+	// offers no other means of canceling the git commands a worktree executes,
+	// so any that are still running when it is canceled are killed.
+	ctx           context.Context
+	url           string
+	homeDir       string
+	dir           string
+	currentBranch string
+	cached        *cachedRepo
+	closeOnce     sync.Once
+}
+
+var _ git.Repo = &worktree{}
+
+// initCachedRepo initializes the cached clone if it does not already exist and
+// clears away anything left behind by earlier users of it.
+func (w *worktree) initCachedRepo() error {
+	cmd := w.buildCommand("init", "--bare", "--quiet", w.cached.dir)
+	cmd.Dir = w.homeDir
+	if _, err := libExec.Exec(cmd); err != nil {
+		return errors.Wrapf(err, "error initializing cached clone of repo %q", w.url)
+	}
+	// The URL is set every time because the same repository may be referred to
+	// by slightly different URLs.
+	for _, setting := range [][]string{
+		{"remote.origin.url", w.url},
+		{"remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*"},
+	} {
+		if _, err := libExec.Exec(
+			w.buildCachedRepoCommand("config", setting[0], setting[1]),
+		); err != nil {
+			return errors.Wrapf(
+				err,
+				"error configuring cached clone of repo %q",
+				w.url,
+			)
+		}
+	}
+	// Forget worktrees that no longer exist, e.g. because the process that
+	// created them did not live long enough to remove them.
+	if _, err := libExec.Exec(
+		w.buildCachedRepoCommand("worktree", "prune"),
+	); err != nil {
+		return errors.Wrapf(err, "error pruning worktrees of repo %q", w.url)
+	}
+	// Local branches only ever exist for the benefit of a single worktree, so
+	// any that are left over are stale.
+	res, err := libExec.Exec(w.buildCachedRepoCommand(
+		"for-each-ref",
+		"--format=delete %(refname)",
+		"refs/heads/",
+	))
+	if err != nil {
+		return errors.Wrapf(err, "error listing local branches of repo %q", w.url)
+	}
+	if len(res) > 0 {
+		cmd = w.buildCachedRepoCommand("update-ref", "--stdin")
+		cmd.Stdin = bytes.NewReader(res)
+		if _, err = libExec.Exec(cmd); err != nil {
+			return errors.Wrapf(
+				err,
+				"error deleting stale local branches of repo %q",
+				w.url,
+			)
+		}
+	}
+	return nil
+}
+
+// fetch brings the cached clone up to date with the remote repository. If the
+// worktree's context is canceled while fetching, the fetch is aborted and the
+// context's error is returned.
+func (w *worktree) fetch() error {
+	_, err := libExec.Exec(w.buildCachedRepoCommand(
+		"fetch",
+		"--prune",
+		"--no-tags",
+		"origin",
+		"+refs/heads/*:refs/remotes/origin/*",
+		"+HEAD:"+defaultBranchRef,
+	))
+	if err != nil && w.ctx.Err() != nil {
+		err = w.ctx.Err()
+	}
+	return errors.Wrapf(err, "error fetching from repo %q", w.url)
+}
+
+func (w *worktree) AddAll() error {
+	_, err := libExec.Exec(w.buildCommand("add", "."))
+	return errors.Wrap(err, "error staging changes for commit")
+}
+
+func (w *worktree) AddAllAndCommit(message string) error {
+	if err := w.AddAll(); err != nil {
+		return err
+	}
+	return w.Commit(message)
+}
+
+func (w *worktree) Clean() error {
+	_, err := libExec.Exec(w.buildCommand("clean", "-fd"))
+	return errors.Wrapf(err, "error cleaning branch %q", w.currentBranch)
+}
+
+// Close removes the worktree and releases the cached clone for use by others.
+func (w *worktree) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		defer func() { <-w.cached.lock }()
+		// The worktree must be removed even if its context has been canceled
+		cmd := w.buildCommandContext(
+			context.Background(),
+			"worktree",
+			"remove",
+			"--force",
+			w.dir,
+		)
+		cmd.Dir = w.cached.dir
+		if _, err = libExec.Exec(cmd); err != nil {
+			err = errors.Wrapf(err, "error removing worktree of repo %q", w.url)
+		}
+		if rmErr := os.RemoveAll(w.homeDir); err == nil {
+			err = rmErr
+		}
+	})
+	return err
+}
+
+// Checkout checks out the specified branch. If the branch exists in the remote
+// repository, the local branch is made to match it. Commit IDs may also be
+// checked out.
+func (w *worktree) Checkout(branch string) error {
+	w.currentBranch = branch
+	args := []string{"checkout", branch}
+	if !w.refExists("refs/heads/"+branch) &&
+		w.refExists("refs/remotes/origin/"+branch) {
+		args = []string{"checkout", "-B", branch, "refs/remotes/origin/" + branch}
+	}
+	// The next argument makes it crystal clear to git that we're checking out a
+	// branch. We need to do this because branch names can often resemble paths
+	// within the repo.
+	args = append(args, "--")
+	_, err := libExec.Exec(w.buildCommand(args...))
+	return errors.Wrapf(
+		err,
+		"error checking out branch %q from repo %q",
+		branch,
+		w.url,
+	)
+}
+
+func (w *worktree) Commit(message string) error {
+	_, err := libExec.Exec(w.buildCommand("commit", "-m", message))
+	return errors.Wrapf(
+		err,
+		"error committing changes to branch %q",
+		w.currentBranch,
+	)
+}
+
+func (w *worktree) CreateChildBranch(branch string) error {
+	w.currentBranch = branch
+	_, err := libExec.Exec(w.buildCommand(
+		"checkout",
+		"-b",
+		branch,
+		// The next line makes it crystal clear to git that we're checking out
+		// a branch. We need to do this because branch names can often resemble
+		// paths within the repo.
+		"--",
+	))
+	return errors.Wrapf(
+		err,
+		"error creating new branch %q for repo %q",
+		branch,
+		w.url,
+	)
+}
+
+func (w *worktree) CreateOrphanedBranch(branch string) error {
+	w.currentBranch = branch
+	if _, err := libExec.Exec(w.buildCommand(
+		"switch",
+		"--orphan",
+		branch,
+		"--discard-changes",
+	)); err != nil {
+		return errors.Wrapf(
+			err,
+			"error creating orphaned branch %q for repo %q",
+			branch,
+			w.url,
+		)
+	}
+	return w.Clean()
+}
+
+func (w *worktree) HasDiffs() (bool, error) {
+	resBytes, err := libExec.Exec(w.buildCommand("status", "-s"))
+	return len(resBytes) > 0,
+		errors.Wrapf(err, "error checking status of branch %q", w.currentBranch)
+}
+
+func (w *worktree) GetDiffPaths() ([]string, error) {
+	resBytes, err := libExec.Exec(w.buildCommand("status", "-s"))
+	if err != nil {
+		return nil,
+			errors.Wrapf(err, "error checking status of branch %q", w.currentBranch)
+	}
+	paths := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(resBytes))
+	scanner.Split(bufio.ScanLines)
+	for scanner.Scan() {
+		paths = append(
+			paths,
+			strings.SplitN(strings.TrimSpace(scanner.Text()), " ", 2)[1],
+		)
+	}
+	return paths, nil
+}
+
+func (w *worktree) LastCommitID() (string, error) {
+	shaBytes, err := libExec.Exec(w.buildCommand("rev-parse", "HEAD"))
+	return strings.TrimSpace(string(shaBytes)),
+		errors.Wrap(err, "error obtaining ID of last commit")
+}
+
+func (w *worktree) CommitMessage(id string) (string, error) {
+	msgBytes, err := libExec.Exec(
+		w.buildCommand("log", "-n", "1", "--pretty=format:%s", id),
+	)
+	return string(msgBytes),
+		errors.Wrapf(err, "error obtaining commit message for commit %q", id)
+}
+
+func (w *worktree) CommitMessages(id1, id2 string) ([]string, error) {
+	allMsgBytes, err := libExec.Exec(w.buildCommand(
+		"log",
+		"--pretty=oneline",
+		"--decorate-refs=",
+		"--decorate-refs-exclude=",
+		fmt.Sprintf("%s..%s", id1, id2),
+	))
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error obtaining commit messages between commits %q and %q",
+			id1,
+			id2,
+		)
+	}
+	msgs := []string{}
+	for _, msgBytes := range bytes.Split(allMsgBytes, []byte("\n")) {
+		if strings.TrimSpace(string(msgBytes)) != "" {
+			msgs = append(msgs, string(msgBytes))
+		}
+	}
+	return msgs, nil
+}
+
+func (w *worktree) Push() error {
+	_, err :=
+		libExec.Exec(w.buildCommand("push", "origin", w.currentBranch))
+	return errors.Wrapf(err, "error pushing branch %q", w.currentBranch)
+}
+
+func (w *worktree) RemoteBranchExists(branch string) (bool, error) {
+	_, err := libExec.Exec(w.buildCommand(
+		"ls-remote",
+		"--heads",
+		"--exit-code", // Return 2 if not found
+		w.url,
+		branch,
+	))
+	if exitErr, ok := err.(*libExec.ExitError); ok && exitErr.ExitCode == 2 {
+		// Branch does not exist
+		return false, nil
+	}
+	return err == nil, errors.Wrapf(
+		err,
+		"error checking for existence of branch %q in remote repo %q",
+		branch,
+		w.url,
+	)
+}
+
+func (w *worktree) ResetHard() error {
+	_, err := libExec.Exec(w.buildCommand("reset", "--hard"))
+	return errors.Wrap(err, "error resetting branch working tree")
+}
+
+func (w *worktree) URL() string {
+	return w.url
+}
+
+func (w *worktree) HomeDir() string {
+	return w.homeDir
+}
+
+func (w *worktree) WorkingDir() string {
+	return w.dir
+}
+
+// refExists returns a bool indicating whether the specified ref exists.
+func (w *worktree) refExists(ref string) bool {
+	_, err := libExec.Exec(
+		w.buildCommand("show-ref", "--verify", "--quiet", ref),
+	)
+	return err == nil
+}
+
+// buildCommand returns a command that executes git within the worktree and
+// that is killed if the worktree's context is canceled.
+func (w *worktree) buildCommand(arg ...string) *exec.Cmd {
+	return w.buildCommandContext(w.ctx, arg...)
+}
+
+// buildCommandContext returns a command that executes git within the worktree
+// and that is killed if the provided context is canceled.
+func (w *worktree) buildCommandContext(
+	ctx context.Context,
+	arg ...string,
+) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "git", arg...)
+	// Killing git does not kill helpers it has started, such as the one used
+	// for fetching over HTTP, and those may keep its output open indefinitely
+	cmd.WaitDelay = commandWaitDelay
+	cmd.Env = []string{"HOME=" + w.homeDir}
+	cmd.Dir = w.dir
+	return cmd
+}
+
+// buildCachedRepoCommand returns a command that executes git within the cached
+// clone the worktree belongs to.
+func (w *worktree) buildCachedRepoCommand(arg ...string) *exec.Cmd {
+	cmd := w.buildCommand(arg...)
+	cmd.Dir = w.cached.dir
+	return cmd
+}
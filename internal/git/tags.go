@@ -0,0 +1,288 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/pkg/errors"
+
+	"github.com/akuity/bookkeeper/pkg/git"
+	libExec "github.com/akuity/kargo/internal/exec"
+)
+
+// TagSelectionStrategy specifies how the tag of interest is selected from
+// among a repository's tags.
+type TagSelectionStrategy string
+
+const (
+	// TagSelectionStrategySemVer selects the tag representing the greatest
+	// semantic version.
+	TagSelectionStrategySemVer TagSelectionStrategy = "SemVer"
+	// TagSelectionStrategyLexical selects the lexically greatest tag.
+	TagSelectionStrategyLexical TagSelectionStrategy = "Lexical"
+	// TagSelectionStrategyNewestTag selects the most recently created tag.
+	TagSelectionStrategyNewestTag TagSelectionStrategy = "NewestTag"
+)
+
+// TagSelectionOptions represents options for selecting a tag from among a
+// remote git repository's tags.
+type TagSelectionOptions struct {
+	// Strategy is the strategy by which a tag is selected.
+	Strategy TagSelectionStrategy
+	// SemverConstraint, if specified, restricts the tags considered by the
+	// SemVer strategy to those satisfying it.
+	SemverConstraint string
+	// AllowTags, if specified, is a regular expression that restricts the tags
+	// considered to those matching it.
+	AllowTags string
+	// IgnoreTags is a list of regular expressions. Tags matching any of them in
+	// their entirety are not considered.
+	IgnoreTags []string
+}
+
+// GetLatestTaggedCommit selects a tag from among those of the remote git
+// repository at the specified URL according to the provided options and
+// returns the commit it references. Tags are listed by querying the remote
+// repository's refs, without cloning it. Only the NewestTag strategy requires
+// anything to be fetched in order to select a tag, and then only the tags
+// under consideration and the commits they reference, without any history,
+// trees or blobs.
+func (c *Cache) GetLatestTaggedCommit(
+	ctx context.Context,
+	repoURL string,
+	opts TagSelectionOptions,
+	creds git.RepoCredentials,
+) (*Commit, error) {
+	homeDir, err := os.MkdirTemp("", "")
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error creating home directory for repo %q",
+			repoURL,
+		)
+	}
+	defer os.RemoveAll(homeDir) // nolint: errcheck
+	if err = setupAuth(homeDir, repoURL, creds); err != nil {
+		return nil, err
+	}
+	tags, err := listRemoteTags(ctx, homeDir, repoURL)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := filterTags(tags, opts.AllowTags, opts.IgnoreTags)
+	if err != nil {
+		return nil, err
+	}
+	var tag string
+	switch opts.Strategy {
+	case TagSelectionStrategySemVer:
+		tag, err = selectSemverTag(candidates, opts.SemverConstraint)
+	case TagSelectionStrategyLexical:
+		tag = selectLexicalTag(candidates)
+	case TagSelectionStrategyNewestTag:
+		tag, err = selectNewestTag(ctx, homeDir, repoURL, candidates)
+	default:
+		return nil, errors.Errorf(
+			"unsupported tag selection strategy %q",
+			opts.Strategy,
+		)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error selecting tag from repo %q", repoURL)
+	}
+	if tag == "" {
+		return nil, errors.Errorf("found no suitable tag in repo %q", repoURL)
+	}
+	commit, err := c.getCommit(ctx, homeDir, repoURL, tags[tag])
+	if err != nil {
+		return nil, err
+	}
+	commit.Tag = tag
+	return commit, nil
+}
+
+// listRemoteTags returns the IDs of the commits referenced by all tags of the
+// remote repository at the specified URL, indexed by tag name.
+func listRemoteTags(
+	ctx context.Context,
+	homeDir string,
+	repoURL string,
+) (map[string]string, error) {
+	res, err := libExec.Exec(
+		buildRemoteCommand(ctx, homeDir, "ls-remote", "--tags", repoURL),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing tags of repo %q", repoURL)
+	}
+	tags := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(res))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		name := strings.TrimPrefix(fields[1], "refs/tags/")
+		// Annotated tags are listed twice. The entry with the ^{} suffix is the
+		// one that references the commit rather than the tag object.
+		if peeled := strings.TrimSuffix(name, "^{}"); peeled != name {
+			tags[peeled] = fields[0]
+		} else if _, ok := tags[name]; !ok {
+			tags[name] = fields[0]
+		}
+	}
+	return tags, nil
+}
+
+// filterTags returns the names of those of the provided tags that match the
+// allowTags regular expression, if specified, and none of the ignoreTags
+// regular expressions.
+func filterTags(
+	tags map[string]string,
+	allowTags string,
+	ignoreTags []string,
+) ([]string, error) {
+	var allowRegex *regexp.Regexp
+	if allowTags != "" {
+		var err error
+		if allowRegex, err = regexp.Compile(allowTags); err != nil {
+			return nil, errors.Wrapf(err, "error parsing regular expression %q", allowTags)
+		}
+	}
+	ignoreRegexes := make([]*regexp.Regexp, len(ignoreTags))
+	for i, ignoreTag := range ignoreTags {
+		var err error
+		if ignoreRegexes[i], err = regexp.Compile(`^(?:` + ignoreTag + `)$`); err != nil {
+			return nil, errors.Wrapf(err, "error parsing regular expression %q", ignoreTag)
+		}
+	}
+	names := make([]string, 0, len(tags))
+tags:
+	for name := range tags {
+		if allowRegex != nil && !allowRegex.MatchString(name) {
+			continue
+		}
+		for _, ignoreRegex := range ignoreRegexes {
+			if ignoreRegex.MatchString(name) {
+				continue tags
+			}
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// selectSemverTag returns the tag representing the greatest semantic version
+// that satisfies the provided constraint, if any. Tags that are not semantic
+// versions are ignored. Ties, e.g. between v1.0.0 and 1.0.0, are broken by a
+// lexical comparison so that results are deterministic. If no tag is suitable,
+// the empty string is returned.
+func selectSemverTag(tags []string, constraintStr string) (string, error) {
+	var constraint *semver.Constraints
+	if constraintStr != "" {
+		var err error
+		if constraint, err = semver.NewConstraint(constraintStr); err != nil {
+			return "", errors.Wrapf(err, "error parsing constraint %q", constraintStr)
+		}
+	}
+	var latest *semver.Version
+	for _, tag := range tags {
+		version, err := semver.NewVersion(tag)
+		if err != nil {
+			continue
+		}
+		if constraint != nil && !constraint.Check(version) {
+			continue
+		}
+		if latest == nil {
+			latest = version
+			continue
+		}
+		if comp := version.Compare(latest); comp > 0 ||
+			(comp == 0 && version.Original() > latest.Original()) {
+			latest = version
+		}
+	}
+	if latest == nil {
+		return "", nil
+	}
+	return latest.Original(), nil
+}
+
+// selectLexicalTag returns the lexically greatest of the provided tags. If no
+// tags are provided, the empty string is returned.
+func selectLexicalTag(tags []string) string {
+	var latest string
+	for _, tag := range tags {
+		if tag > latest {
+			latest = tag
+		}
+	}
+	return latest
+}
+
+// selectNewestTag returns the most recently created of the provided tags of
+// the remote repository at the specified URL. The creation date of an annotated
+// tag is the date it was tagged, while that of a lightweight tag is the date
+// the commit it references was committed. To learn those dates, the tags and
+// the commits they reference are fetched into a scratch repository within the
+// specified home directory. If no tags are provided, the empty string is
+// returned.
+func selectNewestTag(
+	ctx context.Context,
+	homeDir string,
+	repoURL string,
+	tags []string,
+) (string, error) {
+	if len(tags) == 0 {
+		return "", nil
+	}
+	dir := filepath.Join(homeDir, "tags")
+	if _, err := libExec.Exec(
+		buildRemoteCommand(ctx, homeDir, "init", "--bare", "--quiet", dir),
+	); err != nil {
+		return "", errors.Wrapf(
+			err,
+			"error initializing repository for tags from repo %q",
+			repoURL,
+		)
+	}
+	args := []string{
+		"fetch",
+		"--quiet",
+		"--depth=1",
+		"--filter=tree:0",
+		"--no-tags",
+		repoURL,
+	}
+	for _, tag := range tags {
+		args = append(args, "+refs/tags/"+tag+":refs/tags/"+tag)
+	}
+	cmd := buildRemoteCommand(ctx, homeDir, args...)
+	cmd.Dir = dir
+	if _, err := libExec.Exec(cmd); err != nil {
+		return "", errors.Wrapf(err, "error fetching tags from repo %q", repoURL)
+	}
+	cmd = buildRemoteCommand(
+		ctx,
+		homeDir,
+		"for-each-ref",
+		"--count=1",
+		"--sort=-creatordate",
+		"--format=%(refname:strip=2)",
+		"refs/tags/",
+	)
+	cmd.Dir = dir
+	res, err := libExec.Exec(cmd)
+	if err != nil {
+		return "", errors.Wrapf(err, "error sorting tags from repo %q", repoURL)
+	}
+	return strings.TrimSpace(string(res)), nil
+}
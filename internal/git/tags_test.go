@@ -0,0 +1,259 @@
+package git
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/bookkeeper/pkg/git"
+)
+
+func TestCacheGetLatestTaggedCommit(t *testing.T) {
+	remoteURL := newTestRemoteRepo(t)
+	commit1 := commitToTestRemoteRepo(t, remoteURL, "main", "a.txt")
+	commit2 := commitToTestRemoteRepo(t, remoteURL, "main", "b.txt")
+	commit3 := commitToTestRemoteRepo(t, remoteURL, "main", "c.txt")
+	// v1.10.0 is the greatest semantic version, v1.9.0 is lexically greatest
+	// and v1.2.0 was created most recently.
+	tagTestRemoteRepo(t, remoteURL, "v1.10.0", commit1, "2023-01-01T00:00:00Z")
+	tagTestRemoteRepo(t, remoteURL, "v1.9.0", commit2, "2023-01-02T00:00:00Z")
+	tagTestRemoteRepo(t, remoteURL, "v1.2.0", commit3, "2023-01-03T00:00:00Z")
+	tagTestRemoteRepo(t, remoteURL, "v2.0.0-rc.1", commit3, "")
+	tagTestRemoteRepo(t, remoteURL, "not-a-version", commit3, "")
+
+	cache, err := NewCache(t.TempDir(), 0)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		opts       TagSelectionOptions
+		assertions func(*Commit, error)
+	}{
+		{
+			name: "unsupported strategy",
+			opts: TagSelectionOptions{Strategy: "bogus"},
+			assertions: func(_ *Commit, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "unsupported tag selection strategy")
+			},
+		},
+		{
+			name: "invalid regular expression",
+			opts: TagSelectionOptions{
+				Strategy:  TagSelectionStrategyLexical,
+				AllowTags: "(",
+			},
+			assertions: func(_ *Commit, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error parsing regular expression")
+			},
+		},
+		{
+			name: "no suitable tag",
+			opts: TagSelectionOptions{
+				Strategy:         TagSelectionStrategySemVer,
+				SemverConstraint: ">=3.0.0",
+			},
+			assertions: func(_ *Commit, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "found no suitable tag")
+			},
+		},
+		{
+			name: "SemVer",
+			opts: TagSelectionOptions{
+				Strategy:         TagSelectionStrategySemVer,
+				SemverConstraint: "^1.0.0",
+			},
+			assertions: func(commit *Commit, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					&Commit{
+						ID:      commit1,
+						Message: "add a.txt",
+						Author:  "Test <test@example.com>",
+						Tag:     "v1.10.0",
+					},
+					commit,
+				)
+			},
+		},
+		{
+			name: "SemVer without constraint",
+			opts: TagSelectionOptions{Strategy: TagSelectionStrategySemVer},
+			assertions: func(commit *Commit, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "v2.0.0-rc.1", commit.Tag)
+				require.Equal(t, commit3, commit.ID)
+			},
+		},
+		{
+			name: "Lexical",
+			opts: TagSelectionOptions{
+				Strategy:   TagSelectionStrategyLexical,
+				AllowTags:  "^v",
+				IgnoreTags: []string{"v2.*"},
+			},
+			assertions: func(commit *Commit, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "v1.9.0", commit.Tag)
+				require.Equal(t, commit2, commit.ID)
+			},
+		},
+		{
+			name: "NewestTag",
+			opts: TagSelectionOptions{
+				Strategy:   TagSelectionStrategyNewestTag,
+				IgnoreTags: []string{"v2.0.0-rc.1", "not-a-version"},
+			},
+			assertions: func(commit *Commit, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "v1.2.0", commit.Tag)
+				require.Equal(t, commit3, commit.ID)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				cache.GetLatestTaggedCommit(
+					context.Background(),
+					remoteURL,
+					testCase.opts,
+					git.RepoCredentials{},
+				),
+			)
+		})
+	}
+}
+
+func TestFilterTags(t *testing.T) {
+	tags := map[string]string{
+		"v1.0.0":     "fake-commit",
+		"v1.0.0-rc1": "fake-commit",
+		"v1.1.0":     "fake-commit",
+		"latest":     "fake-commit",
+	}
+	testCases := []struct {
+		name       string
+		allowTags  string
+		ignoreTags []string
+		assertions func([]string, error)
+	}{
+		{
+			name: "no filters",
+			assertions: func(names []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"latest", "v1.0.0", "v1.0.0-rc1", "v1.1.0"}, names)
+			},
+		},
+		{
+			name:       "invalid ignore regular expression",
+			ignoreTags: []string{"("},
+			assertions: func(_ []string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error parsing regular expression")
+			},
+		},
+		{
+			name:       "allowed and ignored tags",
+			allowTags:  `^v\d`,
+			ignoreTags: []string{`.*-rc\d+`, "v1.1.0"},
+			assertions: func(names []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"v1.0.0"}, names)
+			},
+		},
+		{
+			name: "ignored tags must match in their entirety",
+			// This would match every tag if it were not anchored
+			ignoreTags: []string{"v1"},
+			assertions: func(names []string, err error) {
+				require.NoError(t, err)
+				require.Len(t, names, 4)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				filterTags(tags, testCase.allowTags, testCase.ignoreTags),
+			)
+		})
+	}
+}
+
+func TestSelectSemverTag(t *testing.T) {
+	testCases := []struct {
+		name       string
+		tags       []string
+		constraint string
+		assertions func(string, error)
+	}{
+		{
+			name:       "invalid constraint",
+			tags:       []string{"v1.0.0"},
+			constraint: "bogus",
+			assertions: func(_ string, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error parsing constraint")
+			},
+		},
+		{
+			name: "no semantic versions",
+			tags: []string{"latest"},
+			assertions: func(tag string, err error) {
+				require.NoError(t, err)
+				require.Empty(t, tag)
+			},
+		},
+		{
+			name:       "with constraint",
+			tags:       []string{"v1.0.0", "v1.2.0", "v2.0.0", "latest"},
+			constraint: "<2.0.0",
+			assertions: func(tag string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "v1.2.0", tag)
+			},
+		},
+		{
+			name: "ties are broken deterministically",
+			tags: []string{"v1.0.0", "1.0.0"},
+			assertions: func(tag string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "v1.0.0", tag)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(selectSemverTag(testCase.tags, testCase.constraint))
+		})
+	}
+}
+
+// tagTestRemoteRepo creates a tag with the specified name that references the
+// specified commit in the remote repository at the specified URL. If a date is
+// specified, the tag is an annotated tag created at that date. Otherwise, it is
+// a lightweight tag.
+func tagTestRemoteRepo(t *testing.T, remoteURL, tag, commit, date string) {
+	dir := strings.TrimPrefix(remoteURL, "file://")
+	if date == "" {
+		runTestGit(t, dir, "tag", tag, commit)
+		return
+	}
+	cmd := exec.Command(
+		"git",
+		"-c", "user.name=Test",
+		"-c", "user.email=test@example.com",
+		"tag", "-a", "-m", tag, tag, commit,
+	)
+	cmd.Dir = dir
+	cmd.Env = []string{"HOME=" + t.TempDir(), "GIT_COMMITTER_DATE=" + date}
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
@@ -0,0 +1,159 @@
+package gitprovider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ProviderGitea = "gitea"
+
+	// giteaPageSize is the number of pull requests requested per page when
+	// searching for a pull request. Gitea cannot filter pull requests by head
+	// branch, so they are filtered client-side.
+	giteaPageSize = 50
+	// giteaMaxPages bounds how many pages of pull requests are searched.
+	giteaMaxPages = 10
+)
+
+func init() {
+	// Gitea is always self-hosted, so it can never be inferred from a
+	// repository URL.
+	Register(ProviderGitea, Registration{
+		NewProvider: newGiteaProvider,
+	})
+}
+
+// giteaProvider is an implementation of Interface for Gitea.
+type giteaProvider struct {
+	apiURL     string
+	owner      string
+	repo       string
+	token      string
+	httpClient *http.Client
+}
+
+// giteaPullRequest is the subset of Gitea's representation of a pull request
+// that we care about.
+type giteaPullRequest struct {
+	Number         int64  `json:"number"`
+	HTMLURL        string `json:"html_url"`
+	State          string `json:"state"`
+	Merged         bool   `json:"merged"`
+	MergeCommitSHA string `json:"merge_commit_sha"`
+	Head           struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+func newGiteaProvider(repoURL string, opts *Options) (Interface, error) {
+	info, err := parseRepoURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	owner, repo, err := ownerAndName(info.path)
+	if err != nil {
+		return nil, err
+	}
+	return &giteaProvider{
+		apiURL:     fmt.Sprintf("%s://%s/api/v1", info.scheme, info.host),
+		owner:      owner,
+		repo:       repo,
+		token:      opts.Token,
+		httpClient: opts.HTTPClient,
+	}, nil
+}
+
+// CreatePullRequest implements Interface.
+func (g *giteaProvider) CreatePullRequest(
+	ctx context.Context,
+	opts CreatePullRequestOpts,
+) (*PullRequest, error) {
+	pr := giteaPullRequest{}
+	if err := doJSONRequest(
+		ctx,
+		g.httpClient,
+		http.MethodPost,
+		apiURL(g.apiURL, "repos", g.owner, g.repo, "pulls"),
+		g.headers(),
+		map[string]string{
+			"head":  opts.Head,
+			"base":  opts.Base,
+			"title": opts.Title,
+			"body":  opts.Description,
+		},
+		&pr,
+	); err != nil {
+		return nil, errors.Wrap(err, "error creating Gitea pull request")
+	}
+	return pr.toPullRequest(), nil
+}
+
+// FindPullRequest implements Interface.
+func (g *giteaProvider) FindPullRequest(
+	ctx context.Context,
+	head string,
+	base string,
+) (*PullRequest, error) {
+	for page := 1; page <= giteaMaxPages; page++ {
+		// Gitea lists the most recently opened pull requests first by default.
+		query := url.Values{}
+		query.Set("state", "all")
+		query.Set("page", strconv.Itoa(page))
+		query.Set("limit", strconv.Itoa(giteaPageSize))
+		prs := []giteaPullRequest{}
+		if err := doJSONRequest(
+			ctx,
+			g.httpClient,
+			http.MethodGet,
+			fmt.Sprintf(
+				"%s?%s",
+				apiURL(g.apiURL, "repos", g.owner, g.repo, "pulls"),
+				query.Encode(),
+			),
+			g.headers(),
+			nil,
+			&prs,
+		); err != nil {
+			return nil, errors.Wrap(err, "error listing Gitea pull requests")
+		}
+		for _, pr := range prs {
+			if pr.Head.Ref == head && pr.Base.Ref == base {
+				return pr.toPullRequest(), nil
+			}
+		}
+		if len(prs) < giteaPageSize {
+			break
+		}
+	}
+	return nil, nil
+}
+
+func (g *giteaProvider) headers() map[string]string {
+	headers := map[string]string{}
+	if g.token != "" {
+		headers["Authorization"] = fmt.Sprintf("token %s", g.token)
+	}
+	return headers
+}
+
+func (g giteaPullRequest) toPullRequest() *PullRequest {
+	pr := &PullRequest{
+		Number: g.Number,
+		URL:    g.HTMLURL,
+		Open:   g.State == "open",
+		Merged: g.Merged,
+	}
+	if pr.Merged {
+		pr.MergeCommitID = g.MergeCommitSHA
+	}
+	return pr
+}
@@ -0,0 +1,80 @@
+package gitprovider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGiteaProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/api/v1/repos/akuity/kargo/pulls", r.URL.Path)
+			require.Equal(t, "token fake-token", r.Header.Get("Authorization"))
+			switch r.Method {
+			case http.MethodPost:
+				body := map[string]string{}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				require.Equal(t, "fake-head", body["head"])
+				require.Equal(t, "fake-base", body["base"])
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(
+					`{"number":2,"html_url":"fake-url","state":"open","merged":false}`,
+				))
+			case http.MethodGet:
+				// Pull requests that don't match the requested branches are ignored
+				_, _ = w.Write([]byte(
+					`[{"number":3,"html_url":"another-fake-url","state":"open",` +
+						`"head":{"ref":"another-fake-head"},"base":{"ref":"fake-base"}},` +
+						`{"number":2,"html_url":"fake-url","state":"open",` +
+						`"head":{"ref":"fake-head"},"base":{"ref":"fake-base"}}]`,
+				))
+			}
+		},
+	))
+	defer server.Close()
+
+	provider, err := newGiteaProvider(
+		server.URL+"/akuity/kargo.git",
+		&Options{Token: "fake-token"},
+	)
+	require.NoError(t, err)
+
+	pr, err := provider.CreatePullRequest(
+		context.Background(),
+		CreatePullRequestOpts{
+			Head: "fake-head",
+			Base: "fake-base",
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		&PullRequest{
+			Number: 2,
+			URL:    "fake-url",
+			Open:   true,
+		},
+		pr,
+	)
+
+	pr, err = provider.FindPullRequest(context.Background(), "fake-head", "fake-base")
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		&PullRequest{
+			Number: 2,
+			URL:    "fake-url",
+			Open:   true,
+		},
+		pr,
+	)
+
+	pr, err = provider.FindPullRequest(context.Background(), "bogus-head", "fake-base")
+	require.NoError(t, err)
+	require.Nil(t, pr)
+}
@@ -0,0 +1,147 @@
+package gitprovider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const ProviderGitHub = "github"
+
+func init() {
+	Register(ProviderGitHub, Registration{
+		Predicate: func(repoURL string) bool {
+			info, err := parseRepoURL(repoURL)
+			return err == nil && info.host == "github.com"
+		},
+		NewProvider: newGitHubProvider,
+	})
+}
+
+// gitHubProvider is an implementation of Interface for GitHub and GitHub
+// Enterprise.
+type gitHubProvider struct {
+	apiURL     string
+	owner      string
+	repo       string
+	token      string
+	httpClient *http.Client
+}
+
+// gitHubPullRequest is the subset of GitHub's representation of a pull request
+// that we care about.
+type gitHubPullRequest struct {
+	Number         int64      `json:"number"`
+	HTMLURL        string     `json:"html_url"`
+	State          string     `json:"state"`
+	MergedAt       *time.Time `json:"merged_at"`
+	MergeCommitSHA string     `json:"merge_commit_sha"`
+}
+
+func newGitHubProvider(repoURL string, opts *Options) (Interface, error) {
+	info, err := parseRepoURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	owner, repo, err := ownerAndName(info.path)
+	if err != nil {
+		return nil, err
+	}
+	apiURL := fmt.Sprintf("%s://%s/api/v3", info.scheme, info.host)
+	if info.host == "github.com" {
+		apiURL = "https://api.github.com"
+	}
+	return &gitHubProvider{
+		apiURL:     apiURL,
+		owner:      owner,
+		repo:       repo,
+		token:      opts.Token,
+		httpClient: opts.HTTPClient,
+	}, nil
+}
+
+// CreatePullRequest implements Interface.
+func (g *gitHubProvider) CreatePullRequest(
+	ctx context.Context,
+	opts CreatePullRequestOpts,
+) (*PullRequest, error) {
+	pr := gitHubPullRequest{}
+	if err := doJSONRequest(
+		ctx,
+		g.httpClient,
+		http.MethodPost,
+		apiURL(g.apiURL, "repos", g.owner, g.repo, "pulls"),
+		g.headers(),
+		map[string]string{
+			"head":  opts.Head,
+			"base":  opts.Base,
+			"title": opts.Title,
+			"body":  opts.Description,
+		},
+		&pr,
+	); err != nil {
+		return nil, errors.Wrap(err, "error creating GitHub pull request")
+	}
+	return pr.toPullRequest(), nil
+}
+
+// FindPullRequest implements Interface.
+func (g *gitHubProvider) FindPullRequest(
+	ctx context.Context,
+	head string,
+	base string,
+) (*PullRequest, error) {
+	query := url.Values{}
+	query.Set("head", fmt.Sprintf("%s:%s", g.owner, head))
+	query.Set("base", base)
+	query.Set("state", "all")
+	query.Set("sort", "created")
+	query.Set("direction", "desc")
+	prs := []gitHubPullRequest{}
+	if err := doJSONRequest(
+		ctx,
+		g.httpClient,
+		http.MethodGet,
+		fmt.Sprintf(
+			"%s?%s",
+			apiURL(g.apiURL, "repos", g.owner, g.repo, "pulls"),
+			query.Encode(),
+		),
+		g.headers(),
+		nil,
+		&prs,
+	); err != nil {
+		return nil, errors.Wrap(err, "error listing GitHub pull requests")
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return prs[0].toPullRequest(), nil
+}
+
+func (g *gitHubProvider) headers() map[string]string {
+	headers := map[string]string{
+		"Accept": "application/vnd.github+json",
+	}
+	if g.token != "" {
+		headers["Authorization"] = fmt.Sprintf("Bearer %s", g.token)
+	}
+	return headers
+}
+
+func (g gitHubPullRequest) toPullRequest() *PullRequest {
+	pr := &PullRequest{
+		Number: g.Number,
+		URL:    g.HTMLURL,
+		Open:   g.State == "open",
+		Merged: g.MergedAt != nil,
+	}
+	if pr.Merged {
+		pr.MergeCommitID = g.MergeCommitSHA
+	}
+	return pr
+}
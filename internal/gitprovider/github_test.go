@@ -0,0 +1,93 @@
+package gitprovider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/api/v3/repos/akuity/kargo/pulls", r.URL.Path)
+			require.Equal(t, "Bearer fake-token", r.Header.Get("Authorization"))
+			switch r.Method {
+			case http.MethodPost:
+				body := map[string]string{}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				require.Equal(t, "fake-head", body["head"])
+				require.Equal(t, "fake-base", body["base"])
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(
+					`{"number":1,"html_url":"fake-url","state":"open","merged_at":null}`,
+				))
+			case http.MethodGet:
+				require.Equal(t, "akuity:fake-head", r.URL.Query().Get("head"))
+				require.Equal(t, "fake-base", r.URL.Query().Get("base"))
+				_, _ = w.Write([]byte(
+					`[{"number":1,"html_url":"fake-url","state":"closed",` +
+						`"merged_at":"2023-01-01T00:00:00Z","merge_commit_sha":"fake-sha"}]`,
+				))
+			}
+		},
+	))
+	defer server.Close()
+
+	provider, err := newGitHubProvider(
+		server.URL+"/akuity/kargo.git",
+		&Options{Token: "fake-token"},
+	)
+	require.NoError(t, err)
+
+	pr, err := provider.CreatePullRequest(
+		context.Background(),
+		CreatePullRequestOpts{
+			Head: "fake-head",
+			Base: "fake-base",
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		&PullRequest{
+			Number: 1,
+			URL:    "fake-url",
+			Open:   true,
+		},
+		pr,
+	)
+
+	pr, err = provider.FindPullRequest(context.Background(), "fake-head", "fake-base")
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		&PullRequest{
+			Number:        1,
+			URL:           "fake-url",
+			Merged:        true,
+			MergeCommitID: "fake-sha",
+		},
+		pr,
+	)
+}
+
+func TestGitHubProviderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"Validation Failed"}`))
+		},
+	))
+	defer server.Close()
+
+	provider, err := newGitHubProvider(server.URL+"/akuity/kargo", &Options{})
+	require.NoError(t, err)
+	_, err = provider.CreatePullRequest(context.Background(), CreatePullRequestOpts{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error creating GitHub pull request")
+	require.Contains(t, err.Error(), "Validation Failed")
+}
@@ -0,0 +1,146 @@
+package gitprovider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+const ProviderGitLab = "gitlab"
+
+func init() {
+	Register(ProviderGitLab, Registration{
+		Predicate: func(repoURL string) bool {
+			info, err := parseRepoURL(repoURL)
+			return err == nil && info.host == "gitlab.com"
+		},
+		NewProvider: newGitLabProvider,
+	})
+}
+
+// gitLabProvider is an implementation of Interface for GitLab. GitLab refers
+// to pull requests as merge requests.
+type gitLabProvider struct {
+	apiURL      string
+	projectPath string
+	token       string
+	httpClient  *http.Client
+}
+
+// gitLabMergeRequest is the subset of GitLab's representation of a merge
+// request that we care about.
+type gitLabMergeRequest struct {
+	IID             int64  `json:"iid"`
+	WebURL          string `json:"web_url"`
+	State           string `json:"state"`
+	SHA             string `json:"sha"`
+	MergeCommitSHA  string `json:"merge_commit_sha"`
+	SquashCommitSHA string `json:"squash_commit_sha"`
+}
+
+func newGitLabProvider(repoURL string, opts *Options) (Interface, error) {
+	info, err := parseRepoURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	return &gitLabProvider{
+		apiURL: fmt.Sprintf("%s://%s/api/v4", info.scheme, info.host),
+		// GitLab projects may be nested within any number of groups, so the
+		// whole path identifies the project.
+		projectPath: info.path,
+		token:       opts.Token,
+		httpClient:  opts.HTTPClient,
+	}, nil
+}
+
+// CreatePullRequest implements Interface.
+func (g *gitLabProvider) CreatePullRequest(
+	ctx context.Context,
+	opts CreatePullRequestOpts,
+) (*PullRequest, error) {
+	mr := gitLabMergeRequest{}
+	if err := doJSONRequest(
+		ctx,
+		g.httpClient,
+		http.MethodPost,
+		apiURL(g.apiURL, "projects", g.projectPath, "merge_requests"),
+		g.headers(),
+		map[string]string{
+			"source_branch": opts.Head,
+			"target_branch": opts.Base,
+			"title":         opts.Title,
+			"description":   opts.Description,
+		},
+		&mr,
+	); err != nil {
+		return nil, errors.Wrap(err, "error creating GitLab merge request")
+	}
+	return mr.toPullRequest(), nil
+}
+
+// FindPullRequest implements Interface.
+func (g *gitLabProvider) FindPullRequest(
+	ctx context.Context,
+	head string,
+	base string,
+) (*PullRequest, error) {
+	query := url.Values{}
+	query.Set("source_branch", head)
+	query.Set("target_branch", base)
+	query.Set("state", "all")
+	query.Set("order_by", "created_at")
+	query.Set("sort", "desc")
+	mrs := []gitLabMergeRequest{}
+	if err := doJSONRequest(
+		ctx,
+		g.httpClient,
+		http.MethodGet,
+		fmt.Sprintf(
+			"%s?%s",
+			apiURL(g.apiURL, "projects", g.projectPath, "merge_requests"),
+			query.Encode(),
+		),
+		g.headers(),
+		nil,
+		&mrs,
+	); err != nil {
+		return nil, errors.Wrap(err, "error listing GitLab merge requests")
+	}
+	if len(mrs) == 0 {
+		return nil, nil
+	}
+	return mrs[0].toPullRequest(), nil
+}
+
+func (g *gitLabProvider) headers() map[string]string {
+	headers := map[string]string{}
+	if g.token != "" {
+		headers["PRIVATE-TOKEN"] = g.token
+	}
+	return headers
+}
+
+func (g gitLabMergeRequest) toPullRequest() *PullRequest {
+	pr := &PullRequest{
+		Number: g.IID,
+		URL:    g.WebURL,
+		Open:   g.State == "opened",
+		Merged: g.State == "merged",
+	}
+	if pr.Merged {
+		// Depending on the project's merge method, a merge request may have been
+		// merged with a merge commit, squashed, or fast-forwarded.
+		switch {
+		case g.MergeCommitSHA != "":
+			pr.MergeCommitID = g.MergeCommitSHA
+		case g.SquashCommitSHA != "":
+			pr.MergeCommitID = g.SquashCommitSHA
+		default:
+			pr.MergeCommitID = g.SHA
+		}
+	}
+	return pr
+}
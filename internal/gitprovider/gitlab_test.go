@@ -0,0 +1,81 @@
+package gitprovider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitLabProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(
+				t,
+				"/api/v4/projects/group%2Fsubgroup%2Fkargo/merge_requests",
+				r.URL.EscapedPath(),
+			)
+			require.Equal(t, "fake-token", r.Header.Get("PRIVATE-TOKEN"))
+			switch r.Method {
+			case http.MethodPost:
+				body := map[string]string{}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				require.Equal(t, "fake-head", body["source_branch"])
+				require.Equal(t, "fake-base", body["target_branch"])
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(
+					`{"iid":1,"web_url":"fake-url","state":"opened"}`,
+				))
+			case http.MethodGet:
+				require.Equal(t, "fake-head", r.URL.Query().Get("source_branch"))
+				require.Equal(t, "fake-base", r.URL.Query().Get("target_branch"))
+				_, _ = w.Write([]byte(
+					`[{"iid":1,"web_url":"fake-url","state":"merged",` +
+						`"sha":"fake-head-sha","squash_commit_sha":"fake-squash-sha"}]`,
+				))
+			}
+		},
+	))
+	defer server.Close()
+
+	provider, err := newGitLabProvider(
+		server.URL+"/group/subgroup/kargo.git",
+		&Options{Token: "fake-token"},
+	)
+	require.NoError(t, err)
+
+	pr, err := provider.CreatePullRequest(
+		context.Background(),
+		CreatePullRequestOpts{
+			Head: "fake-head",
+			Base: "fake-base",
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		&PullRequest{
+			Number: 1,
+			URL:    "fake-url",
+			Open:   true,
+		},
+		pr,
+	)
+
+	// A squashed merge request is identified by its squash commit
+	pr, err = provider.FindPullRequest(context.Background(), "fake-head", "fake-base")
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		&PullRequest{
+			Number:        1,
+			URL:           "fake-url",
+			Merged:        true,
+			MergeCommitID: "fake-squash-sha",
+		},
+		pr,
+	)
+}
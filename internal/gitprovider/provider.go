@@ -0,0 +1,246 @@
+package gitprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// PullRequest describes a pull request (or, in GitLab's terms, a merge
+// request) in a Git hosting provider.
+type PullRequest struct {
+	// Number identifies the pull request within its repository.
+	Number int64
+	// URL is the URL at which a human can view the pull request.
+	URL string
+	// Open indicates whether the pull request is still open.
+	Open bool
+	// Merged indicates whether the pull request has been merged.
+	Merged bool
+	// MergeCommitID is the ID of the commit that resulted from merging the pull
+	// request. It is empty if the pull request has not been merged.
+	MergeCommitID string
+}
+
+// CreatePullRequestOpts describes a pull request to be opened.
+type CreatePullRequestOpts struct {
+	// Head is the branch containing the proposed changes.
+	Head string
+	// Base is the branch the proposed changes should be merged into.
+	Base string
+	// Title is the title of the pull request.
+	Title string
+	// Description is the body of the pull request.
+	Description string
+}
+
+// Interface is an abstraction over the pull request APIs of Git hosting
+// providers.
+type Interface interface {
+	// CreatePullRequest opens a pull request.
+	CreatePullRequest(context.Context, CreatePullRequestOpts) (*PullRequest, error)
+	// FindPullRequest returns the most recently opened pull request, in any
+	// state, that proposes merging the head branch into the base branch. If
+	// there is no such pull request, nil is returned.
+	FindPullRequest(ctx context.Context, head, base string) (*PullRequest, error)
+}
+
+// Options are options for constructing an implementation of Interface.
+type Options struct {
+	// Token is used to authenticate to the Git hosting provider's API.
+	Token string
+	// HTTPClient is used to communicate with the Git hosting provider's API. If
+	// nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// Registration describes a Git hosting provider.
+type Registration struct {
+	// Predicate reports whether the provider is the one that hosts the
+	// repository with the provided URL. It is used to infer a provider when none
+	// is specified.
+	Predicate func(repoURL string) bool
+	// NewProvider returns an implementation of Interface for the repository with
+	// the provided URL.
+	NewProvider func(repoURL string, opts *Options) (Interface, error)
+}
+
+var (
+	registrations   = map[string]Registration{}
+	registrationsMu sync.RWMutex
+)
+
+// Register makes the named Git hosting provider available to New. It is
+// intended to be called from the init() function of a package that implements
+// a provider. Registering a provider under a name that is already registered
+// replaces the existing registration.
+func Register(name string, registration Registration) {
+	registrationsMu.Lock()
+	defer registrationsMu.Unlock()
+	registrations[name] = registration
+}
+
+// New returns an implementation of Interface for the repository with the
+// provided URL using the named Git hosting provider. If no name is provided,
+// the provider is inferred from the repository URL.
+func New(repoURL string, name string, opts *Options) (Interface, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	registrationsMu.RLock()
+	defer registrationsMu.RUnlock()
+	if name != "" {
+		registration, ok := registrations[name]
+		if !ok {
+			return nil, errors.Errorf("unknown Git hosting provider %q", name)
+		}
+		return registration.NewProvider(repoURL, opts)
+	}
+	for _, registration := range registrations {
+		if registration.Predicate != nil && registration.Predicate(repoURL) {
+			return registration.NewProvider(repoURL, opts)
+		}
+	}
+	return nil, errors.Errorf(
+		"unable to infer Git hosting provider for repository %q; a provider "+
+			"must be specified explicitly",
+		repoURL,
+	)
+}
+
+var scpLikeURLRegex = regexp.MustCompile(`^[\w-]+@([^:/]+):(.+)$`)
+
+// repoInfo is a breakdown of a repository URL.
+type repoInfo struct {
+	// scheme is the scheme to use for communicating with the repository's Git
+	// hosting provider's API. It is http only if the repository URL itself uses
+	// http and is https otherwise.
+	scheme string
+	// host is the host (and port, if any) of the repository URL.
+	host string
+	// path is the path of the repository, without any leading slash or trailing
+	// .git suffix. e.g. akuity/kargo
+	path string
+}
+
+// parseRepoURL breaks down the provided repository URL. It supports http(s)
+// and ssh URLs as well as scp-like URLs of the form git@host:path.
+func parseRepoURL(repoURL string) (repoInfo, error) {
+	var info repoInfo
+	if matches := scpLikeURLRegex.FindStringSubmatch(repoURL); matches != nil {
+		info.scheme = "https"
+		info.host = matches[1]
+		info.path = matches[2]
+	} else {
+		u, err := url.Parse(repoURL)
+		if err != nil {
+			return info, errors.Wrapf(err, "error parsing repository URL %q", repoURL)
+		}
+		info.scheme = "https"
+		if u.Scheme == "http" {
+			info.scheme = "http"
+		}
+		info.host = u.Host
+		if u.Scheme == "ssh" {
+			// The port of an ssh URL has nothing to do with the provider's API
+			info.host = u.Hostname()
+		}
+		info.path = u.Path
+	}
+	info.path = strings.TrimSuffix(strings.Trim(info.path, "/"), ".git")
+	if info.host == "" || info.path == "" {
+		return info, errors.Errorf("error parsing repository URL %q", repoURL)
+	}
+	return info, nil
+}
+
+// ownerAndName splits the provided repository path into an owner and a name.
+func ownerAndName(path string) (string, string, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 {
+		return "", "", errors.Errorf(
+			"repository path %q is not of the form <owner>/<name>",
+			path,
+		)
+	}
+	return parts[0], parts[1], nil
+}
+
+// doJSONRequest sends a request to a Git hosting provider's API. If reqBody is
+// non-nil, it is sent as JSON. If resBody is non-nil, the JSON response is
+// unmarshaled into it. Any non-2xx response results in an error.
+func doJSONRequest(
+	ctx context.Context,
+	httpClient *http.Client,
+	method string,
+	reqURL string,
+	headers map[string]string,
+	reqBody any,
+	resBody any,
+) error {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	var bodyReader io.Reader
+	if reqBody != nil {
+		bodyBytes, err := json.Marshal(reqBody)
+		if err != nil {
+			return errors.Wrap(err, "error marshaling request body")
+		}
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, bodyReader)
+	if err != nil {
+		return errors.Wrap(err, "error creating request")
+	}
+	req.Header.Set("Accept", "application/json")
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "error sending %s request to %s", method, reqURL)
+	}
+	defer res.Body.Close()
+	resBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return errors.Wrap(err, "error reading response body")
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return errors.Errorf(
+			"%s request to %s returned status %d: %s",
+			method,
+			reqURL,
+			res.StatusCode,
+			strings.TrimSpace(string(resBytes)),
+		)
+	}
+	if resBody != nil {
+		if err = json.Unmarshal(resBytes, resBody); err != nil {
+			return errors.Wrap(err, "error unmarshaling response body")
+		}
+	}
+	return nil
+}
+
+// apiURL joins the provided base URL and path elements. Path elements are
+// escaped.
+func apiURL(base string, elems ...string) string {
+	escaped := make([]string, len(elems))
+	for i, elem := range elems {
+		escaped[i] = url.PathEscape(elem)
+	}
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(base, "/"), strings.Join(escaped, "/"))
+}
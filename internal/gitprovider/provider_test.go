@@ -0,0 +1,155 @@
+package gitprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name       string
+		repoURL    string
+		provider   string
+		assertions func(Interface, error)
+	}{
+		{
+			name:     "unknown provider",
+			repoURL:  "https://github.com/akuity/kargo",
+			provider: "bogus",
+			assertions: func(_ Interface, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "unknown Git hosting provider")
+			},
+		},
+		{
+			name:    "provider cannot be inferred",
+			repoURL: "https://git.example.com/akuity/kargo",
+			assertions: func(_ Interface, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "unable to infer")
+			},
+		},
+		{
+			name:    "GitHub inferred",
+			repoURL: "https://github.com/akuity/kargo.git",
+			assertions: func(provider Interface, err error) {
+				require.NoError(t, err)
+				require.IsType(t, &gitHubProvider{}, provider)
+			},
+		},
+		{
+			name:    "GitLab inferred",
+			repoURL: "git@gitlab.com:akuity/kargo.git",
+			assertions: func(provider Interface, err error) {
+				require.NoError(t, err)
+				require.IsType(t, &gitLabProvider{}, provider)
+			},
+		},
+		{
+			name:     "explicit provider",
+			repoURL:  "https://git.example.com/akuity/kargo",
+			provider: ProviderGitea,
+			assertions: func(provider Interface, err error) {
+				require.NoError(t, err)
+				require.IsType(t, &giteaProvider{}, provider)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(New(testCase.repoURL, testCase.provider, nil))
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	const name = "fake-provider"
+	Register(name, Registration{
+		NewProvider: func(string, *Options) (Interface, error) {
+			return &fakeProvider{}, nil
+		},
+	})
+	defer func() {
+		registrationsMu.Lock()
+		defer registrationsMu.Unlock()
+		delete(registrations, name)
+	}()
+	provider, err := New("https://git.example.com/akuity/kargo", name, nil)
+	require.NoError(t, err)
+	require.IsType(t, &fakeProvider{}, provider)
+}
+
+func TestParseRepoURL(t *testing.T) {
+	testCases := []struct {
+		repoURL  string
+		expected repoInfo
+		errors   bool
+	}{
+		{
+			repoURL: "https://github.com/akuity/kargo.git",
+			expected: repoInfo{
+				scheme: "https",
+				host:   "github.com",
+				path:   "akuity/kargo",
+			},
+		},
+		{
+			repoURL: "http://localhost:3000/akuity/kargo",
+			expected: repoInfo{
+				scheme: "http",
+				host:   "localhost:3000",
+				path:   "akuity/kargo",
+			},
+		},
+		{
+			repoURL: "ssh://git@gitlab.example.com:2222/group/subgroup/kargo.git",
+			expected: repoInfo{
+				scheme: "https",
+				host:   "gitlab.example.com",
+				path:   "group/subgroup/kargo",
+			},
+		},
+		{
+			repoURL: "git@github.com:akuity/kargo.git",
+			expected: repoInfo{
+				scheme: "https",
+				host:   "github.com",
+				path:   "akuity/kargo",
+			},
+		},
+		{
+			repoURL: "https://github.com",
+			errors:  true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.repoURL, func(t *testing.T) {
+			info, err := parseRepoURL(testCase.repoURL)
+			if testCase.errors {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, info)
+		})
+	}
+}
+
+type fakeProvider struct{}
+
+func (f *fakeProvider) CreatePullRequest(
+	context.Context,
+	CreatePullRequestOpts,
+) (*PullRequest, error) {
+	return nil, nil
+}
+
+func (f *fakeProvider) FindPullRequest(
+	context.Context,
+	string,
+	string,
+) (*PullRequest, error) {
+	return nil, nil
+}
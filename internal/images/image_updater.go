@@ -24,6 +24,20 @@ func getNewestVersionFromTags(
 	vc *image.VersionConstraint,
 	tagList *tagpkg.ImageTagList,
 ) (string, error) {
+	candidates, err := getCandidateVersionsFromTags(img, vc, tagList)
+	if err != nil || len(candidates) == 0 {
+		return "", err
+	}
+	return candidates[len(candidates)-1], nil
+}
+
+// getCandidateVersionsFromTags returns all tags that are update candidates
+// according to the update strategy, ordered from oldest to newest.
+func getCandidateVersionsFromTags(
+	img *image.ContainerImage,
+	vc *image.VersionConstraint,
+	tagList *tagpkg.ImageTagList,
+) ([]string, error) {
 	var availableTags []string
 	switch vc.Strategy {
 	case image.StrategySemVer:
@@ -38,7 +52,7 @@ func getNewestVersionFromTags(
 
 	// It makes no sense to proceed if we have no available tags
 	if len(availableTags) == 0 {
-		return "", nil
+		return nil, nil
 	}
 
 	considerTags := make([]string, 0, len(availableTags))
@@ -51,7 +65,7 @@ func getNewestVersionFromTags(
 		// This prevents updating from a non-semver tag currently.
 		if img.ImageTag != nil && img.ImageTag.TagName != "" {
 			if _, err = semver.NewVersion(img.ImageTag.TagName); err != nil {
-				return "", err
+				return nil, err
 			}
 		}
 
@@ -59,7 +73,7 @@ func getNewestVersionFromTags(
 			if vc.Strategy == image.StrategySemVer {
 				semverConstraint, err = semver.NewConstraint(vc.Constraint)
 				if err != nil {
-					return "", err
+					return nil, err
 				}
 			}
 		}
@@ -91,13 +105,7 @@ func getNewestVersionFromTags(
 		considerTags = append(considerTags, tag)
 	}
 
-	// If we found tags to consider, return the most recent tag found according
-	// to the update strategy.
-	if len(considerTags) > 0 {
-		return considerTags[len(considerTags)-1], nil
-	}
-
-	return "", nil
+	return considerTags, nil
 }
 
 // sortBySemVer is a replacement for similar functionality in Argo CD Image
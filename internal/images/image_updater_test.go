@@ -99,6 +99,28 @@ func TestGetNewestVersionFromTags(t *testing.T) {
 
 }
 
+func TestGetCandidateVersionsFromTags(t *testing.T) {
+	t.Run("Find all candidates with a semver constraint", func(t *testing.T) {
+		tagList := newImageTagList([]string{
+			"0.1", "1.1.2", "0.5.1", "1.0", "0.9", "1.0.1", "2.0.3",
+		})
+		img := image.NewFromIdentifier("jannfis/test:1.0")
+		vc := image.VersionConstraint{Constraint: "^1.0"}
+		candidates, err := getCandidateVersionsFromTags(img, &vc, tagList)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1.0", "1.0.1", "1.1.2"}, candidates)
+	})
+
+	t.Run("Find all candidates with no tags", func(t *testing.T) {
+		tagList := newImageTagList([]string{})
+		img := image.NewFromIdentifier("jannfis/test:1.0")
+		vc := image.VersionConstraint{}
+		candidates, err := getCandidateVersionsFromTags(img, &vc, tagList)
+		require.NoError(t, err)
+		assert.Empty(t, candidates)
+	})
+}
+
 func newImageTagList(tagNames []string) *tag.ImageTagList {
 	tagList := tag.NewImageTagList()
 	for _, tagName := range tagNames {
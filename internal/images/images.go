@@ -1,8 +1,11 @@
 package images
 
 import (
+	"context"
+	"crypto/sha256"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/argoproj-labs/argocd-image-updater/pkg/image"
 	argoLog "github.com/argoproj-labs/argocd-image-updater/pkg/log"
@@ -27,7 +30,36 @@ func init() {
 	}
 }
 
+// maxVerificationAttempts is the maximum number of tags, beginning with the
+// newest, whose signatures are checked when searching for the newest version of
+// an image that satisfies a VerificationPolicy.
+const maxVerificationAttempts = 10
+
+// Tag identifies a specific version of an image.
+type Tag struct {
+	// Name is the name of the tag.
+	Name string
+	// Digest is the digest of the manifest the tag referenced when it was
+	// selected.
+	Digest string
+	// Rejected describes any newer tags that were passed over because their
+	// signatures did not satisfy the VerificationPolicy.
+	Rejected []RejectedTag
+	// Source describes the source code the image was built from, as recorded
+	// by the image's standard OCI labels or annotations.
+	Source SourceMetadata
+}
+
+// GetLatestTag returns the tag of the image in the specified repository that
+// best satisfies the provided constraints, along with the digest of the
+// manifest that tag currently references and a description of the source code
+// the image was built from. If a VerificationPolicy is provided, tags whose
+// signatures do not satisfy it are passed over. If every candidate tag is
+// passed over, a *NoVerifiedTagError describing each of them is returned.
+// Requests made through the image updater's registry client cannot be
+// canceled, so the provided context is consulted between them.
 func GetLatestTag(
+	ctx context.Context,
 	repoURL string,
 	updateStrategy ImageUpdateStrategy,
 	semverConstraint string,
@@ -35,7 +67,8 @@ func GetLatestTag(
 	ignoreTags []string,
 	platform string,
 	creds *Credentials,
-) (string, error) {
+	verification *VerificationPolicy,
+) (*Tag, error) {
 	img := image.NewFromIdentifier(repoURL)
 	vc := &image.VersionConstraint{
 		Constraint: semverConstraint,
@@ -48,10 +81,11 @@ func GetLatestTag(
 	}
 	vc.IgnoreList = ignoreTags
 	vc.Options = options.NewManifestOptions()
+	var plat *imagePlatform
 	if platform != "" {
 		os, arch, variant, err := image.ParsePlatform(platform)
 		if err != nil {
-			return "", errors.Wrapf(
+			return nil, errors.Wrapf(
 				err,
 				"error parsing platform %q for image %q",
 				platform,
@@ -59,12 +93,17 @@ func GetLatestTag(
 			)
 		}
 		vc.Options = vc.Options.WithPlatform(os, arch, variant)
+		plat = &imagePlatform{
+			OS:           os,
+			Architecture: arch,
+			Variant:      variant,
+		}
 	}
 	vc.Options = vc.Options.WithMetadata(vc.Strategy.NeedsMetadata())
 
 	rep, err := registry.GetRegistryEndpoint(img.RegistryURL)
 	if err != nil {
-		return "", errors.Wrapf(
+		return nil, errors.Wrapf(
 			err,
 			"error getting container registry endpoint for image %q",
 			repoURL,
@@ -76,36 +115,159 @@ func GetLatestTag(
 	}
 	regClient, err := registry.NewClient(rep, creds.Username, creds.Password)
 	if err != nil {
-		return "", errors.Wrapf(
+		return nil, errors.Wrapf(
 			err,
 			"error creating registry client for image %q",
 			repoURL,
 		)
 	}
 
+	if err = ctx.Err(); err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"interrupted before fetching tags for image %q",
+			repoURL,
+		)
+	}
 	tags, err := rep.GetTags(img, regClient, vc)
 	if err != nil {
-		return "", errors.Wrapf(
+		return nil, errors.Wrapf(
 			err,
 			"error fetching tags for image %q",
 			repoURL,
 		)
 	}
 
-	tag, err := getNewestVersionFromTags(img, vc, tags)
+	candidates, err := getCandidateVersionsFromTags(img, vc, tags)
 	if err != nil {
-		return "", errors.Wrapf(
+		return nil, errors.Wrapf(
 			err,
 			"error finding newest tag for %q",
 			repoURL,
 		)
 	}
-	if tag == "" {
-		return "", errors.Errorf(
+	if len(candidates) == 0 {
+		return nil, errors.Errorf(
 			"found no suitable version of image %q",
 			repoURL,
 		)
 	}
 
-	return tag, nil
+	var v *verifier
+	if verification != nil {
+		if v, err = newVerifier(*verification); err != nil {
+			return nil, errors.Wrapf(
+				err,
+				"error parsing verification policy for image %q",
+				repoURL,
+			)
+		}
+	}
+	artifacts, err := newRegistryClient(
+		ctx,
+		rep.RegistryAPI,
+		rep.GetTransport(),
+		getNameInRegistry(img, rep),
+		creds,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error creating registry client for image %q",
+			repoURL,
+		)
+	}
+
+	var rejected []RejectedTag
+	for i := len(candidates) - 1; i >= 0; i-- {
+		tag := candidates[i]
+		if err = ctx.Err(); err != nil {
+			return nil, errors.Wrapf(
+				err,
+				"interrupted before fetching manifest for tag %q of image %q",
+				tag,
+				repoURL,
+			)
+		}
+		// Tags are mutable, so the content the tag references right now is
+		// recorded as well
+		digest, err := getDigest(regClient, tag)
+		if err != nil {
+			return nil, errors.Wrapf(
+				err,
+				"error fetching manifest for tag %q of image %q",
+				tag,
+				repoURL,
+			)
+		}
+		if v != nil {
+			if err = v.verify(ctx, artifacts, digest); err != nil {
+				rejected = append(
+					rejected,
+					RejectedTag{
+						Name:   tag,
+						Digest: digest,
+						Reason: err.Error(),
+					},
+				)
+				if len(rejected) == maxVerificationAttempts {
+					break
+				}
+				continue
+			}
+		}
+		return &Tag{
+			Name:     tag,
+			Digest:   digest,
+			Rejected: rejected,
+			Source: readSourceMetadata(
+				ctx,
+				artifacts,
+				repoURL,
+				tag,
+				digest,
+				plat,
+			),
+		}, nil
+	}
+
+	// Signatures that could not be retrieved because the context is done were
+	// not found wanting, so the tags are not reported as rejected
+	if err = ctx.Err(); err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"interrupted while verifying tags of image %q",
+			repoURL,
+		)
+	}
+	return nil, &NoVerifiedTagError{
+		RepoURL:  repoURL,
+		Rejected: rejected,
+	}
+}
+
+// getDigest returns the digest of the manifest the specified tag references.
+func getDigest(regClient registry.RegistryClient, tag string) (string, error) {
+	manifest, err := regClient.ManifestForTag(tag)
+	if err != nil {
+		return "", err
+	}
+	_, payload, err := manifest.Payload()
+	if err != nil {
+		return "", errors.Wrap(err, "error reading manifest")
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(payload)), nil
+}
+
+// getNameInRegistry returns the name by which the provided image is known to
+// the provided registry. Some registries, e.g. Docker Hub, have a default
+// namespace that applies to images whose names do not specify one.
+func getNameInRegistry(
+	img *image.ContainerImage,
+	rep *registry.RegistryEndpoint,
+) string {
+	if !strings.Contains(img.ImageName, "/") && rep.DefaultNS != "" {
+		return rep.DefaultNS + "/" + img.ImageName
+	}
+	return img.ImageName
 }
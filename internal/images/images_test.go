@@ -1,6 +1,7 @@
 package images
 
 import (
+	"context"
 	"testing"
 
 	"github.com/Masterminds/semver"
@@ -13,13 +14,13 @@ func TestGetLatestTag(t *testing.T) {
 		repoURL          string
 		platform         string
 		semverConstraint string
-		assertions       func(string, error)
+		assertions       func(*Tag, error)
 	}{
 		{
 			name:     "error parsing platform",
 			repoURL:  "nginx",
 			platform: "bogus",
-			assertions: func(s string, err error) {
+			assertions: func(_ *Tag, err error) {
 				require.Error(t, err)
 				require.Contains(t, err.Error(), "error parsing platform")
 			},
@@ -29,7 +30,7 @@ func TestGetLatestTag(t *testing.T) {
 			name: "error getting tags",
 			// This will force a failure because this repo doesn't exist
 			repoURL: "bogus",
-			assertions: func(s string, err error) {
+			assertions: func(_ *Tag, err error) {
 				require.Error(t, err)
 				require.Contains(t, err.Error(), "error fetching tags for image")
 			},
@@ -39,7 +40,7 @@ func TestGetLatestTag(t *testing.T) {
 			name:             "no suitable version found",
 			repoURL:          "nginx",
 			semverConstraint: "^15.0.0", // Doesn't exist
-			assertions: func(tag string, err error) {
+			assertions: func(_ *Tag, err error) {
 				require.Error(t, err)
 				require.Contains(t, err.Error(), "found no suitable version of image")
 			},
@@ -50,11 +51,12 @@ func TestGetLatestTag(t *testing.T) {
 			repoURL:          "nginx",
 			platform:         "linux/amd64",
 			semverConstraint: "^1.0.0",
-			assertions: func(tag string, err error) {
+			assertions: func(tag *Tag, err error) {
 				require.NoError(t, err)
-				ver, err := semver.NewVersion(tag)
+				ver, err := semver.NewVersion(tag.Name)
 				require.NoError(t, err)
 				require.Equal(t, int64(1), ver.Major())
+				require.Regexp(t, "^sha256:[0-9a-f]{64}$", tag.Digest)
 			},
 		},
 	}
@@ -62,6 +64,7 @@ func TestGetLatestTag(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			testCase.assertions(
 				GetLatestTag(
+					context.Background(),
 					testCase.repoURL,
 					ImageUpdateStrategySemVer,
 					testCase.semverConstraint,
@@ -69,6 +72,7 @@ func TestGetLatestTag(t *testing.T) {
 					nil,
 					testCase.platform,
 					&Credentials{},
+					nil,
 				),
 			)
 		})
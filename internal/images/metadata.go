@@ -0,0 +1,186 @@
+package images
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/akuity/kargo/internal/logging"
+)
+
+const (
+	// sourceLabelKey is the key of the standard OCI label or annotation that
+	// records the URL of the repository containing the source code an image was
+	// built from.
+	sourceLabelKey = "org.opencontainers.image.source"
+	// revisionLabelKey is the key of the standard OCI label or annotation that
+	// records the revision of the source code an image was built from.
+	revisionLabelKey = "org.opencontainers.image.revision"
+	// createdLabelKey is the key of the standard OCI label or annotation that
+	// records, in RFC 3339 format, when an image was built.
+	createdLabelKey = "org.opencontainers.image.created"
+)
+
+// SourceMetadata describes the source code an image was built from, as
+// recorded by the image's standard OCI labels or annotations.
+type SourceMetadata struct {
+	// RepoURL is the URL of the repository containing the source code.
+	RepoURL string
+	// Revision is the revision of the source code, typically a commit ID.
+	Revision string
+	// Created is when the image was built.
+	Created *time.Time
+}
+
+// imagePlatform identifies the platform an image was built for.
+type imagePlatform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// imageManifest is the subset of an OCI or Docker image manifest or image index
+// that is of interest when reading an image's metadata.
+type imageManifest struct {
+	Config      *ociDescriptor    `json:"config,omitempty"`
+	Manifests   []indexEntry      `json:"manifests,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// indexEntry is an entry in an OCI image index or Docker manifest list.
+type indexEntry struct {
+	ociDescriptor
+	Platform *imagePlatform `json:"platform,omitempty"`
+}
+
+// readSourceMetadata returns SourceMetadata for the image whose manifest has
+// the provided digest, as getSourceMetadata does. Where an image came from is
+// nice to know, but not knowing is no reason to disregard the image, so any
+// error reading its metadata is logged and empty SourceMetadata is returned.
+func readSourceMetadata(
+	ctx context.Context,
+	artifacts artifactGetter,
+	repoURL string,
+	tag string,
+	digest string,
+	plat *imagePlatform,
+) SourceMetadata {
+	metadata, err := getSourceMetadata(ctx, artifacts, digest, plat)
+	if err != nil {
+		logging.LoggerFromContext(ctx).
+			WithField("repo", repoURL).
+			WithField("tag", tag).
+			Warnf("error reading source metadata of image: %s", err)
+		return SourceMetadata{}
+	}
+	return *metadata
+}
+
+// getSourceMetadata returns SourceMetadata for the image whose manifest has the
+// provided digest. If that manifest is an image index, the metadata of the
+// image for the provided platform is returned or, if no platform is specified,
+// that of the first image in the index. Labels in an image's config take
+// precedence over annotations of its manifest, which in turn take precedence
+// over annotations of the image index, if any.
+func getSourceMetadata(
+	ctx context.Context,
+	artifacts artifactGetter,
+	digest string,
+	plat *imagePlatform,
+) (*SourceMetadata, error) {
+	manifest, err := getImageManifest(ctx, artifacts, digest)
+	if err != nil {
+		return nil, err
+	}
+	labels := map[string]string{}
+	mergeLabels(labels, manifest.Annotations)
+	if len(manifest.Manifests) > 0 {
+		entry := selectIndexEntry(manifest.Manifests, plat)
+		if entry == nil {
+			return nil, errors.Errorf(
+				"image index %q includes no image for the specified platform",
+				digest,
+			)
+		}
+		if manifest, err = getImageManifest(ctx, artifacts, entry.Digest); err != nil {
+			return nil, err
+		}
+		mergeLabels(labels, manifest.Annotations)
+	}
+	if manifest.Config != nil && manifest.Config.Digest != "" {
+		configBytes, err := getLayer(ctx, artifacts, *manifest.Config)
+		if err != nil {
+			return nil, errors.Wrap(err, "error getting image config")
+		}
+		config := struct {
+			Config struct {
+				Labels map[string]string `json:"Labels"`
+			} `json:"config"`
+		}{}
+		if err = json.Unmarshal(configBytes, &config); err != nil {
+			return nil, errors.Wrapf(
+				err,
+				"error unmarshaling image config %q",
+				manifest.Config.Digest,
+			)
+		}
+		mergeLabels(labels, config.Config.Labels)
+	}
+
+	metadata := &SourceMetadata{
+		RepoURL:  labels[sourceLabelKey],
+		Revision: labels[revisionLabelKey],
+	}
+	// A malformed build time is not worth failing over
+	if created, err := time.Parse(time.RFC3339, labels[createdLabelKey]); err == nil {
+		metadata.Created = &created
+	}
+	return metadata, nil
+}
+
+func getImageManifest(
+	ctx context.Context,
+	artifacts artifactGetter,
+	digest string,
+) (*imageManifest, error) {
+	manifestBytes, err := artifacts.getManifest(ctx, digest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting manifest %q", digest)
+	}
+	manifest := &imageManifest{}
+	if err = json.Unmarshal(manifestBytes, manifest); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling manifest %q", digest)
+	}
+	return manifest, nil
+}
+
+// selectIndexEntry returns the entry of an image index that is for the
+// provided platform or, if no platform is specified, the first entry that is
+// for any platform at all. Entries such as build attestations are for no
+// platform and are never selected. If no entry is suitable, nil is returned.
+func selectIndexEntry(entries []indexEntry, plat *imagePlatform) *indexEntry {
+	for i, entry := range entries {
+		if entry.Platform == nil || entry.Platform.OS == "unknown" {
+			continue
+		}
+		if plat == nil || (entry.Platform.OS == plat.OS &&
+			entry.Platform.Architecture == plat.Architecture &&
+			(plat.Variant == "" || entry.Platform.Variant == plat.Variant)) {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+// mergeLabels copies the standard OCI labels that are of interest from src to
+// dst, overwriting any values already in dst.
+func mergeLabels(dst map[string]string, src map[string]string) {
+	for _, key := range []string{sourceLabelKey, revisionLabelKey, createdLabelKey} {
+		if val := strings.TrimSpace(src[key]); val != "" {
+			dst[key] = val
+		}
+	}
+}
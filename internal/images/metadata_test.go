@@ -0,0 +1,282 @@
+package images
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSourceMetadata(t *testing.T) {
+	testCreated := time.Date(2023, time.October, 1, 12, 0, 0, 0, time.UTC)
+	testLabels := map[string]string{
+		sourceLabelKey:   "https://github.com/example/app",
+		revisionLabelKey: "fake-commit",
+		createdLabelKey:  testCreated.Format(time.RFC3339),
+	}
+	testCases := []struct {
+		name       string
+		setup      func(*fakeArtifacts) string
+		platform   *imagePlatform
+		assertions func(*SourceMetadata, error)
+	}{
+		{
+			name: "manifest not found",
+			setup: func(*fakeArtifacts) string {
+				return "sha256:fake"
+			},
+			assertions: func(_ *SourceMetadata, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error getting manifest")
+			},
+		},
+		{
+			name: "labels in image config",
+			setup: func(a *fakeArtifacts) string {
+				return a.addImage(t, nil, testLabels)
+			},
+			assertions: func(metadata *SourceMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					&SourceMetadata{
+						RepoURL:  "https://github.com/example/app",
+						Revision: "fake-commit",
+						Created:  &testCreated,
+					},
+					metadata,
+				)
+			},
+		},
+		{
+			name: "annotations of manifest",
+			setup: func(a *fakeArtifacts) string {
+				return a.addImage(t, testLabels, nil)
+			},
+			assertions: func(metadata *SourceMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "https://github.com/example/app", metadata.RepoURL)
+				require.Equal(t, "fake-commit", metadata.Revision)
+				require.Equal(t, &testCreated, metadata.Created)
+			},
+		},
+		{
+			name: "labels take precedence over annotations",
+			setup: func(a *fakeArtifacts) string {
+				return a.addImage(
+					t,
+					testLabels,
+					map[string]string{revisionLabelKey: "other-fake-commit"},
+				)
+			},
+			assertions: func(metadata *SourceMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "https://github.com/example/app", metadata.RepoURL)
+				require.Equal(t, "other-fake-commit", metadata.Revision)
+			},
+		},
+		{
+			name: "no labels",
+			setup: func(a *fakeArtifacts) string {
+				return a.addImage(t, nil, nil)
+			},
+			assertions: func(metadata *SourceMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, &SourceMetadata{}, metadata)
+			},
+		},
+		{
+			name: "malformed build time",
+			setup: func(a *fakeArtifacts) string {
+				return a.addImage(
+					t,
+					nil,
+					map[string]string{createdLabelKey: "yesterday"},
+				)
+			},
+			assertions: func(metadata *SourceMetadata, err error) {
+				require.NoError(t, err)
+				require.Nil(t, metadata.Created)
+			},
+		},
+		{
+			name: "image index without platform specified",
+			setup: func(a *fakeArtifacts) string {
+				return a.addTestIndex(t, testLabels)
+			},
+			assertions: func(metadata *SourceMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "https://github.com/example/app", metadata.RepoURL)
+				require.Equal(t, "amd64-fake-commit", metadata.Revision)
+				require.Equal(t, &testCreated, metadata.Created)
+			},
+		},
+		{
+			name: "image index with platform specified",
+			setup: func(a *fakeArtifacts) string {
+				return a.addTestIndex(t, testLabels)
+			},
+			platform: &imagePlatform{
+				OS:           "linux",
+				Architecture: "arm64",
+			},
+			assertions: func(metadata *SourceMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "arm64-fake-commit", metadata.Revision)
+			},
+		},
+		{
+			name: "image index lacking specified platform",
+			setup: func(a *fakeArtifacts) string {
+				return a.addTestIndex(t, testLabels)
+			},
+			platform: &imagePlatform{
+				OS:           "windows",
+				Architecture: "amd64",
+			},
+			assertions: func(_ *SourceMetadata, err error) {
+				require.Error(t, err)
+				require.Contains(
+					t,
+					err.Error(),
+					"includes no image for the specified platform",
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			artifacts := newFakeArtifacts()
+			digest := testCase.setup(artifacts)
+			testCase.assertions(
+				getSourceMetadata(
+					context.Background(),
+					artifacts,
+					digest,
+					testCase.platform,
+				),
+			)
+		})
+	}
+}
+
+func TestReadSourceMetadata(t *testing.T) {
+	testLabels := map[string]string{
+		sourceLabelKey:   "https://github.com/example/app",
+		revisionLabelKey: "fake-commit",
+	}
+
+	t.Run("metadata read", func(t *testing.T) {
+		artifacts := newFakeArtifacts()
+		digest := artifacts.addImage(t, nil, testLabels)
+		require.Equal(
+			t,
+			SourceMetadata{
+				RepoURL:  "https://github.com/example/app",
+				Revision: "fake-commit",
+			},
+			readSourceMetadata(
+				context.Background(),
+				artifacts,
+				"fake-url",
+				"fake-tag",
+				digest,
+				nil,
+			),
+		)
+	})
+
+	t.Run("error getting image config", func(t *testing.T) {
+		artifacts := newFakeArtifacts()
+		digest := artifacts.addImage(t, nil, testLabels)
+		// Lose the image's config
+		artifacts.blobs = map[string][]byte{}
+		_, err := getSourceMetadata(context.Background(), artifacts, digest, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error getting image config")
+		require.Equal(
+			t,
+			SourceMetadata{},
+			readSourceMetadata(
+				context.Background(),
+				artifacts,
+				"fake-url",
+				"fake-tag",
+				digest,
+				nil,
+			),
+		)
+	})
+}
+
+// addTestIndex adds an image index bearing the provided annotations that
+// includes a build attestation and images for linux/amd64 and linux/arm64,
+// each with its own revision label. It returns the digest of the index.
+func (f *fakeArtifacts) addTestIndex(
+	t *testing.T,
+	annotations map[string]string,
+) string {
+	entries := []indexEntry{
+		{
+			ociDescriptor: ociDescriptor{
+				Digest: f.addImage(t, nil, map[string]string{
+					revisionLabelKey: "attestation-fake-commit",
+				}),
+			},
+			Platform: &imagePlatform{OS: "unknown", Architecture: "unknown"},
+		},
+	}
+	for _, arch := range []string{"amd64", "arm64"} {
+		entries = append(
+			entries,
+			indexEntry{
+				ociDescriptor: ociDescriptor{
+					Digest: f.addImage(t, nil, map[string]string{
+						revisionLabelKey: fmt.Sprintf("%s-fake-commit", arch),
+					}),
+				},
+				Platform: &imagePlatform{OS: "linux", Architecture: arch},
+			},
+		)
+	}
+	return f.addManifest(t, imageManifest{
+		Manifests:   entries,
+		Annotations: annotations,
+	})
+}
+
+// addImage adds an image whose manifest bears the provided annotations and
+// whose config bears the provided labels. It returns the digest of the image's
+// manifest.
+func (f *fakeArtifacts) addImage(
+	t *testing.T,
+	annotations map[string]string,
+	labels map[string]string,
+) string {
+	config, err := json.Marshal(map[string]any{
+		"architecture": "amd64",
+		"os":           "linux",
+		"config": map[string]any{
+			"Labels": labels,
+		},
+	})
+	require.NoError(t, err)
+	configDigest := fmt.Sprintf("sha256:%x", sha256.Sum256(config))
+	f.blobs[configDigest] = config
+	return f.addManifest(t, imageManifest{
+		Config:      &ociDescriptor{Digest: configDigest},
+		Annotations: annotations,
+	})
+}
+
+func (f *fakeArtifacts) addManifest(t *testing.T, manifest imageManifest) string {
+	manifestBytes, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	digest := fmt.Sprintf("sha256:%x", sha256.Sum256(manifestBytes))
+	f.manifests[digest] = manifestBytes
+	return digest
+}
@@ -0,0 +1,168 @@
+package images
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/distribution/distribution/v3/registry/client/auth"
+	"github.com/distribution/distribution/v3/registry/client/auth/challenge"
+	"github.com/distribution/distribution/v3/registry/client/transport"
+	"github.com/pkg/errors"
+)
+
+// maxArtifactBytes is the maximum size of a manifest or blob that is retrieved
+// from a registry. Signatures and attestations are far smaller than this.
+const maxArtifactBytes = 4 << 20
+
+// manifestMediaTypes are the media types of manifests that are accepted from a
+// registry.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+}
+
+// registryClient is an artifactGetter that retrieves manifests and blobs from
+// a single repository in any registry that implements the OCI distribution
+// API.
+type registryClient struct {
+	repoURL    string
+	httpClient *http.Client
+}
+
+// newRegistryClient returns a registryClient for the named repository in the
+// registry whose API is served at the provided URL. Requests are made using
+// the provided http.RoundTripper and are authenticated using the provided
+// credentials, if any, in whatever manner the registry demands.
+func newRegistryClient(
+	ctx context.Context,
+	apiURL string,
+	rt http.RoundTripper,
+	name string,
+	creds *Credentials,
+) (*registryClient, error) {
+	apiURL = strings.TrimSuffix(apiURL, "/")
+	// The registry's response to an unauthenticated request reveals how it
+	// expects requests to be authenticated
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+"/v2/", nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating request for %q", apiURL)
+	}
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error pinging registry %q", apiURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK &&
+		resp.StatusCode != http.StatusUnauthorized {
+		return nil, errors.Errorf(
+			"received unexpected HTTP %d pinging registry %q",
+			resp.StatusCode,
+			apiURL,
+		)
+	}
+	challenges := challenge.NewSimpleManager()
+	if err = challenges.AddResponse(resp); err != nil {
+		return nil, errors.Wrapf(err, "error reading challenges from %q", apiURL)
+	}
+	if creds == nil {
+		creds = &Credentials{}
+	}
+	store := &credentialStore{
+		creds:         *creds,
+		refreshTokens: map[string]string{},
+	}
+	return &registryClient{
+		repoURL: fmt.Sprintf("%s/v2/%s", apiURL, name),
+		httpClient: &http.Client{
+			Transport: transport.NewTransport(
+				rt,
+				auth.NewAuthorizer(
+					challenges,
+					auth.NewTokenHandler(rt, store, name, "pull"),
+					auth.NewBasicHandler(store),
+				),
+			),
+		},
+	}, nil
+}
+
+func (r *registryClient) getManifest(
+	ctx context.Context,
+	ref string,
+) ([]byte, error) {
+	return r.get(
+		ctx,
+		fmt.Sprintf("%s/manifests/%s", r.repoURL, ref),
+		strings.Join(manifestMediaTypes, ", "),
+	)
+}
+
+func (r *registryClient) getBlob(
+	ctx context.Context,
+	digest string,
+) ([]byte, error) {
+	return r.get(ctx, fmt.Sprintf("%s/blobs/%s", r.repoURL, digest), "")
+}
+
+func (r *registryClient) get(
+	ctx context.Context,
+	url string,
+	accept string,
+) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating request for %q", url)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting %q", url)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, errArtifactNotFound
+	default:
+		return nil, errors.Errorf(
+			"received unexpected HTTP %d requesting %q",
+			resp.StatusCode,
+			url,
+		)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxArtifactBytes+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading response from %q", url)
+	}
+	if len(body) > maxArtifactBytes {
+		return nil, errors.Errorf("response from %q is too large", url)
+	}
+	return body, nil
+}
+
+// credentialStore adapts Credentials for use by the registry's
+// authentication handlers.
+type credentialStore struct {
+	creds         Credentials
+	refreshTokens map[string]string
+}
+
+func (c *credentialStore) Basic(*url.URL) (string, string) {
+	return c.creds.Username, c.creds.Password
+}
+
+func (c *credentialStore) RefreshToken(_ *url.URL, service string) string {
+	return c.refreshTokens[service]
+}
+
+func (c *credentialStore) SetRefreshToken(_ *url.URL, service, token string) {
+	c.refreshTokens[service] = token
+}
@@ -0,0 +1,87 @@
+package images
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryClient(t *testing.T) {
+	const (
+		testUsername = "fake-username"
+		testPassword = "fake-password"
+	)
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if username, password, ok := r.BasicAuth(); !ok ||
+				username != testUsername || password != testPassword {
+				w.Header().Set("WWW-Authenticate", `Basic realm="fake-realm"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			switch r.URL.Path {
+			case "/v2/":
+			case "/v2/example/app/manifests/sha256-abc.sig":
+				_, _ = w.Write([]byte("fake-manifest"))
+			case "/v2/example/app/blobs/sha256:abc":
+				_, _ = w.Write([]byte("fake-blob"))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}),
+	)
+	defer srv.Close()
+
+	t.Run("without credentials", func(t *testing.T) {
+		client, err := newRegistryClient(
+			context.Background(),
+			srv.URL,
+			http.DefaultTransport,
+			"example/app",
+			nil,
+		)
+		require.NoError(t, err)
+		_, err = client.getManifest(context.Background(), "sha256-abc.sig")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no basic auth credentials")
+	})
+
+	client, err := newRegistryClient(
+		context.Background(),
+		srv.URL,
+		http.DefaultTransport,
+		"example/app",
+		&Credentials{
+			Username: testUsername,
+			Password: testPassword,
+		},
+	)
+	require.NoError(t, err)
+
+	t.Run("get manifest", func(t *testing.T) {
+		manifest, err := client.getManifest(context.Background(), "sha256-abc.sig")
+		require.NoError(t, err)
+		require.Equal(t, "fake-manifest", string(manifest))
+	})
+
+	t.Run("get blob", func(t *testing.T) {
+		blob, err := client.getBlob(context.Background(), "sha256:abc")
+		require.NoError(t, err)
+		require.Equal(t, "fake-blob", string(blob))
+	})
+
+	t.Run("artifact not found", func(t *testing.T) {
+		_, err := client.getManifest(context.Background(), "sha256-abc.att")
+		require.Equal(t, errArtifactNotFound, err)
+	})
+
+	t.Run("request canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := client.getManifest(ctx, "sha256-abc.sig")
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
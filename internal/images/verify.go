@@ -0,0 +1,750 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	protobundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	protodsse "github.com/sigstore/protobuf-specs/gen/pb-go/dsse"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/sigstore-go/pkg/bundle"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/verify"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+const (
+	// cosignSignatureAnnotationKey is the key of the annotation on a layer of a
+	// cosign signature manifest that holds the base64-encoded signature of the
+	// layer's content.
+	cosignSignatureAnnotationKey = "dev.cosignproject.cosign/signature"
+	// cosignCertificateAnnotationKey is the key of the annotation on a layer of
+	// a cosign signature or attestation manifest that holds the PEM-encoded
+	// certificate a keyless signature was made with.
+	cosignCertificateAnnotationKey = "dev.sigstore.cosign/certificate"
+	// cosignBundleAnnotationKey is the key of the annotation on a layer of a
+	// cosign signature or attestation manifest that holds the transparency log
+	// entry a signature was recorded in.
+	cosignBundleAnnotationKey = "dev.sigstore.cosign/bundle"
+
+	// dsseEnvelopeMediaType is the media type of layers of cosign attestation
+	// manifests.
+	dsseEnvelopeMediaType = "application/vnd.dsse.envelope.v1+json"
+	// bundleMediaType is the media type of the sigstore bundles that cosign
+	// signatures and attestations are assembled into for verification. Version
+	// 0.1 bundles are the ones that, like cosign, rely on a transparency log's
+	// promise of inclusion rather than on a proof of it.
+	bundleMediaType = "application/vnd.dev.sigstore.bundle+json;version=0.1"
+)
+
+// errArtifactNotFound is returned by an artifactGetter when the requested
+// artifact does not exist.
+var errArtifactNotFound = errors.New("artifact not found")
+
+// VerificationPolicy describes a policy that cosign signatures of an image must
+// satisfy. An image satisfies the policy if it has at least one signature made
+// using any of the public keys or by any of the keyless identities.
+type VerificationPolicy struct {
+	// PublicKeys is a list of PEM-encoded public keys. Signatures made using any
+	// of these keys are trusted.
+	PublicKeys []string
+	// Keyless describes identities whose keyless signatures are trusted.
+	Keyless *KeylessVerificationPolicy
+	// Attestations is a list of in-toto predicate types for each of which an
+	// image must also have an attestation signed by a trusted key or identity.
+	Attestations []string
+}
+
+// KeylessVerificationPolicy describes identities whose keyless signatures are
+// trusted.
+type KeylessVerificationPolicy struct {
+	// Identities is a list of identities. A keyless signature is trusted if the
+	// certificate it was made with was issued to any of these identities.
+	Identities []KeylessIdentity
+	// RootCertificates is a PEM-encoded bundle of the certificates that keyless
+	// signing certificates must chain to. Any intermediate certificates must
+	// also be included, as those that accompany signatures are not trusted.
+	RootCertificates string
+	// TransparencyLogPublicKey is the PEM-encoded public key of the
+	// transparency log that keyless signatures must have been recorded in.
+	TransparencyLogPublicKey string
+}
+
+// KeylessIdentity describes an identity that keyless signing certificates may
+// be issued to. A certificate matches the identity if it matches every field
+// that is non-empty.
+type KeylessIdentity struct {
+	// Issuer is the exact OIDC issuer that authenticated the signer.
+	Issuer string
+	// IssuerRegexp is a regular expression that the OIDC issuer that
+	// authenticated the signer must match.
+	IssuerRegexp string
+	// Subject is the exact subject, i.e. an email address or URI, that the
+	// certificate was issued to.
+	Subject string
+	// SubjectRegexp is a regular expression that the subject that the
+	// certificate was issued to must match.
+	SubjectRegexp string
+}
+
+// RejectedTag describes a tag that was passed over because its signatures did
+// not satisfy a VerificationPolicy.
+type RejectedTag struct {
+	// Name is the name of the tag.
+	Name string
+	// Digest is the digest of the manifest the tag referenced.
+	Digest string
+	// Reason describes why the tag was passed over.
+	Reason string
+}
+
+// NoVerifiedTagError is the error returned by GetLatestTag when none of the
+// candidate tags of an image satisfies its VerificationPolicy.
+type NoVerifiedTagError struct {
+	// RepoURL is the URL of the image's repository.
+	RepoURL string
+	// Rejected describes each tag that was passed over.
+	Rejected []RejectedTag
+}
+
+func (e *NoVerifiedTagError) Error() string {
+	reasons := make([]string, len(e.Rejected))
+	for i, r := range e.Rejected {
+		reasons[i] = fmt.Sprintf("%s: %s", r.Name, r.Reason)
+	}
+	return fmt.Sprintf(
+		"found no version of image %q that satisfies its verification policy; "+
+			"passed over %s",
+		e.RepoURL,
+		strings.Join(reasons, "; "),
+	)
+}
+
+// artifactGetter is an interface for components that retrieve manifests and
+// blobs from a single repository in an OCI registry.
+type artifactGetter interface {
+	// getManifest returns the manifest identified by the provided tag or
+	// digest. If no such manifest exists, errArtifactNotFound is returned.
+	getManifest(ctx context.Context, ref string) ([]byte, error)
+	// getBlob returns the blob identified by the provided digest. If no such
+	// blob exists, errArtifactNotFound is returned.
+	getBlob(ctx context.Context, digest string) ([]byte, error)
+}
+
+// ociDescriptor describes content, such as a layer, referenced by an OCI
+// manifest.
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// verifier verifies cosign signatures and attestations of images according
+// to a VerificationPolicy.
+type verifier struct {
+	// keys holds a sigstore verifier for each trusted public key.
+	keys         []*verify.Verifier
+	keyless      *keylessVerifier
+	attestations []string
+}
+
+// keylessVerifier verifies keyless signatures according to a
+// KeylessVerificationPolicy.
+type keylessVerifier struct {
+	verifier   *verify.Verifier
+	identities []verify.PolicyOption
+}
+
+// newVerifier returns a verifier for the provided VerificationPolicy. An error
+// is returned if the policy is invalid.
+func newVerifier(policy VerificationPolicy) (*verifier, error) {
+	if len(policy.PublicKeys) == 0 && policy.Keyless == nil {
+		return nil, errors.New(
+			"verification policy specifies neither public keys nor keyless " +
+				"identities",
+		)
+	}
+	v := &verifier{
+		keys:         make([]*verify.Verifier, len(policy.PublicKeys)),
+		attestations: policy.Attestations,
+	}
+	for i, keyPEM := range policy.PublicKeys {
+		var err error
+		if v.keys[i], err = newKeyVerifier(keyPEM); err != nil {
+			return nil, errors.Wrapf(err, "error parsing public key %d", i)
+		}
+	}
+	if policy.Keyless != nil {
+		var err error
+		if v.keyless, err = newKeylessVerifier(*policy.Keyless); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// newKeyVerifier returns a sigstore verifier that trusts signatures made using
+// the private key corresponding to the provided PEM-encoded public key.
+func newKeyVerifier(keyPEM string) (*verify.Verifier, error) {
+	key, err := parsePublicKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	// Like cosign, always use SHA-256, whatever the size of the key
+	sigVerifier, err := signature.LoadVerifier(key, crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	trustedKey := root.NewExpiringKey(sigVerifier, time.Time{}, time.Time{})
+	return verify.NewVerifier(
+		root.NewTrustedPublicKeyMaterial(
+			func(string) (root.TimeConstrainedVerifier, error) {
+				return trustedKey, nil
+			},
+		),
+		// Signatures made using a key need not have been recorded anywhere
+		verify.WithNoObserverTimestamps(),
+	)
+}
+
+func newKeylessVerifier(
+	policy KeylessVerificationPolicy,
+) (*keylessVerifier, error) {
+	if len(policy.Identities) == 0 {
+		return nil, errors.New("keyless verification specifies no identities")
+	}
+	k := &keylessVerifier{
+		identities: make([]verify.PolicyOption, len(policy.Identities)),
+	}
+	for i, identity := range policy.Identities {
+		if identity.Issuer == "" && identity.IssuerRegexp == "" {
+			return nil, errors.Errorf("keyless identity %d specifies no issuer", i)
+		}
+		if identity.Subject == "" && identity.SubjectRegexp == "" {
+			return nil, errors.Errorf("keyless identity %d specifies no subject", i)
+		}
+		certIdentity, err := verify.NewShortCertificateIdentity(
+			identity.Issuer,
+			identity.IssuerRegexp,
+			identity.Subject,
+			identity.SubjectRegexp,
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing keyless identity %d", i)
+		}
+		k.identities[i] = verify.WithCertificateIdentity(certIdentity)
+	}
+	certs, err := parseCertificates(policy.RootCertificates)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing root certificates")
+	}
+	var roots, intermediates []*x509.Certificate
+	for _, cert := range certs {
+		// Self-signed certificates are roots. Anything else is an intermediate.
+		if bytes.Equal(cert.RawIssuer, cert.RawSubject) &&
+			cert.CheckSignatureFrom(cert) == nil {
+			roots = append(roots, cert)
+		} else {
+			intermediates = append(intermediates, cert)
+		}
+	}
+	if len(roots) == 0 {
+		return nil, errors.New("root certificates include no root certificate")
+	}
+	authorities := make([]root.CertificateAuthority, len(roots))
+	for i, rootCert := range roots {
+		authorities[i] = &root.FulcioCertificateAuthority{
+			Root:          rootCert,
+			Intermediates: intermediates,
+		}
+	}
+	tlog, err := newTransparencyLog(policy.TransparencyLogPublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing transparency log public key")
+	}
+	trustedRoot, err := root.NewTrustedRoot(
+		root.TrustedRootMediaType01,
+		authorities,
+		nil,
+		nil,
+		map[string]*root.TransparencyLog{hex.EncodeToString(tlog.ID): tlog},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error assembling trusted root")
+	}
+	if k.verifier, err = verify.NewVerifier(
+		trustedRoot,
+		verify.WithTransparencyLog(1),
+		// The certificate need only have been valid when the signature was
+		// recorded in the transparency log
+		verify.WithIntegratedTimestamps(1),
+	); err != nil {
+		return nil, errors.Wrap(err, "error creating keyless verifier")
+	}
+	return k, nil
+}
+
+// newTransparencyLog returns a description of the transparency log with the
+// provided PEM-encoded public key.
+func newTransparencyLog(keyPEM string) (*root.TransparencyLog, error) {
+	key, err := parsePublicKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := key.(*ecdsa.PublicKey); !ok {
+		return nil, errors.Errorf("unsupported public key type %T", key)
+	}
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	// A transparency log is identified by the digest of its public key
+	id := sha256.Sum256(der)
+	return &root.TransparencyLog{
+		ID:                id[:],
+		HashFunc:          crypto.SHA256,
+		PublicKey:         key,
+		SignatureHashFunc: crypto.SHA256,
+		// The policy does not limit when the key is valid, but sigstore-go
+		// insists on knowing when that began
+		ValidityPeriodStart: time.Unix(0, 0),
+	}, nil
+}
+
+// verify returns an error if the image with the provided manifest digest does
+// not have signatures, and attestations, that satisfy the verifier's policy.
+func (v *verifier) verify(
+	ctx context.Context,
+	artifacts artifactGetter,
+	digest string,
+) error {
+	if err := v.verifySignatures(ctx, artifacts, digest); err != nil {
+		return err
+	}
+	for _, predicateType := range v.attestations {
+		if err := v.verifyAttestations(
+			ctx,
+			artifacts,
+			digest,
+			predicateType,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *verifier) verifySignatures(
+	ctx context.Context,
+	artifacts artifactGetter,
+	digest string,
+) error {
+	layers, err := getCosignLayers(ctx, artifacts, digest, "sig")
+	if err != nil {
+		if errors.Is(err, errArtifactNotFound) {
+			return errors.New("image is not signed")
+		}
+		return errors.Wrap(err, "error getting signatures")
+	}
+	var reasons []string
+	for _, layer := range layers {
+		if err = v.verifySignature(ctx, artifacts, digest, layer); err == nil {
+			return nil
+		}
+		reasons = append(reasons, err.Error())
+	}
+	return errors.Errorf(
+		"no signature satisfies the verification policy: %s",
+		joinReasons(reasons),
+	)
+}
+
+func (v *verifier) verifySignature(
+	ctx context.Context,
+	artifacts artifactGetter,
+	digest string,
+	layer ociDescriptor,
+) error {
+	payload, err := getLayer(ctx, artifacts, layer)
+	if err != nil {
+		return err
+	}
+	simpleSigning := struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+		} `json:"critical"`
+	}{}
+	if err = json.Unmarshal(payload, &simpleSigning); err != nil {
+		return errors.Wrap(err, "error unmarshaling signature payload")
+	}
+	if simpleSigning.Critical.Image.DockerManifestDigest != digest {
+		return errors.New("signature is for a different image")
+	}
+	sig, err := base64.StdEncoding.DecodeString(
+		layer.Annotations[cosignSignatureAnnotationKey],
+	)
+	if err != nil || len(sig) == 0 {
+		return errors.New("signature is missing or malformed")
+	}
+	payloadDigest := sha256.Sum256(payload)
+	return v.verifySigner(
+		&protobundle.Bundle{
+			Content: &protobundle.Bundle_MessageSignature{
+				MessageSignature: &protocommon.MessageSignature{
+					MessageDigest: &protocommon.HashOutput{
+						Algorithm: protocommon.HashAlgorithm_SHA2_256,
+						Digest:    payloadDigest[:],
+					},
+					Signature: sig,
+				},
+			},
+		},
+		layer.Annotations,
+		func() verify.ArtifactPolicyOption {
+			return verify.WithArtifact(bytes.NewReader(payload))
+		},
+	)
+}
+
+func (v *verifier) verifyAttestations(
+	ctx context.Context,
+	artifacts artifactGetter,
+	digest string,
+	predicateType string,
+) error {
+	layers, err := getCosignLayers(ctx, artifacts, digest, "att")
+	if err != nil && !errors.Is(err, errArtifactNotFound) {
+		return errors.Wrap(err, "error getting attestations")
+	}
+	var reasons []string
+	for _, layer := range layers {
+		if layer.MediaType != dsseEnvelopeMediaType {
+			continue
+		}
+		var found bool
+		if found, err =
+			v.verifyAttestation(ctx, artifacts, digest, predicateType, layer); found {
+			if err == nil {
+				return nil
+			}
+			reasons = append(reasons, err.Error())
+		}
+	}
+	if len(reasons) == 0 {
+		return errors.Errorf("image has no %q attestation", predicateType)
+	}
+	return errors.Errorf(
+		"no %q attestation satisfies the verification policy: %s",
+		predicateType,
+		joinReasons(reasons),
+	)
+}
+
+// verifyAttestation verifies the attestation contained in the provided layer.
+// The returned bool indicates whether the attestation is of the specified
+// predicate type. If it is not, the attestation is not verified.
+func (v *verifier) verifyAttestation(
+	ctx context.Context,
+	artifacts artifactGetter,
+	digest string,
+	predicateType string,
+	layer ociDescriptor,
+) (bool, error) {
+	envelopeBytes, err := getLayer(ctx, artifacts, layer)
+	if err != nil {
+		return true, err
+	}
+	envelope := struct {
+		PayloadType string `json:"payloadType"`
+		Payload     []byte `json:"payload"`
+		Signatures  []struct {
+			KeyID string `json:"keyid"`
+			Sig   []byte `json:"sig"`
+		} `json:"signatures"`
+	}{}
+	if err = json.Unmarshal(envelopeBytes, &envelope); err != nil {
+		return true, errors.Wrap(err, "error unmarshaling attestation")
+	}
+	statement := struct {
+		PredicateType string `json:"predicateType"`
+	}{}
+	if err = json.Unmarshal(envelope.Payload, &statement); err != nil {
+		return true, errors.Wrap(err, "error unmarshaling attestation statement")
+	}
+	if statement.PredicateType != predicateType {
+		return false, nil
+	}
+	if len(envelope.Signatures) == 0 {
+		return true, errors.New("attestation is not signed")
+	}
+	imageDigest, err := hex.DecodeString(strings.TrimPrefix(digest, "sha256:"))
+	if err != nil {
+		return true, errors.Wrapf(err, "error decoding digest %q", digest)
+	}
+	// A sigstore bundle holds an envelope with a single signature, so each
+	// signature is verified separately
+	for _, sig := range envelope.Signatures {
+		if err = v.verifySigner(
+			&protobundle.Bundle{
+				Content: &protobundle.Bundle_DsseEnvelope{
+					DsseEnvelope: &protodsse.Envelope{
+						Payload:     envelope.Payload,
+						PayloadType: envelope.PayloadType,
+						Signatures: []*protodsse.Signature{
+							{
+								Sig:   sig.Sig,
+								Keyid: sig.KeyID,
+							},
+						},
+					},
+				},
+			},
+			layer.Annotations,
+			func() verify.ArtifactPolicyOption {
+				// The attestation's subject must be the image
+				return verify.WithArtifactDigest("sha256", imageDigest)
+			},
+		); err == nil {
+			return true, nil
+		}
+	}
+	return true, err
+}
+
+// verifySigner returns an error if the signature held by the provided bundle
+// was not made using a trusted key or by a trusted keyless identity. Keyless
+// signatures are described by the provided annotations. The provided function
+// returns the policy for checking what was signed.
+func (v *verifier) verifySigner(
+	b *protobundle.Bundle,
+	annotations map[string]string,
+	artifactPolicy func() verify.ArtifactPolicyOption,
+) error {
+	b.MediaType = bundleMediaType
+	for _, key := range v.keys {
+		b.VerificationMaterial = &protobundle.VerificationMaterial{
+			Content: &protobundle.VerificationMaterial_PublicKey{
+				PublicKey: &protocommon.PublicKeyIdentifier{},
+			},
+		}
+		if verifyBundle(
+			key,
+			b,
+			verify.NewPolicy(artifactPolicy(), verify.WithKey()),
+		) == nil {
+			return nil
+		}
+	}
+	if v.keyless == nil || annotations[cosignCertificateAnnotationKey] == "" {
+		return errors.New("signature was not made using any trusted key")
+	}
+	return v.keyless.verify(b, annotations, artifactPolicy)
+}
+
+// verify returns an error if the keyless signature held by the provided
+// bundle, described by the provided annotations, was not made by a trusted
+// identity while its certificate was valid, or was not recorded in the
+// trusted transparency log.
+func (k *keylessVerifier) verify(
+	b *protobundle.Bundle,
+	annotations map[string]string,
+	artifactPolicy func() verify.ArtifactPolicyOption,
+) error {
+	certs, err := parseCertificates(annotations[cosignCertificateAnnotationKey])
+	if err != nil {
+		return errors.Wrap(err, "error parsing signing certificate")
+	}
+	entry, err := getTransparencyLogEntry(annotations[cosignBundleAnnotationKey])
+	if err != nil {
+		return err
+	}
+	b.VerificationMaterial = &protobundle.VerificationMaterial{
+		Content: &protobundle.VerificationMaterial_X509CertificateChain{
+			X509CertificateChain: &protocommon.X509CertificateChain{
+				Certificates: []*protocommon.X509Certificate{
+					{RawBytes: certs[0].Raw},
+				},
+			},
+		},
+		TlogEntries: []*protorekor.TransparencyLogEntry{entry},
+	}
+	if err = verifyBundle(
+		k.verifier,
+		b,
+		verify.NewPolicy(artifactPolicy(), k.identities...),
+	); err != nil {
+		return errors.Wrap(
+			err,
+			"keyless signature does not satisfy the verification policy",
+		)
+	}
+	return nil
+}
+
+// verifyBundle verifies the provided bundle using the provided verifier
+// according to the provided policy.
+func verifyBundle(
+	v *verify.Verifier,
+	pb *protobundle.Bundle,
+	policy verify.PolicyBuilder,
+) error {
+	b, err := bundle.NewBundle(pb)
+	if err != nil {
+		return errors.Wrap(err, "error assembling signature bundle")
+	}
+	_, err = v.Verify(b, policy)
+	return err
+}
+
+// getTransparencyLogEntry converts the provided transparency log entry, as
+// cosign records it in the annotations of a signature, into the form sigstore
+// bundles hold it in.
+func getTransparencyLogEntry(
+	bundleJSON string,
+) (*protorekor.TransparencyLogEntry, error) {
+	if bundleJSON == "" {
+		return nil, errors.New("signature was not recorded in a transparency log")
+	}
+	cosignBundle := struct {
+		SignedEntryTimestamp []byte `json:"SignedEntryTimestamp"`
+		Payload              struct {
+			Body           string `json:"body"`
+			IntegratedTime int64  `json:"integratedTime"`
+			LogIndex       int64  `json:"logIndex"`
+			LogID          string `json:"logID"`
+		} `json:"Payload"`
+	}{}
+	if err := json.Unmarshal([]byte(bundleJSON), &cosignBundle); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling transparency log entry")
+	}
+	body, err := base64.StdEncoding.DecodeString(cosignBundle.Payload.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding transparency log entry")
+	}
+	kindVersion := struct {
+		Kind       string `json:"kind"`
+		APIVersion string `json:"apiVersion"`
+	}{}
+	if err = json.Unmarshal(body, &kindVersion); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling transparency log entry")
+	}
+	logID, err := hex.DecodeString(cosignBundle.Payload.LogID)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding transparency log ID")
+	}
+	return &protorekor.TransparencyLogEntry{
+		LogIndex: cosignBundle.Payload.LogIndex,
+		LogId:    &protocommon.LogId{KeyId: logID},
+		KindVersion: &protorekor.KindVersion{
+			Kind:    kindVersion.Kind,
+			Version: kindVersion.APIVersion,
+		},
+		IntegratedTime: cosignBundle.Payload.IntegratedTime,
+		InclusionPromise: &protorekor.InclusionPromise{
+			SignedEntryTimestamp: cosignBundle.SignedEntryTimestamp,
+		},
+		CanonicalizedBody: body,
+	}, nil
+}
+
+// getCosignLayers returns the layers of the cosign manifest of the specified
+// sort, e.g. "sig" or "att", that pertains to the image with the provided
+// manifest digest.
+func getCosignLayers(
+	ctx context.Context,
+	artifacts artifactGetter,
+	digest string,
+	suffix string,
+) ([]ociDescriptor, error) {
+	ref := fmt.Sprintf("%s.%s", strings.Replace(digest, ":", "-", 1), suffix)
+	manifestBytes, err := artifacts.getManifest(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	manifest := struct {
+		Layers []ociDescriptor `json:"layers"`
+	}{}
+	if err = json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling manifest %q", ref)
+	}
+	return manifest.Layers, nil
+}
+
+// getLayer returns the content of the provided layer after verifying that it
+// matches the layer's digest.
+func getLayer(
+	ctx context.Context,
+	artifacts artifactGetter,
+	layer ociDescriptor,
+) ([]byte, error) {
+	content, err := artifacts.getBlob(ctx, layer.Digest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting layer %q", layer.Digest)
+	}
+	if fmt.Sprintf("sha256:%x", sha256.Sum256(content)) != layer.Digest {
+		return nil, errors.Errorf("layer %q does not match its digest", layer.Digest)
+	}
+	return content, nil
+}
+
+// parsePublicKey parses the provided PEM-encoded public key.
+func parsePublicKey(keyPEM string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, errors.New("no PEM-encoded key found")
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// parseCertificates parses the provided PEM-encoded certificates. At least one
+// certificate must be found.
+func parseCertificates(certsPEM string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(certsPEM)
+	for {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM-encoded certificate found")
+	}
+	return certs, nil
+}
+
+// joinReasons joins the provided reasons, omitting duplicates.
+func joinReasons(reasons []string) string {
+	seen := make(map[string]struct{}, len(reasons))
+	unique := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		if _, ok := seen[reason]; !ok {
+			seen[reason] = struct{}{}
+			unique = append(unique, reason)
+		}
+	}
+	return strings.Join(unique, "; ")
+}
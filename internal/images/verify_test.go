@@ -0,0 +1,781 @@
+package images
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testDigest        = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	testIssuer        = "https://token.actions.githubusercontent.com"
+	testSubject       = "https://github.com/example/app/.github/workflows/release.yaml@refs/heads/main"
+	testPredicateType = "https://slsa.dev/provenance/v0.2"
+)
+
+// fulcioIssuerV2OID identifies the extension Fulcio adds to the certificates
+// it issues to record the OIDC issuer that authenticated the signer.
+var fulcioIssuerV2OID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+
+func TestNewVerifier(t *testing.T) {
+	key := newTestKey(t)
+	testCases := []struct {
+		name       string
+		policy     VerificationPolicy
+		assertions func(error)
+	}{
+		{
+			name: "neither public keys nor keyless identities",
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "specifies neither public keys nor")
+			},
+		},
+		{
+			name: "invalid public key",
+			policy: VerificationPolicy{
+				PublicKeys: []string{"bogus"},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error parsing public key 0")
+			},
+		},
+		{
+			name: "keyless without identities",
+			policy: VerificationPolicy{
+				Keyless: &KeylessVerificationPolicy{},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "specifies no identities")
+			},
+		},
+		{
+			name: "keyless identity without issuer",
+			policy: VerificationPolicy{
+				Keyless: &KeylessVerificationPolicy{
+					Identities: []KeylessIdentity{{Subject: testSubject}},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "specifies no issuer")
+			},
+		},
+		{
+			name: "keyless without root certificates",
+			policy: VerificationPolicy{
+				Keyless: &KeylessVerificationPolicy{
+					Identities: []KeylessIdentity{
+						{
+							Issuer:  testIssuer,
+							Subject: testSubject,
+						},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error parsing root certificates")
+			},
+		},
+		{
+			name: "success",
+			policy: VerificationPolicy{
+				PublicKeys: []string{key.publicKeyPEM(t)},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := newVerifier(testCase.policy)
+			testCase.assertions(err)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	trustedKey := newTestKey(t)
+	untrustedKey := newTestKey(t)
+	p384Key := newTestKeyOnCurve(t, elliptic.P384())
+	ca := newTestCA(t)
+	tlog := newTestKey(t)
+	signedAt := time.Now().Add(-24 * time.Hour)
+	trustedSignature := keylessSignature{
+		issuer:   testIssuer,
+		subject:  testSubject,
+		signedAt: signedAt,
+	}
+
+	keyPolicy := VerificationPolicy{
+		PublicKeys: []string{trustedKey.publicKeyPEM(t)},
+	}
+	keylessPolicy := VerificationPolicy{
+		Keyless: &KeylessVerificationPolicy{
+			Identities: []KeylessIdentity{
+				{
+					Issuer:        testIssuer,
+					SubjectRegexp: `^https://github\.com/example/`,
+				},
+			},
+			RootCertificates:         ca.certPEM,
+			TransparencyLogPublicKey: tlog.publicKeyPEM(t),
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		policy     VerificationPolicy
+		setup      func(*fakeArtifacts)
+		assertions func(error)
+	}{
+		{
+			name:   "image is not signed",
+			policy: keyPolicy,
+			setup:  func(*fakeArtifacts) {},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Equal(t, "image is not signed", err.Error())
+			},
+		},
+		{
+			name:   "signed using a trusted key",
+			policy: keyPolicy,
+			setup: func(a *fakeArtifacts) {
+				a.addSignature(t, testDigest, trustedKey.signer(t))
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:   "signed using an untrusted key",
+			policy: keyPolicy,
+			setup: func(a *fakeArtifacts) {
+				a.addSignature(t, testDigest, untrustedKey.signer(t))
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "not made using any trusted key")
+			},
+		},
+		{
+			name:   "any one signature suffices",
+			policy: keyPolicy,
+			setup: func(a *fakeArtifacts) {
+				a.addSignature(
+					t,
+					testDigest,
+					untrustedKey.signer(t),
+					trustedKey.signer(t),
+				)
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "signed using a trusted P-384 key the way cosign signs",
+			policy: VerificationPolicy{
+				PublicKeys: []string{p384Key.publicKeyPEM(t)},
+			},
+			setup: func(a *fakeArtifacts) {
+				a.addSignature(t, testDigest, p384Key.signer(t))
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "signed using a trusted P-384 key over a SHA-384 digest",
+			policy: VerificationPolicy{
+				PublicKeys: []string{p384Key.publicKeyPEM(t)},
+			},
+			setup: func(a *fakeArtifacts) {
+				a.addSignature(
+					t,
+					testDigest,
+					func(data []byte) ([]byte, map[string]string) {
+						return p384Key.signUsingHash(t, data, crypto.SHA384),
+							map[string]string{}
+					},
+				)
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "not made using any trusted key")
+			},
+		},
+		{
+			name:   "signature is for a different image",
+			policy: keyPolicy,
+			setup: func(a *fakeArtifacts) {
+				a.addSignatureOfPayload(
+					t,
+					testDigest,
+					newSimpleSigningPayload(t, "sha256:fedcba"),
+					trustedKey.signer(t),
+				)
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "signature is for a different image")
+			},
+		},
+		{
+			name:   "signed keylessly by a trusted identity",
+			policy: keylessPolicy,
+			setup: func(a *fakeArtifacts) {
+				a.addSignature(
+					t,
+					testDigest,
+					ca.keylessSigner(t, tlog, trustedSignature),
+				)
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:   "signed keylessly using a P-384 key",
+			policy: keylessPolicy,
+			setup: func(a *fakeArtifacts) {
+				a.addSignature(
+					t,
+					testDigest,
+					ca.keylessSigner(t, tlog, keylessSignature{
+						issuer:   testIssuer,
+						subject:  testSubject,
+						curve:    elliptic.P384(),
+						signedAt: signedAt,
+					}),
+				)
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:   "signed keylessly by an untrusted identity",
+			policy: keylessPolicy,
+			setup: func(a *fakeArtifacts) {
+				a.addSignature(
+					t,
+					testDigest,
+					ca.keylessSigner(t, tlog, keylessSignature{
+						issuer:   testIssuer,
+						subject:  "https://github.com/someone-else/app",
+						signedAt: signedAt,
+					}),
+				)
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to verify certificate identity")
+			},
+		},
+		{
+			name:   "signed keylessly by an untrusted issuer",
+			policy: keylessPolicy,
+			setup: func(a *fakeArtifacts) {
+				a.addSignature(
+					t,
+					testDigest,
+					ca.keylessSigner(t, tlog, keylessSignature{
+						issuer:   "https://accounts.example.com",
+						subject:  testSubject,
+						signedAt: signedAt,
+					}),
+				)
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to verify certificate identity")
+			},
+		},
+		{
+			name:   "signed keylessly using an untrusted certificate",
+			policy: keylessPolicy,
+			setup: func(a *fakeArtifacts) {
+				a.addSignature(
+					t,
+					testDigest,
+					newTestCA(t).keylessSigner(t, tlog, trustedSignature),
+				)
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "failed to verify leaf certificate")
+			},
+		},
+		{
+			name:   "signed keylessly after the certificate expired",
+			policy: keylessPolicy,
+			setup: func(a *fakeArtifacts) {
+				a.addSignature(
+					t,
+					testDigest,
+					ca.keylessSigner(t, tlog, keylessSignature{
+						issuer:   testIssuer,
+						subject:  testSubject,
+						signedAt: signedAt,
+						expired:  true,
+					}),
+				)
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "integrated time outside certificate validity")
+			},
+		},
+		{
+			name:   "signed keylessly but recorded in an untrusted log",
+			policy: keylessPolicy,
+			setup: func(a *fakeArtifacts) {
+				a.addSignature(
+					t,
+					testDigest,
+					ca.keylessSigner(t, newTestKey(t), trustedSignature),
+				)
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "not enough verified log entries")
+			},
+		},
+		{
+			name:   "signed keylessly but recorded in an unsupported kind of entry",
+			policy: keylessPolicy,
+			setup: func(a *fakeArtifacts) {
+				a.addSignature(
+					t,
+					testDigest,
+					ca.keylessSigner(t, tlog, keylessSignature{
+						issuer:    testIssuer,
+						subject:   testSubject,
+						signedAt:  signedAt,
+						entryKind: "rekord",
+					}),
+				)
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "kind 'rekord'")
+			},
+		},
+		{
+			name: "required attestation is missing",
+			policy: VerificationPolicy{
+				PublicKeys:   keyPolicy.PublicKeys,
+				Attestations: []string{testPredicateType},
+			},
+			setup: func(a *fakeArtifacts) {
+				a.addSignature(t, testDigest, trustedKey.signer(t))
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "image has no")
+				require.Contains(t, err.Error(), testPredicateType)
+			},
+		},
+		{
+			name: "required attestation is signed using an untrusted key",
+			policy: VerificationPolicy{
+				PublicKeys:   keyPolicy.PublicKeys,
+				Attestations: []string{testPredicateType},
+			},
+			setup: func(a *fakeArtifacts) {
+				a.addSignature(t, testDigest, trustedKey.signer(t))
+				a.addAttestation(
+					t,
+					testDigest,
+					testPredicateType,
+					untrustedKey.signer(t),
+				)
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "attestation satisfies")
+				require.Contains(t, err.Error(), "not made using any trusted key")
+			},
+		},
+		{
+			name: "required attestation is signed using a trusted key",
+			policy: VerificationPolicy{
+				PublicKeys:   keyPolicy.PublicKeys,
+				Attestations: []string{testPredicateType},
+			},
+			setup: func(a *fakeArtifacts) {
+				a.addSignature(t, testDigest, trustedKey.signer(t))
+				a.addAttestation(
+					t,
+					testDigest,
+					"https://example.com/other-predicate",
+					untrustedKey.signer(t),
+				)
+				a.addAttestation(
+					t,
+					testDigest,
+					testPredicateType,
+					trustedKey.signer(t),
+				)
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			v, err := newVerifier(testCase.policy)
+			require.NoError(t, err)
+			artifacts := newFakeArtifacts()
+			testCase.setup(artifacts)
+			testCase.assertions(v.verify(context.Background(), artifacts, testDigest))
+		})
+	}
+}
+
+// fakeArtifacts is an in-memory artifactGetter.
+type fakeArtifacts struct {
+	manifests map[string][]byte
+	blobs     map[string][]byte
+}
+
+func newFakeArtifacts() *fakeArtifacts {
+	return &fakeArtifacts{
+		manifests: map[string][]byte{},
+		blobs:     map[string][]byte{},
+	}
+}
+
+func (f *fakeArtifacts) getManifest(
+	_ context.Context,
+	ref string,
+) ([]byte, error) {
+	if manifest, ok := f.manifests[ref]; ok {
+		return manifest, nil
+	}
+	return nil, errArtifactNotFound
+}
+
+func (f *fakeArtifacts) getBlob(
+	_ context.Context,
+	digest string,
+) ([]byte, error) {
+	if blob, ok := f.blobs[digest]; ok {
+		return blob, nil
+	}
+	return nil, errArtifactNotFound
+}
+
+// signerFn signs the provided data and returns the signature along with any
+// annotations that describe it.
+type signerFn func(data []byte) ([]byte, map[string]string)
+
+func (f *fakeArtifacts) addSignature(t *testing.T, digest string, signers ...signerFn) {
+	f.addSignatureOfPayload(t, digest, newSimpleSigningPayload(t, digest), signers...)
+}
+
+func (f *fakeArtifacts) addSignatureOfPayload(
+	t *testing.T,
+	digest string,
+	payload []byte,
+	signers ...signerFn,
+) {
+	for _, signer := range signers {
+		sig, annotations := signer(payload)
+		annotations[cosignSignatureAnnotationKey] =
+			base64.StdEncoding.EncodeToString(sig)
+		f.addLayer(t, digest, "sig", "application/vnd.dev.cosign.simplesigning.v1+json", payload, annotations)
+	}
+}
+
+func (f *fakeArtifacts) addAttestation(
+	t *testing.T,
+	digest string,
+	predicateType string,
+	signer signerFn,
+) {
+	statement, err := json.Marshal(map[string]any{
+		"_type":         "https://in-toto.io/Statement/v0.1",
+		"predicateType": predicateType,
+		"subject": []map[string]any{
+			{
+				"name":   "example/app",
+				"digest": map[string]string{"sha256": strings.TrimPrefix(digest, "sha256:")},
+			},
+		},
+		"predicate": map[string]any{},
+	})
+	require.NoError(t, err)
+	const payloadType = "application/vnd.in-toto+json"
+	pae := fmt.Sprintf(
+		"DSSEv1 %d %s %d %s",
+		len(payloadType),
+		payloadType,
+		len(statement),
+		statement,
+	)
+	sig, annotations := signer([]byte(pae))
+	envelope, err := json.Marshal(map[string]any{
+		"payloadType": payloadType,
+		"payload":     statement,
+		"signatures":  []map[string]any{{"sig": sig}},
+	})
+	require.NoError(t, err)
+	f.addLayer(t, digest, "att", dsseEnvelopeMediaType, envelope, annotations)
+}
+
+// addLayer adds a layer with the provided content to the cosign manifest of
+// the specified sort that pertains to the image with the provided digest.
+func (f *fakeArtifacts) addLayer(
+	t *testing.T,
+	digest string,
+	suffix string,
+	mediaType string,
+	content []byte,
+	annotations map[string]string,
+) {
+	ref := fmt.Sprintf("%s.%s", strings.Replace(digest, ":", "-", 1), suffix)
+	manifest := struct {
+		Layers []ociDescriptor `json:"layers"`
+	}{}
+	if existing, ok := f.manifests[ref]; ok {
+		require.NoError(t, json.Unmarshal(existing, &manifest))
+	}
+	layerDigest := fmt.Sprintf("sha256:%x", sha256.Sum256(content))
+	f.blobs[layerDigest] = content
+	manifest.Layers = append(
+		manifest.Layers,
+		ociDescriptor{
+			MediaType:   mediaType,
+			Digest:      layerDigest,
+			Annotations: annotations,
+		},
+	)
+	manifestBytes, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	f.manifests[ref] = manifestBytes
+}
+
+func newSimpleSigningPayload(t *testing.T, digest string) []byte {
+	payload, err := json.Marshal(map[string]any{
+		"critical": map[string]any{
+			"identity": map[string]string{"docker-reference": "example/app"},
+			"image":    map[string]string{"docker-manifest-digest": digest},
+			"type":     "cosign container image signature",
+		},
+		"optional": nil,
+	})
+	require.NoError(t, err)
+	return payload
+}
+
+type testKey struct {
+	*ecdsa.PrivateKey
+}
+
+func newTestKey(t *testing.T) testKey {
+	return newTestKeyOnCurve(t, elliptic.P256())
+}
+
+func newTestKeyOnCurve(t *testing.T, curve elliptic.Curve) testKey {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+	return testKey{key}
+}
+
+func (k testKey) publicKeyPEM(t *testing.T) string {
+	der, err := x509.MarshalPKIXPublicKey(&k.PublicKey)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// sign signs the provided data the way cosign does, i.e. using SHA-256
+// whatever the curve of the key.
+func (k testKey) sign(t *testing.T, data []byte) []byte {
+	return k.signUsingHash(t, data, crypto.SHA256)
+}
+
+func (k testKey) signUsingHash(
+	t *testing.T,
+	data []byte,
+	hash crypto.Hash,
+) []byte {
+	h := hash.New()
+	h.Write(data) // nolint: errcheck
+	sig, err := ecdsa.SignASN1(rand.Reader, k.PrivateKey, h.Sum(nil))
+	require.NoError(t, err)
+	return sig
+}
+
+func (k testKey) signer(t *testing.T) signerFn {
+	return func(data []byte) ([]byte, map[string]string) {
+		return k.sign(t, data), map[string]string{}
+	}
+}
+
+// logID returns the ID of a transparency log that signs its entries using the
+// key, which is the hex-encoded digest of the public key.
+func (k testKey) logID(t *testing.T) string {
+	der, err := x509.MarshalPKIXPublicKey(&k.PublicKey)
+	require.NoError(t, err)
+	id := sha256.Sum256(der)
+	return hex.EncodeToString(id[:])
+}
+
+type testCA struct {
+	key     testKey
+	cert    *x509.Certificate
+	certPEM string
+}
+
+func newTestCA(t *testing.T) testCA {
+	key := newTestKey(t)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-7 * 24 * time.Hour),
+		NotAfter:              time.Now().Add(7 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key.PrivateKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return testCA{
+		key:     key,
+		cert:    cert,
+		certPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+	}
+}
+
+// keylessSignature describes a keyless signature made by a keylessSigner.
+type keylessSignature struct {
+	// issuer and subject identify the signer.
+	issuer  string
+	subject string
+	// curve is the curve of the key the signature is made with. P-256 is used
+	// if it is nil.
+	curve elliptic.Curve
+	// signedAt is when the signature is made.
+	signedAt time.Time
+	// expired indicates whether the signature is recorded in the transparency
+	// log after the certificate expired.
+	expired bool
+	// entryKind is the kind of transparency log entry the signature is
+	// recorded in. A hashedrekord entry is used if it is empty.
+	entryKind string
+}
+
+// keylessSigner returns a signerFn that signs data using a short-lived
+// certificate issued by the CA to the specified identity, and that records
+// the signature in the provided transparency log.
+func (c testCA) keylessSigner(
+	t *testing.T,
+	tlog testKey,
+	signature keylessSignature,
+) signerFn {
+	return func(data []byte) ([]byte, map[string]string) {
+		curve := signature.curve
+		if curve == nil {
+			curve = elliptic.P256()
+		}
+		key := newTestKeyOnCurve(t, curve)
+		issuerExt, err := asn1.MarshalWithParams(signature.issuer, "utf8")
+		require.NoError(t, err)
+		subjectURI, err := url.Parse(signature.subject)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			NotBefore:    signature.signedAt.Add(-time.Minute),
+			NotAfter:     signature.signedAt.Add(10 * time.Minute),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+			URIs:         []*url.URL{subjectURI},
+			ExtraExtensions: []pkix.Extension{
+				{Id: fulcioIssuerV2OID, Value: issuerExt},
+			},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, c.cert, &key.PublicKey, c.key.PrivateKey)
+		require.NoError(t, err)
+		certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+		sig := key.sign(t, data)
+
+		entryKind := signature.entryKind
+		if entryKind == "" {
+			entryKind = "hashedrekord"
+		}
+		body, err := json.Marshal(map[string]any{
+			"apiVersion": "0.0.1",
+			"kind":       entryKind,
+			"spec": map[string]any{
+				"data": map[string]any{
+					"hash": map[string]string{
+						"algorithm": "sha256",
+						"value":     fmt.Sprintf("%x", sha256.Sum256(data)),
+					},
+				},
+				"signature": map[string]any{
+					"content": sig,
+					"publicKey": map[string]any{
+						"content": certPEM,
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		integratedTime := signature.signedAt
+		if signature.expired {
+			integratedTime = signature.signedAt.Add(time.Hour)
+		}
+		payload := struct {
+			Body           string `json:"body"`
+			IntegratedTime int64  `json:"integratedTime"`
+			LogID          string `json:"logID"`
+			LogIndex       int64  `json:"logIndex"`
+		}{
+			Body:           base64.StdEncoding.EncodeToString(body),
+			IntegratedTime: integratedTime.Unix(),
+			LogID:          tlog.logID(t),
+			LogIndex:       42,
+		}
+		canonical, err := json.Marshal(payload)
+		require.NoError(t, err)
+		bundle, err := json.Marshal(map[string]any{
+			"SignedEntryTimestamp": tlog.sign(t, canonical),
+			"Payload":              payload,
+		})
+		require.NoError(t, err)
+
+		return sig, map[string]string{
+			cosignCertificateAnnotationKey: string(certPEM),
+			cosignBundleAnnotationKey:      string(bundle),
+		}
+	}
+}
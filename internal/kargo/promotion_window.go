@@ -0,0 +1,114 @@
+package kargo
+
+import (
+	"fmt"
+	"time"
+	// Embed the IANA time zone database so that time zones used by
+	// PromotionWindows can be resolved regardless of whether the host provides
+	// it.
+	_ "time/tzdata"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+// IsPromotionPermitted returns a boolean indicating whether Promotions to the
+// Stage governed by the provided PromotionPolicy are permitted to proceed at
+// the specified time, given the policy's windows and blackouts. If they are
+// not, a human-readable reason is also returned. A nil PromotionPolicy permits
+// all Promotions. Windows or blackouts that cannot be evaluated are treated as
+// blocking Promotions.
+func IsPromotionPermitted(
+	policy *kargoapi.PromotionPolicy,
+	now time.Time,
+) (bool, string) {
+	if policy == nil {
+		return true, ""
+	}
+	for _, blackout := range policy.Blackouts {
+		active, err := IsPromotionWindowActive(blackout, now)
+		if err != nil {
+			return false, fmt.Sprintf(
+				"PromotionPolicy %q has an invalid blackout: %s",
+				policy.Name,
+				err,
+			)
+		}
+		if active {
+			return false, fmt.Sprintf(
+				"blocked by blackout %q (%s) of PromotionPolicy %q",
+				blackout.Schedule,
+				blackout.Duration.Duration,
+				policy.Name,
+			)
+		}
+	}
+	if len(policy.Windows) == 0 {
+		return true, ""
+	}
+	for _, window := range policy.Windows {
+		active, err := IsPromotionWindowActive(window, now)
+		if err != nil {
+			return false, fmt.Sprintf(
+				"PromotionPolicy %q has an invalid window: %s",
+				policy.Name,
+				err,
+			)
+		}
+		if active {
+			return true, ""
+		}
+	}
+	return false, fmt.Sprintf(
+		"outside of all windows of PromotionPolicy %q",
+		policy.Name,
+	)
+}
+
+// IsPromotionWindowActive returns a boolean indicating whether the specified
+// time falls within the provided PromotionWindow. i.e. It returns true if the
+// window's schedule began the window no more than the window's duration before
+// the specified time.
+func IsPromotionWindowActive(
+	window kargoapi.PromotionWindow,
+	now time.Time,
+) (bool, error) {
+	schedule, loc, err := parsePromotionWindow(window)
+	if err != nil {
+		return false, err
+	}
+	// Next returns the first activation strictly after the time it is passed,
+	// so if that is not after now, the window is currently open.
+	start := schedule.Next(now.In(loc).Add(-window.Duration.Duration))
+	return !start.IsZero() && !start.After(now), nil
+}
+
+// ValidatePromotionWindow returns an error if the provided PromotionWindow's
+// schedule, duration, or time zone are invalid.
+func ValidatePromotionWindow(window kargoapi.PromotionWindow) error {
+	if window.Duration.Duration <= 0 {
+		return errors.New("duration must be positive")
+	}
+	_, _, err := parsePromotionWindow(window)
+	return err
+}
+
+func parsePromotionWindow(
+	window kargoapi.PromotionWindow,
+) (cron.Schedule, *time.Location, error) {
+	schedule, err := cron.ParseStandard(window.Schedule)
+	if err != nil {
+		return nil, nil,
+			errors.Wrapf(err, "error parsing schedule %q", window.Schedule)
+	}
+	loc := time.UTC
+	if window.TimeZone != "" {
+		if loc, err = time.LoadLocation(window.TimeZone); err != nil {
+			return nil, nil,
+				errors.Wrapf(err, "error loading time zone %q", window.TimeZone)
+		}
+	}
+	return schedule, loc, nil
+}
@@ -0,0 +1,237 @@
+package kargo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestIsPromotionPermitted(t *testing.T) {
+	// A Friday at 23:00 UTC
+	fridayNight := time.Date(2023, time.September, 22, 23, 0, 0, 0, time.UTC)
+	// A Tuesday at 10:00 UTC
+	tuesdayMorning := time.Date(2023, time.September, 19, 10, 0, 0, 0, time.UTC)
+
+	fridayNightBlackout := kargoapi.PromotionWindow{
+		Schedule: "0 18 * * 5",
+		Duration: metav1.Duration{Duration: 60 * time.Hour},
+	}
+	businessHours := kargoapi.PromotionWindow{
+		Schedule: "0 9 * * 1-5",
+		Duration: metav1.Duration{Duration: 8 * time.Hour},
+	}
+
+	testCases := []struct {
+		name       string
+		policy     *kargoapi.PromotionPolicy
+		now        time.Time
+		assertions func(bool, string)
+	}{
+		{
+			name: "nil policy",
+			now:  fridayNight,
+			assertions: func(permitted bool, reason string) {
+				require.True(t, permitted)
+				require.Empty(t, reason)
+			},
+		},
+		{
+			name:   "no windows or blackouts",
+			policy: &kargoapi.PromotionPolicy{},
+			now:    fridayNight,
+			assertions: func(permitted bool, reason string) {
+				require.True(t, permitted)
+				require.Empty(t, reason)
+			},
+		},
+		{
+			name: "during blackout",
+			policy: &kargoapi.PromotionPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake-policy",
+				},
+				Blackouts: []kargoapi.PromotionWindow{fridayNightBlackout},
+			},
+			now: fridayNight,
+			assertions: func(permitted bool, reason string) {
+				require.False(t, permitted)
+				require.Contains(t, reason, "blocked by blackout")
+			},
+		},
+		{
+			name: "outside of blackout",
+			policy: &kargoapi.PromotionPolicy{
+				Blackouts: []kargoapi.PromotionWindow{fridayNightBlackout},
+			},
+			now: tuesdayMorning,
+			assertions: func(permitted bool, reason string) {
+				require.True(t, permitted)
+				require.Empty(t, reason)
+			},
+		},
+		{
+			name: "outside of windows",
+			policy: &kargoapi.PromotionPolicy{
+				Windows: []kargoapi.PromotionWindow{businessHours},
+			},
+			now: fridayNight,
+			assertions: func(permitted bool, reason string) {
+				require.False(t, permitted)
+				require.Contains(t, reason, "outside of all windows")
+			},
+		},
+		{
+			name: "within window",
+			policy: &kargoapi.PromotionPolicy{
+				Windows: []kargoapi.PromotionWindow{businessHours},
+			},
+			now: tuesdayMorning,
+			assertions: func(permitted bool, reason string) {
+				require.True(t, permitted)
+				require.Empty(t, reason)
+			},
+		},
+		{
+			name: "blackout takes precedence over window",
+			policy: &kargoapi.PromotionPolicy{
+				Windows: []kargoapi.PromotionWindow{
+					{
+						Schedule: "0 0 * * *",
+						Duration: metav1.Duration{Duration: 24 * time.Hour},
+					},
+				},
+				Blackouts: []kargoapi.PromotionWindow{fridayNightBlackout},
+			},
+			now: fridayNight,
+			assertions: func(permitted bool, reason string) {
+				require.False(t, permitted)
+				require.Contains(t, reason, "blocked by blackout")
+			},
+		},
+		{
+			name: "invalid window",
+			policy: &kargoapi.PromotionPolicy{
+				Windows: []kargoapi.PromotionWindow{
+					{
+						Schedule: "bogus",
+						Duration: metav1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+			now: tuesdayMorning,
+			assertions: func(permitted bool, reason string) {
+				require.False(t, permitted)
+				require.Contains(t, reason, "invalid window")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				IsPromotionPermitted(testCase.policy, testCase.now),
+			)
+		})
+	}
+}
+
+func TestIsPromotionWindowActive(t *testing.T) {
+	// 23:00 UTC is 19:00 in New York during daylight saving time
+	now := time.Date(2023, time.September, 22, 23, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name       string
+		window     kargoapi.PromotionWindow
+		assertions func(bool, error)
+	}{
+		{
+			name: "window active in UTC",
+			window: kargoapi.PromotionWindow{
+				Schedule: "0 22 * * *",
+				Duration: metav1.Duration{Duration: 2 * time.Hour},
+			},
+			assertions: func(active bool, err error) {
+				require.NoError(t, err)
+				require.True(t, active)
+			},
+		},
+		{
+			name: "window inactive in another time zone",
+			window: kargoapi.PromotionWindow{
+				Schedule: "0 22 * * *",
+				Duration: metav1.Duration{Duration: 2 * time.Hour},
+				TimeZone: "America/New_York",
+			},
+			assertions: func(active bool, err error) {
+				require.NoError(t, err)
+				require.False(t, active)
+			},
+		},
+		{
+			name: "window active in another time zone",
+			window: kargoapi.PromotionWindow{
+				Schedule: "0 18 * * *",
+				Duration: metav1.Duration{Duration: 2 * time.Hour},
+				TimeZone: "America/New_York",
+			},
+			assertions: func(active bool, err error) {
+				require.NoError(t, err)
+				require.True(t, active)
+			},
+		},
+		{
+			name: "window has ended",
+			window: kargoapi.PromotionWindow{
+				Schedule: "0 21 * * *",
+				Duration: metav1.Duration{Duration: time.Hour},
+			},
+			assertions: func(active bool, err error) {
+				require.NoError(t, err)
+				require.False(t, active)
+			},
+		},
+		{
+			name: "invalid time zone",
+			window: kargoapi.PromotionWindow{
+				Schedule: "0 22 * * *",
+				Duration: metav1.Duration{Duration: time.Hour},
+				TimeZone: "Bogus/Zone",
+			},
+			assertions: func(_ bool, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error loading time zone")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(IsPromotionWindowActive(testCase.window, now))
+		})
+	}
+}
+
+func TestValidatePromotionWindow(t *testing.T) {
+	require.NoError(
+		t,
+		ValidatePromotionWindow(kargoapi.PromotionWindow{
+			Schedule: "0 22 * * 5",
+			Duration: metav1.Duration{Duration: time.Hour},
+			TimeZone: "Europe/London",
+		}),
+	)
+	require.Error(
+		t,
+		ValidatePromotionWindow(kargoapi.PromotionWindow{
+			Schedule: "0 22 * * 5",
+		}),
+	)
+	require.Error(
+		t,
+		ValidatePromotionWindow(kargoapi.PromotionWindow{
+			Schedule: "not a schedule",
+			Duration: metav1.Duration{Duration: time.Hour},
+		}),
+	)
+}
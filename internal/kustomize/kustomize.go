@@ -7,21 +7,22 @@ import (
 	libExec "github.com/akuity/kargo/internal/exec"
 )
 
-// SetImage runs `kustomize edit set image ...` in the specified directory.
-// The specified directory must already exist and contain a kustomization.yaml
-// file.
-func SetImage(dir, repo, tag string) error {
-	_, err := libExec.Exec(buildSetImageCmd(dir, repo, tag))
+// SetImage runs `kustomize edit set image ...` in the specified directory to
+// replace the specified image repository with the specified new image
+// reference, e.g. <image name>:<tag> or <image name>@<digest>. The specified
+// directory must already exist and contain a kustomization.yaml file.
+func SetImage(dir, repo, newImage string) error {
+	_, err := libExec.Exec(buildSetImageCmd(dir, repo, newImage))
 	return err
 }
 
-func buildSetImageCmd(dir, repo, tag string) *exec.Cmd {
+func buildSetImageCmd(dir, repo, newImage string) *exec.Cmd {
 	cmd := exec.Command( // nolint: gosec
 		"kustomize",
 		"edit",
 		"set",
 		"image",
-		fmt.Sprintf("%s=%s:%s", repo, repo, tag),
+		fmt.Sprintf("%s=%s", repo, newImage),
 	)
 	cmd.Dir = dir
 	return cmd
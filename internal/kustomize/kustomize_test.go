@@ -11,8 +11,8 @@ import (
 func TestBuildSetImageCmd(t *testing.T) {
 	const testDir = "/some-dir"
 	const testImage = "some-image"
-	const testTag = "some-tag"
-	cmd := buildSetImageCmd(testDir, testImage, testTag)
+	const testNewImage = "some-image:some-tag"
+	cmd := buildSetImageCmd(testDir, testImage, testNewImage)
 	require.NotNil(t, cmd)
 	require.True(t, strings.HasSuffix(cmd.Path, "kustomize"))
 	require.Equal(
@@ -22,7 +22,7 @@ func TestBuildSetImageCmd(t *testing.T) {
 			"edit",
 			"set",
 			"image",
-			fmt.Sprintf("%s=%s:%s", testImage, testImage, testTag),
+			fmt.Sprintf("%s=%s", testImage, testNewImage),
 		},
 		cmd.Args,
 	)
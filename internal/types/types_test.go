@@ -38,3 +38,34 @@ func TestParseBool(t *testing.T) {
 		})
 	}
 }
+
+func TestParseInt(t *testing.T) {
+	t.Parallel()
+	testSets := map[string]struct {
+		Input     string
+		Expected  int
+		MustPanic bool
+	}{
+		"valid int": {
+			Input:    "4",
+			Expected: 4,
+		},
+		"invalid int": {
+			Input:     "four",
+			MustPanic: true,
+		},
+	}
+	for name, ts := range testSets {
+		ts := ts
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if ts.MustPanic {
+				require.Panics(t, func() {
+					_ = MustParseInt(ts.Input)
+				})
+			} else {
+				require.Equal(t, ts.Expected, MustParseInt(ts.Input))
+			}
+		})
+	}
+}
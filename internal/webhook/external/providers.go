@@ -0,0 +1,258 @@
+package external
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// provider is an interface for components that understand the events sent by
+// a particular sort of external system.
+type provider interface {
+	// hasCredentials returns a bool indicating whether the provided request
+	// carries anything at all by which it might be authenticated. Requests that
+	// do not are rejected without reading their bodies or looking up any
+	// secrets.
+	hasCredentials(req *http.Request) bool
+	// authenticate returns a bool indicating whether the provided request, with
+	// the provided body, is proven genuine by the provided secret.
+	authenticate(req *http.Request, body []byte, secret []byte) bool
+	// getRepos returns the repositories the event represented by the provided
+	// request and body pertains to. If the event is not of interest, nil is
+	// returned.
+	getRepos(req *http.Request, body []byte) (*repos, error)
+}
+
+// githubProvider handles push events from GitHub. Events are authenticated by
+// verifying their HMAC-SHA256 signature.
+type githubProvider struct{}
+
+func (g *githubProvider) hasCredentials(req *http.Request) bool {
+	return req.Header.Get("X-Hub-Signature-256") != ""
+}
+
+func (g *githubProvider) authenticate(
+	req *http.Request,
+	body []byte,
+	secret []byte,
+) bool {
+	sig := strings.TrimPrefix(req.Header.Get("X-Hub-Signature-256"), "sha256=")
+	return verifyHMACSHA256(body, secret, sig)
+}
+
+func (g *githubProvider) getRepos(req *http.Request, body []byte) (*repos, error) {
+	switch event := req.Header.Get("X-GitHub-Event"); event {
+	case "push":
+	case "ping":
+		return nil, nil
+	default:
+		return nil, errors.Errorf("unsupported event type %q", event)
+	}
+	payload := struct {
+		Repository struct {
+			CloneURL string `json:"clone_url"`
+			HTMLURL  string `json:"html_url"`
+			SSHURL   string `json:"ssh_url"`
+		} `json:"repository"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling event")
+	}
+	return newGitRepos(
+		payload.Repository.CloneURL,
+		payload.Repository.HTMLURL,
+		payload.Repository.SSHURL,
+	)
+}
+
+// gitlabProvider handles push and tag push events from GitLab. GitLab does not
+// sign events, so events are authenticated by comparing the secret token they
+// carry to the secret.
+type gitlabProvider struct{}
+
+func (g *gitlabProvider) hasCredentials(req *http.Request) bool {
+	return req.Header.Get("X-Gitlab-Token") != ""
+}
+
+func (g *gitlabProvider) authenticate(
+	req *http.Request,
+	_ []byte,
+	secret []byte,
+) bool {
+	return secretsEqual(req.Header.Get("X-Gitlab-Token"), secret)
+}
+
+func (g *gitlabProvider) getRepos(req *http.Request, body []byte) (*repos, error) {
+	switch event := req.Header.Get("X-Gitlab-Event"); event {
+	case "Push Hook", "Tag Push Hook":
+	default:
+		return nil, errors.Errorf("unsupported event type %q", event)
+	}
+	payload := struct {
+		Project struct {
+			GitHTTPURL string `json:"git_http_url"`
+			GitSSHURL  string `json:"git_ssh_url"`
+			WebURL     string `json:"web_url"`
+		} `json:"project"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling event")
+	}
+	return newGitRepos(
+		payload.Project.GitHTTPURL,
+		payload.Project.GitSSHURL,
+		payload.Project.WebURL,
+	)
+}
+
+// dockerHubProvider handles push events from Docker Hub. Docker Hub neither
+// signs events nor permits custom headers, so events are authenticated by
+// comparing the value of the token query parameter in the webhook's URL to the
+// secret.
+type dockerHubProvider struct{}
+
+func (d *dockerHubProvider) hasCredentials(req *http.Request) bool {
+	return req.URL.Query().Get("token") != ""
+}
+
+func (d *dockerHubProvider) authenticate(
+	req *http.Request,
+	_ []byte,
+	secret []byte,
+) bool {
+	return secretsEqual(req.URL.Query().Get("token"), secret)
+}
+
+func (d *dockerHubProvider) getRepos(_ *http.Request, body []byte) (*repos, error) {
+	payload := struct {
+		Repository struct {
+			RepoName string `json:"repo_name"`
+		} `json:"repository"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling event")
+	}
+	if payload.Repository.RepoName == "" {
+		return nil, errors.New("event does not identify a repository")
+	}
+	return newImageRepos("docker.io/" + payload.Repository.RepoName)
+}
+
+// harborProvider handles artifact push events from Harbor. Events are
+// authenticated by comparing the auth header configured for the webhook to the
+// secret.
+type harborProvider struct{}
+
+func (h *harborProvider) hasCredentials(req *http.Request) bool {
+	return req.Header.Get("Authorization") != ""
+}
+
+func (h *harborProvider) authenticate(
+	req *http.Request,
+	_ []byte,
+	secret []byte,
+) bool {
+	return authenticateAuthorizationHeader(req, secret)
+}
+
+func (h *harborProvider) getRepos(_ *http.Request, body []byte) (*repos, error) {
+	payload := struct {
+		Type      string `json:"type"`
+		EventData struct {
+			Resources []struct {
+				ResourceURL string `json:"resource_url"`
+			} `json:"resources"`
+		} `json:"event_data"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling event")
+	}
+	if payload.Type != "PUSH_ARTIFACT" {
+		return nil, nil
+	}
+	urls := make([]string, len(payload.EventData.Resources))
+	for i, resource := range payload.EventData.Resources {
+		urls[i] = resource.ResourceURL
+	}
+	return newImageRepos(urls...)
+}
+
+// ociProvider handles push notifications from OCI registries, such as the
+// CNCF Distribution registry, that send events in the Distribution
+// notification format. Events are authenticated by comparing the value of the
+// Authorization header configured for the notification endpoint to the secret.
+type ociProvider struct{}
+
+func (o *ociProvider) hasCredentials(req *http.Request) bool {
+	return req.Header.Get("Authorization") != ""
+}
+
+func (o *ociProvider) authenticate(
+	req *http.Request,
+	_ []byte,
+	secret []byte,
+) bool {
+	return authenticateAuthorizationHeader(req, secret)
+}
+
+func (o *ociProvider) getRepos(_ *http.Request, body []byte) (*repos, error) {
+	payload := struct {
+		Events []struct {
+			Action string `json:"action"`
+			Target struct {
+				Repository string `json:"repository"`
+			} `json:"target"`
+			Request struct {
+				Host string `json:"host"`
+			} `json:"request"`
+		} `json:"events"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling event")
+	}
+	var urls []string
+	for _, event := range payload.Events {
+		if event.Action != "push" || event.Target.Repository == "" {
+			continue
+		}
+		urls = append(urls, event.Request.Host+"/"+event.Target.Repository)
+	}
+	if len(urls) == 0 {
+		return nil, nil
+	}
+	return newImageRepos(urls...)
+}
+
+// verifyHMACSHA256 returns a bool indicating whether the provided hex-encoded
+// signature is the HMAC-SHA256 of the provided body using the provided secret.
+func verifyHMACSHA256(body []byte, secret []byte, sig string) bool {
+	sigBytes, err := hex.DecodeString(sig)
+	if err != nil || len(sigBytes) == 0 {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body) // nolint: errcheck
+	return hmac.Equal(mac.Sum(nil), sigBytes)
+}
+
+// authenticateAuthorizationHeader returns a bool indicating whether the value
+// of the provided request's Authorization header, with or without a Bearer
+// prefix, is the provided secret.
+func authenticateAuthorizationHeader(req *http.Request, secret []byte) bool {
+	return secretsEqual(
+		strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "),
+		secret,
+	)
+}
+
+// secretsEqual compares the provided value to the provided secret in constant
+// time.
+func secretsEqual(value string, secret []byte) bool {
+	return value != "" && subtle.ConstantTimeCompare([]byte(value), secret) == 1
+}
@@ -0,0 +1,126 @@
+package external
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v2/util/git"
+	"github.com/pkg/errors"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+// repos is a set of repositories an event pertains to. Repositories are
+// identified by normalized URLs so that different URLs for the same repository
+// are recognized as such.
+type repos struct {
+	git    map[string]struct{}
+	images map[string]struct{}
+}
+
+// newGitRepos returns repos containing the git repositories with the provided
+// URLs. Empty URLs are ignored, but at least one URL must be non-empty.
+func newGitRepos(urls ...string) (*repos, error) {
+	r := &repos{git: map[string]struct{}{}}
+	for _, url := range urls {
+		if url != "" {
+			r.git[normalizeGitURL(url)] = struct{}{}
+		}
+	}
+	if len(r.git) == 0 {
+		return nil, errors.New("event does not identify a repository")
+	}
+	return r, nil
+}
+
+// newImageRepos returns repos containing the image (or other OCI artifact)
+// repositories with the provided URLs, which may include a tag or digest.
+// Empty URLs are ignored, but at least one URL must be non-empty.
+func newImageRepos(urls ...string) (*repos, error) {
+	r := &repos{images: map[string]struct{}{}}
+	for _, url := range urls {
+		if url != "" {
+			r.images[normalizeImageRepoURL(url)] = struct{}{}
+		}
+	}
+	if len(r.images) == 0 {
+		return nil, errors.New("event does not identify a repository")
+	}
+	return r, nil
+}
+
+// subscribedToBy returns a bool indicating whether the provided Stage
+// subscribes to any of the repositories.
+func (r *repos) subscribedToBy(stage *kargoapi.Stage) bool {
+	if stage.Spec == nil || stage.Spec.Subscriptions == nil ||
+		stage.Spec.Subscriptions.Repos == nil {
+		return false
+	}
+	subs := stage.Spec.Subscriptions.Repos
+	for _, sub := range subs.Git {
+		if _, ok := r.git[normalizeGitURL(sub.RepoURL)]; ok {
+			return true
+		}
+	}
+	for _, sub := range subs.Images {
+		if _, ok := r.images[normalizeImageRepoURL(sub.RepoURL)]; ok {
+			return true
+		}
+	}
+	for _, sub := range subs.Charts {
+		// Only charts in OCI registries are pushed the same way images are
+		if !strings.HasPrefix(sub.RegistryURL, "oci://") {
+			continue
+		}
+		chartRepoURL := strings.TrimSuffix(sub.RegistryURL, "/") + "/" + sub.Name
+		if _, ok := r.images[normalizeImageRepoURL(chartRepoURL)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+var gitURLPrefixRegex = regexp.MustCompile(`^([a-z+]+://)?([^@/]+@)?`)
+
+// normalizeGitURL normalizes the provided git repository URL such that HTTPS
+// and SSH URLs for the same repository are equal.
+func normalizeGitURL(url string) string {
+	if normalized := git.NormalizeGitURL(url); normalized != "" {
+		url = normalized
+	}
+	// NormalizeGitURL retains the scheme and the user, but neither identifies
+	// the repository
+	return gitURLPrefixRegex.ReplaceAllString(strings.ToLower(url), "")
+}
+
+// normalizeImageRepoURL normalizes the provided image repository URL, which
+// may include a tag or digest, such that URLs that are equivalent to Docker,
+// e.g. nginx and docker.io/library/nginx:latest, are equal.
+func normalizeImageRepoURL(url string) string {
+	url = strings.ToLower(url)
+	for _, prefix := range []string{"oci://", "https://", "http://"} {
+		url = strings.TrimPrefix(url, prefix)
+	}
+	if i := strings.Index(url, "@"); i >= 0 {
+		url = url[:i]
+	}
+	if i := strings.LastIndex(url, ":"); i > strings.LastIndex(url, "/") {
+		url = url[:i]
+	}
+	url = strings.TrimSuffix(url, "/")
+	parts := strings.SplitN(url, "/", 2)
+	// The first part of the URL only identifies a registry if it looks like a
+	// host name
+	if len(parts) == 1 ||
+		(!strings.ContainsAny(parts[0], ".:") && parts[0] != "localhost") {
+		parts = []string{"docker.io", url}
+	}
+	switch parts[0] {
+	case "index.docker.io", "registry-1.docker.io":
+		parts[0] = "docker.io"
+	}
+	if parts[0] == "docker.io" && !strings.Contains(parts[1], "/") {
+		parts[1] = "library/" + parts[1]
+	}
+	return parts[0] + "/" + parts[1]
+}
@@ -0,0 +1,77 @@
+package external
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeGitURL(t *testing.T) {
+	testCases := []struct {
+		url      string
+		expected string
+	}{
+		{
+			url:      "https://github.com/example/repo",
+			expected: "github.com/example/repo",
+		},
+		{
+			url:      "https://GitHub.com/example/repo.git",
+			expected: "github.com/example/repo",
+		},
+		{
+			url:      "git@github.com:example/repo.git",
+			expected: "github.com/example/repo",
+		},
+		{
+			url:      "ssh://git@github.com/example/repo",
+			expected: "github.com/example/repo",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.url, func(t *testing.T) {
+			require.Equal(t, testCase.expected, normalizeGitURL(testCase.url))
+		})
+	}
+}
+
+func TestNormalizeImageRepoURL(t *testing.T) {
+	testCases := []struct {
+		url      string
+		expected string
+	}{
+		{
+			url:      "nginx",
+			expected: "docker.io/library/nginx",
+		},
+		{
+			url:      "docker.io/library/nginx:latest",
+			expected: "docker.io/library/nginx",
+		},
+		{
+			url:      "index.docker.io/nginx",
+			expected: "docker.io/library/nginx",
+		},
+		{
+			url:      "example/app@sha256:abc",
+			expected: "docker.io/example/app",
+		},
+		{
+			url:      "localhost:5000/app:v1.0.0",
+			expected: "localhost:5000/app",
+		},
+		{
+			url:      "oci://ghcr.io/Example/charts/my-chart",
+			expected: "ghcr.io/example/charts/my-chart",
+		},
+		{
+			url:      "https://harbor.example.com/project/app:1.0.0",
+			expected: "harbor.example.com/project/app",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.url, func(t *testing.T) {
+			require.Equal(t, testCase.expected, normalizeImageRepoURL(testCase.url))
+		})
+	}
+}
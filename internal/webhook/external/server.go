@@ -0,0 +1,382 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+const (
+	// secretTypeLabelKey is the key of the label that identifies the sort of
+	// material a Secret holds.
+	secretTypeLabelKey = "kargo.akuity.io/secret-type" // nolint: gosec
+	// secretTypeWebhook is the value of the secretTypeLabelKey label that
+	// identifies Secrets holding a project's webhook secret.
+	secretTypeWebhook = "webhook"
+	// secretKey is the key within a webhook Secret's data of the secret used to
+	// authenticate events.
+	secretKey = "secret"
+
+	// maxBodyBytes is the maximum size of an event that is accepted.
+	maxBodyBytes = 5 << 20
+)
+
+// errTooManySecretLookups is returned when a project's webhook secrets cannot
+// be looked up because they have been looked up too often lately.
+var errTooManySecretLookups = errors.New("too many webhook secret lookups")
+
+// ServerConfig represents configuration for the external webhooks server.
+type ServerConfig struct {
+	GracefulShutdownTimeout time.Duration `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT" default:"30s"`
+	// SecretCacheTTL specifies how long a project's webhook secrets are reused
+	// before they are looked up again. Zero disables caching.
+	SecretCacheTTL time.Duration `envconfig:"SECRET_CACHE_TTL" default:"30s"`
+	// SecretLookupsPerSecond specifies the maximum sustained rate at which
+	// webhook secrets not already cached are looked up. Events that would
+	// exceed it are rejected. Zero disables rate limiting.
+	SecretLookupsPerSecond float64 `envconfig:"SECRET_LOOKUPS_PER_SECOND" default:"10"`
+	// SecretLookupsBurst specifies the number of lookups that may be made in
+	// excess of SecretLookupsPerSecond in a burst.
+	SecretLookupsBurst int `envconfig:"SECRET_LOOKUPS_BURST" default:"20"`
+}
+
+// ServerConfigFromEnv returns a ServerConfig populated from environment
+// variables.
+func ServerConfigFromEnv() ServerConfig {
+	cfg := ServerConfig{}
+	envconfig.MustProcess("", &cfg)
+	return cfg
+}
+
+// Server is an HTTP server that receives events, such as pushes to git
+// repositories or images being pushed to image repositories, from external
+// systems and requests a refresh of every Stage subscribing to a repository an
+// event pertains to. This permits new Freight to be discovered as soon as it
+// exists instead of only when Stages are next reconciled.
+type Server interface {
+	// Serve serves requests received via the provided listener until the
+	// provided context is canceled.
+	Serve(ctx context.Context, l net.Listener) error
+}
+
+type server struct {
+	cfg       ServerConfig
+	client    client.Client
+	providers map[string]provider
+
+	mu      sync.Mutex
+	secrets map[string]cachedSecrets
+	limiter *rate.Limiter
+
+	// nowFn is overridable for testing purposes
+	nowFn func() time.Time
+}
+
+// cachedSecrets are the webhook secrets of a single project.
+type cachedSecrets struct {
+	keys      [][]byte
+	expiresAt time.Time
+}
+
+// NewServer returns a Server that uses the provided client to find webhook
+// Secrets and Stages and to request Stage refreshes.
+func NewServer(cfg ServerConfig, client client.Client) Server {
+	limiter := rate.NewLimiter(rate.Inf, 0)
+	if cfg.SecretLookupsPerSecond > 0 {
+		burst := cfg.SecretLookupsBurst
+		if burst < 1 {
+			burst = 1
+		}
+		limiter = rate.NewLimiter(rate.Limit(cfg.SecretLookupsPerSecond), burst)
+	}
+	return &server{
+		cfg:     cfg,
+		client:  client,
+		secrets: map[string]cachedSecrets{},
+		limiter: limiter,
+		nowFn:   time.Now,
+		providers: map[string]provider{
+			"github":    &githubProvider{},
+			"gitlab":    &gitlabProvider{},
+			"dockerhub": &dockerHubProvider{},
+			"harbor":    &harborProvider{},
+			"oci":       &ociProvider{},
+		},
+	}
+}
+
+func (s *server) Serve(ctx context.Context, l net.Listener) error {
+	log := logging.LoggerFromContext(ctx)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.Handle("/", s)
+
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: time.Minute,
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+	}
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- srv.Serve(l)
+	}()
+
+	log.Infof("Server is listening on %q", l.Addr().String())
+
+	select {
+	case <-ctx.Done():
+		log.Info("Gracefully stopping server...")
+		time.Sleep(s.cfg.GracefulShutdownTimeout)
+		return srv.Shutdown(context.Background())
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	}
+}
+
+// ServeHTTP handles an event sent to a path of the form
+// /<provider>/<project>. The event is authenticated using the project's
+// webhook Secrets, after which every Stage in the project that subscribes to
+// a repository the event pertains to is refreshed.
+func (s *server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	pathParts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(pathParts) != 2 || pathParts[1] == "" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	providerName, project := pathParts[0], pathParts[1]
+	p, ok := s.providers[providerName]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	logger := logging.LoggerFromContext(ctx).WithFields(logrus.Fields{
+		"provider": providerName,
+		"project":  project,
+	})
+
+	if !p.hasCredentials(req) {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxBodyBytes+1))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "error reading request body")
+		return
+	}
+	if len(body) > maxBodyBytes {
+		writeError(w, http.StatusRequestEntityTooLarge, "request body too large")
+		return
+	}
+
+	authenticated, err := s.authenticate(ctx, p, req, body, project)
+	if errors.Is(err, errTooManySecretLookups) {
+		writeError(w, http.StatusTooManyRequests, "too many requests")
+		return
+	}
+	if err != nil {
+		logger.Error(err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if !authenticated {
+		// No distinction is made between a project that does not exist and a
+		// request that could not be authenticated so as not to reveal which
+		// projects exist.
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	repos, err := p.getRepos(req, body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if repos == nil {
+		// The event is authentic but of no interest, e.g. a ping
+		writeJSON(w, http.StatusOK, refreshResponse{RefreshedStages: []string{}})
+		return
+	}
+
+	refreshed, err := s.refreshStages(ctx, project, repos)
+	if err != nil {
+		logger.Error(err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	logger.WithField("stages", refreshed).Debug("refreshed Stages")
+	writeJSON(w, http.StatusOK, refreshResponse{RefreshedStages: refreshed})
+}
+
+// authenticate returns a bool indicating whether the provided request, with
+// the provided body, is proven genuine by any of the specified project's
+// webhook secrets.
+func (s *server) authenticate(
+	ctx context.Context,
+	p provider,
+	req *http.Request,
+	body []byte,
+	project string,
+) (bool, error) {
+	keys, err := s.getSecrets(ctx, project)
+	if err != nil {
+		return false, err
+	}
+	for _, key := range keys {
+		if p.authenticate(req, body, key) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getSecrets returns the webhook secrets of the specified project. Secrets are
+// reused for a while after they are looked up, and lookups are rate limited,
+// so that a flood of events, authentic or not, does not become a flood of
+// requests to the Kubernetes API server. A project without any webhook
+// secrets, including one that does not exist, is cached just the same.
+func (s *server) getSecrets(
+	ctx context.Context,
+	project string,
+) ([][]byte, error) {
+	now := s.nowFn()
+	s.mu.Lock()
+	cached, ok := s.secrets[project]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.keys, nil
+	}
+	if !s.limiter.Allow() {
+		return nil, errTooManySecretLookups
+	}
+	secrets := corev1.SecretList{}
+	if err := s.client.List(
+		ctx,
+		&secrets,
+		client.InNamespace(project),
+		client.MatchingLabels{secretTypeLabelKey: secretTypeWebhook},
+	); err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"error listing webhook Secrets in namespace %q",
+			project,
+		)
+	}
+	keys := make([][]byte, 0, len(secrets.Items))
+	for _, secret := range secrets.Items {
+		// Surrounding whitespace, e.g. a trailing newline, is easily included in
+		// a Secret by accident, so it is disregarded
+		if key := bytes.TrimSpace(secret.Data[secretKey]); len(key) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	if s.cfg.SecretCacheTTL > 0 {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		// Expired entries are pruned as new ones are added so that events for
+		// any number of projects cannot grow the cache without bound
+		for k, v := range s.secrets {
+			if !now.Before(v.expiresAt) {
+				delete(s.secrets, k)
+			}
+		}
+		s.secrets[project] = cachedSecrets{
+			keys:      keys,
+			expiresAt: now.Add(s.cfg.SecretCacheTTL),
+		}
+	}
+	return keys, nil
+}
+
+// refreshStages requests a refresh of every Stage in the specified project
+// that subscribes to any of the provided repositories and returns the names of
+// those Stages.
+func (s *server) refreshStages(
+	ctx context.Context,
+	project string,
+	repos *repos,
+) ([]string, error) {
+	stages := kargoapi.StageList{}
+	if err := s.client.List(
+		ctx,
+		&stages,
+		client.InNamespace(project),
+	); err != nil {
+		return nil, errors.Wrapf(err, "error listing Stages in namespace %q", project)
+	}
+	refreshed := []string{}
+	for _, stage := range stages.Items {
+		if !repos.subscribedToBy(&stage) {
+			continue
+		}
+		if _, err := kargoapi.RefreshStage(
+			ctx,
+			s.client,
+			types.NamespacedName{
+				Namespace: stage.Namespace,
+				Name:      stage.Name,
+			},
+		); err != nil {
+			return nil, errors.Wrapf(
+				err,
+				"error refreshing Stage %q in namespace %q",
+				stage.Name,
+				stage.Namespace,
+			)
+		}
+		refreshed = append(refreshed, stage.Name)
+	}
+	return refreshed, nil
+}
+
+// refreshResponse is the body of the response to a successfully handled event.
+type refreshResponse struct {
+	RefreshedStages []string `json:"refreshedStages"`
+}
+
+// errorResponse is the body of the response to an event that could not be
+// handled.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, statusCode int, msg string) {
+	writeJSON(w, statusCode, errorResponse{Error: msg})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,371 @@
+package external
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestServeHTTP(t *testing.T) {
+	const testProject = "fake-project"
+	const testSecret = "fake-secret"
+
+	githubSignature := func(body string) string {
+		mac := hmac.New(sha256.New, []byte(testSecret))
+		mac.Write([]byte(body)) // nolint: errcheck
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	githubPushEvent := `{"repository":{` +
+		`"clone_url":"https://github.com/example/repo.git",` +
+		`"ssh_url":"git@github.com:example/repo.git"}}`
+
+	testCases := []struct {
+		name              string
+		method            string
+		path              string
+		headers           map[string]string
+		body              string
+		expectedCode      int
+		expectedRefreshed []string
+	}{
+		{
+			name:         "wrong method",
+			method:       http.MethodGet,
+			path:         "/github/" + testProject,
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "unknown provider",
+			path:         "/bogus/" + testProject,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "no project",
+			path:         "/github",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "github signature missing",
+			path: "/github/" + testProject,
+			headers: map[string]string{
+				"X-GitHub-Event": "push",
+			},
+			body:         githubPushEvent,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "github signature invalid",
+			path: "/github/" + testProject,
+			headers: map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": githubSignature("something else"),
+			},
+			body:         githubPushEvent,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "project without webhook secret",
+			path: "/github/other-project",
+			headers: map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": githubSignature(githubPushEvent),
+			},
+			body:         githubPushEvent,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "github ping",
+			path: "/github/" + testProject,
+			headers: map[string]string{
+				"X-GitHub-Event":      "ping",
+				"X-Hub-Signature-256": githubSignature("{}"),
+			},
+			body:              "{}",
+			expectedCode:      http.StatusOK,
+			expectedRefreshed: []string{},
+		},
+		{
+			name: "github unsupported event",
+			path: "/github/" + testProject,
+			headers: map[string]string{
+				"X-GitHub-Event":      "issues",
+				"X-Hub-Signature-256": githubSignature("{}"),
+			},
+			body:         "{}",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "github push",
+			path: "/github/" + testProject,
+			headers: map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": githubSignature(githubPushEvent),
+			},
+			body:              githubPushEvent,
+			expectedCode:      http.StatusOK,
+			expectedRefreshed: []string{"git-stage"},
+		},
+		{
+			name: "gitlab token invalid",
+			path: "/gitlab/" + testProject,
+			headers: map[string]string{
+				"X-Gitlab-Event": "Push Hook",
+				"X-Gitlab-Token": "bogus",
+			},
+			body:         `{}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "gitlab push",
+			path: "/gitlab/" + testProject,
+			headers: map[string]string{
+				"X-Gitlab-Event": "Push Hook",
+				"X-Gitlab-Token": testSecret,
+			},
+			body: `{"project":{` +
+				`"git_http_url":"https://github.com/example/repo.git"}}`,
+			expectedCode:      http.StatusOK,
+			expectedRefreshed: []string{"git-stage"},
+		},
+		{
+			name:         "dockerhub token missing",
+			path:         "/dockerhub/" + testProject,
+			body:         `{"repository":{"repo_name":"library/nginx"}}`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:              "dockerhub push",
+			path:              "/dockerhub/" + testProject + "?token=" + testSecret,
+			body:              `{"repository":{"repo_name":"library/nginx"}}`,
+			expectedCode:      http.StatusOK,
+			expectedRefreshed: []string{"image-stage"},
+		},
+		{
+			name: "harbor push",
+			path: "/harbor/" + testProject,
+			headers: map[string]string{
+				"Authorization": "Bearer " + testSecret,
+			},
+			body: `{"type":"PUSH_ARTIFACT","event_data":{"resources":[` +
+				`{"resource_url":"harbor.example.com/charts/my-chart:1.0.0"}]}}`,
+			expectedCode:      http.StatusOK,
+			expectedRefreshed: []string{"chart-stage"},
+		},
+		{
+			name: "oci push",
+			path: "/oci/" + testProject,
+			headers: map[string]string{
+				"Authorization": testSecret,
+			},
+			body: `{"events":[{"action":"push",` +
+				`"target":{"repository":"nginx"},` +
+				`"request":{"host":"index.docker.io"}}]}`,
+			expectedCode:      http.StatusOK,
+			expectedRefreshed: []string{"image-stage"},
+		},
+		{
+			name: "oci pull",
+			path: "/oci/" + testProject,
+			headers: map[string]string{
+				"Authorization": testSecret,
+			},
+			body: `{"events":[{"action":"pull",` +
+				`"target":{"repository":"nginx"},` +
+				`"request":{"host":"index.docker.io"}}]}`,
+			expectedCode:      http.StatusOK,
+			expectedRefreshed: []string{},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, corev1.AddToScheme(scheme))
+			require.NoError(t, kargoapi.AddToScheme(scheme))
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testProject,
+						Name:      "webhook",
+						Labels: map[string]string{
+							secretTypeLabelKey: secretTypeWebhook,
+						},
+					},
+					Data: map[string][]byte{
+						secretKey: []byte(testSecret + "\n"),
+					},
+				},
+				newTestStage(testProject, "git-stage", &kargoapi.RepoSubscriptions{
+					Git: []kargoapi.GitSubscription{{
+						RepoURL: "git@github.com:example/repo",
+					}},
+				}),
+				newTestStage(testProject, "image-stage", &kargoapi.RepoSubscriptions{
+					Images: []kargoapi.ImageSubscription{{
+						RepoURL: "nginx",
+					}},
+				}),
+				newTestStage(testProject, "chart-stage", &kargoapi.RepoSubscriptions{
+					Charts: []kargoapi.ChartSubscription{{
+						RegistryURL: "oci://harbor.example.com/charts",
+						Name:        "my-chart",
+					}},
+				}),
+				newTestStage(testProject, "promotion-stage", nil),
+			).Build()
+
+			method := testCase.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req := httptest.NewRequest(
+				method,
+				testCase.path,
+				strings.NewReader(testCase.body),
+			)
+			for k, v := range testCase.headers {
+				req.Header.Set(k, v)
+			}
+			rr := httptest.NewRecorder()
+
+			NewServer(ServerConfig{}, client).(*server).ServeHTTP(rr, req)
+
+			require.Equal(t, testCase.expectedCode, rr.Code)
+			if testCase.expectedCode != http.StatusOK {
+				return
+			}
+			res := refreshResponse{}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+			require.Equal(t, testCase.expectedRefreshed, res.RefreshedStages)
+			for _, name := range testCase.expectedRefreshed {
+				stage := kargoapi.Stage{}
+				require.NoError(
+					t,
+					client.Get(
+						context.Background(),
+						types.NamespacedName{Namespace: testProject, Name: name},
+						&stage,
+					),
+				)
+				require.Contains(t, stage.Annotations, kargoapi.AnnotationKeyRefresh)
+			}
+		})
+	}
+}
+
+func TestServeHTTPWithoutCredentials(t *testing.T) {
+	srv := NewServer(ServerConfig{}, nil).(*server)
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/github/fake-project",
+		iotest.ErrReader(errors.New("body should not have been read")),
+	)
+	req.Header.Set("X-GitHub-Event", "push")
+	rr := httptest.NewRecorder()
+	// The server has no client, so looking up secrets would panic
+	srv.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestGetSecrets(t *testing.T) {
+	const testProject = "fake-project"
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testProject,
+			Name:      "webhook",
+			Labels: map[string]string{
+				secretTypeLabelKey: secretTypeWebhook,
+			},
+		},
+		Data: map[string][]byte{
+			secretKey: []byte("fake-secret"),
+		},
+	}
+
+	t.Run("secrets are cached", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(secret.DeepCopy()).Build()
+		srv := NewServer(
+			ServerConfig{SecretCacheTTL: time.Minute},
+			client,
+		).(*server)
+		now := time.Now()
+		srv.nowFn = func() time.Time { return now }
+		keys, err := srv.getSecrets(context.Background(), testProject)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("fake-secret")}, keys)
+		require.NoError(t, client.Delete(context.Background(), secret.DeepCopy()))
+		// The deleted Secret is still known
+		keys, err = srv.getSecrets(context.Background(), testProject)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("fake-secret")}, keys)
+		// Until the cached secrets expire
+		now = now.Add(time.Minute)
+		keys, err = srv.getSecrets(context.Background(), testProject)
+		require.NoError(t, err)
+		require.Empty(t, keys)
+	})
+
+	t.Run("lookups are rate limited", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(secret.DeepCopy()).Build()
+		srv := NewServer(
+			ServerConfig{
+				SecretCacheTTL:         time.Minute,
+				SecretLookupsPerSecond: 0.001,
+				SecretLookupsBurst:     1,
+			},
+			client,
+		).(*server)
+		_, err := srv.getSecrets(context.Background(), testProject)
+		require.NoError(t, err)
+		// Cached secrets are not subject to the limit
+		_, err = srv.getSecrets(context.Background(), testProject)
+		require.NoError(t, err)
+		_, err = srv.getSecrets(context.Background(), "other-fake-project")
+		require.ErrorIs(t, err, errTooManySecretLookups)
+	})
+}
+
+func newTestStage(
+	namespace string,
+	name string,
+	repos *kargoapi.RepoSubscriptions,
+) *kargoapi.Stage {
+	stage := &kargoapi.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: &kargoapi.StageSpec{
+			Subscriptions: &kargoapi.Subscriptions{},
+		},
+	}
+	if repos == nil {
+		stage.Spec.Subscriptions.UpstreamStages = []kargoapi.StageSubscription{{
+			Name: "upstream",
+		}}
+	} else {
+		stage.Spec.Subscriptions.Repos = repos
+	}
+	return stage
+}
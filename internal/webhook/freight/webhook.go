@@ -0,0 +1,185 @@
+package freight
+
+import (
+	"context"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/pkg/errors"
+	authzv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+var freightGroupResource = schema.GroupResource{
+	Group:    kargoapi.GroupVersion.Group,
+	Resource: "Freight",
+}
+
+// WebhookConfig represents configuration for the Freight webhook.
+type WebhookConfig struct {
+	// KargoAPIUsername is the username with which Kargo's API server
+	// authenticates to Kubernetes. The API server authorizes its own callers
+	// before approving Freight on their behalf, so approvals made by that user
+	// are not subject to further authorization.
+	KargoAPIUsername string `envconfig:"KARGO_API_USERNAME"`
+}
+
+// WebhookConfigFromEnv returns a WebhookConfig populated from environment
+// variables.
+func WebhookConfigFromEnv() WebhookConfig {
+	cfg := WebhookConfig{}
+	envconfig.MustProcess("", &cfg)
+	return cfg
+}
+
+type webhook struct {
+	config WebhookConfig
+	client client.Client
+
+	// The following behaviors are overridable for testing purposes:
+
+	authorizeFn func(
+		ctx context.Context,
+		freight *kargoapi.Freight,
+		stageName string,
+		action string,
+	) error
+
+	admissionRequestFromContextFn func(context.Context) (admission.Request, error)
+
+	createSubjectAccessReviewFn func(
+		context.Context,
+		client.Object,
+		...client.CreateOption,
+	) error
+}
+
+func SetupWebhookWithManager(cfg WebhookConfig, mgr ctrl.Manager) error {
+	w := &webhook{
+		config: cfg,
+		client: mgr.GetClient(),
+	}
+	w.authorizeFn = w.authorize
+	w.admissionRequestFromContextFn = admission.RequestFromContext
+	w.createSubjectAccessReviewFn = w.client.Create
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&kargoapi.Freight{}).
+		WithValidator(w).
+		Complete()
+}
+
+func (w *webhook) ValidateCreate(context.Context, runtime.Object) error {
+	return nil
+}
+
+func (w *webhook) ValidateUpdate(
+	ctx context.Context,
+	oldObj runtime.Object,
+	newObj runtime.Object,
+) error {
+	oldFreight := oldObj.(*kargoapi.Freight) // nolint: forcetypeassert
+	newFreight := newObj.(*kargoapi.Freight) // nolint: forcetypeassert
+	// Any approval that was added or modified must have been made by a subject
+	// that is permitted to approve Freight for the corresponding Stage.
+	for stageName, approval := range newFreight.Status.ApprovedFor {
+		if oldApproval, ok := oldFreight.Status.ApprovedFor[stageName]; ok &&
+			oldApproval.ApprovedBy == approval.ApprovedBy &&
+			oldApproval.ApprovedAt.Equal(approval.ApprovedAt) {
+			continue
+		}
+		if err := w.authorizeFn(ctx, newFreight, stageName, "approve"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *webhook) ValidateDelete(context.Context, runtime.Object) error {
+	return nil
+}
+
+func (w *webhook) authorize(
+	ctx context.Context,
+	freight *kargoapi.Freight,
+	stageName string,
+	action string,
+) error {
+	logger := logging.LoggerFromContext(ctx)
+
+	req, err := w.admissionRequestFromContextFn(ctx)
+	if err != nil {
+		logger.Error(err)
+		return apierrors.NewForbidden(
+			freightGroupResource,
+			freight.Name,
+			errors.Errorf(
+				"error retrieving admission request from context; refusing to "+
+					"%s Freight",
+				action,
+			),
+		)
+	}
+
+	if w.config.KargoAPIUsername != "" &&
+		req.UserInfo.Username == w.config.KargoAPIUsername {
+		// The API server has already authorized the end user on whose behalf it
+		// is acting. Its own identity is deliberately not granted the ability to
+		// approve Freight, so reviewing it here would only ever fail.
+		return nil
+	}
+
+	// The review is of the subject that made the request, with all the same
+	// attributes Kubernetes itself would consider when authorizing it.
+	extra := make(map[string]authzv1.ExtraValue, len(req.UserInfo.Extra))
+	for k, v := range req.UserInfo.Extra {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	accessReview := &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			User:   req.UserInfo.Username,
+			Groups: req.UserInfo.Groups,
+			UID:    req.UserInfo.UID,
+			Extra:  extra,
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:     kargoapi.GroupVersion.Group,
+				Resource:  "stages",
+				Name:      stageName,
+				Verb:      "approve",
+				Namespace: freight.Namespace,
+			},
+		},
+	}
+	if err := w.createSubjectAccessReviewFn(ctx, accessReview); err != nil {
+		logger.Error(err)
+		return apierrors.NewForbidden(
+			freightGroupResource,
+			freight.Name,
+			errors.Errorf(
+				"error creating SubjectAccessReview; refusing to %s Freight",
+				action,
+			),
+		)
+	}
+
+	if !accessReview.Status.Allowed {
+		return apierrors.NewForbidden(
+			freightGroupResource,
+			freight.Name,
+			errors.Errorf(
+				"subject %q is not permitted to %s Freight for Stage %q",
+				req.UserInfo.Username,
+				action,
+				stageName,
+			),
+		)
+	}
+
+	return nil
+}
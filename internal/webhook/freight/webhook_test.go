@@ -0,0 +1,324 @@
+package freight
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestValidateUpdate(t *testing.T) {
+	approvedAt := metav1.NewTime(time.Now().Truncate(time.Second))
+	oldFreight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-freight",
+			Namespace: "fake-namespace",
+		},
+		Status: kargoapi.FreightStatus{
+			ApprovedFor: map[string]kargoapi.ApprovedStage{
+				"fake-stage": {
+					ApprovedAt: &approvedAt,
+					ApprovedBy: "fake-user",
+				},
+			},
+		},
+	}
+	testCases := []struct {
+		name        string
+		newFreight  func() *kargoapi.Freight
+		authorizeFn func(
+			ctx context.Context,
+			freight *kargoapi.Freight,
+			stageName string,
+			action string,
+		) error
+		assertions func(error)
+	}{
+		{
+			name: "no new approvals",
+			newFreight: func() *kargoapi.Freight {
+				newFreight := oldFreight.DeepCopy()
+				newFreight.Status.VerifiedIn = map[string]kargoapi.VerifiedStage{
+					"another-fake-stage": {},
+				}
+				return newFreight
+			},
+			authorizeFn: func(context.Context, *kargoapi.Freight, string, string) error {
+				return errors.New("authorization should not have been attempted")
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+
+		{
+			name: "new approval not authorized",
+			newFreight: func() *kargoapi.Freight {
+				newFreight := oldFreight.DeepCopy()
+				newFreight.Status.ApprovedFor["another-fake-stage"] =
+					kargoapi.ApprovedStage{}
+				return newFreight
+			},
+			authorizeFn: func(
+				_ context.Context,
+				_ *kargoapi.Freight,
+				stageName string,
+				action string,
+			) error {
+				require.Equal(t, "another-fake-stage", stageName)
+				require.Equal(t, "approve", action)
+				return errors.New("something went wrong")
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Equal(t, "something went wrong", err.Error())
+			},
+		},
+
+		{
+			name: "modified approval not authorized",
+			newFreight: func() *kargoapi.Freight {
+				newFreight := oldFreight.DeepCopy()
+				newFreight.Status.ApprovedFor["fake-stage"] = kargoapi.ApprovedStage{
+					ApprovedAt: &approvedAt,
+					ApprovedBy: "another-fake-user",
+				}
+				return newFreight
+			},
+			authorizeFn: func(context.Context, *kargoapi.Freight, string, string) error {
+				return errors.New("something went wrong")
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Equal(t, "something went wrong", err.Error())
+			},
+		},
+
+		{
+			name: "new approval authorized",
+			newFreight: func() *kargoapi.Freight {
+				newFreight := oldFreight.DeepCopy()
+				newFreight.Status.ApprovedFor["another-fake-stage"] =
+					kargoapi.ApprovedStage{}
+				return newFreight
+			},
+			authorizeFn: func(context.Context, *kargoapi.Freight, string, string) error {
+				return nil
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			w := &webhook{
+				authorizeFn: testCase.authorizeFn,
+			}
+			testCase.assertions(
+				w.ValidateUpdate(
+					context.Background(),
+					oldFreight,
+					testCase.newFreight(),
+				),
+			)
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	const testAPIUsername = "system:serviceaccount:kargo:kargo-api"
+	testCases := []struct {
+		name                          string
+		config                        WebhookConfig
+		admissionRequestFromContextFn func(context.Context) (admission.Request, error)
+		createSubjectAccessReviewFn   func(
+			context.Context,
+			client.Object,
+			...client.CreateOption,
+		) error
+		assertions func(err error)
+	}{
+		{
+			name: "error getting admission request bound to context",
+			admissionRequestFromContextFn: func(
+				context.Context,
+			) (admission.Request, error) {
+				return admission.Request{}, errors.New("something went wrong")
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(
+					t,
+					err.Error(),
+					"error retrieving admission request from context; refusing to",
+				)
+			},
+		},
+		{
+			name: "error creating subject access review",
+			admissionRequestFromContextFn: func(
+				context.Context,
+			) (admission.Request, error) {
+				return admission.Request{}, nil
+			},
+			createSubjectAccessReviewFn: func(
+				context.Context,
+				client.Object,
+				...client.CreateOption,
+			) error {
+				return errors.New("something went wrong")
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error creating SubjectAccessReview")
+			},
+		},
+		{
+			name: "subject is not authorized",
+			admissionRequestFromContextFn: func(
+				context.Context,
+			) (admission.Request, error) {
+				return admission.Request{}, nil
+			},
+			createSubjectAccessReviewFn: func(
+				_ context.Context,
+				obj client.Object,
+				_ ...client.CreateOption,
+			) error {
+				obj.(*authzv1.SubjectAccessReview).Status.Allowed = false // nolint: forcetypeassert
+				return nil
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "is not permitted")
+			},
+		},
+		{
+			name:   "request made by Kargo API server",
+			config: WebhookConfig{KargoAPIUsername: testAPIUsername},
+			admissionRequestFromContextFn: func(
+				context.Context,
+			) (admission.Request, error) {
+				return admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						UserInfo: authnv1.UserInfo{Username: testAPIUsername},
+					},
+				}, nil
+			},
+			createSubjectAccessReviewFn: func(
+				context.Context,
+				client.Object,
+				...client.CreateOption,
+			) error {
+				return errors.New("access review should not have been attempted")
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:   "unauthorized end user is denied",
+			config: WebhookConfig{KargoAPIUsername: testAPIUsername},
+			admissionRequestFromContextFn: func(
+				context.Context,
+			) (admission.Request, error) {
+				return admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						UserInfo: authnv1.UserInfo{
+							Username: "fake-user",
+							UID:      "fake-uid",
+							Groups:   []string{"fake-group"},
+							Extra: map[string]authnv1.ExtraValue{
+								"fake-key": {"fake-value"},
+							},
+						},
+					},
+				}, nil
+			},
+			createSubjectAccessReviewFn: func(
+				_ context.Context,
+				obj client.Object,
+				_ ...client.CreateOption,
+			) error {
+				accessReview := obj.(*authzv1.SubjectAccessReview) // nolint: forcetypeassert
+				// The review must be of the end user and not of anyone acting on
+				// their behalf.
+				require.Equal(t, "fake-user", accessReview.Spec.User)
+				require.Equal(t, "fake-uid", accessReview.Spec.UID)
+				require.Equal(t, []string{"fake-group"}, accessReview.Spec.Groups)
+				require.Equal(
+					t,
+					map[string]authzv1.ExtraValue{"fake-key": {"fake-value"}},
+					accessReview.Spec.Extra,
+				)
+				accessReview.Status.Allowed = false
+				return nil
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.True(t, apierrors.IsForbidden(err))
+				require.Contains(
+					t,
+					err.Error(),
+					`subject "fake-user" is not permitted to approve Freight`,
+				)
+			},
+		},
+		{
+			name: "subject is authorized",
+			admissionRequestFromContextFn: func(
+				context.Context,
+			) (admission.Request, error) {
+				return admission.Request{}, nil
+			},
+			createSubjectAccessReviewFn: func(
+				_ context.Context,
+				obj client.Object,
+				_ ...client.CreateOption,
+			) error {
+				accessReview := obj.(*authzv1.SubjectAccessReview) // nolint: forcetypeassert
+				require.Equal(t, "approve", accessReview.Spec.ResourceAttributes.Verb)
+				require.Equal(t, "fake-stage", accessReview.Spec.ResourceAttributes.Name)
+				accessReview.Status.Allowed = true
+				return nil
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			w := &webhook{
+				config:                        testCase.config,
+				admissionRequestFromContextFn: testCase.admissionRequestFromContextFn,
+				createSubjectAccessReviewFn:   testCase.createSubjectAccessReviewFn,
+			}
+			testCase.assertions(
+				w.authorize(
+					context.Background(),
+					&kargoapi.Freight{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "fake-freight",
+							Namespace: "fake-namespace",
+						},
+					},
+					"fake-stage",
+					"approve",
+				),
+			)
+		})
+	}
+}
@@ -2,8 +2,12 @@ package promotion
 
 import (
 	"context"
+	"reflect"
+	"strings"
 
+	"github.com/kelseyhightower/envconfig"
 	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
 	authzv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -21,6 +25,10 @@ import (
 	"github.com/akuity/kargo/internal/logging"
 )
 
+// serviceAccountUsernamePrefix is the prefix of the usernames Kubernetes
+// assigns to service accounts.
+const serviceAccountUsernamePrefix = "system:serviceaccount:"
+
 var (
 	promotionGroupKind = schema.GroupKind{
 		Group: kargoapi.GroupVersion.Group,
@@ -32,7 +40,24 @@ var (
 	}
 )
 
+// WebhookConfig represents configuration for the Promotion webhook.
+type WebhookConfig struct {
+	// KargoAPIUsername is the username with which Kargo's API server
+	// authenticates to Kubernetes. Only that user is trusted to record who
+	// created a Promotion on an end user's behalf.
+	KargoAPIUsername string `envconfig:"KARGO_API_USERNAME"`
+}
+
+// WebhookConfigFromEnv returns a WebhookConfig populated from environment
+// variables.
+func WebhookConfigFromEnv() WebhookConfig {
+	cfg := WebhookConfig{}
+	envconfig.MustProcess("", &cfg)
+	return cfg
+}
+
 type webhook struct {
+	config WebhookConfig
 	client client.Client
 
 	// The following behaviors are overridable for testing purposes:
@@ -52,16 +77,31 @@ type webhook struct {
 	) error
 
 	validateProjectFn func(context.Context, *kargoapi.Promotion) error
+
+	getFreightFn func(
+		ctx context.Context,
+		stage *kargoapi.Stage,
+		freightID string,
+	) (*kargoapi.SimpleFreight, error)
+
+	getKargoFreightFn func(
+		context.Context,
+		client.Client,
+		types.NamespacedName,
+	) (*kargoapi.Freight, error)
 }
 
-func SetupWebhookWithManager(mgr ctrl.Manager) error {
+func SetupWebhookWithManager(cfg WebhookConfig, mgr ctrl.Manager) error {
 	w := &webhook{
+		config: cfg,
 		client: mgr.GetClient(),
 	}
 	w.authorizeFn = w.authorize
 	w.admissionRequestFromContextFn = admission.RequestFromContext
 	w.createSubjectAccessReviewFn = w.client.Create
 	w.validateProjectFn = w.validateProject
+	w.getFreightFn = w.getFreight
+	w.getKargoFreightFn = kargoapi.GetFreight
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&kargoapi.Promotion{}).
 		WithDefaulter(w).
@@ -96,6 +136,49 @@ func (w *webhook) Default(ctx context.Context, obj runtime.Object) error {
 	}
 	ownerRef := metav1.NewControllerRef(stage, kargoapi.GroupVersion.WithKind("Stage"))
 	promo.ObjectMeta.OwnerReferences = []metav1.OwnerReference{*ownerRef}
+
+	// The Freight snapshot is only ever resolved upon creation. Any attempt to
+	// change it afterwards will be rejected by ValidateUpdate.
+	req, err := w.admissionRequestFromContextFn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error retrieving admission request from context")
+	}
+	if req.Operation != admissionv1.Create {
+		return nil
+	}
+	// Record who created the Promotion. Anyone other than Kargo's API server,
+	// which records the actual creator itself, is not permitted to claim to be
+	// someone they are not.
+	switch username := req.UserInfo.Username; {
+	case w.config.KargoAPIUsername != "" && username == w.config.KargoAPIUsername:
+	case username == "" || strings.HasPrefix(username, serviceAccountUsernamePrefix):
+		// Other service accounts are not recorded as creators at all
+		delete(promo.Annotations, kargoapi.AnnotationKeyCreateActor)
+	default:
+		if promo.Annotations == nil {
+			promo.Annotations = map[string]string{}
+		}
+		promo.Annotations[kargoapi.AnnotationKeyCreateActor] = username
+	}
+	if promo.Spec.Rollback {
+		// A rollback returns the Stage to Freight that was previously deployed to
+		// it, so that Freight is resolved from the Stage's history.
+		if promo.Spec.FreightSnapshot, err = w.getHistoricalFreight(
+			ctx,
+			stage,
+			promo.Spec.Freight,
+		); err != nil {
+			return err
+		}
+		return nil
+	}
+	if promo.Spec.FreightSnapshot, err = w.getFreightFn(
+		ctx,
+		stage,
+		promo.Spec.Freight,
+	); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -124,7 +207,7 @@ func (w *webhook) ValidateUpdate(
 	}
 
 	// PromotionSpecs are meant to be immutable
-	if *promo.Spec != *(oldObj.(*kargoapi.Promotion).Spec) { // nolint: forcetypeassert
+	if !reflect.DeepEqual(promo.Spec, oldObj.(*kargoapi.Promotion).Spec) { // nolint: forcetypeassert
 		return apierrors.NewInvalid(
 			promotionGroupKind,
 			promo.Name,
@@ -213,6 +296,186 @@ func (w *webhook) authorize(
 	return nil
 }
 
+// getFreight resolves the specified Freight ID to a complete SimpleFreight
+// using the Freight resource of that name in the Stage's namespace. Freight
+// that is among the Stage's available Freight or has been manually approved
+// for the Stage may always be promoted to it. Other Freight may be promoted to
+// a Stage that subscribes to upstream Stages only if it has been verified in
+// at least one of them (or in all of them, if the Stage uses the
+// JoinStrategyAll join strategy), and to a Stage that subscribes to
+// repositories only if it consists of material from exactly those
+// repositories.
+func (w *webhook) getFreight(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	freightID string,
+) (*kargoapi.SimpleFreight, error) {
+	freight, err := w.getKargoFreightFn(
+		ctx,
+		w.client,
+		types.NamespacedName{
+			Namespace: stage.Namespace,
+			Name:      freightID,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if freight == nil {
+		return nil, errors.Errorf(
+			"could not find Freight %q in namespace %q",
+			freightID,
+			stage.Namespace,
+		)
+	}
+	simpleFreight := freight.ToSimpleFreight()
+	for _, availableFreight := range stage.Status.AvailableFreight {
+		if availableFreight.ID == freightID {
+			return &simpleFreight, nil
+		}
+	}
+	if freight.IsApprovedFor(stage.Name) {
+		return &simpleFreight, nil
+	}
+	upstreams := stage.Spec.Subscriptions.UpstreamStages
+	if len(upstreams) == 0 {
+		if !matchesSubscriptions(freight, stage.Spec.Subscriptions.Repos) {
+			return nil, errors.Errorf(
+				"Freight %q does not consist of material from the repositories "+
+					"Stage %q in namespace %q subscribes to and has not been approved "+
+					"for it",
+				freightID,
+				stage.Name,
+				stage.Namespace,
+			)
+		}
+	} else {
+		var verifiedCount int
+		for _, upstream := range upstreams {
+			if freight.IsVerifiedIn(upstream.Name) {
+				verifiedCount++
+			}
+		}
+		if stage.Spec.Subscriptions.UpstreamJoinStrategy == kargoapi.JoinStrategyAll &&
+			verifiedCount < len(upstreams) {
+			return nil, errors.Errorf(
+				"Freight %q has not been verified in every Stage upstream from "+
+					"Stage %q in namespace %q and has not been approved for it",
+				freightID,
+				stage.Name,
+				stage.Namespace,
+			)
+		}
+		if verifiedCount == 0 {
+			return nil, errors.Errorf(
+				"Freight %q has not been verified in any Stage upstream from "+
+					"Stage %q in namespace %q and has not been approved for it",
+				freightID,
+				stage.Name,
+				stage.Namespace,
+			)
+		}
+	}
+	return &simpleFreight, nil
+}
+
+// matchesSubscriptions returns true if the provided Freight consists of
+// exactly one commit, image or chart from each of the provided repository
+// subscriptions and of nothing else.
+func matchesSubscriptions(
+	freight *kargoapi.Freight,
+	subs *kargoapi.RepoSubscriptions,
+) bool {
+	if subs == nil {
+		subs = &kargoapi.RepoSubscriptions{}
+	}
+	if len(freight.Commits) != len(subs.Git) ||
+		len(freight.Images) != len(subs.Images) ||
+		len(freight.Charts) != len(subs.Charts) {
+		return false
+	}
+	gitRepos := make(map[string]struct{}, len(subs.Git))
+	for _, sub := range subs.Git {
+		gitRepos[sub.RepoURL] = struct{}{}
+	}
+	for _, commit := range freight.Commits {
+		if _, ok := gitRepos[commit.RepoURL]; !ok {
+			return false
+		}
+		delete(gitRepos, commit.RepoURL)
+	}
+	imageRepos := make(map[string]struct{}, len(subs.Images))
+	for _, sub := range subs.Images {
+		imageRepos[sub.RepoURL] = struct{}{}
+	}
+	for _, image := range freight.Images {
+		if _, ok := imageRepos[image.RepoURL]; !ok {
+			return false
+		}
+		delete(imageRepos, image.RepoURL)
+	}
+	type chartKey struct{ registryURL, name string }
+	charts := make(map[chartKey]struct{}, len(subs.Charts))
+	for _, sub := range subs.Charts {
+		charts[chartKey{sub.RegistryURL, sub.Name}] = struct{}{}
+	}
+	for _, chart := range freight.Charts {
+		key := chartKey{chart.RegistryURL, chart.Name}
+		if _, ok := charts[key]; !ok {
+			return false
+		}
+		delete(charts, key)
+	}
+	return true
+}
+
+// getHistoricalFreight resolves the specified Freight ID, which must be in the
+// Stage's history, to a complete SimpleFreight using the Freight resource of
+// that name in the Stage's namespace.
+func (w *webhook) getHistoricalFreight(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	freightID string,
+) (*kargoapi.SimpleFreight, error) {
+	var found bool
+	for _, historicalFreight := range stage.Status.History {
+		if historicalFreight.ID == freightID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, errors.Errorf(
+			"could not find Freight %q in the history of Stage %q in namespace %q",
+			freightID,
+			stage.Name,
+			stage.Namespace,
+		)
+	}
+	freight, err := w.getKargoFreightFn(
+		ctx,
+		w.client,
+		types.NamespacedName{
+			Namespace: stage.Namespace,
+			Name:      freightID,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if freight == nil {
+		return nil, errors.Errorf(
+			"Freight %q from the history of Stage %q no longer exists in "+
+				"namespace %q",
+			freightID,
+			stage.Name,
+			stage.Namespace,
+		)
+	}
+	simpleFreight := freight.ToSimpleFreight()
+	return &simpleFreight, nil
+}
+
 func (w *webhook) validateProject(ctx context.Context, promo *kargoapi.Promotion) error {
 	if err := validation.ValidateProject(ctx, w.client, promo.GetNamespace()); err != nil {
 		if errors.Is(err, validation.ErrProjectNotFound) {
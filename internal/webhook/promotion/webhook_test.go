@@ -6,14 +6,845 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authnv1 "k8s.io/api/authentication/v1"
 	authzv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 )
 
+func TestDefault(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(scheme))
+
+	testStage := &kargoapi.Stage{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "fake-stage",
+			Namespace: "fake-namespace",
+		},
+	}
+
+	testConfig := WebhookConfig{
+		KargoAPIUsername: "system:serviceaccount:kargo:kargo-api",
+	}
+
+	testCases := []struct {
+		name                          string
+		client                        client.Client
+		annotations                   map[string]string
+		admissionRequestFromContextFn func(context.Context) (admission.Request, error)
+		getFreightFn                  func(
+			context.Context,
+			*kargoapi.Stage,
+			string,
+		) (*kargoapi.SimpleFreight, error)
+		assertions func(*kargoapi.Promotion, error)
+	}{
+		{
+			name:   "Stage not found",
+			client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+			assertions: func(_ *kargoapi.Promotion, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "could not find Stage")
+			},
+		},
+
+		{
+			name:   "error getting admission request bound to context",
+			client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(testStage).Build(),
+			admissionRequestFromContextFn: func(
+				context.Context,
+			) (admission.Request, error) {
+				return admission.Request{}, errors.New("something went wrong")
+			},
+			assertions: func(_ *kargoapi.Promotion, err error) {
+				require.Error(t, err)
+				require.Contains(
+					t,
+					err.Error(),
+					"error retrieving admission request from context",
+				)
+				require.Contains(t, err.Error(), "something went wrong")
+			},
+		},
+
+		{
+			name:   "update",
+			client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(testStage).Build(),
+			admissionRequestFromContextFn: func(
+				context.Context,
+			) (admission.Request, error) {
+				return admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Operation: admissionv1.Update,
+					},
+				}, nil
+			},
+			assertions: func(promo *kargoapi.Promotion, err error) {
+				require.NoError(t, err)
+				require.Len(t, promo.OwnerReferences, 1)
+				require.Nil(t, promo.Spec.FreightSnapshot)
+			},
+		},
+
+		{
+			name:   "error resolving Freight",
+			client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(testStage).Build(),
+			admissionRequestFromContextFn: func(
+				context.Context,
+			) (admission.Request, error) {
+				return admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Operation: admissionv1.Create,
+					},
+				}, nil
+			},
+			getFreightFn: func(
+				context.Context,
+				*kargoapi.Stage,
+				string,
+			) (*kargoapi.SimpleFreight, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(_ *kargoapi.Promotion, err error) {
+				require.Error(t, err)
+				require.Equal(t, "something went wrong", err.Error())
+			},
+		},
+
+		{
+			name:   "success",
+			client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(testStage).Build(),
+			admissionRequestFromContextFn: func(
+				context.Context,
+			) (admission.Request, error) {
+				return admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Operation: admissionv1.Create,
+					},
+				}, nil
+			},
+			getFreightFn: func(
+				_ context.Context,
+				_ *kargoapi.Stage,
+				freightID string,
+			) (*kargoapi.SimpleFreight, error) {
+				return &kargoapi.SimpleFreight{ID: freightID}, nil
+			},
+			assertions: func(promo *kargoapi.Promotion, err error) {
+				require.NoError(t, err)
+				require.Len(t, promo.OwnerReferences, 1)
+				require.Equal(t, "fake-stage", promo.OwnerReferences[0].Name)
+				require.Equal(
+					t,
+					&kargoapi.SimpleFreight{ID: "fake-freight"},
+					promo.Spec.FreightSnapshot,
+				)
+				require.Empty(t, promo.Annotations[kargoapi.AnnotationKeyCreateActor])
+			},
+		},
+
+		{
+			name:   "creator recorded",
+			client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(testStage).Build(),
+			// Users cannot claim to be someone else
+			annotations: map[string]string{
+				kargoapi.AnnotationKeyCreateActor: "admin",
+			},
+			admissionRequestFromContextFn: func(
+				context.Context,
+			) (admission.Request, error) {
+				return admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Operation: admissionv1.Create,
+						UserInfo: authnv1.UserInfo{
+							Username: "fake-user@example.com",
+						},
+					},
+				}, nil
+			},
+			getFreightFn: func(
+				_ context.Context,
+				_ *kargoapi.Stage,
+				freightID string,
+			) (*kargoapi.SimpleFreight, error) {
+				return &kargoapi.SimpleFreight{ID: freightID}, nil
+			},
+			assertions: func(promo *kargoapi.Promotion, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					"fake-user@example.com",
+					promo.Annotations[kargoapi.AnnotationKeyCreateActor],
+				)
+			},
+		},
+
+		{
+			name:   "creator recorded by Kargo API server",
+			client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(testStage).Build(),
+			annotations: map[string]string{
+				kargoapi.AnnotationKeyCreateActor: "admin",
+			},
+			admissionRequestFromContextFn: func(
+				context.Context,
+			) (admission.Request, error) {
+				return admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Operation: admissionv1.Create,
+						UserInfo: authnv1.UserInfo{
+							Username: "system:serviceaccount:kargo:kargo-api",
+						},
+					},
+				}, nil
+			},
+			getFreightFn: func(
+				_ context.Context,
+				_ *kargoapi.Stage,
+				freightID string,
+			) (*kargoapi.SimpleFreight, error) {
+				return &kargoapi.SimpleFreight{ID: freightID}, nil
+			},
+			assertions: func(promo *kargoapi.Promotion, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					"admin",
+					promo.Annotations[kargoapi.AnnotationKeyCreateActor],
+				)
+			},
+		},
+
+		{
+			name:   "creator recorded by another service account",
+			client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(testStage).Build(),
+			// Service accounts other than Kargo API server's cannot claim to be
+			// someone else either
+			annotations: map[string]string{
+				kargoapi.AnnotationKeyCreateActor: "admin",
+			},
+			admissionRequestFromContextFn: func(
+				context.Context,
+			) (admission.Request, error) {
+				return admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Operation: admissionv1.Create,
+						UserInfo: authnv1.UserInfo{
+							Username: "system:serviceaccount:fake-namespace:fake-sa",
+						},
+					},
+				}, nil
+			},
+			getFreightFn: func(
+				_ context.Context,
+				_ *kargoapi.Stage,
+				freightID string,
+			) (*kargoapi.SimpleFreight, error) {
+				return &kargoapi.SimpleFreight{ID: freightID}, nil
+			},
+			assertions: func(promo *kargoapi.Promotion, err error) {
+				require.NoError(t, err)
+				require.NotContains(
+					t,
+					promo.Annotations,
+					kargoapi.AnnotationKeyCreateActor,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			w := &webhook{
+				config:                        testConfig,
+				client:                        testCase.client,
+				admissionRequestFromContextFn: testCase.admissionRequestFromContextFn,
+				getFreightFn:                  testCase.getFreightFn,
+			}
+			promo := &kargoapi.Promotion{
+				ObjectMeta: v1.ObjectMeta{
+					Name:        "fake-promotion",
+					Namespace:   "fake-namespace",
+					Annotations: testCase.annotations,
+				},
+				Spec: &kargoapi.PromotionSpec{
+					Stage:   "fake-stage",
+					Freight: "fake-freight",
+				},
+			}
+			err := w.Default(context.Background(), promo)
+			testCase.assertions(promo, err)
+		})
+	}
+}
+
+func TestGetFreight(t *testing.T) {
+	testCases := []struct {
+		name              string
+		stage             *kargoapi.Stage
+		getKargoFreightFn func(
+			context.Context,
+			client.Client,
+			types.NamespacedName,
+		) (*kargoapi.Freight, error)
+		assertions func(*kargoapi.SimpleFreight, error)
+	}{
+		{
+			name: "Freight found among available Freight",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						// Available Freight needs no verification upstream
+						UpstreamStages: []kargoapi.StageSubscription{
+							{
+								Name: "fake-upstream-stage",
+							},
+						},
+					},
+				},
+				Status: kargoapi.StageStatus{
+					AvailableFreight: kargoapi.FreightStack{
+						{
+							ID:        "fake-freight",
+							Qualified: true,
+						},
+					},
+				},
+			},
+			getKargoFreightFn: func(
+				_ context.Context,
+				_ client.Client,
+				namespacedName types.NamespacedName,
+			) (*kargoapi.Freight, error) {
+				return &kargoapi.Freight{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      namespacedName.Name,
+						Namespace: namespacedName.Namespace,
+					},
+					Images: []kargoapi.Image{
+						{
+							RepoURL: "fake-url",
+							Tag:     "fake-tag",
+						},
+					},
+				}, nil
+			},
+			assertions: func(freight *kargoapi.SimpleFreight, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, freight)
+				require.Equal(t, "fake-freight", freight.ID)
+				require.False(t, freight.Qualified)
+				require.Equal(
+					t,
+					[]kargoapi.Image{
+						{
+							RepoURL: "fake-url",
+							Tag:     "fake-tag",
+						},
+					},
+					freight.Images,
+				)
+			},
+		},
+
+		{
+			name:  "error getting Freight resource",
+			stage: &kargoapi.Stage{},
+			getKargoFreightFn: func(
+				context.Context,
+				client.Client,
+				types.NamespacedName,
+			) (*kargoapi.Freight, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(_ *kargoapi.SimpleFreight, err error) {
+				require.Error(t, err)
+				require.Equal(t, "something went wrong", err.Error())
+			},
+		},
+
+		{
+			name:  "Freight resource not found",
+			stage: &kargoapi.Stage{},
+			getKargoFreightFn: func(
+				context.Context,
+				client.Client,
+				types.NamespacedName,
+			) (*kargoapi.Freight, error) {
+				return nil, nil
+			},
+			assertions: func(_ *kargoapi.SimpleFreight, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "could not find Freight")
+			},
+		},
+
+		{
+			name: "Freight resource not verified upstream",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						UpstreamStages: []kargoapi.StageSubscription{
+							{
+								Name: "fake-upstream-stage",
+							},
+						},
+					},
+				},
+			},
+			getKargoFreightFn: func(
+				context.Context,
+				client.Client,
+				types.NamespacedName,
+			) (*kargoapi.Freight, error) {
+				return &kargoapi.Freight{}, nil
+			},
+			assertions: func(_ *kargoapi.SimpleFreight, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "has not been verified")
+			},
+		},
+
+		{
+			name: "Freight resource not verified in all upstream Stages",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						UpstreamStages: []kargoapi.StageSubscription{
+							{
+								Name: "fake-upstream-stage",
+							},
+							{
+								Name: "another-fake-upstream-stage",
+							},
+						},
+						UpstreamJoinStrategy: kargoapi.JoinStrategyAll,
+					},
+				},
+			},
+			getKargoFreightFn: func(
+				context.Context,
+				client.Client,
+				types.NamespacedName,
+			) (*kargoapi.Freight, error) {
+				return &kargoapi.Freight{
+					Status: kargoapi.FreightStatus{
+						VerifiedIn: map[string]kargoapi.VerifiedStage{
+							"fake-upstream-stage": {},
+						},
+					},
+				}, nil
+			},
+			assertions: func(_ *kargoapi.SimpleFreight, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "has not been verified in every Stage")
+			},
+		},
+
+		{
+			name: "Freight resource approved for Stage",
+			stage: &kargoapi.Stage{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "fake-stage",
+				},
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						UpstreamStages: []kargoapi.StageSubscription{
+							{
+								Name: "fake-upstream-stage",
+							},
+						},
+					},
+				},
+			},
+			getKargoFreightFn: func(
+				_ context.Context,
+				_ client.Client,
+				namespacedName types.NamespacedName,
+			) (*kargoapi.Freight, error) {
+				return &kargoapi.Freight{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      namespacedName.Name,
+						Namespace: namespacedName.Namespace,
+					},
+					Status: kargoapi.FreightStatus{
+						ApprovedFor: map[string]kargoapi.ApprovedStage{
+							"fake-stage": {},
+						},
+					},
+				}, nil
+			},
+			assertions: func(freight *kargoapi.SimpleFreight, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, freight)
+				require.Equal(t, "fake-freight", freight.ID)
+			},
+		},
+
+		{
+			name: "Freight resource verified upstream",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						UpstreamStages: []kargoapi.StageSubscription{
+							{
+								Name: "fake-upstream-stage",
+							},
+						},
+					},
+				},
+			},
+			getKargoFreightFn: func(
+				_ context.Context,
+				_ client.Client,
+				namespacedName types.NamespacedName,
+			) (*kargoapi.Freight, error) {
+				return &kargoapi.Freight{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      namespacedName.Name,
+						Namespace: namespacedName.Namespace,
+					},
+					Images: []kargoapi.Image{
+						{
+							RepoURL: "fake-url",
+							Tag:     "fake-tag",
+						},
+					},
+					Status: kargoapi.FreightStatus{
+						VerifiedIn: map[string]kargoapi.VerifiedStage{
+							"fake-upstream-stage": {},
+						},
+					},
+				}, nil
+			},
+			assertions: func(freight *kargoapi.SimpleFreight, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, freight)
+				require.Equal(t, "fake-freight", freight.ID)
+				require.Equal(
+					t,
+					[]kargoapi.Image{
+						{
+							RepoURL: "fake-url",
+							Tag:     "fake-tag",
+						},
+					},
+					freight.Images,
+				)
+			},
+		},
+
+		{
+			name: "Freight resource not from repositories Stage subscribes to",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						Repos: &kargoapi.RepoSubscriptions{
+							Images: []kargoapi.ImageSubscription{
+								{
+									RepoURL: "fake-url",
+								},
+							},
+						},
+					},
+				},
+			},
+			getKargoFreightFn: func(
+				context.Context,
+				client.Client,
+				types.NamespacedName,
+			) (*kargoapi.Freight, error) {
+				return &kargoapi.Freight{
+					Images: []kargoapi.Image{
+						{
+							RepoURL: "another-fake-url",
+							Tag:     "fake-tag",
+						},
+					},
+				}, nil
+			},
+			assertions: func(_ *kargoapi.SimpleFreight, err error) {
+				require.Error(t, err)
+				require.Contains(
+					t,
+					err.Error(),
+					"does not consist of material from the repositories",
+				)
+			},
+		},
+
+		{
+			name: "Freight resource with material Stage does not subscribe to",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						Repos: &kargoapi.RepoSubscriptions{
+							Images: []kargoapi.ImageSubscription{
+								{
+									RepoURL: "fake-url",
+								},
+							},
+						},
+					},
+				},
+			},
+			getKargoFreightFn: func(
+				context.Context,
+				client.Client,
+				types.NamespacedName,
+			) (*kargoapi.Freight, error) {
+				return &kargoapi.Freight{
+					Commits: []kargoapi.GitCommit{
+						{
+							RepoURL: "fake-git-url",
+							ID:      "fake-commit",
+						},
+					},
+					Images: []kargoapi.Image{
+						{
+							RepoURL: "fake-url",
+							Tag:     "fake-tag",
+						},
+					},
+				}, nil
+			},
+			assertions: func(_ *kargoapi.SimpleFreight, err error) {
+				require.Error(t, err)
+				require.Contains(
+					t,
+					err.Error(),
+					"does not consist of material from the repositories",
+				)
+			},
+		},
+
+		{
+			name: "Freight resource from repositories Stage subscribes to",
+			stage: &kargoapi.Stage{
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						Repos: &kargoapi.RepoSubscriptions{
+							Git: []kargoapi.GitSubscription{
+								{
+									RepoURL: "fake-git-url",
+								},
+							},
+							Images: []kargoapi.ImageSubscription{
+								{
+									RepoURL: "fake-url",
+								},
+							},
+							Charts: []kargoapi.ChartSubscription{
+								{
+									RegistryURL: "fake-registry-url",
+									Name:        "fake-chart",
+								},
+							},
+						},
+					},
+				},
+			},
+			getKargoFreightFn: func(
+				_ context.Context,
+				_ client.Client,
+				namespacedName types.NamespacedName,
+			) (*kargoapi.Freight, error) {
+				return &kargoapi.Freight{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      namespacedName.Name,
+						Namespace: namespacedName.Namespace,
+					},
+					Commits: []kargoapi.GitCommit{
+						{
+							RepoURL: "fake-git-url",
+							ID:      "fake-commit",
+						},
+					},
+					Images: []kargoapi.Image{
+						{
+							RepoURL: "fake-url",
+							Tag:     "fake-tag",
+						},
+					},
+					Charts: []kargoapi.Chart{
+						{
+							RegistryURL: "fake-registry-url",
+							Name:        "fake-chart",
+							Version:     "1.0.0",
+						},
+					},
+				}, nil
+			},
+			assertions: func(freight *kargoapi.SimpleFreight, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, freight)
+				require.Equal(t, "fake-freight", freight.ID)
+			},
+		},
+
+		{
+			name: "Freight resource not from repositories but approved for Stage",
+			stage: &kargoapi.Stage{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "fake-stage",
+				},
+				Spec: &kargoapi.StageSpec{
+					Subscriptions: &kargoapi.Subscriptions{
+						Repos: &kargoapi.RepoSubscriptions{
+							Images: []kargoapi.ImageSubscription{
+								{
+									RepoURL: "fake-url",
+								},
+							},
+						},
+					},
+				},
+			},
+			getKargoFreightFn: func(
+				_ context.Context,
+				_ client.Client,
+				namespacedName types.NamespacedName,
+			) (*kargoapi.Freight, error) {
+				return &kargoapi.Freight{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      namespacedName.Name,
+						Namespace: namespacedName.Namespace,
+					},
+					Images: []kargoapi.Image{
+						{
+							RepoURL: "another-fake-url",
+							Tag:     "fake-tag",
+						},
+					},
+					Status: kargoapi.FreightStatus{
+						ApprovedFor: map[string]kargoapi.ApprovedStage{
+							"fake-stage": {},
+						},
+					},
+				}, nil
+			},
+			assertions: func(freight *kargoapi.SimpleFreight, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, freight)
+				require.Equal(t, "fake-freight", freight.ID)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			w := &webhook{
+				getKargoFreightFn: testCase.getKargoFreightFn,
+			}
+			testCase.assertions(
+				w.getFreight(context.Background(), testCase.stage, "fake-freight"),
+			)
+		})
+	}
+}
+
+func TestDefaultWithRepoSubscriptions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kargoapi.SchemeBuilder.AddToScheme(scheme))
+
+	testStage := &kargoapi.Stage{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "fake-stage",
+			Namespace: "fake-namespace",
+		},
+		Spec: &kargoapi.StageSpec{
+			Subscriptions: &kargoapi.Subscriptions{
+				Repos: &kargoapi.RepoSubscriptions{
+					Images: []kargoapi.ImageSubscription{
+						{
+							RepoURL: "fake-url",
+						},
+					},
+				},
+			},
+		},
+	}
+	// Freight produced by another Stage that subscribes to a different image
+	otherFreight := &kargoapi.Freight{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "other-fake-freight",
+			Namespace: "fake-namespace",
+		},
+		Images: []kargoapi.Image{
+			{
+				RepoURL: "another-fake-url",
+				Tag:     "fake-tag",
+			},
+		},
+	}
+	freight := &kargoapi.Freight{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "fake-freight",
+			Namespace: "fake-namespace",
+		},
+		Images: []kargoapi.Image{
+			{
+				RepoURL: "fake-url",
+				Tag:     "fake-tag",
+			},
+		},
+	}
+
+	w := &webhook{
+		client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(testStage, otherFreight, freight).Build(),
+		admissionRequestFromContextFn: func(
+			context.Context,
+		) (admission.Request, error) {
+			return admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+				},
+			}, nil
+		},
+		getKargoFreightFn: kargoapi.GetFreight,
+	}
+	w.getFreightFn = w.getFreight
+
+	newPromotion := func(freightID string) *kargoapi.Promotion {
+		return &kargoapi.Promotion{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "fake-promotion",
+				Namespace: "fake-namespace",
+			},
+			Spec: &kargoapi.PromotionSpec{
+				Stage:   "fake-stage",
+				Freight: freightID,
+			},
+		}
+	}
+
+	t.Run("Freight from another Stage's subscription", func(t *testing.T) {
+		promo := newPromotion("other-fake-freight")
+		err := w.Default(context.Background(), promo)
+		require.Error(t, err)
+		require.Contains(
+			t,
+			err.Error(),
+			"does not consist of material from the repositories",
+		)
+		require.Nil(t, promo.Spec.FreightSnapshot)
+	})
+
+	t.Run("Freight from Stage's own subscription", func(t *testing.T) {
+		promo := newPromotion("fake-freight")
+		err := w.Default(context.Background(), promo)
+		require.NoError(t, err)
+		require.NotNil(t, promo.Spec.FreightSnapshot)
+		require.Equal(t, "fake-freight", promo.Spec.FreightSnapshot.ID)
+		require.Equal(t, freight.Images, promo.Spec.FreightSnapshot.Images)
+	})
+}
+
 func TestValidateCreate(t *testing.T) {
 	w := &webhook{
 		authorizeFn: func(context.Context, *kargoapi.Promotion, string) error {
@@ -78,6 +909,42 @@ func TestValidateUpdate(t *testing.T) {
 			},
 		},
 
+		{
+			name: "attempt to mutate Freight snapshot",
+			setup: func() (*kargoapi.Promotion, *kargoapi.Promotion) {
+				oldPromo := &kargoapi.Promotion{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "fake-name",
+						Namespace: "fake-namespace",
+					},
+					Spec: &kargoapi.PromotionSpec{
+						Stage:   "fake-stage",
+						Freight: "fake-freight",
+						FreightSnapshot: &kargoapi.SimpleFreight{
+							ID: "fake-freight",
+							Images: []kargoapi.Image{
+								{
+									RepoURL: "fake-url",
+									Tag:     "fake-tag",
+								},
+							},
+						},
+					},
+				}
+				newPromo := oldPromo.DeepCopy()
+				newPromo.Spec.FreightSnapshot.Images[0].Tag = "another-fake-tag"
+				return oldPromo, newPromo
+			},
+			authorizeFn: func(context.Context, *kargoapi.Promotion, string) error {
+				return nil
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "\"fake-name\" is invalid")
+				require.Contains(t, err.Error(), "spec is immutable")
+			},
+		},
+
 		{
 			name: "update without mutation",
 			setup: func() (*kargoapi.Promotion, *kargoapi.Promotion) {
@@ -240,3 +1107,131 @@ func TestAuthorize(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHistoricalFreight(t *testing.T) {
+	stage := &kargoapi.Stage{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "fake-stage",
+			Namespace: "fake-namespace",
+		},
+		Status: kargoapi.StageStatus{
+			History: kargoapi.FreightStack{
+				{
+					ID:        "fake-freight",
+					Qualified: true,
+				},
+				{
+					ID:        "another-fake-freight",
+					Qualified: true,
+					Rollback:  true,
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name              string
+		freightID         string
+		getKargoFreightFn func(
+			context.Context,
+			client.Client,
+			types.NamespacedName,
+		) (*kargoapi.Freight, error)
+		assertions func(*kargoapi.SimpleFreight, error)
+	}{
+		{
+			name:      "Freight not found in history",
+			freightID: "bogus-freight",
+			assertions: func(_ *kargoapi.SimpleFreight, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "could not find Freight")
+			},
+		},
+
+		{
+			name:      "error getting Freight resource",
+			freightID: "another-fake-freight",
+			getKargoFreightFn: func(
+				context.Context,
+				client.Client,
+				types.NamespacedName,
+			) (*kargoapi.Freight, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(_ *kargoapi.SimpleFreight, err error) {
+				require.Error(t, err)
+				require.Equal(t, "something went wrong", err.Error())
+			},
+		},
+
+		{
+			name:      "Freight resource no longer exists",
+			freightID: "another-fake-freight",
+			getKargoFreightFn: func(
+				context.Context,
+				client.Client,
+				types.NamespacedName,
+			) (*kargoapi.Freight, error) {
+				return nil, nil
+			},
+			assertions: func(_ *kargoapi.SimpleFreight, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "no longer exists")
+			},
+		},
+
+		{
+			name:      "Freight found in history",
+			freightID: "another-fake-freight",
+			getKargoFreightFn: func(
+				_ context.Context,
+				_ client.Client,
+				namespacedName types.NamespacedName,
+			) (*kargoapi.Freight, error) {
+				return &kargoapi.Freight{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      namespacedName.Name,
+						Namespace: namespacedName.Namespace,
+					},
+					Images: []kargoapi.Image{
+						{
+							RepoURL: "fake-url",
+							Tag:     "fake-tag",
+						},
+					},
+				}, nil
+			},
+			assertions: func(freight *kargoapi.SimpleFreight, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, freight)
+				require.Equal(t, "another-fake-freight", freight.ID)
+				require.False(t, freight.Qualified)
+				require.False(t, freight.Rollback)
+				require.Equal(
+					t,
+					[]kargoapi.Image{
+						{
+							RepoURL: "fake-url",
+							Tag:     "fake-tag",
+						},
+					},
+					freight.Images,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			w := &webhook{
+				getKargoFreightFn: testCase.getKargoFreightFn,
+			}
+			testCase.assertions(
+				w.getHistoricalFreight(
+					context.Background(),
+					stage,
+					testCase.freightID,
+				),
+			)
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/api/validation"
+	"github.com/akuity/kargo/internal/kargo"
 	"github.com/akuity/kargo/internal/kubeclient"
 )
 
@@ -47,6 +48,12 @@ func (w *webhook) ValidateCreate(ctx context.Context, obj runtime.Object) error
 	if err := w.validateProject(ctx, policy); err != nil {
 		return err
 	}
+	if err := validateWindows(policy); err != nil {
+		return err
+	}
+	if err := validateRetryAndTimeout(policy); err != nil {
+		return err
+	}
 	return w.validateStageUniqueness(ctx, policy)
 }
 
@@ -55,6 +62,12 @@ func (w *webhook) ValidateUpdate(ctx context.Context, _ runtime.Object, newObj r
 	if err := w.validateProject(ctx, policy); err != nil {
 		return err
 	}
+	if err := validateWindows(policy); err != nil {
+		return err
+	}
+	if err := validateRetryAndTimeout(policy); err != nil {
+		return err
+	}
 	return w.validateStageUniqueness(ctx, policy)
 }
 
@@ -98,3 +111,82 @@ func (w *webhook) validateStageUniqueness(ctx context.Context, policy *kargoapi.
 	}
 	return nil
 }
+
+func validateWindows(policy *kargoapi.PromotionPolicy) error {
+	var errs field.ErrorList
+	for i, window := range policy.Windows {
+		if err := kargo.ValidatePromotionWindow(window); err != nil {
+			errs = append(
+				errs,
+				field.Invalid(field.NewPath("windows").Index(i), window, err.Error()),
+			)
+		}
+	}
+	for i, blackout := range policy.Blackouts {
+		if err := kargo.ValidatePromotionWindow(blackout); err != nil {
+			errs = append(
+				errs,
+				field.Invalid(field.NewPath("blackouts").Index(i), blackout, err.Error()),
+			)
+		}
+	}
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(promotionPolicyGroupKind, policy.GetName(), errs)
+	}
+	return nil
+}
+
+func validateRetryAndTimeout(policy *kargoapi.PromotionPolicy) error {
+	var errs field.ErrorList
+	if retry := policy.Retry; retry != nil {
+		retryPath := field.NewPath("retry")
+		if retry.MaxAttempts < 1 {
+			errs = append(
+				errs,
+				field.Invalid(
+					retryPath.Child("maxAttempts"),
+					retry.MaxAttempts,
+					"must be at least 1",
+				),
+			)
+		}
+		if retry.Backoff != nil && retry.Backoff.Duration <= 0 {
+			errs = append(
+				errs,
+				field.Invalid(retryPath.Child("backoff"), retry.Backoff, "must be positive"),
+			)
+		}
+		if retry.MaxBackoff != nil {
+			if retry.MaxBackoff.Duration <= 0 {
+				errs = append(
+					errs,
+					field.Invalid(
+						retryPath.Child("maxBackoff"),
+						retry.MaxBackoff,
+						"must be positive",
+					),
+				)
+			} else if retry.Backoff != nil &&
+				retry.MaxBackoff.Duration < retry.Backoff.Duration {
+				errs = append(
+					errs,
+					field.Invalid(
+						retryPath.Child("maxBackoff"),
+						retry.MaxBackoff,
+						"must not be less than backoff",
+					),
+				)
+			}
+		}
+	}
+	if policy.Timeout != nil && policy.Timeout.Duration <= 0 {
+		errs = append(
+			errs,
+			field.Invalid(field.NewPath("timeout"), policy.Timeout, "must be positive"),
+		)
+	}
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(promotionPolicyGroupKind, policy.GetName(), errs)
+	}
+	return nil
+}
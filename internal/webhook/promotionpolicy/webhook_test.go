@@ -0,0 +1,153 @@
+package promotionpolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestValidateWindows(t *testing.T) {
+	testCases := []struct {
+		name       string
+		policy     *kargoapi.PromotionPolicy
+		assertions func(error)
+	}{
+		{
+			name:   "no windows or blackouts",
+			policy: &kargoapi.PromotionPolicy{},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "valid windows and blackouts",
+			policy: &kargoapi.PromotionPolicy{
+				Windows: []kargoapi.PromotionWindow{
+					{
+						Schedule: "0 9 * * 1-5",
+						Duration: metav1.Duration{Duration: 8 * time.Hour},
+						TimeZone: "America/New_York",
+					},
+				},
+				Blackouts: []kargoapi.PromotionWindow{
+					{
+						Schedule: "0 0 24 12 *",
+						Duration: metav1.Duration{Duration: 48 * time.Hour},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "invalid window",
+			policy: &kargoapi.PromotionPolicy{
+				Windows: []kargoapi.PromotionWindow{
+					{
+						Schedule: "bogus",
+						Duration: metav1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "windows[0]")
+			},
+		},
+		{
+			name: "invalid blackout",
+			policy: &kargoapi.PromotionPolicy{
+				Blackouts: []kargoapi.PromotionWindow{
+					{
+						Schedule: "* * * * *",
+						Duration: metav1.Duration{Duration: time.Hour},
+						TimeZone: "Bogus/Zone",
+					},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "blackouts[0]")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(validateWindows(testCase.policy))
+		})
+	}
+}
+
+func TestValidateRetryAndTimeout(t *testing.T) {
+	testCases := []struct {
+		name       string
+		policy     *kargoapi.PromotionPolicy
+		assertions func(error)
+	}{
+		{
+			name:   "no retry or timeout",
+			policy: &kargoapi.PromotionPolicy{},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "valid retry and timeout",
+			policy: &kargoapi.PromotionPolicy{
+				Retry: &kargoapi.PromotionRetry{
+					MaxAttempts: 3,
+					Backoff:     &metav1.Duration{Duration: 30 * time.Second},
+					MaxBackoff:  &metav1.Duration{Duration: 5 * time.Minute},
+				},
+				Timeout: &metav1.Duration{Duration: 30 * time.Minute},
+			},
+			assertions: func(err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "invalid max attempts",
+			policy: &kargoapi.PromotionPolicy{
+				Retry: &kargoapi.PromotionRetry{},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "retry.maxAttempts")
+			},
+		},
+		{
+			name: "max backoff less than backoff",
+			policy: &kargoapi.PromotionPolicy{
+				Retry: &kargoapi.PromotionRetry{
+					MaxAttempts: 3,
+					Backoff:     &metav1.Duration{Duration: time.Minute},
+					MaxBackoff:  &metav1.Duration{Duration: time.Second},
+				},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "retry.maxBackoff")
+			},
+		},
+		{
+			name: "non-positive timeout",
+			policy: &kargoapi.PromotionPolicy{
+				Timeout: &metav1.Duration{},
+			},
+			assertions: func(err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "timeout")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(validateRetryAndTimeout(testCase.policy))
+		})
+	}
+}
@@ -3,6 +3,8 @@ package stage
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"text/template"
 
 	"github.com/Masterminds/semver"
 	"github.com/argoproj-labs/argocd-image-updater/pkg/image"
@@ -104,12 +106,20 @@ func (w *webhook) validateSpec(
 		return nil
 	}
 	errs := w.validateSubs(f.Child("subscriptions"), spec.Subscriptions)
-	return append(
+	errs = append(
 		errs,
 		w.validatePromotionMechanisms(
 			f.Child("promotionMechanisms"),
 			spec.PromotionMechanisms)...,
 	)
+	errs = append(
+		errs,
+		w.validateVerifiedImageRefs(f.Child("promotionMechanisms"), spec)...,
+	)
+	return append(
+		errs,
+		w.validateVerification(f.Child("verification"), spec.Verification)...,
+	)
 }
 
 func (w *webhook) validateSubs(
@@ -159,10 +169,84 @@ func (w *webhook) validateRepoSubs(
 			),
 		}
 	}
-	errs := w.validateImageSubs(f.Child("images"), subs.Images)
+	errs := w.validateGitSubs(f.Child("git"), subs.Git)
+	errs = append(errs, w.validateImageSubs(f.Child("images"), subs.Images)...)
 	return append(errs, w.validateChartSubs(f.Child("charts"), subs.Charts)...)
 }
 
+func (w *webhook) validateGitSubs(
+	f *field.Path,
+	subs []kargoapi.GitSubscription,
+) field.ErrorList {
+	var errs field.ErrorList
+	for i, sub := range subs {
+		errs = append(errs, w.validateGitSub(f.Index(i), sub)...)
+	}
+	return errs
+}
+
+func (w *webhook) validateGitSub(
+	f *field.Path,
+	sub kargoapi.GitSubscription,
+) field.ErrorList {
+	var errs field.ErrorList
+	if sub.CommitSelectionStrategy != "" &&
+		sub.CommitSelectionStrategy != kargoapi.CommitSelectionStrategyNewestFromBranch {
+		// Commits selected by tag are not found on any particular branch and
+		// their history is not searched
+		for _, branchOnly := range []struct {
+			name  string
+			value any
+			isSet bool
+		}{
+			{"branch", sub.Branch, sub.Branch != ""},
+			{"includePaths", sub.IncludePaths, len(sub.IncludePaths) > 0},
+			{"excludePaths", sub.ExcludePaths, len(sub.ExcludePaths) > 0},
+			{"ignoreMessages", sub.IgnoreMessages, len(sub.IgnoreMessages) > 0},
+			{"ignoreAuthors", sub.IgnoreAuthors, len(sub.IgnoreAuthors) > 0},
+		} {
+			if branchOnly.isSet {
+				errs = append(
+					errs,
+					field.Invalid(
+						f.Child(branchOnly.name),
+						branchOnly.value,
+						fmt.Sprintf(
+							"%s cannot be specified when commitSelectionStrategy is %q",
+							branchOnly.name,
+							sub.CommitSelectionStrategy,
+						),
+					),
+				)
+			}
+		}
+	}
+	if err := validateSemverConstraint(
+		f.Child("semverConstraint"),
+		sub.SemverConstraint,
+	); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateRegex(f.Child("allowTags"), sub.AllowTags); err != nil {
+		errs = append(errs, err)
+	}
+	for _, regexes := range []struct {
+		name   string
+		values []string
+	}{
+		{"ignoreTags", sub.IgnoreTags},
+		{"ignoreMessages", sub.IgnoreMessages},
+		{"ignoreAuthors", sub.IgnoreAuthors},
+	} {
+		for i, regex := range regexes.values {
+			if err := validateRegex(f.Child(regexes.name).Index(i), regex); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
 func (w *webhook) validateImageSubs(
 	f *field.Path,
 	subs []kargoapi.ImageSubscription,
@@ -286,6 +370,46 @@ func (w *webhook) validateGitRepoUpdate(
 			),
 		}
 	}
+	if update.Bookkeeper != nil && update.PullRequest != nil {
+		return field.ErrorList{
+			field.Invalid(
+				f.Child("pullRequest"),
+				update.PullRequest,
+				fmt.Sprintf(
+					"%s.pullRequest may not be defined in conjunction with "+
+						"%s.bookkeeper",
+					f.String(),
+					f.String(),
+				),
+			),
+		}
+	}
+	if update.Bookkeeper != nil && update.Author != nil {
+		return field.ErrorList{
+			field.Invalid(
+				f.Child("author"),
+				update.Author,
+				fmt.Sprintf(
+					"%s.author may not be defined in conjunction with "+
+						"%s.bookkeeper",
+					f.String(),
+					f.String(),
+				),
+			),
+		}
+	}
+	if update.CommitMessageTemplate != "" {
+		if _, err :=
+			template.New("commitMessage").Parse(update.CommitMessageTemplate); err != nil {
+			return field.ErrorList{
+				field.Invalid(
+					f.Child("commitMessageTemplate"),
+					update.CommitMessageTemplate,
+					err.Error(),
+				),
+			}
+		}
+	}
 	return w.validateHelmPromotionMechanism(f.Child("helm"), update.Helm)
 }
 
@@ -313,6 +437,145 @@ func (w *webhook) validateHelmPromotionMechanism(
 	return nil
 }
 
+// validateVerifiedImageRefs returns errors for any promotion mechanisms that
+// would reference an image subject to verification by its tag. A tag can be
+// moved to reference different, unverified content after the image was
+// verified, so such images must be referenced by digest.
+func (w *webhook) validateVerifiedImageRefs(
+	f *field.Path,
+	spec *kargoapi.StageSpec,
+) field.ErrorList {
+	if spec.Subscriptions == nil || spec.Subscriptions.Repos == nil ||
+		spec.PromotionMechanisms == nil {
+		return nil
+	}
+	verified := make(map[string]struct{}, len(spec.Subscriptions.Repos.Images))
+	for _, sub := range spec.Subscriptions.Repos.Images {
+		if sub.Verification != nil {
+			verified[sub.RepoURL] = struct{}{}
+		}
+	}
+	if len(verified) == 0 {
+		return nil
+	}
+	var errs field.ErrorList
+	validateValue := func(
+		f *field.Path,
+		image string,
+		value kargoapi.ImageUpdateValueType,
+	) {
+		if _, ok := verified[image]; !ok || isDigestValueType(value) {
+			return
+		}
+		errs = append(
+			errs,
+			field.Invalid(
+				f,
+				value,
+				fmt.Sprintf(
+					"image %q is subject to verification and must be referenced by "+
+						"digest; value must be %q or %q",
+					image,
+					kargoapi.ImageUpdateValueTypeDigest,
+					kargoapi.ImageUpdateValueTypeTagAtDigest,
+				),
+			),
+		)
+	}
+	for i, update := range spec.PromotionMechanisms.GitRepoUpdates {
+		updatePath := f.Child("gitRepoUpdates").Index(i)
+		if update.Bookkeeper != nil {
+			errs = append(
+				errs,
+				field.Invalid(
+					updatePath.Child("bookkeeper"),
+					update.Bookkeeper,
+					"Bookkeeper references images by tag, so it cannot be used when "+
+						"any image subscription specifies verification",
+				),
+			)
+		}
+		if update.Kustomize != nil {
+			for j, image := range update.Kustomize.Images {
+				validateValue(
+					updatePath.Child("kustomize", "images").Index(j).Child("value"),
+					image.Image,
+					image.Value,
+				)
+			}
+		}
+		if update.Helm != nil {
+			for j, image := range update.Helm.Images {
+				validateValue(
+					updatePath.Child("helm", "images").Index(j).Child("value"),
+					image.Image,
+					image.Value,
+				)
+			}
+		}
+	}
+	for i, update := range spec.PromotionMechanisms.ArgoCDAppUpdates {
+		for j, source := range update.SourceUpdates {
+			sourcePath := f.Child("argoCDAppUpdates").Index(i).
+				Child("sourceUpdates").Index(j)
+			if source.Kustomize != nil {
+				// A single value applies to all of the images
+				for _, image := range source.Kustomize.Images {
+					if _, ok := verified[image]; ok {
+						validateValue(
+							sourcePath.Child("kustomize", "value"),
+							image,
+							source.Kustomize.Value,
+						)
+						break
+					}
+				}
+			}
+			if source.Helm != nil {
+				for k, image := range source.Helm.Images {
+					validateValue(
+						sourcePath.Child("helm", "images").Index(k).Child("value"),
+						image.Image,
+						image.Value,
+					)
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// isDigestValueType returns a bool indicating whether the provided
+// ImageUpdateValueType references an image by its digest.
+func isDigestValueType(value kargoapi.ImageUpdateValueType) bool {
+	return value == kargoapi.ImageUpdateValueTypeDigest ||
+		value == kargoapi.ImageUpdateValueTypeTagAtDigest
+}
+
+func (w *webhook) validateVerification(
+	f *field.Path,
+	verification *kargoapi.Verification,
+) field.ErrorList {
+	if verification == nil {
+		return nil
+	}
+	// Job names must be unique because they are used to correlate the Jobs
+	// that get created with their templates
+	var errs field.ErrorList
+	names := make(map[string]struct{}, len(verification.Jobs))
+	for i, job := range verification.Jobs {
+		if _, ok := names[job.Name]; ok {
+			errs = append(
+				errs,
+				field.Duplicate(f.Child("jobs").Index(i).Child("name"), job.Name),
+			)
+			continue
+		}
+		names[job.Name] = struct{}{}
+	}
+	return errs
+}
+
 func validateSemverConstraint(
 	f *field.Path,
 	semverConstraint string,
@@ -325,3 +588,13 @@ func validateSemverConstraint(
 	}
 	return nil
 }
+
+func validateRegex(f *field.Path, regex string) *field.Error {
+	if regex == "" {
+		return nil
+	}
+	if _, err := regexp.Compile(regex); err != nil {
+		return field.Invalid(f, regex, err.Error())
+	}
+	return nil
+}
@@ -288,6 +288,166 @@ func TestValidateRepoSubs(t *testing.T) {
 	}
 }
 
+func TestValidateGitSubs(t *testing.T) {
+	testCases := []struct {
+		name       string
+		sub        kargoapi.GitSubscription
+		assertions func(field.ErrorList)
+	}{
+		{
+			name: "invalid",
+			sub: kargoapi.GitSubscription{
+				SemverConstraint: "bogus",
+			},
+			assertions: func(errs field.ErrorList) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "git[0].semverConstraint",
+							BadValue: "bogus",
+						},
+					},
+					errs,
+				)
+			},
+		},
+
+		{
+			name: "valid",
+			assertions: func(errs field.ErrorList) {
+				require.Nil(t, errs)
+			},
+		},
+	}
+	w := &webhook{}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				w.validateGitSubs(
+					field.NewPath("git"),
+					[]kargoapi.GitSubscription{
+						testCase.sub,
+					},
+				),
+			)
+		})
+	}
+}
+
+func TestValidateGitSub(t *testing.T) {
+	testCases := []struct {
+		name       string
+		sub        kargoapi.GitSubscription
+		assertions func(field.ErrorList)
+	}{
+		{
+			name: "invalid",
+			sub: kargoapi.GitSubscription{
+				Branch:                  "main",
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategySemVer,
+				SemverConstraint:        "bogus",
+				AllowTags:               "(",
+				IgnoreTags:              []string{"v1", "["},
+			},
+			assertions: func(errs field.ErrorList) {
+				require.Len(t, errs, 4)
+				require.Equal(
+					t,
+					&field.Error{
+						Type:     field.ErrorTypeInvalid,
+						Field:    "git.branch",
+						BadValue: "main",
+						Detail: "branch cannot be specified when " +
+							`commitSelectionStrategy is "SemVer"`,
+					},
+					errs[0],
+				)
+				require.Equal(t, "git.semverConstraint", errs[1].Field)
+				require.Equal(t, "git.allowTags", errs[2].Field)
+				require.Equal(t, "git.ignoreTags[1]", errs[3].Field)
+			},
+		},
+
+		{
+			name: "invalid filters",
+			sub: kargoapi.GitSubscription{
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategyLexical,
+				IncludePaths:            []string{"apps/**"},
+				IgnoreMessages:          []string{"("},
+				IgnoreAuthors:           []string{"["},
+			},
+			assertions: func(errs field.ErrorList) {
+				require.Len(t, errs, 5)
+				require.Equal(
+					t,
+					&field.Error{
+						Type:     field.ErrorTypeInvalid,
+						Field:    "git.includePaths",
+						BadValue: []string{"apps/**"},
+						Detail: "includePaths cannot be specified when " +
+							`commitSelectionStrategy is "Lexical"`,
+					},
+					errs[0],
+				)
+				require.Equal(t, "git.ignoreMessages", errs[1].Field)
+				require.Equal(t, "git.ignoreAuthors", errs[2].Field)
+				require.Equal(t, "git.ignoreMessages[0]", errs[3].Field)
+				require.Equal(t, "git.ignoreAuthors[0]", errs[4].Field)
+			},
+		},
+
+		{
+			name: "valid branch subscription",
+			sub: kargoapi.GitSubscription{
+				Branch:                  "main",
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategyNewestFromBranch,
+			},
+			assertions: func(errs field.ErrorList) {
+				require.Nil(t, errs)
+			},
+		},
+
+		{
+			name: "valid filtered branch subscription",
+			sub: kargoapi.GitSubscription{
+				IncludePaths:   []string{"apps/**"},
+				ExcludePaths:   []string{"apps/**/*.md"},
+				IgnoreMessages: []string{`\[skip kargo\]`},
+				IgnoreAuthors:  []string{`^renovate\[bot\]`},
+			},
+			assertions: func(errs field.ErrorList) {
+				require.Nil(t, errs)
+			},
+		},
+
+		{
+			name: "valid tag subscription",
+			sub: kargoapi.GitSubscription{
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategySemVer,
+				SemverConstraint:        "^1.0.0",
+				AllowTags:               `^v\d`,
+				IgnoreTags:              []string{`.*-rc\d+`},
+			},
+			assertions: func(errs field.ErrorList) {
+				require.Nil(t, errs)
+			},
+		},
+	}
+	w := &webhook{}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				w.validateGitSub(
+					field.NewPath("git"),
+					testCase.sub,
+				),
+			)
+		})
+	}
+}
+
 func TestValidateImageSubs(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -644,10 +804,71 @@ func TestValidateGitRepoUpdate(t *testing.T) {
 			},
 		},
 
+		{
+			name: "pull request with Bookkeeper",
+			update: kargoapi.GitRepoUpdate{
+				Bookkeeper:  &kargoapi.BookkeeperPromotionMechanism{},
+				PullRequest: &kargoapi.PullRequestPromotionMechanism{},
+			},
+			assertions: func(update kargoapi.GitRepoUpdate, errs field.ErrorList) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "gitRepoUpdate.pullRequest",
+							BadValue: update.PullRequest,
+							Detail: "gitRepoUpdate.pullRequest may not be defined in " +
+								"conjunction with gitRepoUpdate.bookkeeper",
+						},
+					},
+					errs,
+				)
+			},
+		},
+
+		{
+			name: "author with Bookkeeper",
+			update: kargoapi.GitRepoUpdate{
+				Bookkeeper: &kargoapi.BookkeeperPromotionMechanism{},
+				Author:     &kargoapi.GitAuthor{Name: "Fake Author"},
+			},
+			assertions: func(update kargoapi.GitRepoUpdate, errs field.ErrorList) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "gitRepoUpdate.author",
+							BadValue: update.Author,
+							Detail: "gitRepoUpdate.author may not be defined in " +
+								"conjunction with gitRepoUpdate.bookkeeper",
+						},
+					},
+					errs,
+				)
+			},
+		},
+
+		{
+			name: "invalid commit message template",
+			update: kargoapi.GitRepoUpdate{
+				Kustomize:             &kargoapi.KustomizePromotionMechanism{},
+				CommitMessageTemplate: "Promote {{ .Freight.ID",
+			},
+			assertions: func(_ kargoapi.GitRepoUpdate, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, field.ErrorTypeInvalid, errs[0].Type)
+				require.Equal(t, "gitRepoUpdate.commitMessageTemplate", errs[0].Field)
+			},
+		},
+
 		{
 			name: "valid",
 			update: kargoapi.GitRepoUpdate{
-				Kustomize: &kargoapi.KustomizePromotionMechanism{},
+				Kustomize:             &kargoapi.KustomizePromotionMechanism{},
+				CommitMessageTemplate: "Promote {{ .Freight.ID }} to {{ .Stage.Name }}",
+				Author:                &kargoapi.GitAuthor{UsePromotionCreator: true},
 			},
 			assertions: func(_ kargoapi.GitRepoUpdate, errs field.ErrorList) {
 				require.Nil(t, errs)
@@ -731,6 +952,248 @@ func TestValidateHelmPromotionMechanism(t *testing.T) {
 	}
 }
 
+func TestValidateVerifiedImageRefs(t *testing.T) {
+	verifiedSubs := &kargoapi.Subscriptions{
+		Repos: &kargoapi.RepoSubscriptions{
+			Images: []kargoapi.ImageSubscription{
+				{
+					RepoURL: "fake-verified-image",
+					Verification: &kargoapi.ImageVerification{
+						PublicKeys: []string{"fake-key"},
+					},
+				},
+				{
+					RepoURL: "fake-image",
+				},
+			},
+		},
+	}
+	testCases := []struct {
+		name       string
+		spec       *kargoapi.StageSpec
+		assertions func(field.ErrorList)
+	}{
+		{
+			name: "no image subscription specifies verification",
+			spec: &kargoapi.StageSpec{
+				Subscriptions: &kargoapi.Subscriptions{
+					Repos: &kargoapi.RepoSubscriptions{
+						Images: []kargoapi.ImageSubscription{
+							{
+								RepoURL: "fake-image",
+							},
+						},
+					},
+				},
+				PromotionMechanisms: &kargoapi.PromotionMechanisms{
+					GitRepoUpdates: []kargoapi.GitRepoUpdate{
+						{
+							Bookkeeper: &kargoapi.BookkeeperPromotionMechanism{},
+						},
+					},
+				},
+			},
+			assertions: func(errs field.ErrorList) {
+				require.Empty(t, errs)
+			},
+		},
+
+		{
+			name: "verified images referenced by tag",
+			spec: &kargoapi.StageSpec{
+				Subscriptions: verifiedSubs,
+				PromotionMechanisms: &kargoapi.PromotionMechanisms{
+					GitRepoUpdates: []kargoapi.GitRepoUpdate{
+						{
+							Bookkeeper: &kargoapi.BookkeeperPromotionMechanism{},
+						},
+						{
+							Kustomize: &kargoapi.KustomizePromotionMechanism{
+								Images: []kargoapi.KustomizeImageUpdate{
+									{
+										Image: "fake-verified-image",
+									},
+									{
+										Image: "fake-image",
+									},
+								},
+							},
+						},
+						{
+							Helm: &kargoapi.HelmPromotionMechanism{
+								Images: []kargoapi.HelmImageUpdate{
+									{
+										Image: "fake-verified-image",
+										Value: kargoapi.ImageUpdateValueTypeTag,
+									},
+								},
+							},
+						},
+					},
+					ArgoCDAppUpdates: []kargoapi.ArgoCDAppUpdate{
+						{
+							SourceUpdates: []kargoapi.ArgoCDSourceUpdate{
+								{
+									Kustomize: &kargoapi.ArgoCDKustomize{
+										Images: []string{"fake-image", "fake-verified-image"},
+									},
+									Helm: &kargoapi.ArgoCDHelm{
+										Images: []kargoapi.ArgoCDHelmImageUpdate{
+											{
+												Image: "fake-verified-image",
+												Value: kargoapi.ImageUpdateValueTypeImage,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			assertions: func(errs field.ErrorList) {
+				require.Len(t, errs, 5)
+				fields := make([]string, len(errs))
+				for i, err := range errs {
+					fields[i] = err.Field
+				}
+				require.Equal(
+					t,
+					[]string{
+						"promotionMechanisms.gitRepoUpdates[0].bookkeeper",
+						"promotionMechanisms.gitRepoUpdates[1].kustomize.images[0].value",
+						"promotionMechanisms.gitRepoUpdates[2].helm.images[0].value",
+						"promotionMechanisms.argoCDAppUpdates[0].sourceUpdates[0]." +
+							"kustomize.value",
+						"promotionMechanisms.argoCDAppUpdates[0].sourceUpdates[0]." +
+							"helm.images[0].value",
+					},
+					fields,
+				)
+				require.Contains(t, errs[1].Detail, "must be referenced by digest")
+			},
+		},
+
+		{
+			name: "verified images referenced by digest",
+			spec: &kargoapi.StageSpec{
+				Subscriptions: verifiedSubs,
+				PromotionMechanisms: &kargoapi.PromotionMechanisms{
+					GitRepoUpdates: []kargoapi.GitRepoUpdate{
+						{
+							Kustomize: &kargoapi.KustomizePromotionMechanism{
+								Images: []kargoapi.KustomizeImageUpdate{
+									{
+										Image: "fake-verified-image",
+										Value: kargoapi.ImageUpdateValueTypeDigest,
+									},
+								},
+							},
+						},
+					},
+					ArgoCDAppUpdates: []kargoapi.ArgoCDAppUpdate{
+						{
+							SourceUpdates: []kargoapi.ArgoCDSourceUpdate{
+								{
+									Kustomize: &kargoapi.ArgoCDKustomize{
+										Images: []string{"fake-verified-image"},
+										Value:  kargoapi.ImageUpdateValueTypeTagAtDigest,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			assertions: func(errs field.ErrorList) {
+				require.Empty(t, errs)
+			},
+		},
+	}
+	w := &webhook{}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				w.validateVerifiedImageRefs(
+					field.NewPath("promotionMechanisms"),
+					testCase.spec,
+				),
+			)
+		})
+	}
+}
+
+func TestValidateVerification(t *testing.T) {
+	testCases := []struct {
+		name         string
+		verification *kargoapi.Verification
+		assertions   func(field.ErrorList)
+	}{
+		{
+			name: "nil",
+			assertions: func(errs field.ErrorList) {
+				require.Empty(t, errs)
+			},
+		},
+
+		{
+			name: "invalid",
+			// Has duplicate Job names
+			verification: &kargoapi.Verification{
+				Jobs: []kargoapi.VerificationJob{
+					{
+						Name: "smoke-tests",
+					},
+					{
+						Name: "smoke-tests",
+					},
+				},
+			},
+			assertions: func(errs field.ErrorList) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						{
+							Type:     field.ErrorTypeDuplicate,
+							Field:    "verification.jobs[1].name",
+							BadValue: "smoke-tests",
+						},
+					},
+					errs,
+				)
+			},
+		},
+
+		{
+			name: "valid",
+			verification: &kargoapi.Verification{
+				Jobs: []kargoapi.VerificationJob{
+					{
+						Name: "smoke-tests",
+					},
+					{
+						Name: "integration-tests",
+					},
+				},
+			},
+			assertions: func(errs field.ErrorList) {
+				require.Empty(t, errs)
+			},
+		},
+	}
+	w := &webhook{}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				w.validateVerification(
+					field.NewPath("verification"),
+					testCase.verification,
+				),
+			)
+		})
+	}
+}
+
 func TestValidateSemverConstraint(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -11,7 +11,7 @@ import {
   promoteStage,
   promoteSubscribers
 } from '@ui/gen/service/v1alpha1/service-KargoService_connectquery';
-import { Freight, GitCommit, Stage } from '@ui/gen/v1alpha1/types_pb';
+import { Freight, GitCommit, Image, Stage } from '@ui/gen/v1alpha1/types_pb';
 
 export type PromotionType = 'default' | 'subscribers';
 
@@ -197,6 +197,33 @@ const CommitInfo = ({ commit }: { commit: GitCommit }) => (
   </div>
 );
 
+const ImageInfo = ({ image }: { image: Image }) => (
+  <div className='grid grid-cols-2'>
+    <div>Image:</div>
+    <div>{`${image.repoUrl}:${image.tag}`}</div>
+    {image.sourceRepoUrl && (
+      <>
+        <div>Source:</div>
+        <div>
+          <a href={image.gitRepoUrl || image.sourceRepoUrl}>{image.sourceRepoUrl}</a>
+        </div>
+      </>
+    )}
+    {image.sourceCommitId && (
+      <>
+        <div>Commit:</div>
+        <div>{image.sourceCommitId}</div>
+      </>
+    )}
+    {image.buildTime && (
+      <>
+        <div>Built:</div>
+        <div>{formatDistance(image.buildTime.toDate(), new Date(), { addSuffix: true })}</div>
+      </>
+    )}
+  </div>
+);
+
 const FreightContents = (props: {
   freight?: Freight;
   highlighted: boolean;
@@ -241,7 +268,7 @@ const FreightContents = (props: {
       {(freight?.images || []).map((i) => (
         <FreightContentItem
           key={`${i.repoUrl}:${i.tag}`}
-          title={`${i.repoUrl}:${i.tag}`}
+          overlay={<ImageInfo image={i} />}
           icon={faDocker}
         >
           <div>{i.tag}</div>
@@ -5,7 +5,7 @@ import { generatePath, useNavigate } from 'react-router-dom';
 
 import { paths } from '@ui/config/paths';
 import { getStageColors } from '@ui/features/stage/utils';
-import { Stage } from '@ui/gen/v1alpha1/types_pb';
+import { Freight, Stage } from '@ui/gen/v1alpha1/types_pb';
 import { useLocalStorage } from '@ui/utils/use-local-storage';
 
 interface StagePixelStyle {
@@ -76,14 +76,26 @@ const ImageTagRow = ({
   );
 };
 
-export const Images = ({ projectName, stages }: { projectName: string; stages: Stage[] }) => {
+export const Images = ({
+  projectName,
+  stages,
+  freight
+}: {
+  projectName: string;
+  stages: Stage[];
+  freight: Freight[];
+}) => {
   const images = useMemo(() => {
     const images = new Map<string, Map<string, StageStyleMap>>();
     const colors = getStageColors([...stages]);
+    // A Stage's history only references Freight by ID
+    const freightById = new Map<string, Freight>();
+    freight.forEach((f) => freightById.set(f.id, f));
     stages.forEach((stage) => {
       const len = stage.status?.history?.length || 0;
-      stage.status?.history?.forEach((freight, i) => {
-        freight.images?.forEach((image) => {
+      stage.status?.history?.forEach((ref, i) => {
+        const freightImages = freightById.get(ref.id)?.images || ref.images;
+        freightImages?.forEach((image) => {
           let repo = images.get(image.repoUrl);
           if (!repo) {
             repo = new Map<string, StageStyleMap>();
@@ -119,7 +131,7 @@ export const Images = ({ projectName, stages }: { projectName: string; stages: S
       });
     });
     return images;
-  }, [stages]);
+  }, [stages, freight]);
 
   const [imageURL, setImageURL] = useState(images.keys().next().value as string);
   const image = imageURL && images.get(imageURL);
@@ -361,7 +361,11 @@ export const ProjectDetails = () => {
             <FontAwesomeIcon icon={faDocker} className='mr-2' /> IMAGES
           </h3>
           <div className='p-4'>
-            <Images projectName={name as string} stages={sortedStages} />
+            <Images
+              projectName={name as string}
+              stages={sortedStages}
+              freight={freightData?.groups['']?.freight || []}
+            />
           </div>
         </div>
       </div>
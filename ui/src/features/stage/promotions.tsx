@@ -3,7 +3,10 @@ import {
   faCircleCheck,
   faCircleExclamation,
   faCircleNotch,
-  faCircleQuestion
+  faCircleQuestion,
+  faCircleStop,
+  faCodePullRequest,
+  faForward
 } from '@fortawesome/free-solid-svg-icons';
 import { FontAwesomeIcon } from '@fortawesome/react-fontawesome';
 import { useQuery, useQueryClient } from '@tanstack/react-query';
@@ -112,6 +115,24 @@ export const Promotions = () => {
                 />
               </Popover>
             );
+          case 'Aborted':
+            return (
+              <Popover content={promotion.status.message} title='Aborted' placement='right'>
+                <FontAwesomeIcon color='#aaa' icon={faCircleStop} size='lg' />
+              </Popover>
+            );
+          case 'Superseded':
+            return (
+              <Popover content={promotion.status.message} title='Superseded' placement='right'>
+                <FontAwesomeIcon color='#aaa' icon={faForward} size='lg' />
+              </Popover>
+            );
+          case 'Waiting':
+            return (
+              <Popover content={promotion.status.message} title='Waiting' placement='right'>
+                <FontAwesomeIcon icon={faCodePullRequest} size='lg' />
+              </Popover>
+            );
           case 'Pending':
           case 'Running':
             return (
@@ -1566,6 +1566,11 @@ export class PromoteStageRequest extends Message<PromoteStageRequest> {
    */
   freight = "";
 
+  /**
+   * @generated from field: optional int32 priority = 4;
+   */
+  priority?: number;
+
   constructor(data?: PartialMessage<PromoteStageRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1577,6 +1582,7 @@ export class PromoteStageRequest extends Message<PromoteStageRequest> {
     { no: 1, name: "project", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 3, name: "freight", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "priority", kind: "scalar", T: 5 /* ScalarType.INT32 */, opt: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PromoteStageRequest {
@@ -1799,6 +1805,105 @@ export class RefreshStageResponse extends Message<RefreshStageResponse> {
   }
 }
 
+/**
+ * @generated from message akuity.io.kargo.service.v1alpha1.RollbackStageRequest
+ */
+export class RollbackStageRequest extends Message<RollbackStageRequest> {
+  /**
+   * @generated from field: string project = 1;
+   */
+  project = "";
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name = "";
+
+  /**
+   * steps is the number of distinct Freight to step back through the Stage's
+   * history. Entries for the current Freight and entries repeating the Freight
+   * of the previous step are not counted. It defaults to 1 and is mutually exclusive
+   * with freight.
+   *
+   * @generated from field: optional uint32 steps = 3;
+   */
+  steps?: number;
+
+  /**
+   * freight is the ID of a Freight in the Stage's history to roll back to.
+   *
+   * @generated from field: optional string freight = 4;
+   */
+  freight?: string;
+
+  constructor(data?: PartialMessage<RollbackStageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "akuity.io.kargo.service.v1alpha1.RollbackStageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "project", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "steps", kind: "scalar", T: 13 /* ScalarType.UINT32 */, opt: true },
+    { no: 4, name: "freight", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RollbackStageRequest {
+    return new RollbackStageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RollbackStageRequest {
+    return new RollbackStageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RollbackStageRequest {
+    return new RollbackStageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RollbackStageRequest | PlainMessage<RollbackStageRequest> | undefined, b: RollbackStageRequest | PlainMessage<RollbackStageRequest> | undefined): boolean {
+    return proto3.util.equals(RollbackStageRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message akuity.io.kargo.service.v1alpha1.RollbackStageResponse
+ */
+export class RollbackStageResponse extends Message<RollbackStageResponse> {
+  /**
+   * @generated from field: github.com.akuity.kargo.pkg.api.v1alpha1.Promotion promotion = 1;
+   */
+  promotion?: Promotion;
+
+  constructor(data?: PartialMessage<RollbackStageResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "akuity.io.kargo.service.v1alpha1.RollbackStageResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "promotion", kind: "message", T: Promotion },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RollbackStageResponse {
+    return new RollbackStageResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RollbackStageResponse {
+    return new RollbackStageResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RollbackStageResponse {
+    return new RollbackStageResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RollbackStageResponse | PlainMessage<RollbackStageResponse> | undefined, b: RollbackStageResponse | PlainMessage<RollbackStageResponse> | undefined): boolean {
+    return proto3.util.equals(RollbackStageResponse, a, b);
+  }
+}
+
 /**
  * @generated from message akuity.io.kargo.service.v1alpha1.TypedPromotionPolicySpec
  */
@@ -2186,6 +2291,86 @@ export class WatchPromotionResponse extends Message<WatchPromotionResponse> {
   }
 }
 
+/**
+ * @generated from message akuity.io.kargo.service.v1alpha1.AbortPromotionRequest
+ */
+export class AbortPromotionRequest extends Message<AbortPromotionRequest> {
+  /**
+   * @generated from field: string project = 1;
+   */
+  project = "";
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name = "";
+
+  constructor(data?: PartialMessage<AbortPromotionRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "akuity.io.kargo.service.v1alpha1.AbortPromotionRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "project", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AbortPromotionRequest {
+    return new AbortPromotionRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AbortPromotionRequest {
+    return new AbortPromotionRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AbortPromotionRequest {
+    return new AbortPromotionRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AbortPromotionRequest | PlainMessage<AbortPromotionRequest> | undefined, b: AbortPromotionRequest | PlainMessage<AbortPromotionRequest> | undefined): boolean {
+    return proto3.util.equals(AbortPromotionRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message akuity.io.kargo.service.v1alpha1.AbortPromotionResponse
+ */
+export class AbortPromotionResponse extends Message<AbortPromotionResponse> {
+  /**
+   * @generated from field: github.com.akuity.kargo.pkg.api.v1alpha1.Promotion promotion = 1;
+   */
+  promotion?: Promotion;
+
+  constructor(data?: PartialMessage<AbortPromotionResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "akuity.io.kargo.service.v1alpha1.AbortPromotionResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "promotion", kind: "message", T: Promotion },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AbortPromotionResponse {
+    return new AbortPromotionResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AbortPromotionResponse {
+    return new AbortPromotionResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AbortPromotionResponse {
+    return new AbortPromotionResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AbortPromotionResponse | PlainMessage<AbortPromotionResponse> | undefined, b: AbortPromotionResponse | PlainMessage<AbortPromotionResponse> | undefined): boolean {
+    return proto3.util.equals(AbortPromotionResponse, a, b);
+  }
+}
+
 /**
  * @generated from message akuity.io.kargo.service.v1alpha1.SetAutoPromotionForStageRequest
  */
@@ -3074,3 +3259,89 @@ export class FreightList extends Message<FreightList> {
   }
 }
 
+/**
+ * @generated from message akuity.io.kargo.service.v1alpha1.ApproveFreightRequest
+ */
+export class ApproveFreightRequest extends Message<ApproveFreightRequest> {
+  /**
+   * @generated from field: string project = 1;
+   */
+  project = "";
+
+  /**
+   * @generated from field: string id = 2;
+   */
+  id = "";
+
+  /**
+   * @generated from field: string stage = 3;
+   */
+  stage = "";
+
+  constructor(data?: PartialMessage<ApproveFreightRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "akuity.io.kargo.service.v1alpha1.ApproveFreightRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "project", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "stage", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ApproveFreightRequest {
+    return new ApproveFreightRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ApproveFreightRequest {
+    return new ApproveFreightRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ApproveFreightRequest {
+    return new ApproveFreightRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ApproveFreightRequest | PlainMessage<ApproveFreightRequest> | undefined, b: ApproveFreightRequest | PlainMessage<ApproveFreightRequest> | undefined): boolean {
+    return proto3.util.equals(ApproveFreightRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message akuity.io.kargo.service.v1alpha1.ApproveFreightResponse
+ */
+export class ApproveFreightResponse extends Message<ApproveFreightResponse> {
+  /**
+   * @generated from field: github.com.akuity.kargo.pkg.api.v1alpha1.Freight freight = 1;
+   */
+  freight?: Freight;
+
+  constructor(data?: PartialMessage<ApproveFreightResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "akuity.io.kargo.service.v1alpha1.ApproveFreightResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "freight", kind: "message", T: Freight },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ApproveFreightResponse {
+    return new ApproveFreightResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ApproveFreightResponse {
+    return new ApproveFreightResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ApproveFreightResponse {
+    return new ApproveFreightResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ApproveFreightResponse | PlainMessage<ApproveFreightResponse> | undefined, b: ApproveFreightResponse | PlainMessage<ApproveFreightResponse> | undefined): boolean {
+    return proto3.util.equals(ApproveFreightResponse, a, b);
+  }
+}
+
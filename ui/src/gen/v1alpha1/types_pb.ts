@@ -4,7 +4,7 @@
 // @ts-nocheck
 
 import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
-import { Message, proto3, Timestamp } from "@bufbuild/protobuf";
+import { Message, proto3, Struct, Timestamp } from "@bufbuild/protobuf";
 import { ListMeta, ObjectMeta } from "../metav1/types_pb.js";
 
 /**
@@ -151,6 +151,11 @@ export class ArgoCDKustomize extends Message<ArgoCDKustomize> {
    */
   images: string[] = [];
 
+  /**
+   * @generated from field: optional string value = 2;
+   */
+  value?: string;
+
   constructor(data?: PartialMessage<ArgoCDKustomize>) {
     super();
     proto3.util.initPartial(data, this);
@@ -160,6 +165,7 @@ export class ArgoCDKustomize extends Message<ArgoCDKustomize> {
   static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.ArgoCDKustomize";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "images", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 2, name: "value", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ArgoCDKustomize {
@@ -369,6 +375,55 @@ export class ChartSubscription extends Message<ChartSubscription> {
   }
 }
 
+/**
+ * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.GitAuthor
+ */
+export class GitAuthor extends Message<GitAuthor> {
+  /**
+   * @generated from field: optional string name = 1;
+   */
+  name?: string;
+
+  /**
+   * @generated from field: optional string email = 2;
+   */
+  email?: string;
+
+  /**
+   * @generated from field: optional bool use_promotion_creator = 3;
+   */
+  usePromotionCreator?: boolean;
+
+  constructor(data?: PartialMessage<GitAuthor>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.GitAuthor";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 2, name: "email", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 3, name: "use_promotion_creator", kind: "scalar", T: 8 /* ScalarType.BOOL */, opt: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GitAuthor {
+    return new GitAuthor().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GitAuthor {
+    return new GitAuthor().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GitAuthor {
+    return new GitAuthor().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GitAuthor | PlainMessage<GitAuthor> | undefined, b: GitAuthor | PlainMessage<GitAuthor> | undefined): boolean {
+    return proto3.util.equals(GitAuthor, a, b);
+  }
+}
+
 /**
  * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.GitCommit
  */
@@ -403,6 +458,11 @@ export class GitCommit extends Message<GitCommit> {
    */
   author = "";
 
+  /**
+   * @generated from field: string tag = 7;
+   */
+  tag = "";
+
   constructor(data?: PartialMessage<GitCommit>) {
     super();
     proto3.util.initPartial(data, this);
@@ -417,6 +477,7 @@ export class GitCommit extends Message<GitCommit> {
     { no: 4, name: "health_check_commit", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
     { no: 5, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 6, name: "author", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "tag", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GitCommit {
@@ -470,6 +531,21 @@ export class GitRepoUpdate extends Message<GitRepoUpdate> {
    */
   helm?: HelmPromotionMechanism;
 
+  /**
+   * @generated from field: optional github.com.akuity.kargo.pkg.api.v1alpha1.PullRequestPromotionMechanism pull_request = 7;
+   */
+  pullRequest?: PullRequestPromotionMechanism;
+
+  /**
+   * @generated from field: optional string commit_message_template = 8;
+   */
+  commitMessageTemplate?: string;
+
+  /**
+   * @generated from field: optional github.com.akuity.kargo.pkg.api.v1alpha1.GitAuthor author = 9;
+   */
+  author?: GitAuthor;
+
   constructor(data?: PartialMessage<GitRepoUpdate>) {
     super();
     proto3.util.initPartial(data, this);
@@ -484,6 +560,9 @@ export class GitRepoUpdate extends Message<GitRepoUpdate> {
     { no: 4, name: "bookkeeper", kind: "message", T: BookkeeperPromotionMechanism, opt: true },
     { no: 5, name: "kustomize", kind: "message", T: KustomizePromotionMechanism, opt: true },
     { no: 6, name: "helm", kind: "message", T: HelmPromotionMechanism, opt: true },
+    { no: 7, name: "pull_request", kind: "message", T: PullRequestPromotionMechanism, opt: true },
+    { no: 8, name: "commit_message_template", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 9, name: "author", kind: "message", T: GitAuthor, opt: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GitRepoUpdate {
@@ -517,6 +596,46 @@ export class GitSubscription extends Message<GitSubscription> {
    */
   branch = "";
 
+  /**
+   * @generated from field: string commit_selection_strategy = 3;
+   */
+  commitSelectionStrategy = "";
+
+  /**
+   * @generated from field: optional string semver_constraint = 4;
+   */
+  semverConstraint?: string;
+
+  /**
+   * @generated from field: optional string allow_tags = 5;
+   */
+  allowTags?: string;
+
+  /**
+   * @generated from field: repeated string ignore_tags = 6;
+   */
+  ignoreTags: string[] = [];
+
+  /**
+   * @generated from field: repeated string include_paths = 7;
+   */
+  includePaths: string[] = [];
+
+  /**
+   * @generated from field: repeated string exclude_paths = 8;
+   */
+  excludePaths: string[] = [];
+
+  /**
+   * @generated from field: repeated string ignore_messages = 9;
+   */
+  ignoreMessages: string[] = [];
+
+  /**
+   * @generated from field: repeated string ignore_authors = 10;
+   */
+  ignoreAuthors: string[] = [];
+
   constructor(data?: PartialMessage<GitSubscription>) {
     super();
     proto3.util.initPartial(data, this);
@@ -527,6 +646,14 @@ export class GitSubscription extends Message<GitSubscription> {
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "repo_url", jsonName: "repoURL", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "branch", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "commit_selection_strategy", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "semver_constraint", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 5, name: "allow_tags", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 6, name: "ignore_tags", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 7, name: "include_paths", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 8, name: "exclude_paths", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 9, name: "ignore_messages", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 10, name: "ignore_authors", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GitSubscription {
@@ -903,6 +1030,31 @@ export class Image extends Message<Image> {
    */
   tag = "";
 
+  /**
+   * @generated from field: optional string digest = 3;
+   */
+  digest?: string;
+
+  /**
+   * @generated from field: optional string git_repo_url = 4 [json_name = "gitRepoURL"];
+   */
+  gitRepoUrl?: string;
+
+  /**
+   * @generated from field: optional string source_repo_url = 5 [json_name = "sourceRepoURL"];
+   */
+  sourceRepoUrl?: string;
+
+  /**
+   * @generated from field: optional string source_commit_id = 6 [json_name = "sourceCommitID"];
+   */
+  sourceCommitId?: string;
+
+  /**
+   * @generated from field: optional google.protobuf.Timestamp build_time = 7;
+   */
+  buildTime?: Timestamp;
+
   constructor(data?: PartialMessage<Image>) {
     super();
     proto3.util.initPartial(data, this);
@@ -913,6 +1065,11 @@ export class Image extends Message<Image> {
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "repo_url", jsonName: "repoURL", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "tag", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "digest", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 4, name: "git_repo_url", jsonName: "gitRepoURL", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 5, name: "source_repo_url", jsonName: "sourceRepoURL", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 6, name: "source_commit_id", jsonName: "sourceCommitID", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 7, name: "build_time", kind: "message", T: Timestamp, opt: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Image {
@@ -966,6 +1123,11 @@ export class ImageSubscription extends Message<ImageSubscription> {
    */
   platform?: string;
 
+  /**
+   * @generated from field: optional github.com.akuity.kargo.pkg.api.v1alpha1.ImageVerification verification = 7;
+   */
+  verification?: ImageVerification;
+
   constructor(data?: PartialMessage<ImageSubscription>) {
     super();
     proto3.util.initPartial(data, this);
@@ -980,6 +1142,7 @@ export class ImageSubscription extends Message<ImageSubscription> {
     { no: 4, name: "allow_tags", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
     { no: 5, name: "ignore_tags", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
     { no: 6, name: "platform", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 7, name: "verification", kind: "message", T: ImageVerification, opt: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ImageSubscription {
@@ -999,6 +1162,159 @@ export class ImageSubscription extends Message<ImageSubscription> {
   }
 }
 
+/**
+ * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.ImageVerification
+ */
+export class ImageVerification extends Message<ImageVerification> {
+  /**
+   * @generated from field: repeated string public_keys = 1;
+   */
+  publicKeys: string[] = [];
+
+  /**
+   * @generated from field: optional github.com.akuity.kargo.pkg.api.v1alpha1.KeylessVerification keyless = 2;
+   */
+  keyless?: KeylessVerification;
+
+  /**
+   * @generated from field: repeated string attestations = 3;
+   */
+  attestations: string[] = [];
+
+  constructor(data?: PartialMessage<ImageVerification>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.ImageVerification";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "public_keys", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 2, name: "keyless", kind: "message", T: KeylessVerification, opt: true },
+    { no: 3, name: "attestations", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ImageVerification {
+    return new ImageVerification().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ImageVerification {
+    return new ImageVerification().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ImageVerification {
+    return new ImageVerification().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ImageVerification | PlainMessage<ImageVerification> | undefined, b: ImageVerification | PlainMessage<ImageVerification> | undefined): boolean {
+    return proto3.util.equals(ImageVerification, a, b);
+  }
+}
+
+/**
+ * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.KeylessIdentity
+ */
+export class KeylessIdentity extends Message<KeylessIdentity> {
+  /**
+   * @generated from field: optional string issuer = 1;
+   */
+  issuer?: string;
+
+  /**
+   * @generated from field: optional string issuer_regexp = 2;
+   */
+  issuerRegexp?: string;
+
+  /**
+   * @generated from field: optional string subject = 3;
+   */
+  subject?: string;
+
+  /**
+   * @generated from field: optional string subject_regexp = 4;
+   */
+  subjectRegexp?: string;
+
+  constructor(data?: PartialMessage<KeylessIdentity>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.KeylessIdentity";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "issuer", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 2, name: "issuer_regexp", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 3, name: "subject", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 4, name: "subject_regexp", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): KeylessIdentity {
+    return new KeylessIdentity().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): KeylessIdentity {
+    return new KeylessIdentity().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): KeylessIdentity {
+    return new KeylessIdentity().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: KeylessIdentity | PlainMessage<KeylessIdentity> | undefined, b: KeylessIdentity | PlainMessage<KeylessIdentity> | undefined): boolean {
+    return proto3.util.equals(KeylessIdentity, a, b);
+  }
+}
+
+/**
+ * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.KeylessVerification
+ */
+export class KeylessVerification extends Message<KeylessVerification> {
+  /**
+   * @generated from field: repeated github.com.akuity.kargo.pkg.api.v1alpha1.KeylessIdentity identities = 1;
+   */
+  identities: KeylessIdentity[] = [];
+
+  /**
+   * @generated from field: string root_certificates = 2;
+   */
+  rootCertificates = "";
+
+  /**
+   * @generated from field: string transparency_log_public_key = 3;
+   */
+  transparencyLogPublicKey = "";
+
+  constructor(data?: PartialMessage<KeylessVerification>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.KeylessVerification";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "identities", kind: "message", T: KeylessIdentity, repeated: true },
+    { no: 2, name: "root_certificates", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "transparency_log_public_key", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): KeylessVerification {
+    return new KeylessVerification().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): KeylessVerification {
+    return new KeylessVerification().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): KeylessVerification {
+    return new KeylessVerification().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: KeylessVerification | PlainMessage<KeylessVerification> | undefined, b: KeylessVerification | PlainMessage<KeylessVerification> | undefined): boolean {
+    return proto3.util.equals(KeylessVerification, a, b);
+  }
+}
+
 /**
  * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.KustomizeImageUpdate
  */
@@ -1013,6 +1329,11 @@ export class KustomizeImageUpdate extends Message<KustomizeImageUpdate> {
    */
   path = "";
 
+  /**
+   * @generated from field: optional string value = 3;
+   */
+  value?: string;
+
   constructor(data?: PartialMessage<KustomizeImageUpdate>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1023,6 +1344,7 @@ export class KustomizeImageUpdate extends Message<KustomizeImageUpdate> {
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "image", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "value", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): KustomizeImageUpdate {
@@ -1140,6 +1462,49 @@ export class Promotion extends Message<Promotion> {
   }
 }
 
+/**
+ * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.PromotionAttempt
+ */
+export class PromotionAttempt extends Message<PromotionAttempt> {
+  /**
+   * @generated from field: optional google.protobuf.Timestamp started_at = 1;
+   */
+  startedAt?: Timestamp;
+
+  /**
+   * @generated from field: optional string error = 2;
+   */
+  error?: string;
+
+  constructor(data?: PartialMessage<PromotionAttempt>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.PromotionAttempt";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "started_at", kind: "message", T: Timestamp, opt: true },
+    { no: 2, name: "error", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PromotionAttempt {
+    return new PromotionAttempt().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PromotionAttempt {
+    return new PromotionAttempt().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PromotionAttempt {
+    return new PromotionAttempt().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: PromotionAttempt | PlainMessage<PromotionAttempt> | undefined, b: PromotionAttempt | PlainMessage<PromotionAttempt> | undefined): boolean {
+    return proto3.util.equals(PromotionAttempt, a, b);
+  }
+}
+
 /**
  * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.PromotionInfo
  */
@@ -1183,6 +1548,49 @@ export class PromotionInfo extends Message<PromotionInfo> {
   }
 }
 
+/**
+ * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.PromotionLease
+ */
+export class PromotionLease extends Message<PromotionLease> {
+  /**
+   * @generated from field: optional string holder_identity = 1;
+   */
+  holderIdentity?: string;
+
+  /**
+   * @generated from field: optional google.protobuf.Timestamp renew_time = 2;
+   */
+  renewTime?: Timestamp;
+
+  constructor(data?: PartialMessage<PromotionLease>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.PromotionLease";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "holder_identity", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 2, name: "renew_time", kind: "message", T: Timestamp, opt: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PromotionLease {
+    return new PromotionLease().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PromotionLease {
+    return new PromotionLease().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PromotionLease {
+    return new PromotionLease().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: PromotionLease | PlainMessage<PromotionLease> | undefined, b: PromotionLease | PlainMessage<PromotionLease> | undefined): boolean {
+    return proto3.util.equals(PromotionLease, a, b);
+  }
+}
+
 /**
  * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.PromotionList
  */
@@ -1298,6 +1706,31 @@ export class PromotionPolicy extends Message<PromotionPolicy> {
    */
   enableAutoPromotion = false;
 
+  /**
+   * @generated from field: repeated github.com.akuity.kargo.pkg.api.v1alpha1.PromotionWindow windows = 6;
+   */
+  windows: PromotionWindow[] = [];
+
+  /**
+   * @generated from field: repeated github.com.akuity.kargo.pkg.api.v1alpha1.PromotionWindow blackouts = 7;
+   */
+  blackouts: PromotionWindow[] = [];
+
+  /**
+   * @generated from field: optional github.com.akuity.kargo.pkg.api.v1alpha1.PromotionRetry retry = 8;
+   */
+  retry?: PromotionRetry;
+
+  /**
+   * @generated from field: optional string timeout = 9;
+   */
+  timeout?: string;
+
+  /**
+   * @generated from field: optional bool supersede = 10;
+   */
+  supersede?: boolean;
+
   constructor(data?: PartialMessage<PromotionPolicy>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1311,6 +1744,11 @@ export class PromotionPolicy extends Message<PromotionPolicy> {
     { no: 3, name: "metadata", kind: "message", T: ObjectMeta },
     { no: 4, name: "stage", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 5, name: "enable_auto_promotion", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 6, name: "windows", kind: "message", T: PromotionWindow, repeated: true },
+    { no: 7, name: "blackouts", kind: "message", T: PromotionWindow, repeated: true },
+    { no: 8, name: "retry", kind: "message", T: PromotionRetry, opt: true },
+    { no: 9, name: "timeout", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 10, name: "supersede", kind: "scalar", T: 8 /* ScalarType.BOOL */, opt: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PromotionPolicy {
@@ -1374,29 +1812,96 @@ export class PromotionPolicyList extends Message<PromotionPolicyList> {
 }
 
 /**
- * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.PromotionSpec
+ * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.PromotionRetry
  */
-export class PromotionSpec extends Message<PromotionSpec> {
+export class PromotionRetry extends Message<PromotionRetry> {
   /**
-   * @generated from field: string stage = 1;
+   * @generated from field: int32 max_attempts = 1;
    */
-  stage = "";
+  maxAttempts = 0;
 
   /**
-   * @generated from field: string freight = 2;
+   * @generated from field: optional string backoff = 2;
    */
-  freight = "";
+  backoff?: string;
 
-  constructor(data?: PartialMessage<PromotionSpec>) {
+  /**
+   * @generated from field: optional string max_backoff = 3;
+   */
+  maxBackoff?: string;
+
+  constructor(data?: PartialMessage<PromotionRetry>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.PromotionSpec";
+  static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.PromotionRetry";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "max_attempts", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 2, name: "backoff", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 3, name: "max_backoff", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PromotionRetry {
+    return new PromotionRetry().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PromotionRetry {
+    return new PromotionRetry().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PromotionRetry {
+    return new PromotionRetry().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: PromotionRetry | PlainMessage<PromotionRetry> | undefined, b: PromotionRetry | PlainMessage<PromotionRetry> | undefined): boolean {
+    return proto3.util.equals(PromotionRetry, a, b);
+  }
+}
+
+/**
+ * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.PromotionSpec
+ */
+export class PromotionSpec extends Message<PromotionSpec> {
+  /**
+   * @generated from field: string stage = 1;
+   */
+  stage = "";
+
+  /**
+   * @generated from field: string freight = 2;
+   */
+  freight = "";
+
+  /**
+   * @generated from field: optional github.com.akuity.kargo.pkg.api.v1alpha1.Freight freight_snapshot = 3;
+   */
+  freightSnapshot?: Freight;
+
+  /**
+   * @generated from field: optional bool rollback = 4;
+   */
+  rollback?: boolean;
+
+  /**
+   * @generated from field: optional int32 priority = 5;
+   */
+  priority?: number;
+
+  constructor(data?: PartialMessage<PromotionSpec>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.PromotionSpec";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "stage", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "freight", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "freight_snapshot", kind: "message", T: Freight, opt: true },
+    { no: 4, name: "rollback", kind: "scalar", T: 8 /* ScalarType.BOOL */, opt: true },
+    { no: 5, name: "priority", kind: "scalar", T: 5 /* ScalarType.INT32 */, opt: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PromotionSpec {
@@ -1430,6 +1935,31 @@ export class PromotionStatus extends Message<PromotionStatus> {
    */
   error = "";
 
+  /**
+   * @generated from field: optional string message = 3;
+   */
+  message?: string;
+
+  /**
+   * @generated from field: repeated github.com.akuity.kargo.pkg.api.v1alpha1.PromotionAttempt attempts = 4;
+   */
+  attempts: PromotionAttempt[] = [];
+
+  /**
+   * @generated from field: optional google.protobuf.Timestamp next_attempt_at = 5;
+   */
+  nextAttemptAt?: Timestamp;
+
+  /**
+   * @generated from field: optional github.com.akuity.kargo.pkg.api.v1alpha1.PromotionLease lease = 6;
+   */
+  lease?: PromotionLease;
+
+  /**
+   * @generated from field: repeated github.com.akuity.kargo.pkg.api.v1alpha1.PromotionStep steps = 7;
+   */
+  steps: PromotionStep[] = [];
+
   constructor(data?: PartialMessage<PromotionStatus>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1440,6 +1970,11 @@ export class PromotionStatus extends Message<PromotionStatus> {
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "phase", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 4, name: "attempts", kind: "message", T: PromotionAttempt, repeated: true },
+    { no: 5, name: "next_attempt_at", kind: "message", T: Timestamp, opt: true },
+    { no: 6, name: "lease", kind: "message", T: PromotionLease, opt: true },
+    { no: 7, name: "steps", kind: "message", T: PromotionStep, repeated: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PromotionStatus {
@@ -1459,6 +1994,244 @@ export class PromotionStatus extends Message<PromotionStatus> {
   }
 }
 
+/**
+ * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.PromotionStep
+ */
+export class PromotionStep extends Message<PromotionStep> {
+  /**
+   * @generated from field: string mechanism = 1;
+   */
+  mechanism = "";
+
+  /**
+   * @generated from field: optional google.protobuf.Timestamp started_at = 2;
+   */
+  startedAt?: Timestamp;
+
+  /**
+   * @generated from field: optional google.protobuf.Timestamp finished_at = 3;
+   */
+  finishedAt?: Timestamp;
+
+  /**
+   * @generated from field: optional string repo_url = 4 [json_name = "repoURL"];
+   */
+  repoUrl?: string;
+
+  /**
+   * @generated from field: optional string branch = 5;
+   */
+  branch?: string;
+
+  /**
+   * @generated from field: optional string commit_id = 6 [json_name = "commitID"];
+   */
+  commitId?: string;
+
+  /**
+   * @generated from field: optional string application = 7;
+   */
+  application?: string;
+
+  /**
+   * @generated from field: string phase = 8;
+   */
+  phase = "";
+
+  /**
+   * @generated from field: optional string message = 9;
+   */
+  message?: string;
+
+  /**
+   * @generated from field: optional string pull_request_url = 10 [json_name = "pullRequestURL"];
+   */
+  pullRequestUrl?: string;
+
+  /**
+   * @generated from field: optional string signing_key_fingerprint = 11;
+   */
+  signingKeyFingerprint?: string;
+
+  constructor(data?: PartialMessage<PromotionStep>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.PromotionStep";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "mechanism", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "started_at", kind: "message", T: Timestamp, opt: true },
+    { no: 3, name: "finished_at", kind: "message", T: Timestamp, opt: true },
+    { no: 4, name: "repo_url", jsonName: "repoURL", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 5, name: "branch", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 6, name: "commit_id", jsonName: "commitID", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 7, name: "application", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 8, name: "phase", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 9, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 10, name: "pull_request_url", jsonName: "pullRequestURL", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 11, name: "signing_key_fingerprint", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PromotionStep {
+    return new PromotionStep().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PromotionStep {
+    return new PromotionStep().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PromotionStep {
+    return new PromotionStep().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: PromotionStep | PlainMessage<PromotionStep> | undefined, b: PromotionStep | PlainMessage<PromotionStep> | undefined): boolean {
+    return proto3.util.equals(PromotionStep, a, b);
+  }
+}
+
+/**
+ * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.PromotionWindow
+ */
+export class PromotionWindow extends Message<PromotionWindow> {
+  /**
+   * @generated from field: string schedule = 1;
+   */
+  schedule = "";
+
+  /**
+   * @generated from field: string duration = 2;
+   */
+  duration = "";
+
+  /**
+   * @generated from field: optional string time_zone = 3;
+   */
+  timeZone?: string;
+
+  constructor(data?: PartialMessage<PromotionWindow>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.PromotionWindow";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "schedule", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "duration", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "time_zone", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PromotionWindow {
+    return new PromotionWindow().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PromotionWindow {
+    return new PromotionWindow().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PromotionWindow {
+    return new PromotionWindow().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: PromotionWindow | PlainMessage<PromotionWindow> | undefined, b: PromotionWindow | PlainMessage<PromotionWindow> | undefined): boolean {
+    return proto3.util.equals(PromotionWindow, a, b);
+  }
+}
+
+/**
+ * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.PullRequestPromotionMechanism
+ */
+export class PullRequestPromotionMechanism extends Message<PullRequestPromotionMechanism> {
+  /**
+   * @generated from field: optional string provider = 1;
+   */
+  provider?: string;
+
+  constructor(data?: PartialMessage<PullRequestPromotionMechanism>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.PullRequestPromotionMechanism";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "provider", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PullRequestPromotionMechanism {
+    return new PullRequestPromotionMechanism().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PullRequestPromotionMechanism {
+    return new PullRequestPromotionMechanism().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PullRequestPromotionMechanism {
+    return new PullRequestPromotionMechanism().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: PullRequestPromotionMechanism | PlainMessage<PullRequestPromotionMechanism> | undefined, b: PullRequestPromotionMechanism | PlainMessage<PullRequestPromotionMechanism> | undefined): boolean {
+    return proto3.util.equals(PullRequestPromotionMechanism, a, b);
+  }
+}
+
+/**
+ * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.RejectedImageTag
+ */
+export class RejectedImageTag extends Message<RejectedImageTag> {
+  /**
+   * @generated from field: string repo_url = 1 [json_name = "repoURL"];
+   */
+  repoUrl = "";
+
+  /**
+   * @generated from field: string tag = 2;
+   */
+  tag = "";
+
+  /**
+   * @generated from field: optional string digest = 3;
+   */
+  digest?: string;
+
+  /**
+   * @generated from field: string reason = 4;
+   */
+  reason = "";
+
+  constructor(data?: PartialMessage<RejectedImageTag>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.RejectedImageTag";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "repo_url", jsonName: "repoURL", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "tag", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "digest", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 4, name: "reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RejectedImageTag {
+    return new RejectedImageTag().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RejectedImageTag {
+    return new RejectedImageTag().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RejectedImageTag {
+    return new RejectedImageTag().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RejectedImageTag | PlainMessage<RejectedImageTag> | undefined, b: RejectedImageTag | PlainMessage<RejectedImageTag> | undefined): boolean {
+    return proto3.util.equals(RejectedImageTag, a, b);
+  }
+}
+
 /**
  * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.RepoSubscriptions
  */
@@ -1626,6 +2399,11 @@ export class StageSpec extends Message<StageSpec> {
    */
   promotionMechanisms?: PromotionMechanisms;
 
+  /**
+   * @generated from field: optional github.com.akuity.kargo.pkg.api.v1alpha1.Verification verification = 3;
+   */
+  verification?: Verification;
+
   constructor(data?: PartialMessage<StageSpec>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1636,6 +2414,7 @@ export class StageSpec extends Message<StageSpec> {
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "subscriptions", kind: "message", T: Subscriptions },
     { no: 2, name: "promotion_mechanisms", kind: "message", T: PromotionMechanisms },
+    { no: 3, name: "verification", kind: "message", T: Verification, opt: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StageSpec {
@@ -1694,6 +2473,11 @@ export class Freight extends Message<Freight> {
    */
   qualified?: boolean;
 
+  /**
+   * @generated from field: optional bool rollback = 9;
+   */
+  rollback?: boolean;
+
   constructor(data?: PartialMessage<Freight>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1709,6 +2493,7 @@ export class Freight extends Message<Freight> {
     { no: 5, name: "images", kind: "message", T: Image, repeated: true },
     { no: 6, name: "charts", kind: "message", T: Chart, repeated: true },
     { no: 8, name: "qualified", kind: "scalar", T: 8 /* ScalarType.BOOL */, opt: true },
+    { no: 9, name: "rollback", kind: "scalar", T: 8 /* ScalarType.BOOL */, opt: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Freight {
@@ -1762,6 +2547,16 @@ export class StageStatus extends Message<StageStatus> {
    */
   currentPromotion?: PromotionInfo;
 
+  /**
+   * @generated from field: optional github.com.akuity.kargo.pkg.api.v1alpha1.VerificationInfo verification = 7;
+   */
+  verification?: VerificationInfo;
+
+  /**
+   * @generated from field: repeated github.com.akuity.kargo.pkg.api.v1alpha1.RejectedImageTag rejected_image_tags = 8;
+   */
+  rejectedImageTags: RejectedImageTag[] = [];
+
   constructor(data?: PartialMessage<StageStatus>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1776,6 +2571,8 @@ export class StageStatus extends Message<StageStatus> {
     { no: 4, name: "error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 5, name: "health", kind: "message", T: Health, opt: true },
     { no: 6, name: "current_promotion", kind: "message", T: PromotionInfo, opt: true },
+    { no: 7, name: "verification", kind: "message", T: VerificationInfo, opt: true },
+    { no: 8, name: "rejected_image_tags", kind: "message", T: RejectedImageTag, repeated: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StageStatus {
@@ -1846,6 +2643,11 @@ export class Subscriptions extends Message<Subscriptions> {
    */
   upstreamStages: StageSubscription[] = [];
 
+  /**
+   * @generated from field: optional string upstream_join_strategy = 3;
+   */
+  upstreamJoinStrategy?: string;
+
   constructor(data?: PartialMessage<Subscriptions>) {
     super();
     proto3.util.initPartial(data, this);
@@ -1856,6 +2658,7 @@ export class Subscriptions extends Message<Subscriptions> {
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "repos", kind: "message", T: RepoSubscriptions, opt: true },
     { no: 2, name: "upstream_stages", kind: "message", T: StageSubscription, repeated: true },
+    { no: 3, name: "upstream_join_strategy", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Subscriptions {
@@ -1875,3 +2678,193 @@ export class Subscriptions extends Message<Subscriptions> {
   }
 }
 
+/**
+ * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.Verification
+ */
+export class Verification extends Message<Verification> {
+  /**
+   * @generated from field: repeated github.com.akuity.kargo.pkg.api.v1alpha1.VerificationJob jobs = 1;
+   */
+  jobs: VerificationJob[] = [];
+
+  constructor(data?: PartialMessage<Verification>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.Verification";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "jobs", kind: "message", T: VerificationJob, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Verification {
+    return new Verification().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Verification {
+    return new Verification().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Verification {
+    return new Verification().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Verification | PlainMessage<Verification> | undefined, b: Verification | PlainMessage<Verification> | undefined): boolean {
+    return proto3.util.equals(Verification, a, b);
+  }
+}
+
+/**
+ * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.VerificationInfo
+ */
+export class VerificationInfo extends Message<VerificationInfo> {
+  /**
+   * @generated from field: string freight_id = 1 [json_name = "freightID"];
+   */
+  freightId = "";
+
+  /**
+   * @generated from field: string phase = 2;
+   */
+  phase = "";
+
+  /**
+   * @generated from field: repeated github.com.akuity.kargo.pkg.api.v1alpha1.VerificationJobStatus jobs = 3;
+   */
+  jobs: VerificationJobStatus[] = [];
+
+  constructor(data?: PartialMessage<VerificationInfo>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.VerificationInfo";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "freight_id", jsonName: "freightID", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "phase", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "jobs", kind: "message", T: VerificationJobStatus, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): VerificationInfo {
+    return new VerificationInfo().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): VerificationInfo {
+    return new VerificationInfo().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): VerificationInfo {
+    return new VerificationInfo().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: VerificationInfo | PlainMessage<VerificationInfo> | undefined, b: VerificationInfo | PlainMessage<VerificationInfo> | undefined): boolean {
+    return proto3.util.equals(VerificationInfo, a, b);
+  }
+}
+
+/**
+ * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.VerificationJob
+ */
+export class VerificationJob extends Message<VerificationJob> {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  /**
+   * @generated from field: google.protobuf.Struct template = 2;
+   */
+  template?: Struct;
+
+  constructor(data?: PartialMessage<VerificationJob>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.VerificationJob";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "template", kind: "message", T: Struct },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): VerificationJob {
+    return new VerificationJob().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): VerificationJob {
+    return new VerificationJob().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): VerificationJob {
+    return new VerificationJob().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: VerificationJob | PlainMessage<VerificationJob> | undefined, b: VerificationJob | PlainMessage<VerificationJob> | undefined): boolean {
+    return proto3.util.equals(VerificationJob, a, b);
+  }
+}
+
+/**
+ * @generated from message github.com.akuity.kargo.pkg.api.v1alpha1.VerificationJobStatus
+ */
+export class VerificationJobStatus extends Message<VerificationJobStatus> {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name = "";
+
+  /**
+   * @generated from field: string job_name = 2;
+   */
+  jobName = "";
+
+  /**
+   * @generated from field: string phase = 3;
+   */
+  phase = "";
+
+  /**
+   * @generated from field: optional string message = 4;
+   */
+  message?: string;
+
+  /**
+   * @generated from field: optional string logs_ref = 5;
+   */
+  logsRef?: string;
+
+  constructor(data?: PartialMessage<VerificationJobStatus>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "github.com.akuity.kargo.pkg.api.v1alpha1.VerificationJobStatus";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "job_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "phase", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+    { no: 5, name: "logs_ref", kind: "scalar", T: 9 /* ScalarType.STRING */, opt: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): VerificationJobStatus {
+    return new VerificationJobStatus().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): VerificationJobStatus {
+    return new VerificationJobStatus().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): VerificationJobStatus {
+    return new VerificationJobStatus().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: VerificationJobStatus | PlainMessage<VerificationJobStatus> | undefined, b: VerificationJobStatus | PlainMessage<VerificationJobStatus> | undefined): boolean {
+    return proto3.util.equals(VerificationJobStatus, a, b);
+  }
+}
+